@@ -0,0 +1,176 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	rspb "k8s.io/helm/pkg/proto/hapi/release"
+	relutil "k8s.io/helm/pkg/releaseutil"
+	"k8s.io/helm/pkg/storage"
+	"k8s.io/helm/pkg/storage/backup"
+	"k8s.io/helm/pkg/storage/driver"
+)
+
+const backupDesc = `
+This command exports the full history of releases to an archive, which
+'helm restore' re-creates through any storage driver:
+
+	$ helm backup releases.tgz
+	$ helm backup releases.tgz happy-panda angry-beaver
+	$ helm backup --namespace production --status deployed - > releases.tgz
+
+Every revision of the releases given as arguments is exported, or of every
+release if none is given. Releases can also be selected by '--namespace', the
+namespace they are deployed in, and '--status', the status of one of their
+revisions. An ARCHIVE of '-' writes the archive to stdout.
+
+The archive is a gzipped tarball holding an index.yaml file, which lists the
+revisions with their SHA-256 digests, and the revisions as serialized release
+protobuf messages. The storage driver is accessed directly, as with
+'helm migrate-storage': '--storage' selects the driver Tiller is started with,
+and '--storage-dedup' decodes the releases Tiller stores deduplicated.
+`
+
+type backupCmd struct {
+	storageOptions
+	archive    string
+	releases   []string
+	storage    string
+	namespaces []string
+	statuses   []string
+	out        io.Writer
+
+	// newDriver returns the storage driver named name.
+	newDriver func(name string) (driver.Driver, error)
+}
+
+func newBackupCmd(out io.Writer) *cobra.Command {
+	b := &backupCmd{out: out}
+	b.newDriver = b.storageDriver
+
+	cmd := &cobra.Command{
+		Use:   "backup [flags] ARCHIVE [RELEASE...]",
+		Short: "export the history of releases to an archive",
+		Long:  backupDesc,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				return errors.New("an archive must be given")
+			}
+			b.archive, b.releases = args[0], args[1:]
+			return b.run()
+		},
+	}
+
+	f := cmd.Flags()
+	f.StringVar(&b.storage, "storage", "", "storage driver of Tiller to export the releases from: one of 'configmap', 'secret' or 'sql'. Defaults to 'secret' with --local, 'configmap' otherwise")
+	f.StringSliceVar(&b.namespaces, "namespace", nil, "only export the releases deployed in these namespaces")
+	f.StringSliceVar(&b.statuses, "status", nil, "only export the releases with a revision in one of these statuses, like 'deployed' or 'failed'")
+	b.addFlags(f, "storage-namespace")
+
+	return cmd
+}
+
+func (b *backupCmd) run() error {
+	filters, err := b.filters()
+	if err != nil {
+		return err
+	}
+	d, err := b.newDriver(storageDriverName(b.storage))
+	if err != nil {
+		return err
+	}
+
+	records, err := backup.Collect(storage.Init(d), filters...)
+	if err != nil {
+		return err
+	}
+	if len(b.releases) > 0 {
+		found := map[string]bool{}
+		for _, r := range records {
+			found[r.Release.Name] = true
+		}
+		for _, name := range b.releases {
+			if !found[name] {
+				return fmt.Errorf("release %q not found", name)
+			}
+		}
+	}
+
+	if b.archive == "-" {
+		return backup.Write(b.out, records)
+	}
+	f, err := os.Create(b.archive)
+	if err != nil {
+		return err
+	}
+	if err := backup.Write(f, records); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	names := map[string]bool{}
+	for _, r := range records {
+		names[r.Release.Name] = true
+	}
+	fmt.Fprintf(b.out, "Exported %d revision(s) of %d release(s) to %s\n", len(records), len(names), b.archive)
+	return nil
+}
+
+// filters returns the filters selecting the releases to export.
+func (b *backupCmd) filters() ([]relutil.FilterFunc, error) {
+	var filters []relutil.FilterFunc
+	if len(b.releases) > 0 {
+		filters = append(filters, anyOf(b.releases, func(rls *rspb.Release) string { return rls.Name }))
+	}
+	if len(b.namespaces) > 0 {
+		filters = append(filters, anyOf(b.namespaces, func(rls *rspb.Release) string { return rls.Namespace }))
+	}
+	if len(b.statuses) > 0 {
+		var statuses []relutil.FilterFunc
+		for _, s := range b.statuses {
+			code, ok := rspb.Status_Code_value[strings.ToUpper(strings.Replace(s, "-", "_", -1))]
+			if !ok {
+				return nil, fmt.Errorf("unknown release status %q", s)
+			}
+			statuses = append(statuses, relutil.StatusFilter(rspb.Status_Code(code)))
+		}
+		filters = append(filters, relutil.Any(statuses...))
+	}
+	return filters, nil
+}
+
+// anyOf returns a filter matching the releases whose field is one of values.
+func anyOf(values []string, field func(*rspb.Release) string) relutil.FilterFunc {
+	return func(rls *rspb.Release) bool {
+		for _, v := range values {
+			if field(rls) == v {
+				return true
+			}
+		}
+		return false
+	}
+}
@@ -0,0 +1,144 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/proto/hapi/release"
+	"k8s.io/helm/pkg/storage/backup"
+	"k8s.io/helm/pkg/storage/driver"
+)
+
+// backupTestDriver returns a storage driver holding two revisions of
+// angry-bird, deployed in the default namespace, and one of happy-panda,
+// failed in the prod namespace.
+func backupTestDriver(t *testing.T) driver.Driver {
+	d := driver.NewMemory()
+	for _, opts := range []*helm.MockReleaseOptions{
+		{Name: "angry-bird", Version: 1, StatusCode: release.Status_SUPERSEDED, Namespace: "default"},
+		{Name: "angry-bird", Version: 2, Namespace: "default"},
+		{Name: "happy-panda", Version: 1, StatusCode: release.Status_FAILED, Namespace: "prod"},
+	} {
+		rel := helm.ReleaseMock(opts)
+		if err := d.Create(fmt.Sprintf("%s.v%d", rel.Name, rel.Version), rel); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return d
+}
+
+func TestBackupCmd(t *testing.T) {
+	tests := []struct {
+		name     string
+		b        backupCmd
+		expected []string
+		err      string
+	}{
+		{
+			name:     "all releases",
+			expected: []string{"angry-bird.v1", "angry-bird.v2", "happy-panda.v1"},
+		},
+		{
+			name:     "by name",
+			b:        backupCmd{releases: []string{"angry-bird"}},
+			expected: []string{"angry-bird.v1", "angry-bird.v2"},
+		},
+		{
+			name:     "by namespace",
+			b:        backupCmd{namespaces: []string{"prod"}},
+			expected: []string{"happy-panda.v1"},
+		},
+		{
+			name:     "by status",
+			b:        backupCmd{statuses: []string{"deployed", "pending-upgrade"}},
+			expected: []string{"angry-bird.v1", "angry-bird.v2"},
+		},
+		{
+			name: "unknown release",
+			b:    backupCmd{releases: []string{"angry-bird", "sad-panda"}},
+			err:  `release "sad-panda" not found`,
+		},
+		{
+			name: "unknown status",
+			b:    backupCmd{statuses: []string{"sleeping"}},
+			err:  `unknown release status "sleeping"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := backupTestDriver(t)
+			var buf bytes.Buffer
+			b := tt.b
+			b.archive = "-"
+			b.out = &buf
+			b.newDriver = func(string) (driver.Driver, error) { return d, nil }
+
+			err := b.run()
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("expected error %q, got %v", tt.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, records, err := backup.Read(&buf)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, r := range records {
+				got = append(got, r.Key())
+			}
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("expected %v to be exported, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestBackupCmdFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "helm-backup-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	archive := filepath.Join(dir, "releases.tgz")
+
+	var buf bytes.Buffer
+	b := &backupCmd{archive: archive, out: &buf}
+	d := backupTestDriver(t)
+	b.newDriver = func(string) (driver.Driver, error) { return d, nil }
+	if err := b.run(); err != nil {
+		t.Fatal(err)
+	}
+	expected := "Exported 3 revision(s) of 2 release(s) to " + archive + "\n"
+	if buf.String() != expected {
+		t.Errorf("expected %q, got %q", expected, buf.String())
+	}
+}
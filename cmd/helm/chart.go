@@ -0,0 +1,76 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/helm/pkg/helm/helmpath"
+	"k8s.io/helm/pkg/registry"
+)
+
+const chartHelp = `
+This command consists of multiple subcommands to store charts in OCI registries.
+
+Charts are saved to a local cache, from which they are pushed to a registry,
+and pulled from a registry into that cache. Charts are referenced as
+HOST[:PORT]/REPOSITORY:TAG.
+
+Example usage:
+    $ helm chart save ./mychart localhost:5000/charts/mychart:0.1.0
+    $ helm chart push localhost:5000/charts/mychart:0.1.0
+    $ helm chart pull localhost:5000/charts/mychart:0.1.0
+
+Charts stored in registries are installed with an oci:// reference:
+    $ helm install oci://localhost:5000/charts/mychart --version 0.1.0
+`
+
+func newChartCmd(out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "chart [FLAGS] save|push|pull|list [ARGS]",
+		Short: "save, push, pull and list charts stored in OCI registries",
+		Long:  chartHelp,
+	}
+
+	cmd.AddCommand(newChartSaveCmd(out))
+	cmd.AddCommand(newChartPushCmd(out))
+	cmd.AddCommand(newChartPullCmd(out))
+	cmd.AddCommand(newChartListCmd(out))
+
+	return cmd
+}
+
+// newRegistryClient returns a registry client authenticating with the
+// credentials stored in the Helm home.
+func newRegistryClient(home helmpath.Home) (*registry.Client, error) {
+	creds, err := registry.LoadCredentials(home.RegistryConfig())
+	if err != nil {
+		return nil, err
+	}
+	return registry.NewClient(creds), nil
+}
+
+func printCacheEntry(out io.Writer, e *registry.CacheEntry) {
+	fmt.Fprintf(out, "ref:     %s\n", e.Ref)
+	fmt.Fprintf(out, "name:    %s\n", e.Name)
+	fmt.Fprintf(out, "version: %s\n", e.Version)
+	fmt.Fprintf(out, "digest:  %s\n", e.Digest)
+	fmt.Fprintf(out, "size:    %d bytes\n", e.Size)
+}
@@ -0,0 +1,74 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/gosuri/uitable"
+	"github.com/spf13/cobra"
+
+	"k8s.io/helm/pkg/helm/helmpath"
+	"k8s.io/helm/pkg/registry"
+)
+
+type chartListCmd struct {
+	home helmpath.Home
+	out  io.Writer
+}
+
+func newChartListCmd(out io.Writer) *cobra.Command {
+	list := &chartListCmd{out: out}
+
+	cmd := &cobra.Command{
+		Use:     "list [flags]",
+		Aliases: []string{"ls"},
+		Short:   "list the charts in the local cache",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			list.home = settings.Home
+			return list.run()
+		},
+	}
+
+	return cmd
+}
+
+func (l *chartListCmd) run() error {
+	entries, err := registry.NewCache(l.home.RegistryCache()).List()
+	if err != nil {
+		return err
+	}
+	if len(entries) == 0 {
+		fmt.Fprintln(l.out, "No charts in the local cache.")
+		return nil
+	}
+
+	table := uitable.New()
+	table.AddRow("REF", "NAME", "VERSION", "DIGEST", "SIZE", "CREATED")
+	for _, e := range entries {
+		digest := strings.TrimPrefix(e.Digest, "sha256:")
+		if len(digest) > 7 {
+			digest = digest[:7]
+		}
+		table.AddRow(e.Ref, e.Name, e.Version, digest, fmt.Sprintf("%d B", e.Size), e.Created.Local().Format(time.ANSIC))
+	}
+	fmt.Fprintln(l.out, table)
+	return nil
+}
@@ -0,0 +1,80 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/helm/pkg/helm/helmpath"
+	"k8s.io/helm/pkg/registry"
+)
+
+const chartPullDesc = `
+This command downloads a chart from a registry into the local cache.
+`
+
+type chartPullCmd struct {
+	ref  string
+	home helmpath.Home
+	out  io.Writer
+}
+
+func newChartPullCmd(out io.Writer) *cobra.Command {
+	pull := &chartPullCmd{out: out}
+
+	cmd := &cobra.Command{
+		Use:   "pull [flags] [REF]",
+		Short: "pull a chart from a registry to the local cache",
+		Long:  chartPullDesc,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := checkArgsLength(len(args), "reference of the chart"); err != nil {
+				return err
+			}
+			pull.ref = args[0]
+			pull.home = settings.Home
+			return pull.run()
+		},
+	}
+
+	return cmd
+}
+
+func (p *chartPullCmd) run() error {
+	ref, err := registry.ParseReference(p.ref)
+	if err != nil {
+		return err
+	}
+	client, err := newRegistryClient(p.home)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(p.out, "%s: Pulling from %s/%s\n", ref.Tag, ref.Registry, ref.Repository)
+	archive, err := client.Pull(ref)
+	if err != nil {
+		return err
+	}
+	entry, err := registry.NewCache(p.home.RegistryCache()).Store(ref, archive)
+	if err != nil {
+		return err
+	}
+	printCacheEntry(p.out, entry)
+	return nil
+}
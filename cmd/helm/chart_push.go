@@ -0,0 +1,81 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/helm/pkg/helm/helmpath"
+	"k8s.io/helm/pkg/registry"
+)
+
+const chartPushDesc = `
+This command uploads a chart saved in the local cache to a registry.
+
+Registries requiring authentication are logged in to with 'helm registry login'.
+`
+
+type chartPushCmd struct {
+	ref  string
+	home helmpath.Home
+	out  io.Writer
+}
+
+func newChartPushCmd(out io.Writer) *cobra.Command {
+	push := &chartPushCmd{out: out}
+
+	cmd := &cobra.Command{
+		Use:   "push [flags] [REF]",
+		Short: "push a chart from the local cache to a registry",
+		Long:  chartPushDesc,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := checkArgsLength(len(args), "reference of the chart"); err != nil {
+				return err
+			}
+			push.ref = args[0]
+			push.home = settings.Home
+			return push.run()
+		},
+	}
+
+	return cmd
+}
+
+func (p *chartPushCmd) run() error {
+	ref, err := registry.ParseReference(p.ref)
+	if err != nil {
+		return err
+	}
+	entry, archive, err := registry.NewCache(p.home.RegistryCache()).Fetch(ref)
+	if err != nil {
+		return err
+	}
+
+	client, err := newRegistryClient(p.home)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(p.out, "The push refers to repository [%s/%s]\n", ref.Registry, ref.Repository)
+	if _, err := client.Push(ref, archive); err != nil {
+		return err
+	}
+	printCacheEntry(p.out, entry)
+	return nil
+}
@@ -0,0 +1,106 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/helm/pkg/chartutil"
+	"k8s.io/helm/pkg/helm/helmpath"
+	"k8s.io/helm/pkg/registry"
+)
+
+const chartSaveDesc = `
+This command stores a chart, a directory or a packaged archive, in the local
+cache under the given reference, so that it can be pushed to a registry.
+
+If the reference has no tag, the version of the chart is used.
+`
+
+type chartSaveCmd struct {
+	path string
+	ref  string
+	home helmpath.Home
+	out  io.Writer
+}
+
+func newChartSaveCmd(out io.Writer) *cobra.Command {
+	save := &chartSaveCmd{out: out}
+
+	cmd := &cobra.Command{
+		Use:   "save [flags] [PATH] [REF]",
+		Short: "save a chart to the local cache",
+		Long:  chartSaveDesc,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := checkArgsLength(len(args), "path to the chart", "reference to store the chart under"); err != nil {
+				return err
+			}
+			save.path = args[0]
+			save.ref = args[1]
+			save.home = settings.Home
+			return save.run()
+		},
+	}
+
+	return cmd
+}
+
+func (s *chartSaveCmd) run() error {
+	ref, err := registry.ParseReference(s.ref)
+	if err != nil {
+		return err
+	}
+	archive, err := chartArchive(s.path)
+	if err != nil {
+		return err
+	}
+
+	entry, err := registry.NewCache(s.home.RegistryCache()).Store(ref, archive)
+	if err != nil {
+		return err
+	}
+	printCacheEntry(s.out, entry)
+	return nil
+}
+
+// chartArchive returns the archive of the chart at path, packaging it if
+// path is a directory.
+func chartArchive(path string) ([]byte, error) {
+	ch, err := chartutil.Load(path)
+	if err != nil {
+		return nil, err
+	}
+	if fi, err := os.Stat(path); err == nil && !fi.IsDir() {
+		return ioutil.ReadFile(path)
+	}
+
+	tmp, err := ioutil.TempDir("", "helm-chart-save-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmp)
+	where, err := chartutil.Save(ch, tmp)
+	if err != nil {
+		return nil, fmt.Errorf("cannot package %s: %s", path, err)
+	}
+	return ioutil.ReadFile(where)
+}
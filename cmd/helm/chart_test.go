@@ -0,0 +1,123 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/helm/pkg/registry/registrytest"
+)
+
+func TestChartSavePushPull(t *testing.T) {
+	srv := registrytest.NewServer()
+	defer srv.Stop()
+
+	hh, err := tempHelmHome(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cleanup := resetEnv()
+	defer func() {
+		os.RemoveAll(hh.String())
+		cleanup()
+	}()
+	settings.Home = hh
+
+	ref := srv.Host() + "/charts/alpine:0.1.0"
+	tests := []struct {
+		name   string
+		cmd    func(io.Writer) *cobra.Command
+		args   []string
+		expect string
+		err    bool
+	}{
+		{
+			name:   "list empty cache",
+			cmd:    newChartListCmd,
+			expect: "No charts in the local cache.",
+		},
+		{
+			name: "push unsaved chart",
+			cmd:  newChartPushCmd,
+			args: []string{ref},
+			err:  true,
+		},
+		{
+			name:   "save chart directory",
+			cmd:    newChartSaveCmd,
+			args:   []string{"testdata/testcharts/alpine", ref},
+			expect: "version: 0.1.0",
+		},
+		{
+			name:   "save chart archive with version as tag",
+			cmd:    newChartSaveCmd,
+			args:   []string{"testdata/testcharts/compressedchart-0.2.0.tgz", srv.Host() + "/charts/compressedchart"},
+			expect: "ref:     " + srv.Host() + "/charts/compressedchart:0.2.0",
+		},
+		{
+			name:   "push chart",
+			cmd:    newChartPushCmd,
+			args:   []string{ref},
+			expect: "The push refers to repository [" + srv.Host() + "/charts/alpine]",
+		},
+		{
+			name: "pull missing chart",
+			cmd:  newChartPullCmd,
+			args: []string{srv.Host() + "/charts/alpine:9.9.9"},
+			err:  true,
+		},
+		{
+			name:   "pull chart",
+			cmd:    newChartPullCmd,
+			args:   []string{ref},
+			expect: "name:    alpine",
+		},
+		{
+			name:   "list cache",
+			cmd:    newChartListCmd,
+			expect: ref,
+		},
+		{
+			name: "invalid reference",
+			cmd:  newChartPullCmd,
+			args: []string{"charts/alpine:0.1.0"},
+			err:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		buf := bytes.NewBuffer(nil)
+		cmd := tt.cmd(buf)
+		err := cmd.RunE(cmd, tt.args)
+		if (err != nil) != tt.err {
+			t.Fatalf("%s: expected error %t, got %v", tt.name, tt.err, err)
+		}
+		if !strings.Contains(buf.String(), tt.expect) {
+			t.Errorf("%s: expected %q in output, got %q", tt.name, tt.expect, buf.String())
+		}
+	}
+
+	if _, ok := srv.Manifest("charts/alpine", "0.1.0"); !ok {
+		t.Error("expected the chart to be pushed to the registry")
+	}
+}
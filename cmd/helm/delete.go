@@ -42,8 +42,9 @@ type deleteCmd struct {
 	timeout      int64
 	description  string
 
-	out    io.Writer
-	client helm.Interface
+	out       io.Writer
+	client    helm.Interface
+	namespace string
 }
 
 func newDeleteCmd(c helm.Interface, out io.Writer) *cobra.Command {
@@ -63,7 +64,7 @@ func newDeleteCmd(c helm.Interface, out io.Writer) *cobra.Command {
 			if len(args) == 0 {
 				return errors.New("command 'delete' requires a release name")
 			}
-			del.client = ensureHelmClient(del.client)
+			del.client = ensureHelmClientInNamespace(del.client, del.namespace)
 
 			for i := 0; i < len(args); i++ {
 				del.name = args[i]
@@ -79,6 +80,7 @@ func newDeleteCmd(c helm.Interface, out io.Writer) *cobra.Command {
 
 	f := cmd.Flags()
 	settings.AddFlagsTLS(f)
+	addReleaseNamespaceFlag(f, &del.namespace)
 	f.BoolVar(&del.dryRun, "dry-run", false, "simulate a delete")
 	f.BoolVar(&del.disableHooks, "no-hooks", false, "prevent hooks from running during deletion")
 	f.BoolVar(&del.purge, "purge", false, "remove the release from the store and make its name free for later use")
@@ -105,10 +105,22 @@ func newDependencyCmd(out io.Writer) *cobra.Command {
 type dependencyListCmd struct {
 	out       io.Writer
 	chartpath string
+	output    outputFormat
+}
+
+// dependencyOutput is a dependency as written by 'helm dependency list' in
+// the structured output formats.
+type dependencyOutput struct {
+	Name       string `json:"name"`
+	Version    string `json:"version"`
+	Repository string `json:"repository"`
+	// Status is the status of the dependency in the charts/ directory, as in
+	// the STATUS column of the table.
+	Status string `json:"status"`
 }
 
 func newDependencyListCmd(out io.Writer) *cobra.Command {
-	dlc := &dependencyListCmd{out: out}
+	dlc := &dependencyListCmd{out: out, output: newOutputFormat("table")}
 
 	cmd := &cobra.Command{
 		Use:     "list [flags] CHART",
@@ -129,6 +141,7 @@ func newDependencyListCmd(out io.Writer) *cobra.Command {
 			return dlc.run()
 		},
 	}
+	cmd.Flags().VarP(&dlc.output, "output", "o", outputUsage)
 	return cmd
 }
 
@@ -141,16 +154,28 @@ func (l *dependencyListCmd) run() error {
 	r, err := chartutil.LoadRequirements(c)
 	if err != nil {
 		if err == chartutil.ErrRequirementsNotFound {
-			fmt.Fprintf(l.out, "WARNING: no requirements at %s\n", filepath.Join(l.chartpath, "charts"))
-			return nil
+			fmt.Fprintf(l.output.messages(l.out), "WARNING: no requirements at %s\n", filepath.Join(l.chartpath, "charts"))
+			return l.output.write(l.out, []dependencyOutput{}, func() error { return nil })
 		}
 		return err
 	}
 
-	l.printRequirements(r, l.out)
-	fmt.Fprintln(l.out)
+	deps := make([]dependencyOutput, 0, len(r.Dependencies))
+	for _, d := range r.Dependencies {
+		deps = append(deps, dependencyOutput{
+			Name:       d.Name,
+			Version:    d.Version,
+			Repository: d.Repository,
+			Status:     l.dependencyStatus(d),
+		})
+	}
+	err = l.output.write(l.out, deps, func() error {
+		l.printRequirements(r, l.out)
+		fmt.Fprintln(l.out)
+		return nil
+	})
 	l.printMissing(r)
-	return nil
+	return err
 }
 
 func (l *dependencyListCmd) dependencyStatus(dep *chartutil.Dependency) string {
@@ -240,17 +265,18 @@ func (l *dependencyListCmd) printRequirements(reqs *chartutil.Requirements, out
 
 // printMissing prints warnings about charts that are present on disk, but are not in the requirements.
 func (l *dependencyListCmd) printMissing(reqs *chartutil.Requirements) {
+	out := l.output.messages(l.out)
 	folder := filepath.Join(l.chartpath, "charts/*")
 	files, err := filepath.Glob(folder)
 	if err != nil {
-		fmt.Fprintln(l.out, err)
+		fmt.Fprintln(out, err)
 		return
 	}
 
 	for _, f := range files {
 		fi, err := os.Stat(f)
 		if err != nil {
-			fmt.Fprintf(l.out, "Warning: %s\n", err)
+			fmt.Fprintf(out, "Warning: %s\n", err)
 		}
 		// Skip anything that is not a directory and not a tgz file.
 		if !fi.IsDir() && filepath.Ext(f) != ".tgz" {
@@ -258,7 +284,7 @@ func (l *dependencyListCmd) printMissing(reqs *chartutil.Requirements) {
 		}
 		c, err := chartutil.Load(f)
 		if err != nil {
-			fmt.Fprintf(l.out, "WARNING: %q is not a chart.\n", f)
+			fmt.Fprintf(out, "WARNING: %q is not a chart.\n", f)
 			continue
 		}
 		found := false
@@ -269,7 +295,7 @@ func (l *dependencyListCmd) printMissing(reqs *chartutil.Requirements) {
 			}
 		}
 		if !found {
-			fmt.Fprintf(l.out, "WARNING: %q is not in requirements.yaml.\n", f)
+			fmt.Fprintf(out, "WARNING: %q is not in requirements.yaml.\n", f)
 		}
 	}
 
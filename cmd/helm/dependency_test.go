@@ -17,6 +17,7 @@ package main
 
 import (
 	"io"
+	"regexp"
 	"testing"
 
 	"github.com/spf13/cobra"
@@ -50,6 +51,18 @@ func TestDependencyListCmd(t *testing.T) {
 			args:     []string{"testdata/testcharts/reqtest-0.1.0.tgz"},
 			expected: "NAME        \tVERSION\tREPOSITORY                \tSTATUS \nreqsubchart \t0.1.0  \thttps://example.com/charts\tmissing\nreqsubchart2\t0.2.0  \thttps://example.com/charts\tmissing\n",
 		},
+		{
+			name:     "Requirements in chart archive with json output",
+			args:     []string{"testdata/testcharts/reqtest-0.1.0.tgz"},
+			flags:    []string{"--output", "json"},
+			expected: regexp.QuoteMeta(`[{"name":"reqsubchart","version":"0.1.0","repository":"https://example.com/charts","status":"missing"},{"name":"reqsubchart2","version":"0.2.0","repository":"https://example.com/charts","status":"missing"}]`),
+		},
+		{
+			name:     "No requirements.yaml with json output",
+			args:     []string{"testdata/testcharts/alpine"},
+			flags:    []string{"--output", "json"},
+			expected: `^\[\]\n$`,
+		},
 	}
 
 	runReleaseCases(t, tests, func(c *helm.FakeClient, out io.Writer) *cobra.Command {
@@ -0,0 +1,337 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"k8s.io/helm/pkg/chartutil"
+	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/proto/hapi/services"
+	"k8s.io/helm/pkg/renderutil"
+)
+
+const diffHelp = `
+This command consists of multiple subcommands to preview the changes an
+operation would make to the resources of a release, without performing it.
+
+Tiller renders the target release and returns, for each resource that would
+be added, removed or modified, the unified diff of its manifest. The values of
+Secrets are masked.
+`
+
+const diffUpgradeHelp = `
+This command shows the changes 'helm upgrade' would make to a release.
+
+It takes the same arguments and value flags as 'helm upgrade'.
+`
+
+const diffRollbackHelp = `
+This command shows the changes 'helm rollback' would make to a release.
+
+The first argument is the name of a release, and the second is the revision
+(version) number to roll back to. Use 0 to roll back to the previous revision.
+`
+
+// ANSI escape sequences used to color the text output.
+const (
+	colorReset  = "\x1b[0m"
+	colorRed    = "\x1b[31m"
+	colorGreen  = "\x1b[32m"
+	colorYellow = "\x1b[33m"
+	colorCyan   = "\x1b[36m"
+)
+
+// diffPrinter prints resource diffs as text or JSON.
+type diffPrinter struct {
+	out     io.Writer
+	output  string
+	noColor bool
+}
+
+func (p *diffPrinter) addFlags(f *pflag.FlagSet) {
+	f.StringVarP(&p.output, "output", "o", "text", "output the diff in the specified format (text or json)")
+	f.BoolVar(&p.noColor, "no-color", false, "disable colored text output")
+}
+
+// diffJSON is the stable JSON representation of a services.ResourceDiff.
+type diffJSON struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Change    string `json:"change"`
+	Diff      string `json:"diff"`
+}
+
+func (p *diffPrinter) print(diffs []*services.ResourceDiff) error {
+	switch p.output {
+	case "json":
+		res := make([]diffJSON, 0, len(diffs))
+		for _, d := range diffs {
+			res = append(res, diffJSON{
+				Kind:      d.Kind,
+				Namespace: d.Namespace,
+				Name:      d.Name,
+				Change:    d.Change.String(),
+				Diff:      d.Diff,
+			})
+		}
+		data, err := json.MarshalIndent(res, "", "  ")
+		if err != nil {
+			return fmt.Errorf("Failed to Marshal JSON output: %s", err)
+		}
+		fmt.Fprintln(p.out, string(data))
+		return nil
+	case "text", "":
+		if len(diffs) == 0 {
+			fmt.Fprintln(p.out, "No changes.")
+			return nil
+		}
+		for _, d := range diffs {
+			header := fmt.Sprintf("%s, %s, %s has been %s:", d.Namespace, d.Name, d.Kind, strings.ToLower(d.Change.String()))
+			fmt.Fprintln(p.out, p.colorize(header, colorYellow))
+			for _, line := range strings.SplitAfter(d.Diff, "\n") {
+				fmt.Fprint(p.out, p.colorizeLine(line))
+			}
+			fmt.Fprintln(p.out)
+		}
+		return nil
+	}
+	return fmt.Errorf("Unknown output format %q", p.output)
+}
+
+func (p *diffPrinter) colorizeLine(line string) string {
+	switch {
+	case strings.HasPrefix(line, "+++"), strings.HasPrefix(line, "---"):
+		return line
+	case strings.HasPrefix(line, "+"):
+		return p.colorize(line, colorGreen)
+	case strings.HasPrefix(line, "-"):
+		return p.colorize(line, colorRed)
+	case strings.HasPrefix(line, "@@"):
+		return p.colorize(line, colorCyan)
+	}
+	return line
+}
+
+func (p *diffPrinter) colorize(s, color string) string {
+	if p.noColor || s == "" {
+		return s
+	}
+	// keep the line break out of the colored sequence
+	trimmed := strings.TrimSuffix(s, "\n")
+	return color + trimmed + colorReset + s[len(trimmed):]
+}
+
+func newDiffCmd(out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "diff [FLAGS] upgrade|rollback [ARGS]",
+		Short: "preview the changes of an upgrade or a rollback",
+		Long:  diffHelp,
+	}
+
+	cmd.AddCommand(newDiffUpgradeCmd(nil, out))
+	cmd.AddCommand(newDiffRollbackCmd(nil, out))
+
+	return cmd
+}
+
+type diffUpgradeCmd struct {
+	release      string
+	chart        string
+	client       helm.Interface
+	namespace    string
+	valueFiles   valueFiles
+	values       []string
+	stringValues []string
+	fileValues   []string
+	verify       bool
+	keyring      string
+	version      string
+	resetValues  bool
+	reuseValues  bool
+	repoURL      string
+	username     string
+	password     string
+	devel        bool
+	printer      diffPrinter
+
+	certFile string
+	keyFile  string
+	caFile   string
+}
+
+func newDiffUpgradeCmd(client helm.Interface, out io.Writer) *cobra.Command {
+	d := &diffUpgradeCmd{
+		client:  client,
+		printer: diffPrinter{out: out},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "upgrade [RELEASE] [CHART]",
+		Short:   "show the changes an upgrade would make to a release",
+		Long:    diffUpgradeHelp,
+		PreRunE: func(_ *cobra.Command, _ []string) error { return setupConnection() },
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := checkArgsLength(len(args), "release name", "chart path"); err != nil {
+				return err
+			}
+
+			if d.version == "" && d.devel {
+				debug("setting version to >0.0.0-0")
+				d.version = ">0.0.0-0"
+			}
+
+			d.release = args[0]
+			d.chart = args[1]
+			d.client = ensureHelmClientInNamespace(d.client, d.namespace)
+
+			return d.run()
+		},
+	}
+
+	f := cmd.Flags()
+	settings.AddFlagsTLS(f)
+	addReleaseNamespaceFlag(f, &d.namespace)
+	f.VarP(&d.valueFiles, "values", "f", "specify values in a YAML file or a URL(can specify multiple)")
+	f.StringArrayVar(&d.values, "set", []string{}, "set values on the command line (can specify multiple or separate values with commas: key1=val1,key2=val2)")
+	f.StringArrayVar(&d.stringValues, "set-string", []string{}, "set STRING values on the command line (can specify multiple or separate values with commas: key1=val1,key2=val2)")
+	f.StringArrayVar(&d.fileValues, "set-file", []string{}, "set values from respective files specified via the command line (can specify multiple or separate values with commas: key1=path1,key2=path2)")
+	f.BoolVar(&d.verify, "verify", false, "verify the provenance of the chart before diffing")
+	f.StringVar(&d.keyring, "keyring", defaultKeyring(), "path to the keyring that contains public signing keys")
+	f.StringVar(&d.version, "version", "", "specify the exact chart version to use. If this is not specified, the latest version is used")
+	f.BoolVar(&d.resetValues, "reset-values", false, "when upgrading, reset the values to the ones built into the chart")
+	f.BoolVar(&d.reuseValues, "reuse-values", false, "when upgrading, reuse the last release's values and merge in any overrides from the command line via --set and -f. If '--reset-values' is specified, this is ignored.")
+	f.StringVar(&d.repoURL, "repo", "", "chart repository url where to locate the requested chart")
+	f.StringVar(&d.username, "username", "", "chart repository username where to locate the requested chart")
+	f.StringVar(&d.password, "password", "", "chart repository password where to locate the requested chart")
+	f.StringVar(&d.certFile, "cert-file", "", "identify HTTPS client using this SSL certificate file")
+	f.StringVar(&d.keyFile, "key-file", "", "identify HTTPS client using this SSL key file")
+	f.StringVar(&d.caFile, "ca-file", "", "verify certificates of HTTPS-enabled servers using this CA bundle")
+	f.BoolVar(&d.devel, "devel", false, "use development versions, too. Equivalent to version '>0.0.0-0'. If --version is set, this is ignored.")
+	d.printer.addFlags(f)
+
+	// set defaults from environment
+	settings.InitTLS(f)
+
+	return cmd
+}
+
+func (d *diffUpgradeCmd) run() error {
+	chartPath, err := locateChartPath(d.repoURL, d.username, d.password, d.chart, d.version, d.verify, d.keyring, d.certFile, d.keyFile, d.caFile)
+	if err != nil {
+		return err
+	}
+
+	rawVals, err := vals(d.valueFiles, d.values, d.stringValues, d.fileValues, d.certFile, d.keyFile, d.caFile)
+	if err != nil {
+		return err
+	}
+
+	// Check chart requirements to make sure all dependencies are present in /charts
+	ch, err := chartutil.Load(chartPath)
+	if err != nil {
+		return prettyError(err)
+	}
+	if req, err := chartutil.LoadRequirements(ch); err == nil {
+		if err := renderutil.CheckDependencies(ch, req); err != nil {
+			return err
+		}
+	} else if err != chartutil.ErrRequirementsNotFound {
+		return fmt.Errorf("cannot load requirements: %v", err)
+	}
+
+	resp, err := d.client.UpdateReleaseFromChart(
+		d.release,
+		ch,
+		helm.UpdateValueOverrides(rawVals),
+		helm.ResetValues(d.resetValues),
+		helm.ReuseValues(d.reuseValues),
+		helm.UpgradeDiff(true))
+	if err != nil {
+		return prettyError(err)
+	}
+
+	return d.printer.print(resp.Diffs)
+}
+
+type diffRollbackCmd struct {
+	name      string
+	revision  int32
+	client    helm.Interface
+	namespace string
+	printer   diffPrinter
+}
+
+func newDiffRollbackCmd(client helm.Interface, out io.Writer) *cobra.Command {
+	d := &diffRollbackCmd{
+		client:  client,
+		printer: diffPrinter{out: out},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "rollback [flags] [RELEASE] [REVISION]",
+		Short:   "show the changes a rollback would make to a release",
+		Long:    diffRollbackHelp,
+		PreRunE: func(_ *cobra.Command, _ []string) error { return setupConnection() },
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := checkArgsLength(len(args), "release name", "revision number"); err != nil {
+				return err
+			}
+
+			d.name = args[0]
+
+			v64, err := strconv.ParseInt(args[1], 10, 32)
+			if err != nil {
+				return fmt.Errorf("invalid revision number '%q': %s", args[1], err)
+			}
+
+			d.revision = int32(v64)
+			d.client = ensureHelmClientInNamespace(d.client, d.namespace)
+			return d.run()
+		},
+	}
+
+	f := cmd.Flags()
+	settings.AddFlagsTLS(f)
+	addReleaseNamespaceFlag(f, &d.namespace)
+	d.printer.addFlags(f)
+
+	// set defaults from environment
+	settings.InitTLS(f)
+
+	return cmd
+}
+
+func (d *diffRollbackCmd) run() error {
+	resp, err := d.client.RollbackRelease(
+		d.name,
+		helm.RollbackVersion(d.revision),
+		helm.RollbackDiff(true))
+	if err != nil {
+		return prettyError(err)
+	}
+
+	return d.printer.print(resp.Diffs)
+}
@@ -0,0 +1,116 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/proto/hapi/services"
+)
+
+func TestDiffRollbackCmd(t *testing.T) {
+	tests := []releaseCase{
+		{
+			name:     "diff a rollback without changes",
+			args:     []string{"funny-honey", "1"},
+			expected: "No changes.",
+		},
+		{
+			name:     "diff a rollback with json output",
+			args:     []string{"funny-honey", "1"},
+			flags:    []string{"--output", "json"},
+			expected: `\[\]`,
+		},
+		{
+			name: "diff a rollback without revision",
+			args: []string{"funny-honey"},
+			err:  true,
+		},
+		{
+			name:  "diff a rollback with an unknown output format",
+			args:  []string{"funny-honey", "1"},
+			flags: []string{"--output", "yaml"},
+			err:   true,
+		},
+	}
+
+	cmd := func(c *helm.FakeClient, out io.Writer) *cobra.Command {
+		return newDiffRollbackCmd(c, out)
+	}
+
+	runReleaseCases(t, tests, cmd)
+}
+
+func TestDiffPrinter(t *testing.T) {
+	diffs := []*services.ResourceDiff{
+		{
+			Kind:      "ConfigMap",
+			Namespace: "default",
+			Name:      "foo",
+			Change:    services.ResourceDiff_MODIFIED,
+			Diff:      "--- foo.v1\n+++ foo.v2\n@@ -1 +1 @@\n-a\n+b\n",
+		},
+	}
+
+	tests := []struct {
+		name     string
+		printer  diffPrinter
+		expected string
+	}{
+		{
+			name:     "text",
+			printer:  diffPrinter{output: "text", noColor: true},
+			expected: "default, foo, ConfigMap has been modified:\n--- foo.v1\n+++ foo.v2\n@@ -1 +1 @@\n-a\n+b\n\n",
+		},
+		{
+			name:     "colored text",
+			printer:  diffPrinter{output: "text"},
+			expected: "\x1b[33mdefault, foo, ConfigMap has been modified:\x1b[0m\n--- foo.v1\n+++ foo.v2\n\x1b[36m@@ -1 +1 @@\x1b[0m\n\x1b[31m-a\x1b[0m\n\x1b[32m+b\x1b[0m\n\n",
+		},
+		{
+			name:    "json",
+			printer: diffPrinter{output: "json"},
+			expected: `[
+  {
+    "kind": "ConfigMap",
+    "namespace": "default",
+    "name": "foo",
+    "change": "MODIFIED",
+    "diff": "--- foo.v1\n+++ foo.v2\n@@ -1 +1 @@\n-a\n+b\n"
+  }
+]
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		tt.printer.out = &buf
+		if err := tt.printer.print(diffs); err != nil {
+			t.Errorf("%q: unexpected error: %s", tt.name, err)
+			continue
+		}
+		if buf.String() != tt.expected {
+			t.Errorf("%q: expected\n%q\ngot\n%q", tt.name, tt.expected, buf.String())
+		}
+	}
+}
@@ -19,10 +19,14 @@ package main
 import (
 	"errors"
 	"io"
+	"time"
 
 	"github.com/spf13/cobra"
 
+	"k8s.io/helm/pkg/chartutil"
 	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/proto/hapi/release"
+	"k8s.io/helm/pkg/timeconv"
 )
 
 var getHelp = `
@@ -35,22 +39,45 @@ It can be used to get extended information about the release, including:
   - The generated manifest file
 
 By default, this prints a human readable collection of information about the
-chart, the supplied values, and the generated manifest file.
+chart, the supplied values, and the generated manifest file. The other output
+formats write the same details as an object, see 'docs/output.md'.
 `
 
 var errReleaseRequired = errors.New("release name is required")
 
 type getCmd struct {
-	release string
-	out     io.Writer
-	client  helm.Interface
-	version int32
+	release   string
+	out       io.Writer
+	client    helm.Interface
+	namespace string
+	version   int32
+	output    outputFormat
+}
+
+// releaseOutput is the object written by 'helm get' in the structured
+// output formats.
+type releaseOutput struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	Revision  int32  `json:"revision"`
+	// Updated is when the revision was deployed, in RFC 3339 format.
+	Updated string `json:"updated"`
+	Status  string `json:"status"`
+	// Chart is the name and version of the chart, as in 'mychart-0.1.0'.
+	Chart string `json:"chart"`
+	// Values are the values supplied by the user.
+	Values chartutil.Values `json:"values"`
+	// ComputedValues are the values merged with the defaults of the chart.
+	ComputedValues chartutil.Values `json:"computedValues"`
+	Hooks          []hookOutput     `json:"hooks"`
+	Manifest       string           `json:"manifest"`
 }
 
 func newGetCmd(client helm.Interface, out io.Writer) *cobra.Command {
 	get := &getCmd{
 		out:    out,
 		client: client,
+		output: newOutputFormat("table"),
 	}
 
 	cmd := &cobra.Command{
@@ -64,7 +91,7 @@ func newGetCmd(client helm.Interface, out io.Writer) *cobra.Command {
 			}
 			get.release = args[0]
 			if get.client == nil {
-				get.client = newClient()
+				get.client = newClientInNamespace(get.namespace)
 			}
 			return get.run()
 		},
@@ -72,7 +99,9 @@ func newGetCmd(client helm.Interface, out io.Writer) *cobra.Command {
 
 	f := cmd.Flags()
 	settings.AddFlagsTLS(f)
+	addReleaseNamespaceFlag(f, &get.namespace)
 	f.Int32Var(&get.version, "revision", 0, "get the named release with revision")
+	f.Var(&get.output, "output", outputUsage)
 
 	cmd.AddCommand(newGetValuesCmd(nil, out))
 	cmd.AddCommand(newGetManifestCmd(nil, out))
@@ -91,5 +120,32 @@ func (g *getCmd) run() error {
 	if err != nil {
 		return prettyError(err)
 	}
-	return printRelease(g.out, res.Release)
+	obj, err := newReleaseOutput(res.Release)
+	if err != nil {
+		return err
+	}
+	return g.output.write(g.out, obj, func() error { return printRelease(g.out, res.Release) })
+}
+
+func newReleaseOutput(rel *release.Release) (*releaseOutput, error) {
+	values, err := chartutil.ReadValues([]byte(rel.Config.GetRaw()))
+	if err != nil {
+		return nil, err
+	}
+	computed, err := chartutil.CoalesceValues(rel.Chart, rel.Config)
+	if err != nil {
+		return nil, err
+	}
+	return &releaseOutput{
+		Name:           rel.Name,
+		Namespace:      rel.Namespace,
+		Revision:       rel.Version,
+		Updated:        timeconv.Time(rel.Info.LastDeployed).UTC().Format(time.RFC3339),
+		Status:         rel.Info.Status.Code.String(),
+		Chart:          rel.Chart.Metadata.Name + "-" + rel.Chart.Metadata.Version,
+		Values:         values,
+		ComputedValues: computed,
+		Hooks:          newHookOutputs(rel.Hooks),
+		Manifest:       rel.Manifest,
+	}, nil
 }
@@ -23,25 +23,44 @@ import (
 	"github.com/spf13/cobra"
 
 	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/proto/hapi/release"
 )
 
 const getHooksHelp = `
 This command downloads hooks for a given release.
 
-Hooks are formatted in YAML and separated by the YAML '---\n' separator.
+Hooks are formatted in YAML and separated by the YAML '---\n' separator. The
+other output formats write the hooks as a list of objects, see
+'docs/output.md'.
 `
 
 type getHooksCmd struct {
-	release string
-	out     io.Writer
-	client  helm.Interface
-	version int32
+	release   string
+	out       io.Writer
+	client    helm.Interface
+	namespace string
+	version   int32
+	output    outputFormat
+}
+
+// hookOutput is a hook as written by 'helm get hooks' in the structured
+// output formats.
+type hookOutput struct {
+	Name string `json:"name"`
+	Kind string `json:"kind"`
+	// Path is the template the hook was rendered from.
+	Path           string   `json:"path"`
+	Events         []string `json:"events"`
+	Weight         int32    `json:"weight"`
+	DeletePolicies []string `json:"deletePolicies"`
+	Manifest       string   `json:"manifest"`
 }
 
 func newGetHooksCmd(client helm.Interface, out io.Writer) *cobra.Command {
 	ghc := &getHooksCmd{
 		out:    out,
 		client: client,
+		output: newOutputFormat("table"),
 	}
 	cmd := &cobra.Command{
 		Use:     "hooks [flags] RELEASE_NAME",
@@ -53,13 +72,15 @@ func newGetHooksCmd(client helm.Interface, out io.Writer) *cobra.Command {
 				return errReleaseRequired
 			}
 			ghc.release = args[0]
-			ghc.client = ensureHelmClient(ghc.client)
+			ghc.client = ensureHelmClientInNamespace(ghc.client, ghc.namespace)
 			return ghc.run()
 		},
 	}
 	f := cmd.Flags()
 	settings.AddFlagsTLS(f)
+	addReleaseNamespaceFlag(f, &ghc.namespace)
 	f.Int32Var(&ghc.version, "revision", 0, "get the named release with revision")
+	f.Var(&ghc.output, "output", outputUsage)
 
 	// set defaults from environment
 	settings.InitTLS(f)
@@ -74,8 +95,33 @@ func (g *getHooksCmd) run() error {
 		return prettyError(err)
 	}
 
-	for _, hook := range res.Release.Hooks {
-		fmt.Fprintf(g.out, "---\n# %s\n%s\n", hook.Name, hook.Manifest)
+	return g.output.write(g.out, newHookOutputs(res.Release.Hooks), func() error {
+		for _, hook := range res.Release.Hooks {
+			fmt.Fprintf(g.out, "---\n# %s\n%s\n", hook.Name, hook.Manifest)
+		}
+		return nil
+	})
+}
+
+func newHookOutputs(hooks []*release.Hook) []hookOutput {
+	out := make([]hookOutput, 0, len(hooks))
+	for _, h := range hooks {
+		o := hookOutput{
+			Name:           h.Name,
+			Kind:           h.Kind,
+			Path:           h.Path,
+			Events:         make([]string, 0, len(h.Events)),
+			Weight:         h.Weight,
+			DeletePolicies: make([]string, 0, len(h.DeletePolicies)),
+			Manifest:       h.Manifest,
+		}
+		for _, e := range h.Events {
+			o.Events = append(o.Events, e.String())
+		}
+		for _, p := range h.DeletePolicies {
+			o.DeletePolicies = append(o.DeletePolicies, p.String())
+		}
+		out = append(out, o)
 	}
-	return nil
+	return out
 }
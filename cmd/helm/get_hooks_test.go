@@ -19,6 +19,7 @@ package main
 import (
 	"fmt"
 	"io"
+	"regexp"
 	"testing"
 
 	"github.com/spf13/cobra"
@@ -36,6 +37,14 @@ func TestGetHooks(t *testing.T) {
 			resp:     helm.ReleaseMock(&helm.MockReleaseOptions{Name: "aeneas"}),
 			rels:     []*release.Release{helm.ReleaseMock(&helm.MockReleaseOptions{Name: "aeneas"})},
 		},
+		{
+			name:     "get hooks with json output",
+			args:     []string{"aeneas"},
+			flags:    []string{"--output", "json"},
+			expected: regexp.QuoteMeta(`[{"name":"pre-install-hook","kind":"Job","path":"pre-install-hook.yaml","events":["PRE_INSTALL"],"weight":0,"deletePolicies":[],"manifest":`),
+			resp:     helm.ReleaseMock(&helm.MockReleaseOptions{Name: "aeneas"}),
+			rels:     []*release.Release{helm.ReleaseMock(&helm.MockReleaseOptions{Name: "aeneas"})},
+		},
 		{
 			name: "get hooks without args",
 			args: []string{},
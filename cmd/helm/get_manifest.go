@@ -19,10 +19,13 @@ package main
 import (
 	"fmt"
 	"io"
+	"strings"
 
+	"github.com/ghodss/yaml"
 	"github.com/spf13/cobra"
 
 	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/releaseutil"
 )
 
 var getManifestHelp = `
@@ -30,20 +33,34 @@ This command fetches the generated manifest for a given release.
 
 A manifest is a YAML-encoded representation of the Kubernetes resources that
 were generated from this release's chart(s). If a chart is dependent on other
-charts, those resources will also be included in the manifest.
+charts, those resources will also be included in the manifest. The other output
+formats write the resources as a list of objects, see 'docs/output.md'.
 `
 
 type getManifestCmd struct {
-	release string
-	out     io.Writer
-	client  helm.Interface
-	version int32
+	release   string
+	out       io.Writer
+	client    helm.Interface
+	namespace string
+	version   int32
+	output    outputFormat
+}
+
+// manifestOutput is a resource as written by 'helm get manifest' in the
+// structured output formats.
+type manifestOutput struct {
+	// Source is the template the resource was rendered from.
+	Source   string `json:"source"`
+	Kind     string `json:"kind"`
+	Name     string `json:"name"`
+	Manifest string `json:"manifest"`
 }
 
 func newGetManifestCmd(client helm.Interface, out io.Writer) *cobra.Command {
 	get := &getManifestCmd{
 		out:    out,
 		client: client,
+		output: newOutputFormat("table"),
 	}
 	cmd := &cobra.Command{
 		Use:     "manifest [flags] RELEASE_NAME",
@@ -55,14 +72,16 @@ func newGetManifestCmd(client helm.Interface, out io.Writer) *cobra.Command {
 				return errReleaseRequired
 			}
 			get.release = args[0]
-			get.client = ensureHelmClient(get.client)
+			get.client = ensureHelmClientInNamespace(get.client, get.namespace)
 			return get.run()
 		},
 	}
 
 	f := cmd.Flags()
 	settings.AddFlagsTLS(f)
+	addReleaseNamespaceFlag(f, &get.namespace)
 	f.Int32Var(&get.version, "revision", 0, "get the named release with revision")
+	f.Var(&get.output, "output", outputUsage)
 
 	// set defaults from environment
 	settings.InitTLS(f)
@@ -76,6 +95,30 @@ func (g *getManifestCmd) run() error {
 	if err != nil {
 		return prettyError(err)
 	}
-	fmt.Fprintln(g.out, res.Release.Manifest)
-	return nil
+	return g.output.write(g.out, newManifestOutputs(res.Release.Manifest), func() error {
+		fmt.Fprintln(g.out, res.Release.Manifest)
+		return nil
+	})
+}
+
+// newManifestOutputs splits the manifest of a release into its resources.
+func newManifestOutputs(manifest string) []manifestOutput {
+	docs := releaseutil.SplitManifests(manifest)
+	out := make([]manifestOutput, 0, len(docs))
+	for i := 0; i < len(docs); i++ {
+		doc := docs[fmt.Sprintf("manifest-%d", i)]
+		o := manifestOutput{Manifest: doc}
+		if strings.HasPrefix(doc, "# Source: ") {
+			o.Source = strings.TrimPrefix(strings.SplitN(doc, "\n", 2)[0], "# Source: ")
+		}
+		var head releaseutil.SimpleHead
+		if err := yaml.Unmarshal([]byte(doc), &head); err == nil {
+			o.Kind = head.Kind
+			if head.Metadata != nil {
+				o.Name = head.Metadata.Name
+			}
+		}
+		out = append(out, o)
+	}
+	return out
 }
@@ -18,6 +18,7 @@ package main
 
 import (
 	"io"
+	"regexp"
 	"testing"
 
 	"github.com/spf13/cobra"
@@ -35,6 +36,22 @@ func TestGetManifest(t *testing.T) {
 			resp:     helm.ReleaseMock(&helm.MockReleaseOptions{Name: "juno"}),
 			rels:     []*release.Release{helm.ReleaseMock(&helm.MockReleaseOptions{Name: "juno"})},
 		},
+		{
+			name:     "get manifest with json output",
+			args:     []string{"juno"},
+			flags:    []string{"--output", "json"},
+			expected: regexp.QuoteMeta(`[{"source":"","kind":"Secret","name":"fixture","manifest":"apiVersion: v1\nkind: Secret\nmetadata:\n  name: fixture"}]`),
+			resp:     helm.ReleaseMock(&helm.MockReleaseOptions{Name: "juno"}),
+			rels:     []*release.Release{helm.ReleaseMock(&helm.MockReleaseOptions{Name: "juno"})},
+		},
+		{
+			name:     "get manifest with jsonpath output",
+			args:     []string{"juno"},
+			flags:    []string{"--output", "jsonpath={[*].kind}/{[*].name}"},
+			expected: "Secret/fixture",
+			resp:     helm.ReleaseMock(&helm.MockReleaseOptions{Name: "juno"}),
+			rels:     []*release.Release{helm.ReleaseMock(&helm.MockReleaseOptions{Name: "juno"})},
+		},
 		{
 			name: "get manifest without args",
 			args: []string{},
@@ -30,10 +30,11 @@ This command shows notes provided by the chart of a named release.
 `
 
 type getNotesCmd struct {
-	release string
-	out     io.Writer
-	client  helm.Interface
-	version int32
+	release   string
+	out       io.Writer
+	client    helm.Interface
+	namespace string
+	version   int32
 }
 
 func newGetNotesCmd(client helm.Interface, out io.Writer) *cobra.Command {
@@ -53,7 +54,7 @@ func newGetNotesCmd(client helm.Interface, out io.Writer) *cobra.Command {
 			}
 			get.release = args[0]
 			if get.client == nil {
-				get.client = newClient()
+				get.client = newClientInNamespace(get.namespace)
 			}
 			return get.run()
 		},
@@ -61,6 +62,7 @@ func newGetNotesCmd(client helm.Interface, out io.Writer) *cobra.Command {
 
 	f := cmd.Flags()
 	settings.AddFlagsTLS(f)
+	addReleaseNamespaceFlag(f, &get.namespace)
 	f.Int32Var(&get.version, "revision", 0, "get the notes of the named release with revision")
 
 	// set defaults from environment
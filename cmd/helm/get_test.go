@@ -35,6 +35,14 @@ func TestGetCmd(t *testing.T) {
 			expected: "REVISION: 1\nRELEASED: (.*)\nCHART: foo-0.1.0-beta.1\nUSER-SUPPLIED VALUES:\nname: \"value\"\nCOMPUTED VALUES:\nname: value\n\nHOOKS:\n---\n# pre-install-hook\n" + helm.MockHookTemplate + "\nMANIFEST:",
 			rels:     []*release.Release{helm.ReleaseMock(&helm.MockReleaseOptions{Name: "thomas-guide"})},
 		},
+		{
+			name:     "get with go-template output",
+			args:     []string{"thomas-guide"},
+			flags:    []string{"--output", "go-template={{.name}} {{.revision}} {{.status}} {{.chart}} {{.values.name}} {{len .hooks}}"},
+			expected: "thomas-guide 1 DEPLOYED foo-0.1.0-beta.1 value 1",
+			resp:     helm.ReleaseMock(&helm.MockReleaseOptions{Name: "thomas-guide"}),
+			rels:     []*release.Release{helm.ReleaseMock(&helm.MockReleaseOptions{Name: "thomas-guide"})},
+		},
 		{
 			name: "get requires release name arg",
 			err:  true,
@@ -17,7 +17,6 @@ limitations under the License.
 package main
 
 import (
-	"encoding/json"
 	"fmt"
 	"io"
 
@@ -29,6 +28,9 @@ import (
 
 var getValuesHelp = `
 This command downloads a values file for a given release.
+
+The values are written in YAML by default. The table output format writes
+them in YAML too.
 `
 
 type getValuesCmd struct {
@@ -36,14 +38,16 @@ type getValuesCmd struct {
 	allValues bool
 	out       io.Writer
 	client    helm.Interface
+	namespace string
 	version   int32
-	output    string
+	output    outputFormat
 }
 
 func newGetValuesCmd(client helm.Interface, out io.Writer) *cobra.Command {
 	get := &getValuesCmd{
 		out:    out,
 		client: client,
+		output: outputFormat{format: "yaml", yamlBlankLine: true},
 	}
 	cmd := &cobra.Command{
 		Use:     "values [flags] RELEASE_NAME",
@@ -55,16 +59,17 @@ func newGetValuesCmd(client helm.Interface, out io.Writer) *cobra.Command {
 				return errReleaseRequired
 			}
 			get.release = args[0]
-			get.client = ensureHelmClient(get.client)
+			get.client = ensureHelmClientInNamespace(get.client, get.namespace)
 			return get.run()
 		},
 	}
 
 	f := cmd.Flags()
 	settings.AddFlagsTLS(f)
+	addReleaseNamespaceFlag(f, &get.namespace)
 	f.Int32Var(&get.version, "revision", 0, "get the named release with revision")
 	f.BoolVarP(&get.allValues, "all", "a", false, "dump all (computed) values")
-	f.StringVar(&get.output, "output", "yaml", "output the specified format (json or yaml)")
+	f.Var(&get.output, "output", outputUsage)
 
 	// set defaults from environment
 	settings.InitTLS(f)
@@ -92,29 +97,12 @@ func (g *getValuesCmd) run() error {
 		}
 	}
 
-	result, err := formatValues(g.output, values)
-	if err != nil {
-		return err
-	}
-	fmt.Fprintln(g.out, result)
-	return nil
-}
-
-func formatValues(format string, values chartutil.Values) (string, error) {
-	switch format {
-	case "", "yaml":
+	return g.output.write(g.out, values, func() error {
 		out, err := values.YAML()
 		if err != nil {
-			return "", err
-		}
-		return out, nil
-	case "json":
-		out, err := json.Marshal(values)
-		if err != nil {
-			return "", fmt.Errorf("Failed to Marshal JSON output: %s", err)
+			return err
 		}
-		return string(out), nil
-	default:
-		return "", fmt.Errorf("Unknown output format %q", format)
-	}
+		fmt.Fprintln(g.out, out)
+		return nil
+	})
 }
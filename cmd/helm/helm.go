@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"log"
+	"net/http"
 	"os"
 	"strings"
 
@@ -63,6 +64,7 @@ Common actions from this point include:
 Environment:
   $HELM_HOME           set an alternative location for Helm files. By default, these are stored in ~/.helm
   $HELM_HOST           set an alternative Tiller host. The format is host:port
+  $HELM_TILLERLESS     run the release server in-process instead of connecting to Tiller. Set HELM_TILLERLESS=1 to enable.
   $HELM_NO_PLUGINS     disable plugins. Set HELM_NO_PLUGINS=1 to disable plugins.
   $TILLER_NAMESPACE    set an alternative Tiller namespace (default "kube-system")
   $KUBECONFIG          set an alternative Kubernetes configuration file (default "~/.kube/config")
@@ -112,25 +114,33 @@ func newRootCmd(args []string) *cobra.Command {
 
 	cmd.AddCommand(
 		// chart commands
+		newChartCmd(out),
 		newCreateCmd(out),
 		newDependencyCmd(out),
 		newFetchCmd(out),
 		newInspectCmd(out),
 		newLintCmd(out),
 		newPackageCmd(out),
+		newRegistryCmd(out),
 		newRepoCmd(out),
 		newSearchCmd(out),
 		newServeCmd(out),
 		newVerifyCmd(out),
 
 		// release commands
+		newBackupCmd(out),
 		newDeleteCmd(nil, out),
+		newDiffCmd(out),
 		newGetCmd(nil, out),
 		newHistoryCmd(nil, out),
 		newInstallCmd(nil, out),
 		newListCmd(nil, out),
+		newMigrateStorageCmd(out),
+		newRepairCmd(nil, out),
+		newRestoreCmd(out),
 		newRollbackCmd(nil, out),
 		newStatusCmd(nil, out),
+		newUnlockCmd(nil, out),
 		newUpgradeCmd(nil, out),
 
 		newReleaseTestCmd(nil, out),
@@ -184,6 +194,10 @@ func markDeprecated(cmd *cobra.Command, notice string) *cobra.Command {
 }
 
 func setupConnection() error {
+	if settings.Local {
+		// The release server runs in-process, see newLocalServer.
+		return nil
+	}
 	if settings.TillerHost == "" {
 		config, client, err := getKubeClient(settings.KubeContext, settings.KubeConfig)
 		if err != nil {
@@ -268,7 +282,32 @@ func ensureHelmClient(h helm.Interface) helm.Interface {
 	return newClient()
 }
 
+// ensureHelmClientInNamespace returns h, or a new helm client keeping
+// releases in namespace without Tiller if h is nil.
+func ensureHelmClientInNamespace(h helm.Interface, namespace string) helm.Interface {
+	if h != nil {
+		return h
+	}
+	return newClientInNamespace(namespace)
+}
+
 func newClient() helm.Interface {
+	return newClientInNamespace("")
+}
+
+// newClientInNamespace returns a new helm client. With --local, the client
+// runs the release server in-process, keeping releases in namespace or in the
+// namespace of the kubeconfig context.
+func newClientInNamespace(namespace string) helm.Interface {
+	if settings.Local {
+		svc, err := newLocalServer(namespace)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(2)
+		}
+		return helm.NewClient(helm.Local(svc))
+	}
+
 	options := []helm.Option{helm.Host(settings.TillerHost), helm.ConnectTimeout(settings.TillerConnectionTimeout)}
 
 	if settings.TLSVerify || settings.TLSEnable {
@@ -290,5 +329,63 @@ func newClient() helm.Interface {
 		}
 		options = append(options, helm.WithTLS(tlscfg))
 	}
+	if settings.SendToken {
+		// The port forward is tunneled by the Kubernetes API server, any
+		// other connection needs TLS to protect the token.
+		tunneled := tillerTunnel != nil
+		if !tunneled && !settings.TLSEnable && !settings.TLSVerify {
+			fmt.Fprintln(os.Stderr, "Error: --send-token requires --tls when Tiller is not reached through the port forward")
+			os.Exit(2)
+		}
+		token, err := kubeToken(settings.KubeContext, settings.KubeConfig)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: cannot get a bearer token to authenticate to Tiller: %s\n", err)
+			os.Exit(2)
+		}
+		switch {
+		case token == "":
+			debug("the kubeconfig has no bearer token to authenticate to Tiller")
+		case tunneled:
+			options = append(options, helm.WithInsecureBearerToken(token))
+		default:
+			options = append(options, helm.WithBearerToken(token))
+		}
+	}
 	return helm.NewClient(options...)
 }
+
+// kubeToken returns the bearer token the kubeconfig authenticates to
+// Kubernetes with, if any, including tokens of auth provider and exec plugins.
+func kubeToken(context, kubeconfig string) (string, error) {
+	config, err := configForContext(context, kubeconfig)
+	if err != nil {
+		return "", err
+	}
+
+	// Record the Authorization header of a request instead of sending it.
+	var header string
+	rt, err := rest.HTTPWrappersForConfig(config, roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		header = req.Header.Get("Authorization")
+		return &http.Response{StatusCode: http.StatusOK, Body: ioutil.NopCloser(strings.NewReader("")), Request: req}, nil
+	}))
+	if err != nil {
+		return "", err
+	}
+	req, err := http.NewRequest("GET", config.Host, nil)
+	if err != nil {
+		return "", err
+	}
+	if _, err := rt.RoundTrip(req); err != nil {
+		return "", err
+	}
+	if !strings.HasPrefix(header, "Bearer ") {
+		return "", nil
+	}
+	return strings.TrimPrefix(header, "Bearer "), nil
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
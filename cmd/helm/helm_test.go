@@ -540,6 +540,58 @@ func TestTLSFlags(t *testing.T) {
 	}
 }
 
+func TestKubeToken(t *testing.T) {
+	dir, err := ioutil.TempDir("", "helm-kubeconfig-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	kubeconfig := `apiVersion: v1
+kind: Config
+clusters:
+- name: test
+  cluster:
+    server: https://127.0.0.1:6443
+users:
+- name: token
+  user:
+    token: secret-token
+- name: basic
+  user:
+    username: admin
+    password: secret
+contexts:
+- name: token
+  context: {cluster: test, user: token}
+- name: basic
+  context: {cluster: test, user: basic}
+current-context: token
+`
+	path := filepath.Join(dir, "config")
+	if err := ioutil.WriteFile(path, []byte(kubeconfig), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		context string
+		token   string
+	}{
+		{context: "", token: "secret-token"},
+		{context: "basic", token: ""},
+	}
+	for _, tt := range tests {
+		token, err := kubeToken(tt.context, path)
+		if err != nil {
+			t.Errorf("context %q: %s", tt.context, err)
+			continue
+		}
+		if token != tt.token {
+			t.Errorf("context %q: expected token %q, got %q", tt.context, tt.token, token)
+		}
+	}
+}
+
 func resetEnv() func() {
 	origSettings := settings
 	origEnv := os.Environ()
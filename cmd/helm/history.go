@@ -17,11 +17,9 @@ limitations under the License.
 package main
 
 import (
-	"encoding/json"
 	"fmt"
 	"io"
 
-	"github.com/ghodss/yaml"
 	"github.com/gosuri/uitable"
 	"github.com/spf13/cobra"
 
@@ -58,16 +56,17 @@ The historical release set is printed as a formatted table, e.g:
 `
 
 type historyCmd struct {
-	max          int32
-	rls          string
-	out          io.Writer
-	helmc        helm.Interface
-	colWidth     uint
-	outputFormat string
+	max       int32
+	rls       string
+	out       io.Writer
+	helmc     helm.Interface
+	namespace string
+	colWidth  uint
+	output    outputFormat
 }
 
 func newHistoryCmd(c helm.Interface, w io.Writer) *cobra.Command {
-	his := &historyCmd{out: w, helmc: c}
+	his := &historyCmd{out: w, helmc: c, output: outputFormat{format: "table", yamlBlankLine: true}}
 
 	cmd := &cobra.Command{
 		Use:     "history [flags] RELEASE_NAME",
@@ -80,7 +79,7 @@ func newHistoryCmd(c helm.Interface, w io.Writer) *cobra.Command {
 			case len(args) == 0:
 				return errReleaseRequired
 			case his.helmc == nil:
-				his.helmc = newClient()
+				his.helmc = newClientInNamespace(his.namespace)
 			}
 			his.rls = args[0]
 			return his.run()
@@ -89,9 +88,10 @@ func newHistoryCmd(c helm.Interface, w io.Writer) *cobra.Command {
 
 	f := cmd.Flags()
 	settings.AddFlagsTLS(f)
+	addReleaseNamespaceFlag(f, &his.namespace)
 	f.Int32Var(&his.max, "max", 256, "maximum number of revision to include in history")
 	f.UintVar(&his.colWidth, "col-width", 60, "specifies the max column width of output")
-	f.StringVarP(&his.outputFormat, "output", "o", "table", "prints the output in the specified format (json|table|yaml)")
+	f.VarP(&his.output, "output", "o", outputUsage)
 
 	// set defaults from environment
 	settings.InitTLS(f)
@@ -109,27 +109,10 @@ func (cmd *historyCmd) run() error {
 	}
 
 	releaseHistory := getReleaseHistory(r.Releases)
-
-	var history []byte
-	var formattingError error
-
-	switch cmd.outputFormat {
-	case "yaml":
-		history, formattingError = yaml.Marshal(releaseHistory)
-	case "json":
-		history, formattingError = json.Marshal(releaseHistory)
-	case "table":
-		history = formatAsTable(releaseHistory, cmd.colWidth)
-	default:
-		return fmt.Errorf("unknown output format %q", cmd.outputFormat)
-	}
-
-	if formattingError != nil {
-		return prettyError(formattingError)
-	}
-
-	fmt.Fprintln(cmd.out, string(history))
-	return nil
+	return cmd.output.write(cmd.out, releaseHistory, func() error {
+		fmt.Fprintln(cmd.out, string(formatAsTable(releaseHistory, cmd.colWidth)))
+		return nil
+	})
 }
 
 func getReleaseHistory(rls []*release.Release) (history releaseHistory) {
@@ -25,6 +25,7 @@ import (
 	"strings"
 
 	"k8s.io/helm/pkg/chartutil"
+	"k8s.io/helm/pkg/proto/hapi/chart"
 )
 
 const inspectDesc = `
@@ -32,6 +33,7 @@ This command inspects a chart and displays information. It takes a chart referen
 ('stable/drupal'), a full path to a directory or packaged chart, or a URL.
 
 Inspect prints the contents of the Chart.yaml file and the values.yaml file.
+The other output formats write them as an object, see 'docs/output.md'.
 `
 
 const inspectValuesDesc = `
@@ -60,12 +62,23 @@ type inspectCmd struct {
 	username  string
 	password  string
 	devel     bool
+	format    outputFormat
 
 	certFile string
 	keyFile  string
 	caFile   string
 }
 
+// inspectOutput is the object written by 'helm inspect' in the structured
+// output formats. Only the parts of the chart inspected are set.
+type inspectOutput struct {
+	// Chart is the content of Chart.yaml.
+	Chart *chart.Metadata `json:"chart,omitempty"`
+	// Values is the content of values.yaml.
+	Values chartutil.Values `json:"values,omitempty"`
+	Readme string           `json:"readme,omitempty"`
+}
+
 const (
 	chartOnly  = "chart"
 	valuesOnly = "values"
@@ -79,6 +92,7 @@ func newInspectCmd(out io.Writer) *cobra.Command {
 	insp := &inspectCmd{
 		out:    out,
 		output: all,
+		format: newOutputFormat("table"),
 	}
 
 	inspectCommand := &cobra.Command{
@@ -206,6 +220,10 @@ func newInspectCmd(out io.Writer) *cobra.Command {
 		subCmd.Flags().StringVar(&insp.caFile, caFile, "", caFiledesc)
 	}
 
+	for _, subCmd := range cmds {
+		subCmd.Flags().Var(&insp.format, "output", outputUsage)
+	}
+
 	for _, subCmd := range cmds[1:] {
 		inspectCommand.AddCommand(subCmd)
 	}
@@ -234,6 +252,9 @@ func (i *inspectCmd) run() error {
 	if err != nil {
 		return err
 	}
+	if !i.format.isTable() {
+		return i.writeObject(chrt)
+	}
 	cf, err := yaml.Marshal(chrt.Metadata)
 	if err != nil {
 		return err
@@ -263,6 +284,28 @@ func (i *inspectCmd) run() error {
 	return nil
 }
 
+// writeObject writes the inspected parts of chrt in the structured output
+// format.
+func (i *inspectCmd) writeObject(chrt *chart.Chart) error {
+	var obj inspectOutput
+	if i.output == chartOnly || i.output == all {
+		obj.Chart = chrt.Metadata
+	}
+	if (i.output == valuesOnly || i.output == all) && chrt.Values != nil {
+		values, err := chartutil.ReadValues([]byte(chrt.Values.Raw))
+		if err != nil {
+			return err
+		}
+		obj.Values = values
+	}
+	if i.output == readmeOnly || i.output == all {
+		if readme := findReadme(chrt.Files); readme != nil {
+			obj.Readme = string(readme.Value)
+		}
+	}
+	return i.format.write(i.out, obj, nil)
+}
+
 func findReadme(files []*any.Any) (file *any.Any) {
 	for _, file := range files {
 		if containsString(readmeFileNames, strings.ToLower(file.TypeUrl), nil) {
@@ -82,6 +82,32 @@ func TestInspect(t *testing.T) {
 	}
 }
 
+func TestInspectOutput(t *testing.T) {
+	b := bytes.NewBuffer(nil)
+	insp := &inspectCmd{
+		chartpath: "testdata/testcharts/alpine",
+		output:    chartOnly,
+		format:    outputFormat{format: "jsonpath", expr: "{.chart.name} {.chart.version} {.values}"},
+		out:       b,
+	}
+	if err := insp.run(); err != nil {
+		t.Fatal(err)
+	}
+	if expect := "alpine 0.1.0 "; b.String() != expect {
+		t.Errorf("expected %q, got %q", expect, b.String())
+	}
+
+	b.Reset()
+	insp.output = valuesOnly
+	insp.format = outputFormat{format: "json"}
+	if err := insp.run(); err != nil {
+		t.Fatal(err)
+	}
+	if expect := `{"values":{"Name":"my-alpine"}}` + "\n"; b.String() != expect {
+		t.Errorf("expected %q, got %q", expect, b.String())
+	}
+}
+
 func TestInspectPreReleaseChart(t *testing.T) {
 	hh, err := tempHelmHome(t)
 	if err != nil {
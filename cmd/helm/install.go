@@ -190,7 +190,7 @@ func newInstallCmd(c helm.Interface, out io.Writer) *cobra.Command {
 				return err
 			}
 			inst.chartPath = cp
-			inst.client = ensureHelmClient(inst.client)
+			inst.client = ensureHelmClientInNamespace(inst.client, inst.namespace)
 			inst.wait = inst.wait || inst.atomic
 
 			return inst.run()
@@ -309,6 +309,7 @@ func (i *installCmd) run() error {
 		helm.InstallSubNotes(i.subNotes),
 		helm.InstallTimeout(i.timeout),
 		helm.InstallWait(i.wait),
+		helm.InstallProgress(printProgress(i.out)),
 		helm.InstallDescription(i.description))
 	if err != nil {
 		if i.atomic {
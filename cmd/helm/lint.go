@@ -29,8 +29,10 @@ import (
 	"github.com/spf13/cobra"
 
 	"k8s.io/helm/pkg/chartutil"
+	"k8s.io/helm/pkg/kubeschema"
 	"k8s.io/helm/pkg/lint"
 	"k8s.io/helm/pkg/lint/support"
+	"k8s.io/helm/pkg/policy"
 	"k8s.io/helm/pkg/strvals"
 )
 
@@ -41,23 +43,84 @@ the chart is well-formed.
 If the linter encounters things that will cause the chart to fail installation,
 it will emit [ERROR] messages. If it encounters issues that break with convention
 or recommendation, it will emit [WARNING] messages.
+
+Each message names the rule reporting it. The rules can be disabled or their
+severity changed in a '.helmlint.yaml' file in the chart, or in the file given
+with --lint-config, see 'docs/lint.md'. The command fails when a chart has
+messages of the --fail-on severity or higher.
+
+With --policy-config, the rendered manifests are also checked against the
+policy rules configured in the given file. Violations are reported as [ERROR]
+messages, or as [WARNING] messages if the rules only warn.
+
+With --validate, the rendered manifests are also validated offline against the
+Kubernetes schemas of --kube-version, bundled or read from --schema-dir, and
+the custom resources against the custom resource definitions of the chart and
+of --crd-dir. Unknown fields, fields of the wrong type and missing required
+fields are reported as [ERROR] messages.
+
+The other output formats write the messages of each chart as an object, see
+'docs/output.md', or as a JUnit XML or SARIF report. The command fails the same
+way whatever the format.
 `
 
 type lintCmd struct {
-	valueFiles valueFiles
-	values     []string
-	sValues    []string
-	fValues    []string
-	namespace  string
-	strict     bool
-	paths      []string
-	out        io.Writer
+	valueFiles   valueFiles
+	values       []string
+	sValues      []string
+	fValues      []string
+	namespace    string
+	strict       bool
+	policyConfig string
+	lintConfig   string
+	failOn       string
+	validate     bool
+	kubeVersion  string
+	schemaDir    string
+	crdDir       string
+	paths        []string
+	out          io.Writer
+	output       outputFormat
+}
+
+// lintOutput is the object written by 'helm lint' in the structured output
+// formats.
+type lintOutput struct {
+	Charts []lintChartOutput `json:"charts"`
+	// Total is the number of charts linted, and Failures the number of charts
+	// that failed.
+	Total    int `json:"total"`
+	Failures int `json:"failures"`
+}
+
+type lintChartOutput struct {
+	Path string `json:"path"`
+	// Error is why the chart was skipped, if it could not be linted.
+	Error    string              `json:"error,omitempty"`
+	Messages []lintMessageOutput `json:"messages"`
+	Failed   bool                `json:"failed"`
+}
+
+type lintMessageOutput struct {
+	// Rule is the ID of the rule reporting the message.
+	Rule string `json:"rule,omitempty"`
+	// Severity is one of INFO, WARNING or ERROR.
+	Severity string `json:"severity"`
+	Path     string `json:"path"`
+	Message  string `json:"message"`
+	// Failed reports whether the message fails the chart.
+	Failed bool `json:"failed"`
 }
 
+// lintOutputUsage is the usage of the --output flag of 'helm lint', which
+// also writes reports.
+const lintOutputUsage = "prints the output in the specified format (table|json|yaml|junit|sarif|go-template=TEMPLATE|jsonpath=EXPRESSION)"
+
 func newLintCmd(out io.Writer) *cobra.Command {
 	l := &lintCmd{
-		paths: []string{"."},
-		out:   out,
+		paths:  []string{"."},
+		out:    out,
+		output: newOutputFormat("table"),
 	}
 	cmd := &cobra.Command{
 		Use:   "lint [flags] PATH",
@@ -77,6 +140,14 @@ func newLintCmd(out io.Writer) *cobra.Command {
 	cmd.Flags().StringArrayVar(&l.fValues, "set-file", []string{}, "set values from respective files specified via the command line (can specify multiple or separate values with commas: key1=path1,key2=path2)")
 	cmd.Flags().StringVar(&l.namespace, "namespace", "default", "namespace to put the release into")
 	cmd.Flags().BoolVar(&l.strict, "strict", false, "fail on lint warnings")
+	cmd.Flags().StringVar(&l.policyConfig, "policy-config", "", "check the rendered manifests against the policy rules configured in this file")
+	cmd.Flags().StringVar(&l.lintConfig, "lint-config", "", "configure the lint rules with this file instead of the .helmlint.yaml file of the charts")
+	cmd.Flags().StringVar(&l.failOn, "fail-on", "", "fail on messages of this severity or higher (warning|error). Defaults to warning with --strict, error otherwise")
+	cmd.Flags().BoolVar(&l.validate, "validate", false, "validate the rendered manifests against the Kubernetes schemas of --kube-version")
+	cmd.Flags().StringVar(&l.kubeVersion, "kube-version", "", "with --validate, the Kubernetes version to render and validate the manifests for. Defaults to the latest bundled version")
+	cmd.Flags().StringVar(&l.schemaDir, "schema-dir", "", "with --validate, read the schemas from the OpenAPI document named after --kube-version in this directory, such as 1.13.json, if there is one")
+	cmd.Flags().StringVar(&l.crdDir, "crd-dir", "", "with --validate, validate custom resources against the custom resource definitions in this directory")
+	cmd.Flags().VarP(&l.output, "output", "o", lintOutputUsage)
 
 	return cmd
 }
@@ -85,10 +156,13 @@ var errLintNoChart = errors.New("No chart found for linting (missing Chart.yaml)
 
 func (l *lintCmd) run() error {
 	var lowestTolerance int
-	if l.strict {
+	switch {
+	case l.failOn == "warning" || l.failOn == "" && l.strict:
 		lowestTolerance = support.WarningSev
-	} else {
+	case l.failOn == "error" || l.failOn == "":
 		lowestTolerance = support.ErrorSev
+	default:
+		return fmt.Errorf("unknown severity %q for --fail-on, expected warning or error", l.failOn)
 	}
 
 	// Get the raw values
@@ -97,45 +171,105 @@ func (l *lintCmd) run() error {
 		return err
 	}
 
-	var total int
-	var failures int
+	opts := lint.Options{Namespace: l.namespace, Strict: l.strict}
+	if l.policyConfig != "" {
+		if opts.Checker, err = policy.LoadConfig(l.policyConfig); err != nil {
+			return err
+		}
+	}
+	if l.lintConfig != "" {
+		if opts.Config, err = lint.LoadConfig(l.lintConfig); err != nil {
+			return err
+		}
+	}
+	if l.validate {
+		kubeVersion := l.kubeVersion
+		if kubeVersion == "" {
+			kubeVersion = kubeschema.DefaultVersion()
+		}
+		if opts.Schemas, err = loadKubeSchemas(kubeVersion, l.schemaDir, l.crdDir); err != nil {
+			return err
+		}
+	}
+
+	table := l.output.isTable()
+	result := lintOutput{Charts: []lintChartOutput{}}
 	for _, path := range l.paths {
-		if linter, err := lintChart(path, rvals, l.namespace, l.strict); err != nil {
-			fmt.Println("==> Skipping", path)
-			fmt.Println(err)
+		chart := lintChartOutput{Path: path, Messages: []lintMessageOutput{}}
+		if linter, err := lintChartWithOptions(path, rvals, opts); err != nil {
+			if table {
+				fmt.Println("==> Skipping", path)
+				fmt.Println(err)
+			}
+			chart.Error = err.Error()
 			if err == errLintNoChart {
-				failures = failures + 1
+				chart.Failed = true
 			}
 		} else {
-			fmt.Println("==> Linting", path)
-
-			if len(linter.Messages) == 0 {
-				fmt.Println("Lint OK")
+			if table {
+				fmt.Println("==> Linting", path)
+				if len(linter.Messages) == 0 {
+					fmt.Println("Lint OK")
+				}
 			}
 
 			for _, msg := range linter.Messages {
-				fmt.Println(msg)
+				if table {
+					fmt.Println(msg)
+				}
+				chart.Messages = append(chart.Messages, lintMessageOutput{
+					Rule:     msg.RuleID(),
+					Severity: support.SeverityString(msg.Severity),
+					Path:     msg.Path,
+					Message:  msg.Err.Error(),
+					Failed:   msg.Severity >= lowestTolerance,
+				})
 			}
 
-			total = total + 1
-			if linter.HighestSeverity >= lowestTolerance {
-				failures = failures + 1
-			}
+			result.Total = result.Total + 1
+			chart.Failed = linter.HighestSeverity >= lowestTolerance
 		}
-		fmt.Println("")
+		if chart.Failed {
+			result.Failures = result.Failures + 1
+		}
+		if table {
+			fmt.Println("")
+		}
+		result.Charts = append(result.Charts, chart)
 	}
 
-	msg := fmt.Sprintf("%d chart(s) linted", total)
-	if failures > 0 {
-		return fmt.Errorf("%s, %d chart(s) failed", msg, failures)
+	msg := fmt.Sprintf("%d chart(s) linted", result.Total)
+	switch l.output.String() {
+	case "junit":
+		err = writeJUnitReport(l.out, result)
+	case "sarif":
+		err = writeSARIFReport(l.out, result)
+	default:
+		err = l.output.write(l.out, result, func() error {
+			if result.Failures == 0 {
+				fmt.Fprintf(l.out, "%s, no failures\n", msg)
+			}
+			return nil
+		})
+	}
+	if err != nil {
+		return err
+	}
+	if result.Failures > 0 {
+		return fmt.Errorf("%s, %d chart(s) failed", msg, result.Failures)
 	}
-
-	fmt.Fprintf(l.out, "%s, no failures\n", msg)
-
 	return nil
 }
 
 func lintChart(path string, vals []byte, namespace string, strict bool) (support.Linter, error) {
+	return lintChartWithPolicies(path, vals, namespace, strict, nil)
+}
+
+func lintChartWithPolicies(path string, vals []byte, namespace string, strict bool, checker *policy.Checker) (support.Linter, error) {
+	return lintChartWithOptions(path, vals, lint.Options{Namespace: namespace, Strict: strict, Checker: checker})
+}
+
+func lintChartWithOptions(path string, vals []byte, opts lint.Options) (support.Linter, error) {
 	var chartPath string
 	linter := support.Linter{}
 
@@ -171,7 +305,7 @@ func lintChart(path string, vals []byte, namespace string, strict bool) (support
 		return linter, errLintNoChart
 	}
 
-	return lint.All(chartPath, vals, namespace, strict), nil
+	return lint.AllWithOptions(chartPath, vals, opts), nil
 }
 
 // vals merges values from files specified via -f/--values and
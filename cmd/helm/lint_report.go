@@ -0,0 +1,230 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path/filepath"
+
+	"k8s.io/helm/pkg/lint/rules"
+	"k8s.io/helm/pkg/lint/support"
+	"k8s.io/helm/pkg/version"
+)
+
+// JUnit XML reports have a test suite per chart, and a test case per
+// message. Messages failing the chart are reported as failures, and charts
+// that cannot be linted as errors.
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Errors   int              `xml:"errors,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Errors   int             `xml:"errors,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	Classname string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	Error     *junitFailure `xml:"error,omitempty"`
+	Skipped   *junitFailure `xml:"skipped,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr,omitempty"`
+	Text    string `xml:",chardata"`
+}
+
+func writeJUnitReport(out io.Writer, result lintOutput) error {
+	report := junitTestSuites{Name: "helm lint"}
+	for _, chart := range result.Charts {
+		suite := junitTestSuite{Name: chart.Path}
+		if chart.Error != "" {
+			tc := junitTestCase{Name: "lint", Classname: chart.Path}
+			f := &junitFailure{Message: chart.Error}
+			if chart.Failed {
+				tc.Error = f
+				suite.Errors++
+			} else {
+				tc.Skipped = f
+			}
+			suite.Cases = append(suite.Cases, tc)
+		}
+		for _, msg := range chart.Messages {
+			name := msg.Path
+			if msg.Rule != "" {
+				name = msg.Rule + " " + msg.Path
+			}
+			tc := junitTestCase{Name: name, Classname: chart.Path}
+			if msg.Failed {
+				tc.Failure = &junitFailure{Message: msg.Message, Type: msg.Severity, Text: msg.Message}
+				suite.Failures++
+			} else {
+				tc.SystemOut = fmt.Sprintf("[%s] %s", msg.Severity, msg.Message)
+			}
+			suite.Cases = append(suite.Cases, tc)
+		}
+		if len(suite.Cases) == 0 {
+			suite.Cases = append(suite.Cases, junitTestCase{Name: "lint", Classname: chart.Path})
+		}
+		suite.Tests = len(suite.Cases)
+
+		report.Tests += suite.Tests
+		report.Failures += suite.Failures
+		report.Errors += suite.Errors
+		report.Suites = append(report.Suites, suite)
+	}
+
+	b, err := xml.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(out, "%s%s\n", xml.Header, b)
+	return err
+}
+
+// SARIF reports follow version 2.1.0 of the Static Analysis Results
+// Interchange Format, with a result per message.
+type sarifReport struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	Version        string      `json:"version,omitempty"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID                   string             `json:"id"`
+	ShortDescription     sarifMessage       `json:"shortDescription"`
+	DefaultConfiguration sarifConfiguration `json:"defaultConfiguration"`
+}
+
+type sarifConfiguration struct {
+	Level string `json:"level"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId,omitempty"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+// sarifLevels maps the severities of messages to SARIF levels.
+var sarifLevels = map[string]string{
+	support.SeverityString(support.UnknownSev): "none",
+	support.SeverityString(support.InfoSev):    "note",
+	support.SeverityString(support.WarningSev): "warning",
+	support.SeverityString(support.ErrorSev):   "error",
+}
+
+func writeSARIFReport(out io.Writer, result lintOutput) error {
+	run := sarifRun{
+		Tool: sarifTool{Driver: sarifDriver{
+			Name:           "helm lint",
+			Version:        version.GetVersion(),
+			InformationURI: "https://docs.helm.sh/",
+		}},
+		Results: []sarifResult{},
+	}
+	for _, r := range rules.All() {
+		run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule{
+			ID:                   r.ID,
+			ShortDescription:     sarifMessage{Text: r.Description},
+			DefaultConfiguration: sarifConfiguration{Level: sarifLevels[support.SeverityString(r.Severity)]},
+		})
+	}
+
+	for _, chart := range result.Charts {
+		if chart.Error != "" {
+			run.Results = append(run.Results, sarifResult{
+				Level:     "error",
+				Message:   sarifMessage{Text: chart.Error},
+				Locations: sarifLocations(chart.Path),
+			})
+		}
+		for _, msg := range chart.Messages {
+			run.Results = append(run.Results, sarifResult{
+				RuleID:    msg.Rule,
+				Level:     sarifLevels[msg.Severity],
+				Message:   sarifMessage{Text: msg.Message},
+				Locations: sarifLocations(filepath.Join(chart.Path, msg.Path)),
+			})
+		}
+	}
+
+	b, err := json.MarshalIndent(sarifReport{
+		Schema:  "https://raw.githubusercontent.com/oasis-tcs/sarif-spec/master/Schemata/sarif-schema-2.1.0.json",
+		Version: "2.1.0",
+		Runs:    []sarifRun{run},
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(out, string(b))
+	return err
+}
+
+func sarifLocations(path string) []sarifLocation {
+	return []sarifLocation{{PhysicalLocation: sarifPhysicalLocation{
+		ArtifactLocation: sarifArtifactLocation{URI: filepath.ToSlash(path)},
+	}}}
+}
@@ -17,7 +17,14 @@ limitations under the License.
 package main
 
 import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"strings"
 	"testing"
+
+	"k8s.io/helm/pkg/lint/support"
+	"k8s.io/helm/pkg/policy"
 )
 
 var (
@@ -52,3 +59,157 @@ func TestLintChart(t *testing.T) {
 		t.Errorf("Expected a chart parsing error")
 	}
 }
+
+func TestLintChartWithPolicies(t *testing.T) {
+	chartPath := "testdata/testcharts/alpine"
+	tests := []struct {
+		config   string
+		severity int
+	}{
+		{"testdata/policy/enforce.yaml", support.ErrorSev},
+		{"testdata/policy/warn.yaml", support.WarningSev},
+	}
+	for _, tt := range tests {
+		checker, err := policy.LoadConfig(tt.config)
+		if err != nil {
+			t.Fatal(err)
+		}
+		linter, err := lintChartWithPolicies(chartPath, []byte("test:\n  Name: policy"), namespace, strict, checker)
+		if err != nil {
+			t.Fatal(err)
+		}
+		last := linter.Messages[len(linter.Messages)-1]
+		if linter.HighestSeverity != tt.severity || last.Path != "templates/alpine-pod.yaml" || !strings.HasPrefix(last.Err.Error(), "[resource-limits]") {
+			t.Errorf("%s: expected a resource-limits violation of severity %d, got %v", tt.config, tt.severity, linter.Messages)
+		}
+	}
+}
+
+func TestLintCmdJSONOutput(t *testing.T) {
+	var buf bytes.Buffer
+	cmd := newLintCmd(&buf)
+	cmd.ParseFlags([]string{"--output", "json", "--set", "test.Name=policy", "--policy-config", "testdata/policy/enforce.yaml"})
+	err := cmd.RunE(cmd, []string{"testdata/testcharts/alpine", chartMissingManifest})
+	if err == nil || err.Error() != "1 chart(s) linted, 2 chart(s) failed" {
+		t.Errorf("expected the charts to fail, got %v", err)
+	}
+
+	var result lintOutput
+	if err := json.Unmarshal(buf.Bytes(), &result); err != nil {
+		t.Fatalf("cannot parse %q: %s", buf.String(), err)
+	}
+	if result.Total != 1 || result.Failures != 2 || len(result.Charts) != 2 {
+		t.Fatalf("expected 2 charts, 1 linted and 2 failed, got %+v", result)
+	}
+	alpine, missing := result.Charts[0], result.Charts[1]
+	last := alpine.Messages[len(alpine.Messages)-1]
+	if !alpine.Failed || last.Severity != "ERROR" || last.Path != "templates/alpine-pod.yaml" || !strings.HasPrefix(last.Message, "[resource-limits]") {
+		t.Errorf("expected a resource-limits error for alpine, got %+v", alpine)
+	}
+	if !missing.Failed || missing.Error != errLintNoChart.Error() {
+		t.Errorf("expected %s to be skipped, got %+v", chartMissingManifest, missing)
+	}
+}
+
+func TestLintCmdFailOn(t *testing.T) {
+	tests := []struct {
+		flags []string
+		err   string
+	}{
+		{[]string{}, ""},
+		{[]string{"--fail-on", "error"}, ""},
+		{[]string{"--fail-on", "warning"}, "1 chart(s) linted, 1 chart(s) failed"},
+		{[]string{"--strict"}, "1 chart(s) linted, 1 chart(s) failed"},
+		{[]string{"--fail-on", "info"}, `unknown severity "info"`},
+		{[]string{"--lint-config", "testdata/lint/ignore-policy.yaml", "--fail-on", "warning"}, ""},
+	}
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		cmd := newLintCmd(&buf)
+		flags := append([]string{"--output", "json", "--set", "test.Name=policy", "--policy-config", "testdata/policy/warn.yaml"}, tt.flags...)
+		cmd.ParseFlags(flags)
+		err := cmd.RunE(cmd, []string{"testdata/testcharts/alpine"})
+		if tt.err == "" && err != nil || tt.err != "" && (err == nil || !strings.Contains(err.Error(), tt.err)) {
+			t.Errorf("%v: expected error %q, got %v", tt.flags, tt.err, err)
+		}
+	}
+}
+
+func TestLintCmdValidate(t *testing.T) {
+	var buf bytes.Buffer
+	cmd := newLintCmd(&buf)
+	cmd.ParseFlags([]string{"--output", "json", "--set", "test.Name=1", "--validate"})
+	if err := cmd.RunE(cmd, []string{"testdata/testcharts/alpine"}); err == nil {
+		t.Error("expected the chart to fail")
+	}
+	var result lintOutput
+	if err := json.Unmarshal(buf.Bytes(), &result); err != nil {
+		t.Fatalf("cannot parse %q: %s", buf.String(), err)
+	}
+	var found bool
+	for _, msg := range result.Charts[0].Messages {
+		if msg.Rule == "kube-schema" && msg.Path == "templates/alpine-pod.yaml" && strings.Contains(msg.Message, "metadata.labels.values: invalid type") {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected a kube-schema error, got %+v", result.Charts[0].Messages)
+	}
+
+	cmd = newLintCmd(&buf)
+	cmd.ParseFlags([]string{"--validate", "--kube-version", "1.6"})
+	if err := cmd.RunE(cmd, []string{"testdata/testcharts/alpine"}); err == nil || !strings.Contains(err.Error(), "no schemas for Kubernetes 1.6") {
+		t.Errorf("expected an error for a version without schemas, got %v", err)
+	}
+}
+
+func TestLintCmdReports(t *testing.T) {
+	args := []string{"testdata/testcharts/alpine", chartMissingManifest}
+	flags := []string{"--set", "test.Name=policy", "--policy-config", "testdata/policy/enforce.yaml", "--output"}
+
+	var buf bytes.Buffer
+	cmd := newLintCmd(&buf)
+	cmd.ParseFlags(append(flags, "junit"))
+	if err := cmd.RunE(cmd, args); err == nil {
+		t.Error("expected the charts to fail")
+	}
+	var junit junitTestSuites
+	if err := xml.Unmarshal(buf.Bytes(), &junit); err != nil {
+		t.Fatalf("cannot parse %q: %s", buf.String(), err)
+	}
+	if len(junit.Suites) != 2 || junit.Failures != 1 || junit.Errors != 1 {
+		t.Fatalf("expected 2 suites with 1 failure and 1 error, got %+v", junit)
+	}
+	var failure *junitTestCase
+	for i, tc := range junit.Suites[0].Cases {
+		if tc.Failure != nil {
+			failure = &junit.Suites[0].Cases[i]
+		}
+	}
+	if failure == nil || failure.Name != "policy templates/alpine-pod.yaml" || failure.Failure.Type != "ERROR" {
+		t.Errorf("expected a policy failure, got %+v", junit.Suites[0].Cases)
+	}
+
+	buf.Reset()
+	cmd = newLintCmd(&buf)
+	cmd.ParseFlags(append(flags, "sarif"))
+	if err := cmd.RunE(cmd, args); err == nil {
+		t.Error("expected the charts to fail")
+	}
+	var sarif sarifReport
+	if err := json.Unmarshal(buf.Bytes(), &sarif); err != nil {
+		t.Fatalf("cannot parse %q: %s", buf.String(), err)
+	}
+	if sarif.Version != "2.1.0" || len(sarif.Runs) != 1 || len(sarif.Runs[0].Tool.Driver.Rules) == 0 {
+		t.Fatalf("expected a SARIF 2.1.0 run with rules, got %+v", sarif)
+	}
+	var found bool
+	for _, r := range sarif.Runs[0].Results {
+		if r.RuleID == "policy" && r.Level == "error" && r.Locations[0].PhysicalLocation.ArtifactLocation.URI == "testdata/testcharts/alpine/templates/alpine-pod.yaml" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected a policy error on alpine-pod.yaml, got %+v", sarif.Runs[0].Results)
+	}
+}
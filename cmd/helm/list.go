@@ -17,12 +17,10 @@ limitations under the License.
 package main
 
 import (
-	"encoding/json"
 	"fmt"
 	"io"
 	"strings"
 
-	"github.com/ghodss/yaml"
 	"github.com/gosuri/uitable"
 	"github.com/spf13/cobra"
 
@@ -77,7 +75,7 @@ type listCmd struct {
 	pending     bool
 	client      helm.Interface
 	colWidth    uint
-	output      string
+	output      outputFormat
 	byChartName bool
 }
 
@@ -100,6 +98,7 @@ func newListCmd(client helm.Interface, out io.Writer) *cobra.Command {
 	list := &listCmd{
 		out:    out,
 		client: client,
+		output: outputFormat{format: "table", yamlBlankLine: true},
 	}
 
 	cmd := &cobra.Command{
@@ -113,7 +112,7 @@ func newListCmd(client helm.Interface, out io.Writer) *cobra.Command {
 				list.filter = strings.Join(args, " ")
 			}
 			if list.client == nil {
-				list.client = newClient()
+				list.client = newClientInNamespace(list.namespace)
 			}
 			return list.run()
 		},
@@ -134,7 +133,7 @@ func newListCmd(client helm.Interface, out io.Writer) *cobra.Command {
 	f.BoolVar(&list.pending, "pending", false, "show pending releases")
 	f.StringVar(&list.namespace, "namespace", "", "show releases within a specific namespace")
 	f.UintVar(&list.colWidth, "col-width", 60, "specifies the max column width of output")
-	f.StringVar(&list.output, "output", "", "output the specified format (json or yaml)")
+	f.Var(&list.output, "output", outputUsage)
 	f.BoolVarP(&list.byChartName, "chart-name", "c", false, "sort by chart name")
 
 	// TODO: Do we want this as a feature of 'helm list'?
@@ -183,14 +182,17 @@ func (l *listCmd) run() error {
 
 	result := getListResult(rels, res.Next)
 
-	output, err := formatResult(l.output, l.short, result, l.colWidth)
-
-	if err != nil {
-		return prettyError(err)
+	if l.short {
+		short := shortenListResult(result)
+		return l.output.write(l.out, short, func() error {
+			fmt.Fprintln(l.out, formatTextShort(short))
+			return nil
+		})
 	}
-
-	fmt.Fprintln(l.out, output)
-	return nil
+	return l.output.write(l.out, result, func() error {
+		fmt.Fprintln(l.out, formatText(result, l.colWidth))
+		return nil
+	})
 }
 
 // filterList returns a list scrubbed of old releases.
@@ -294,46 +296,6 @@ func shortenListResult(result listResult) []string {
 	return names
 }
 
-func formatResult(format string, short bool, result listResult, colWidth uint) (string, error) {
-	var output string
-	var err error
-
-	var shortResult []string
-	var finalResult interface{}
-	if short {
-		shortResult = shortenListResult(result)
-		finalResult = shortResult
-	} else {
-		finalResult = result
-	}
-
-	switch format {
-	case "":
-		if short {
-			output = formatTextShort(shortResult)
-		} else {
-			output = formatText(result, colWidth)
-		}
-	case "json":
-		o, e := json.Marshal(finalResult)
-		if e != nil {
-			err = fmt.Errorf("Failed to Marshal JSON output: %s", e)
-		} else {
-			output = string(o)
-		}
-	case "yaml":
-		o, e := yaml.Marshal(finalResult)
-		if e != nil {
-			err = fmt.Errorf("Failed to Marshal YAML output: %s", e)
-		} else {
-			output = string(o)
-		}
-	default:
-		err = fmt.Errorf("Unknown output format \"%s\"", format)
-	}
-	return output, err
-}
-
 func formatText(result listResult, colWidth uint) string {
 	nextOutput := ""
 	if result.Next != "" {
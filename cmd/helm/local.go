@@ -0,0 +1,78 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"github.com/spf13/pflag"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/client-go/kubernetes"
+
+	"k8s.io/helm/pkg/kube"
+	"k8s.io/helm/pkg/storage"
+	"k8s.io/helm/pkg/storage/driver"
+	"k8s.io/helm/pkg/tiller"
+	"k8s.io/helm/pkg/tiller/environment"
+)
+
+// addReleaseNamespaceFlag adds the --namespace flag of the commands operating
+// on a release, which the release server run with --local looks for in the
+// given namespace.
+func addReleaseNamespaceFlag(f *pflag.FlagSet, namespace *string) {
+	f.StringVar(namespace, "namespace", "", "namespace of the release, only used with --local. Defaults to the current kube config namespace")
+}
+
+// localClients returns the clients the release server run with --local
+// operates on the cluster with, using the credentials of the kubeconfig.
+var localClients = func() (kubernetes.Interface, environment.KubeClient, error) {
+	_, clientset, err := getKubeClient(settings.KubeContext, settings.KubeConfig)
+	if err != nil {
+		return nil, nil, err
+	}
+	flags := genericclioptions.NewConfigFlags()
+	flags.Context = &settings.KubeContext
+	flags.KubeConfig = &settings.KubeConfig
+	kubeClient := kube.New(flags)
+	kubeClient.Log = debug
+	return clientset, kubeClient, nil
+}
+
+// newLocalServer returns a release server run in-process by the helm client,
+// operating on the cluster with the credentials of the kubeconfig. The
+// releases are stored as secrets in namespace, or in the namespace of the
+// kubeconfig context if namespace is empty.
+func newLocalServer(namespace string) (*tiller.ReleaseServer, error) {
+	clientset, kubeClient, err := localClients()
+	if err != nil {
+		return nil, err
+	}
+	if namespace == "" {
+		namespace = defaultNamespace()
+	}
+	debug("SERVER: in-process, storing releases in namespace %q", namespace)
+
+	secrets := driver.NewSecrets(clientset.CoreV1().Secrets(namespace))
+	secrets.Log = debug
+
+	env := environment.New()
+	env.Releases = storage.Init(secrets)
+	env.Releases.Log = debug
+	env.KubeClient = kubeClient
+
+	svc := tiller.NewReleaseServer(env, clientset, false)
+	svc.Log = debug
+	return svc, nil
+}
@@ -0,0 +1,77 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/tiller/environment"
+)
+
+func TestLocalReleaseNamespace(t *testing.T) {
+	clientset := fake.NewSimpleClientset()
+	defer func(local bool, clients func() (kubernetes.Interface, environment.KubeClient, error)) {
+		settings.Local = local
+		localClients = clients
+	}(settings.Local, localClients)
+	settings.Local = true
+	localClients = func() (kubernetes.Interface, environment.KubeClient, error) {
+		return clientset, &environment.PrintingKubeClient{Out: ioutil.Discard}, nil
+	}
+
+	run := func(newCmd func(helm.Interface, io.Writer) *cobra.Command, args ...string) string {
+		var buf bytes.Buffer
+		cmd := newCmd(nil, &buf)
+		if err := cmd.ParseFlags(args); err != nil {
+			t.Fatal(err)
+		}
+		if err := cmd.RunE(cmd, cmd.Flags().Args()); err != nil {
+			t.Fatalf("%s %v: %s", cmd.Name(), args, err)
+		}
+		return buf.String()
+	}
+
+	run(newInstallCmd, "--namespace", "team-a", "--name", "happy-panda", "testdata/testcharts/novals")
+	run(newUpgradeCmd, "--namespace", "team-a", "happy-panda", "testdata/testcharts/novals")
+	run(newRollbackCmd, "--namespace", "team-a", "happy-panda", "1")
+
+	out := run(newHistoryCmd, "--namespace", "team-a", "happy-panda")
+	if !strings.Contains(out, "Rollback to 1") {
+		t.Errorf("expected the rollback in the history of happy-panda, got\n%s", out)
+	}
+
+	secrets, err := clientset.CoreV1().Secrets("team-a").List(metav1.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(secrets.Items) != 3 {
+		t.Errorf("expected the 3 revisions of happy-panda stored in team-a, got %d", len(secrets.Items))
+	}
+	if secrets, _ := clientset.CoreV1().Secrets("default").List(metav1.ListOptions{}); len(secrets.Items) != 0 {
+		t.Errorf("expected no release stored in default, got %d", len(secrets.Items))
+	}
+}
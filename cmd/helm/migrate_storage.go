@@ -0,0 +1,133 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/helm/pkg/storage"
+	"k8s.io/helm/pkg/storage/driver"
+)
+
+const migrateStorageDesc = `
+This command copies the releases stored by one storage driver of Tiller to
+another, for instance from ConfigMaps to Secrets:
+
+	$ helm migrate-storage --from configmap --to secret
+
+Every revision of every release is copied with its labels, then read back and
+compared to the original. The storage drivers are accessed directly: Tiller
+is not involved, and should be stopped during the migration so that releases
+do not change meanwhile. Start Tiller with the new '--storage' flag
+afterwards.
+
+The drivers are 'configmap', 'secret' and 'sql'. ConfigMaps and Secrets are
+read and written in the namespace of Tiller, or in the namespace given with
+'--namespace'. With --local, the namespace of the kubeconfig context is used by
+default.
+
+Records the destination already holds unchanged are skipped, so a migration
+that was interrupted is resumed by running the same command again. A record
+the destination holds with a different content stops the migration.
+
+With '--delete-source', the records are deleted from the source once every
+record has been copied and verified.
+
+With '--storage-dedup', the releases are read from and written to both drivers
+in the deduplicating encoding of Tiller started with the same flag.
+`
+
+type migrateStorageCmd struct {
+	storageOptions
+	from         string
+	to           string
+	dryRun       bool
+	deleteSource bool
+	out          io.Writer
+
+	// newDriver returns the storage driver named name.
+	newDriver func(name string) (driver.Driver, error)
+}
+
+func newMigrateStorageCmd(out io.Writer) *cobra.Command {
+	m := &migrateStorageCmd{out: out}
+	m.newDriver = m.storageDriver
+
+	cmd := &cobra.Command{
+		Use:   "migrate-storage --from DRIVER --to DRIVER",
+		Short: "copy the releases stored by one storage driver to another",
+		Long:  migrateStorageDesc,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 0 {
+				return errors.New("This command does not accept arguments")
+			}
+			return m.run()
+		},
+	}
+
+	f := cmd.Flags()
+	f.StringVar(&m.from, "from", "", "storage driver to copy the releases from: one of 'configmap', 'secret' or 'sql'")
+	f.StringVar(&m.to, "to", "", "storage driver to copy the releases to: one of 'configmap', 'secret' or 'sql'")
+	m.addFlags(f, "namespace")
+	f.BoolVar(&m.dryRun, "dry-run", false, "list the records that would be copied and deleted without writing anything")
+	f.BoolVar(&m.deleteSource, "delete-source", false, "delete the records from the source driver once they are all copied and verified")
+
+	return cmd
+}
+
+func (m *migrateStorageCmd) run() error {
+	if m.from == "" || m.to == "" {
+		return errors.New("both --from and --to must be set")
+	}
+	if m.from == m.to {
+		return fmt.Errorf("cannot migrate the %s storage driver to itself", m.from)
+	}
+	from, err := m.newDriver(m.from)
+	if err != nil {
+		return err
+	}
+	to, err := m.newDriver(m.to)
+	if err != nil {
+		return err
+	}
+
+	res, err := storage.Migrate(from, to, storage.MigrateOptions{
+		DryRun:       m.dryRun,
+		DeleteSource: m.deleteSource,
+		Log: func(format string, v ...interface{}) {
+			fmt.Fprintf(m.out, format+"\n", v...)
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	if m.dryRun {
+		fmt.Fprintf(m.out, "Would copy %d and delete %d record(s), %d already migrated\n", len(res.Copied), len(res.Deleted), len(res.Skipped))
+		return nil
+	}
+	fmt.Fprintf(m.out, "Copied %d record(s) from the %s driver to the %s driver, %d already migrated", len(res.Copied), from.Name(), to.Name(), len(res.Skipped))
+	if m.deleteSource {
+		fmt.Fprintf(m.out, ", deleted %d record(s) from the %s driver", len(res.Deleted), from.Name())
+	}
+	fmt.Fprintln(m.out)
+	return nil
+}
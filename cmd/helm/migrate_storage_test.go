@@ -0,0 +1,113 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/proto/hapi/release"
+	"k8s.io/helm/pkg/storage/driver"
+)
+
+func TestMigrateStorageCmd(t *testing.T) {
+	tests := []struct {
+		name     string
+		m        migrateStorageCmd
+		expected string
+		err      string
+	}{
+		{
+			name:     "migrate",
+			m:        migrateStorageCmd{from: "configmap", to: "secret"},
+			expected: "copied angry-bird.v1\ncopied angry-bird.v2\nCopied 2 record(s) from the Memory driver to the Memory driver, 0 already migrated\n",
+		},
+		{
+			name:     "migrate and delete the source",
+			m:        migrateStorageCmd{from: "configmap", to: "secret", deleteSource: true},
+			expected: "deleted angry-bird.v2\nCopied 2 record(s) from the Memory driver to the Memory driver, 0 already migrated, deleted 2 record(s) from the Memory driver\n",
+		},
+		{
+			name:     "dry run",
+			m:        migrateStorageCmd{from: "configmap", to: "secret", dryRun: true, deleteSource: true},
+			expected: "would copy angry-bird.v1\nwould copy angry-bird.v2\nwould delete angry-bird.v1\nwould delete angry-bird.v2\nWould copy 2 and delete 2 record(s), 0 already migrated\n",
+		},
+		{
+			name: "migrate to the same driver",
+			m:    migrateStorageCmd{from: "secret", to: "secret"},
+			err:  "cannot migrate the secret storage driver to itself",
+		},
+		{
+			name: "migrate to an unknown driver",
+			m:    migrateStorageCmd{from: "secret", to: "etcd"},
+			err:  `unknown storage driver "etcd"`,
+		},
+		{
+			name: "migrate without a destination",
+			m:    migrateStorageCmd{from: "secret"},
+			err:  "both --from and --to must be set",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			drivers := map[string]driver.Driver{"configmap": driver.NewMemory(), "secret": driver.NewMemory()}
+			for v := int32(1); v <= 2; v++ {
+				rel := helm.ReleaseMock(&helm.MockReleaseOptions{Name: "angry-bird", Version: v})
+				if err := drivers["configmap"].Create(fmt.Sprintf("angry-bird.v%d", v), rel); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			var buf bytes.Buffer
+			m := tt.m
+			m.out = &buf
+			m.newDriver = func(name string) (driver.Driver, error) {
+				if d, ok := drivers[name]; ok {
+					return d, nil
+				}
+				return m.storageDriver(name)
+			}
+
+			err := m.run()
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("expected error %q, got %v", tt.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !strings.HasSuffix(buf.String(), tt.expected) {
+				t.Errorf("expected output ending with %q, got %q", tt.expected, buf.String())
+			}
+
+			copied := 2
+			if tt.m.dryRun {
+				copied = 0
+			}
+			rels, _ := drivers["secret"].List(func(*release.Release) bool { return true })
+			if len(rels) != copied {
+				t.Errorf("expected %d records to be copied, got %d", copied, len(rels))
+			}
+		})
+	}
+}
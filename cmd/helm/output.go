@@ -0,0 +1,161 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/template"
+
+	"github.com/Masterminds/sprig"
+	"github.com/ghodss/yaml"
+	"k8s.io/client-go/util/jsonpath"
+)
+
+// outputUsage is the usage of the --output flag of the commands writing
+// structured output.
+const outputUsage = "prints the output in the specified format (table|json|yaml|go-template=TEMPLATE|jsonpath=EXPRESSION)"
+
+// outputFormat is the format of the output of a command, as set with --output.
+//
+// The table format is the human-readable output of the command. The other
+// formats all render the same object, documented for each command in
+// docs/output.md: templates and JSONPath expressions refer to its JSON field
+// names.
+type outputFormat struct {
+	format string
+	// expr is the template or JSONPath expression of the format.
+	expr string
+	// yamlBlankLine ends the YAML output with a blank line, as helm list,
+	// helm history and helm get values always did.
+	yamlBlankLine bool
+}
+
+func newOutputFormat(format string) outputFormat {
+	return outputFormat{format: format}
+}
+
+// String implements pflag.Value.
+func (o *outputFormat) String() string {
+	if o.expr != "" {
+		return o.format + "=" + o.expr
+	}
+	return o.format
+}
+
+// Set implements pflag.Value. The format is checked when the output is
+// written.
+func (o *outputFormat) Set(s string) error {
+	o.format, o.expr = s, ""
+	if i := strings.Index(s, "="); i >= 0 {
+		o.format, o.expr = s[:i], s[i+1:]
+	}
+	return nil
+}
+
+// Type implements pflag.Value.
+func (o *outputFormat) Type() string {
+	return "format"
+}
+
+// isTable reports whether the output is the human-readable table.
+func (o *outputFormat) isTable() bool {
+	return (o.format == "" || o.format == "table") && o.expr == ""
+}
+
+// messages returns where the messages of a command other than its output,
+// such as warnings, are written: to out for the table format, and to stderr
+// otherwise so that they do not corrupt the structured output.
+func (o *outputFormat) messages(out io.Writer) io.Writer {
+	if o.isTable() {
+		return out
+	}
+	return os.Stderr
+}
+
+// write writes obj to out in the output format. The table format is written
+// by table instead.
+func (o *outputFormat) write(out io.Writer, obj interface{}, table func() error) error {
+	switch o.format {
+	case "", "table", "json", "yaml":
+		if o.expr != "" {
+			return fmt.Errorf("output format %q does not take an expression", o.format)
+		}
+	}
+
+	switch o.format {
+	case "", "table":
+		return table()
+	case "json":
+		b, err := json.Marshal(obj)
+		if err != nil {
+			return fmt.Errorf("Failed to Marshal JSON output: %s", err)
+		}
+		_, err = fmt.Fprintln(out, string(b))
+		return err
+	case "yaml":
+		b, err := yaml.Marshal(obj)
+		if err != nil {
+			return fmt.Errorf("Failed to Marshal YAML output: %s", err)
+		}
+		if o.yamlBlankLine {
+			_, err = fmt.Fprintln(out, string(b))
+			return err
+		}
+		_, err = out.Write(b)
+		return err
+	case "go-template", "jsonpath":
+		if o.expr == "" {
+			return fmt.Errorf("output format %q requires an expression, e.g. %s=EXPRESSION", o.format, o.format)
+		}
+	default:
+		return fmt.Errorf("unknown output format %q", o.format)
+	}
+
+	// Templates and JSONPath expressions see the object as it is written in
+	// JSON, so their field names are the documented ones.
+	b, err := json.Marshal(obj)
+	if err != nil {
+		return fmt.Errorf("Failed to Marshal JSON output: %s", err)
+	}
+	var data interface{}
+	if err := json.Unmarshal(b, &data); err != nil {
+		return err
+	}
+
+	if o.format == "go-template" {
+		t, err := template.New("output").Funcs(sprig.TxtFuncMap()).Parse(o.expr)
+		if err != nil {
+			return fmt.Errorf("invalid output template: %s", err)
+		}
+		return t.Execute(out, data)
+	}
+	// As in kubectl, missing keys yield empty output rather than errors, as
+	// fields such as the server version are left out when not set.
+	jp := jsonpath.New("output").AllowMissingKeys(true)
+	expr := o.expr
+	if !strings.HasPrefix(expr, "{") {
+		expr = "{" + expr + "}"
+	}
+	if err := jp.Parse(expr); err != nil {
+		return fmt.Errorf("invalid JSONPath expression: %s", err)
+	}
+	return jp.Execute(out, data)
+}
@@ -0,0 +1,71 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+)
+
+func TestOutputFormat(t *testing.T) {
+	obj := []map[string]interface{}{
+		{"name": "alpine", "version": "0.1.0"},
+		{"name": "mariadb", "version": "0.3.0"},
+	}
+
+	tests := []struct {
+		format   string
+		expected string
+		err      bool
+	}{
+		{"", "TABLE\n", false},
+		{"table", "TABLE\n", false},
+		{"json", `[{"name":"alpine","version":"0.1.0"},{"name":"mariadb","version":"0.3.0"}]` + "\n", false},
+		{"yaml", "- name: alpine\n  version: 0.1.0\n- name: mariadb\n  version: 0.3.0\n", false},
+		{"go-template={{range .}}{{.name | upper}} {{end}}", "ALPINE MARIADB ", false},
+		{"jsonpath={[*].name}", "alpine mariadb", false},
+		{"jsonpath=[1].version", "0.3.0", false},
+		{"jsonpath={[0].missing}", "", false},
+		{"json=.name", "", true},
+		{"go-template", "", true},
+		{"go-template={{.name", "", true},
+		{"jsonpath={[0}", "", true},
+		{"xml", "", true},
+	}
+
+	for _, tt := range tests {
+		var o outputFormat
+		if err := o.Set(tt.format); err != nil {
+			t.Fatal(err)
+		}
+		var buf bytes.Buffer
+		err := o.write(&buf, obj, func() error {
+			fmt.Fprintln(&buf, "TABLE")
+			return nil
+		})
+		if (err != nil) != tt.err {
+			t.Errorf("%q: expected error %t, got %v", tt.format, tt.err, err)
+		}
+		if got := buf.String(); got != tt.expected {
+			t.Errorf("%q: expected %q, got %q", tt.format, tt.expected, got)
+		}
+		if o.String() != tt.format {
+			t.Errorf("expected the format %q to be kept, got %q", tt.format, o.String())
+		}
+	}
+}
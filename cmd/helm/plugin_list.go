@@ -26,12 +26,21 @@ import (
 )
 
 type pluginListCmd struct {
-	home helmpath.Home
-	out  io.Writer
+	home   helmpath.Home
+	out    io.Writer
+	output outputFormat
+}
+
+// pluginOutput is a plugin as written by 'helm plugin list' in the
+// structured output formats.
+type pluginOutput struct {
+	Name        string `json:"name"`
+	Version     string `json:"version"`
+	Description string `json:"description"`
 }
 
 func newPluginListCmd(out io.Writer) *cobra.Command {
-	pcmd := &pluginListCmd{out: out}
+	pcmd := &pluginListCmd{out: out, output: newOutputFormat("table")}
 	cmd := &cobra.Command{
 		Use:   "list",
 		Short: "list installed Helm plugins",
@@ -40,6 +49,7 @@ func newPluginListCmd(out io.Writer) *cobra.Command {
 			return pcmd.run()
 		},
 	}
+	cmd.Flags().VarP(&pcmd.output, "output", "o", outputUsage)
 	return cmd
 }
 
@@ -50,11 +60,21 @@ func (pcmd *pluginListCmd) run() error {
 		return err
 	}
 
-	table := uitable.New()
-	table.AddRow("NAME", "VERSION", "DESCRIPTION")
+	list := make([]pluginOutput, 0, len(plugins))
 	for _, p := range plugins {
-		table.AddRow(p.Metadata.Name, p.Metadata.Version, p.Metadata.Description)
+		list = append(list, pluginOutput{
+			Name:        p.Metadata.Name,
+			Version:     p.Metadata.Version,
+			Description: p.Metadata.Description,
+		})
 	}
-	fmt.Fprintln(pcmd.out, table)
-	return nil
+	return pcmd.output.write(pcmd.out, list, func() error {
+		table := uitable.New()
+		table.AddRow("NAME", "VERSION", "DESCRIPTION")
+		for _, p := range plugins {
+			table.AddRow(p.Metadata.Name, p.Metadata.Version, p.Metadata.Description)
+		}
+		fmt.Fprintln(pcmd.out, table)
+		return nil
+	})
 }
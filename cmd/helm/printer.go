@@ -24,6 +24,7 @@ import (
 
 	"k8s.io/helm/pkg/chartutil"
 	"k8s.io/helm/pkg/proto/hapi/release"
+	"k8s.io/helm/pkg/proto/hapi/services"
 	"k8s.io/helm/pkg/timeconv"
 )
 
@@ -66,6 +67,36 @@ func printRelease(out io.Writer, rel *release.Release) error {
 	return tpl(printReleaseTemplate, data, out)
 }
 
+// printProgress returns a function printing the progress events of a release
+// operation to out.
+func printProgress(out io.Writer) func(*services.ReleaseEvent) {
+	return func(ev *services.ReleaseEvent) {
+		resource := fmt.Sprintf("%s %s/%s", ev.Kind, ev.Namespace, ev.Name)
+		switch ev.Type {
+		case services.ReleaseEvent_HOOK_STARTED:
+			fmt.Fprintf(out, "Running %s hook %s\n", ev.Hook, resource)
+		case services.ReleaseEvent_HOOK_FINISHED:
+			fmt.Fprintf(out, "Completed %s hook %s\n", ev.Hook, resource)
+		case services.ReleaseEvent_RESOURCE_CREATED:
+			fmt.Fprintf(out, "Created %s\n", resource)
+		case services.ReleaseEvent_RESOURCE_UPDATED:
+			fmt.Fprintf(out, "Updated %s\n", resource)
+		case services.ReleaseEvent_RESOURCE_DELETED:
+			fmt.Fprintf(out, "Deleted %s\n", resource)
+		case services.ReleaseEvent_WAITING:
+			if ev.Total > 0 {
+				fmt.Fprintf(out, "Waiting for %s (%d/%d ready)\n", resource, ev.Ready, ev.Total)
+			} else {
+				fmt.Fprintf(out, "Waiting for %s\n", resource)
+			}
+		case services.ReleaseEvent_WARNING:
+			fmt.Fprintf(out, "WARNING: %s\n", ev.Message)
+		case services.ReleaseEvent_TEST, services.ReleaseEvent_WAVE:
+			fmt.Fprintln(out, ev.Message)
+		}
+	}
+}
+
 func tpl(t string, vals map[string]interface{}, out io.Writer) error {
 	tt, err := template.New("_").Parse(t)
 	if err != nil {
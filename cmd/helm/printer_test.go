@@ -0,0 +1,72 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"k8s.io/helm/pkg/proto/hapi/services"
+)
+
+func TestPrintProgress(t *testing.T) {
+	tests := []struct {
+		event    *services.ReleaseEvent
+		expected string
+	}{
+		{
+			event:    &services.ReleaseEvent{Type: services.ReleaseEvent_HOOK_STARTED, Kind: "Job", Namespace: "default", Name: "migrate", Hook: "pre-upgrade"},
+			expected: "Running pre-upgrade hook Job default/migrate\n",
+		},
+		{
+			event:    &services.ReleaseEvent{Type: services.ReleaseEvent_HOOK_FINISHED, Kind: "Job", Namespace: "default", Name: "migrate", Hook: "pre-upgrade"},
+			expected: "Completed pre-upgrade hook Job default/migrate\n",
+		},
+		{
+			event:    &services.ReleaseEvent{Type: services.ReleaseEvent_RESOURCE_UPDATED, Kind: "Deployment", Namespace: "default", Name: "web"},
+			expected: "Updated Deployment default/web\n",
+		},
+		{
+			event:    &services.ReleaseEvent{Type: services.ReleaseEvent_WAITING, Kind: "Deployment", Namespace: "default", Name: "web", Ready: 1, Total: 3},
+			expected: "Waiting for Deployment default/web (1/3 ready)\n",
+		},
+		{
+			event:    &services.ReleaseEvent{Type: services.ReleaseEvent_WAITING, Kind: "Service", Namespace: "default", Name: "web"},
+			expected: "Waiting for Service default/web\n",
+		},
+		{
+			event:    &services.ReleaseEvent{Type: services.ReleaseEvent_WARNING, Message: "[no-privileged] Deployment/web in mychart/templates/deployment.yaml: container \"web\" is privileged"},
+			expected: "WARNING: [no-privileged] Deployment/web in mychart/templates/deployment.yaml: container \"web\" is privileged\n",
+		},
+		{
+			event:    &services.ReleaseEvent{Type: services.ReleaseEvent_TEST, Message: "PASSED: smoke-test"},
+			expected: "PASSED: smoke-test\n",
+		},
+		{
+			event:    &services.ReleaseEvent{Type: services.ReleaseEvent_WAVE, Message: "Applying wave 2 of 3"},
+			expected: "Applying wave 2 of 3\n",
+		},
+	}
+
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		printProgress(&buf)(tt.event)
+		if buf.String() != tt.expected {
+			t.Errorf("expected %q, got %q", tt.expected, buf.String())
+		}
+	}
+}
@@ -0,0 +1,173 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/helm/pkg/helm/helmpath"
+	"k8s.io/helm/pkg/registry"
+)
+
+const registryHelp = `
+This command consists of multiple subcommands to interact with OCI registries.
+
+Credentials are stored in $HELM_HOME/registry/config.json.
+Example usage:
+    $ helm registry login -u myuser localhost:5000
+`
+
+func newRegistryCmd(out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "registry [FLAGS] login|logout [ARGS]",
+		Short: "log in to or log out from an OCI registry",
+		Long:  registryHelp,
+	}
+
+	cmd.AddCommand(newRegistryLoginCmd(out))
+	cmd.AddCommand(newRegistryLogoutCmd(out))
+
+	return cmd
+}
+
+const registryLoginDesc = `
+This command checks the credentials against the registry and stores them for
+the 'helm chart push' and 'helm chart pull' commands, and for installing
+charts from the registry.
+
+The password is prompted for unless --password or --password-stdin is set.
+`
+
+type registryLoginCmd struct {
+	host          string
+	username      string
+	password      string
+	passwordStdin bool
+	home          helmpath.Home
+	out           io.Writer
+	in            io.Reader
+}
+
+func newRegistryLoginCmd(out io.Writer) *cobra.Command {
+	login := &registryLoginCmd{out: out, in: os.Stdin}
+
+	cmd := &cobra.Command{
+		Use:   "login [flags] [HOST]",
+		Short: "log in to a registry",
+		Long:  registryLoginDesc,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := checkArgsLength(len(args), "registry host"); err != nil {
+				return err
+			}
+			login.host = args[0]
+			login.home = settings.Home
+			return login.run()
+		},
+	}
+
+	f := cmd.Flags()
+	f.StringVarP(&login.username, "username", "u", "", "registry username")
+	f.StringVarP(&login.password, "password", "p", "", "registry password")
+	f.BoolVar(&login.passwordStdin, "password-stdin", false, "read the password from stdin")
+
+	return cmd
+}
+
+func (l *registryLoginCmd) run() error {
+	if l.username == "" {
+		return fmt.Errorf("a username is required, set it with --username")
+	}
+	if l.passwordStdin {
+		if l.password != "" {
+			return fmt.Errorf("--password and --password-stdin are mutually exclusive")
+		}
+		b, err := ioutil.ReadAll(l.in)
+		if err != nil {
+			return err
+		}
+		l.password = strings.TrimRight(string(b), "\r\n")
+	} else if l.password == "" {
+		fmt.Fprint(l.out, "Password: ")
+		password, err := readPassword()
+		fmt.Fprintln(l.out)
+		if err != nil {
+			return err
+		}
+		l.password = password
+	}
+
+	creds, err := registry.LoadCredentials(l.home.RegistryConfig())
+	if err != nil {
+		return err
+	}
+	creds.Set(l.host, l.username, l.password)
+	if err := registry.NewClient(creds).Ping(l.host); err != nil {
+		return fmt.Errorf("cannot log in to %s: %s", l.host, err)
+	}
+	if err := creds.Save(); err != nil {
+		return err
+	}
+	fmt.Fprintln(l.out, "Login succeeded")
+	return nil
+}
+
+type registryLogoutCmd struct {
+	host string
+	home helmpath.Home
+	out  io.Writer
+}
+
+func newRegistryLogoutCmd(out io.Writer) *cobra.Command {
+	logout := &registryLogoutCmd{out: out}
+
+	cmd := &cobra.Command{
+		Use:   "logout [flags] [HOST]",
+		Short: "log out from a registry",
+		Long:  "This command removes the credentials of a registry stored by 'helm registry login'.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := checkArgsLength(len(args), "registry host"); err != nil {
+				return err
+			}
+			logout.host = args[0]
+			logout.home = settings.Home
+			return logout.run()
+		},
+	}
+
+	return cmd
+}
+
+func (l *registryLogoutCmd) run() error {
+	creds, err := registry.LoadCredentials(l.home.RegistryConfig())
+	if err != nil {
+		return err
+	}
+	if !creds.Remove(l.host) {
+		return fmt.Errorf("not logged in to %s", l.host)
+	}
+	if err := creds.Save(); err != nil {
+		return err
+	}
+	fmt.Fprintf(l.out, "Removed login credentials for %s\n", l.host)
+	return nil
+}
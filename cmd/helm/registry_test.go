@@ -0,0 +1,110 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+
+	"k8s.io/helm/pkg/registry"
+	"k8s.io/helm/pkg/registry/registrytest"
+)
+
+func TestRegistryLoginLogout(t *testing.T) {
+	srv := registrytest.NewServer(registrytest.WithBasicAuth("user", "secret"))
+	defer srv.Stop()
+
+	hh, err := tempHelmHome(t)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(hh.String())
+
+	tests := []struct {
+		name     string
+		username string
+		password string
+		stdin    string
+		expect   string
+		err      bool
+	}{
+		{
+			name:     "missing username",
+			password: "secret",
+			err:      true,
+		},
+		{
+			name:     "wrong password",
+			username: "user",
+			password: "wrong",
+			err:      true,
+		},
+		{
+			name:     "password from stdin",
+			username: "user",
+			stdin:    "secret\n",
+			expect:   "Login succeeded",
+		},
+		{
+			name:     "password flag",
+			username: "user",
+			password: "secret",
+			expect:   "Login succeeded",
+		},
+	}
+
+	for _, tt := range tests {
+		buf := bytes.NewBuffer(nil)
+		login := &registryLoginCmd{
+			host:          srv.Host(),
+			username:      tt.username,
+			password:      tt.password,
+			passwordStdin: tt.stdin != "",
+			home:          hh,
+			out:           buf,
+			in:            strings.NewReader(tt.stdin),
+		}
+		if err := login.run(); (err != nil) != tt.err {
+			t.Fatalf("%s: expected error %t, got %v", tt.name, tt.err, err)
+		}
+		if !strings.Contains(buf.String(), tt.expect) {
+			t.Errorf("%s: expected %q in output, got %q", tt.name, tt.expect, buf.String())
+		}
+	}
+
+	creds, err := registry.LoadCredentials(hh.RegistryConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u, p, ok := creds.Get(srv.Host()); !ok || u != "user" || p != "secret" {
+		t.Errorf("expected stored credentials user:secret, got %s:%s", u, p)
+	}
+
+	buf := bytes.NewBuffer(nil)
+	logout := &registryLogoutCmd{host: srv.Host(), home: hh, out: buf}
+	if err := logout.run(); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), "Removed login credentials for "+srv.Host()) {
+		t.Errorf("unexpected output: %q", buf.String())
+	}
+	if err := logout.run(); err == nil {
+		t.Error("expected an error logging out twice")
+	}
+}
@@ -31,21 +31,42 @@ The test command runs the tests for a release.
 
 The argument this command takes is the name of a deployed release.
 The tests to be run are defined in the chart that was installed.
+
+The messages of the tests are printed as they run. The other output formats
+write them as an object once the tests are done, see 'docs/output.md'.
 `
 
 type releaseTestCmd struct {
-	name     string
-	out      io.Writer
-	client   helm.Interface
-	timeout  int64
-	cleanup  bool
-	parallel bool
+	name      string
+	out       io.Writer
+	client    helm.Interface
+	namespace string
+	timeout   int64
+	cleanup   bool
+	parallel  bool
+	output    outputFormat
+}
+
+// releaseTestOutput is the object written by 'helm test' in the structured
+// output formats.
+type releaseTestOutput struct {
+	Release string              `json:"release"`
+	Results []releaseTestResult `json:"results"`
+	// Failed is the number of tests that failed.
+	Failed int `json:"failed"`
+}
+
+type releaseTestResult struct {
+	Message string `json:"message"`
+	// Status is one of UNKNOWN, RUNNING, SUCCESS or FAILURE.
+	Status string `json:"status"`
 }
 
 func newReleaseTestCmd(c helm.Interface, out io.Writer) *cobra.Command {
 	rlsTest := &releaseTestCmd{
 		out:    out,
 		client: c,
+		output: newOutputFormat("table"),
 	}
 
 	cmd := &cobra.Command{
@@ -59,16 +80,18 @@ func newReleaseTestCmd(c helm.Interface, out io.Writer) *cobra.Command {
 			}
 
 			rlsTest.name = args[0]
-			rlsTest.client = ensureHelmClient(rlsTest.client)
+			rlsTest.client = ensureHelmClientInNamespace(rlsTest.client, rlsTest.namespace)
 			return rlsTest.run()
 		},
 	}
 
 	f := cmd.Flags()
 	settings.AddFlagsTLS(f)
+	addReleaseNamespaceFlag(f, &rlsTest.namespace)
 	f.Int64Var(&rlsTest.timeout, "timeout", 300, "time in seconds to wait for any individual Kubernetes operation (like Jobs for hooks)")
 	f.BoolVar(&rlsTest.cleanup, "cleanup", false, "delete test pods upon completion")
 	f.BoolVar(&rlsTest.parallel, "parallel", false, "run test pods in parallel")
+	f.VarP(&rlsTest.output, "output", "o", outputUsage)
 
 	// set defaults from environment
 	settings.InitTLS(f)
@@ -84,14 +107,23 @@ func (t *releaseTestCmd) run() (err error) {
 		helm.ReleaseTestParallel(t.parallel),
 	)
 	testErr := &testErr{}
+	result := releaseTestOutput{Release: t.name, Results: []releaseTestResult{}}
 
 	for {
 		select {
 		case err := <-errc:
-			if prettyError(err) == nil && testErr.failed > 0 {
+			if prettyError(err) != nil {
+				return prettyError(err)
+			}
+			result.Failed = testErr.failed
+			// The table is written as the tests run.
+			if err := t.output.write(t.out, result, func() error { return nil }); err != nil {
+				return err
+			}
+			if testErr.failed > 0 {
 				return testErr.Error()
 			}
-			return prettyError(err)
+			return nil
 		case res, ok := <-c:
 			if !ok {
 				break
@@ -101,8 +133,10 @@ func (t *releaseTestCmd) run() (err error) {
 				testErr.failed++
 			}
 
-			fmt.Fprintf(t.out, res.Msg+"\n")
-
+			if t.output.isTable() {
+				fmt.Fprintf(t.out, res.Msg+"\n")
+			}
+			result.Results = append(result.Results, releaseTestResult{Message: res.Msg, Status: res.Status.String()})
 		}
 	}
 
@@ -18,6 +18,7 @@ package main
 
 import (
 	"io"
+	"regexp"
 	"testing"
 
 	"github.com/spf13/cobra"
@@ -34,6 +35,21 @@ func TestReleaseTesting(t *testing.T) {
 			responses: map[string]release.TestRun_Status{"PASSED: green lights everywhere": release.TestRun_SUCCESS},
 			err:       false,
 		},
+		{
+			name:      "basic test with json output",
+			args:      []string{"example-release"},
+			flags:     []string{"--output", "json"},
+			responses: map[string]release.TestRun_Status{"PASSED: green lights everywhere": release.TestRun_SUCCESS},
+			expected:  regexp.QuoteMeta(`{"release":"example-release","results":[{"message":"PASSED: green lights everywhere","status":"SUCCESS"}],"failed":0}`),
+		},
+		{
+			name:      "test failure with yaml output",
+			args:      []string{"example-fail"},
+			flags:     []string{"--output", "yaml"},
+			responses: map[string]release.TestRun_Status{"FAILURE: red lights everywhere": release.TestRun_FAILURE},
+			expected:  "failed: 1\nrelease: example-fail\nresults:\n- message: 'FAILURE: red lights everywhere'\n  status: FAILURE\n",
+			err:       true,
+		},
 		{
 			name:      "test failure",
 			args:      []string{"example-fail"},
@@ -0,0 +1,105 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/helm/pkg/helm"
+)
+
+const repairDesc = `
+This command repairs releases left pending by an interrupted operation.
+
+If Tiller dies during an install, upgrade or rollback, the last revision of the
+release is left PENDING_INSTALL, PENDING_UPGRADE or PENDING_ROLLBACK, and
+further operations on the release are refused.
+
+Given a release name, the status of its last revision is reconciled with the
+cluster: it is marked DEPLOYED if all its resources exist, and FAILED otherwise.
+The resources missing from the cluster are listed. An upgrade paused between
+waves is left as is, and one interrupted before applying all its waves is
+marked FAILED.
+
+Without a release name, every release left pending for longer than the
+--pending-release-timeout of Tiller is marked FAILED. Tiller also does this
+when it starts.
+`
+
+type repairCmd struct {
+	name      string
+	out       io.Writer
+	client    helm.Interface
+	namespace string
+}
+
+func newRepairCmd(c helm.Interface, out io.Writer) *cobra.Command {
+	repair := &repairCmd{
+		out:    out,
+		client: c,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "repair [flags] [RELEASE]",
+		Short:   "repair releases left pending by an interrupted operation",
+		Long:    repairDesc,
+		PreRunE: func(_ *cobra.Command, _ []string) error { return setupConnection() },
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 1 {
+				return fmt.Errorf("This command accepts at most 1 argument: release name")
+			}
+			if len(args) == 1 {
+				repair.name = args[0]
+			}
+			repair.client = ensureHelmClientInNamespace(repair.client, repair.namespace)
+			return repair.run()
+		},
+	}
+
+	f := cmd.Flags()
+	settings.AddFlagsTLS(f)
+	addReleaseNamespaceFlag(f, &repair.namespace)
+
+	// set defaults from environment
+	settings.InitTLS(f)
+
+	return cmd
+}
+
+func (r *repairCmd) run() error {
+	res, err := r.client.RepairRelease(r.name)
+	if err != nil {
+		return prettyError(err)
+	}
+
+	for _, rel := range res.Releases {
+		fmt.Fprintf(r.out, "%s revision %d is now %s\n", rel.Name, rel.Version, rel.Info.Status.Code)
+	}
+	if len(res.Releases) == 0 {
+		fmt.Fprintln(r.out, "No pending release to repair.")
+	}
+	if len(res.Missing) > 0 {
+		fmt.Fprintf(r.out, "Resources missing from the cluster:\n")
+		for _, m := range res.Missing {
+			fmt.Fprintf(r.out, "  %s\n", m)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"io"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/proto/hapi/release"
+)
+
+func TestRepairCmd(t *testing.T) {
+	pending := func(name string) *release.Release {
+		return helm.ReleaseMock(&helm.MockReleaseOptions{Name: name, Version: 2, StatusCode: release.Status_PENDING_UPGRADE})
+	}
+
+	tests := []releaseCase{
+		{
+			name:     "repair a pending release",
+			args:     []string{"funny-honey"},
+			expected: "funny-honey revision 2 is now DEPLOYED",
+			rels:     []*release.Release{pending("funny-honey")},
+		},
+		{
+			name:     "repair a deployed release",
+			args:     []string{"funny-honey"},
+			expected: "No pending release to repair.",
+			rels:     []*release.Release{helm.ReleaseMock(&helm.MockReleaseOptions{Name: "funny-honey"})},
+		},
+		{
+			name:     "repair all stale releases",
+			expected: "funny-honey revision 2 is now FAILED\nangry-bird revision 2 is now FAILED",
+			rels:     []*release.Release{pending("funny-honey"), pending("angry-bird")},
+		},
+		{
+			name: "repair a missing release",
+			args: []string{"angry-bird"},
+			err:  true,
+		},
+		{
+			name: "repair with too many arguments",
+			args: []string{"funny-honey", "angry-bird"},
+			err:  true,
+		},
+	}
+
+	cmd := func(c *helm.FakeClient, out io.Writer) *cobra.Command {
+		return newRepairCmd(c, out)
+	}
+
+	runReleaseCases(t, tests, cmd)
+}
@@ -29,12 +29,20 @@ import (
 )
 
 type repoListCmd struct {
-	out  io.Writer
-	home helmpath.Home
+	out    io.Writer
+	home   helmpath.Home
+	output outputFormat
+}
+
+// repoOutput is a repository as written by 'helm repo list' in the
+// structured output formats.
+type repoOutput struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
 }
 
 func newRepoListCmd(out io.Writer) *cobra.Command {
-	list := &repoListCmd{out: out}
+	list := &repoListCmd{out: out, output: newOutputFormat("table")}
 
 	cmd := &cobra.Command{
 		Use:   "list [flags]",
@@ -44,6 +52,7 @@ func newRepoListCmd(out io.Writer) *cobra.Command {
 			return list.run()
 		},
 	}
+	cmd.Flags().VarP(&list.output, "output", "o", outputUsage)
 
 	return cmd
 }
@@ -53,14 +62,20 @@ func (a *repoListCmd) run() error {
 	if err != nil {
 		return err
 	}
-	if len(f.Repositories) == 0 {
-		return errors.New("no repositories to show")
-	}
-	table := uitable.New()
-	table.AddRow("NAME", "URL")
+	repos := make([]repoOutput, 0, len(f.Repositories))
 	for _, re := range f.Repositories {
-		table.AddRow(re.Name, re.URL)
+		repos = append(repos, repoOutput{Name: re.Name, URL: re.URL})
 	}
-	fmt.Fprintln(a.out, table)
-	return nil
+	return a.output.write(a.out, repos, func() error {
+		if len(f.Repositories) == 0 {
+			return errors.New("no repositories to show")
+		}
+		table := uitable.New()
+		table.AddRow("NAME", "URL")
+		for _, re := range f.Repositories {
+			table.AddRow(re.Name, re.URL)
+		}
+		fmt.Fprintln(a.out, table)
+		return nil
+	})
 }
@@ -0,0 +1,227 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+
+	"k8s.io/helm/pkg/kube"
+	rspb "k8s.io/helm/pkg/proto/hapi/release"
+	"k8s.io/helm/pkg/storage/backup"
+	"k8s.io/helm/pkg/storage/driver"
+)
+
+const restoreDesc = `
+This command re-creates the releases of an archive written by 'helm backup'
+through a storage driver of Tiller:
+
+	$ helm restore releases.tgz
+	$ helm restore --storage sql --sql-connection-string "$DSN" releases.tgz happy-panda
+
+Every revision of the releases given as arguments is restored, or of every
+release of the archive if none is given. An ARCHIVE of '-' reads the archive
+from stdin. The digests of the archive are verified before anything is
+written.
+
+'--conflict' sets how revisions the storage driver already holds are handled:
+
+- skip: the revisions stored are kept, the others are restored.
+- overwrite: the revisions stored are replaced by those of the archive.
+- renumber: the revisions of the archive are restored after the latest
+  revision stored, and become the current revisions of their releases.
+
+With '--apply', the manifest of the deployed revision of every release
+restored is applied to the cluster, re-creating the resources that are
+missing. Hooks are not run.
+
+With '--storage-dedup', the releases are stored deduplicated, for Tiller
+started with the same flag.
+`
+
+type restoreCmd struct {
+	storageOptions
+	archive  string
+	releases []string
+	storage  string
+	conflict string
+	dryRun   bool
+	apply    bool
+	timeout  int64
+	wait     bool
+	in       io.Reader
+	out      io.Writer
+
+	// newDriver returns the storage driver named name.
+	newDriver func(name string) (driver.Driver, error)
+	// applyRelease applies the manifest of a release to the cluster.
+	applyRelease func(rls *rspb.Release) error
+}
+
+func newRestoreCmd(out io.Writer) *cobra.Command {
+	r := &restoreCmd{in: os.Stdin, out: out}
+	r.newDriver = r.storageDriver
+	r.applyRelease = r.applyManifest
+
+	cmd := &cobra.Command{
+		Use:   "restore [flags] ARCHIVE [RELEASE...]",
+		Short: "re-create the releases of an archive written by 'helm backup'",
+		Long:  restoreDesc,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				return errors.New("an archive must be given")
+			}
+			r.archive, r.releases = args[0], args[1:]
+			return r.run()
+		},
+	}
+
+	f := cmd.Flags()
+	f.StringVar(&r.storage, "storage", "", "storage driver of Tiller to restore the releases to: one of 'configmap', 'secret' or 'sql'. Defaults to 'secret' with --local, 'configmap' otherwise")
+	f.StringVar(&r.conflict, "conflict", string(backup.ConflictSkip), "how to handle revisions already stored: one of 'skip', 'overwrite' or 'renumber'")
+	f.BoolVar(&r.dryRun, "dry-run", false, "list the revisions that would be restored without writing anything")
+	f.BoolVar(&r.apply, "apply", false, "apply the manifest of the deployed revision of every release restored to the cluster")
+	f.Int64Var(&r.timeout, "timeout", 300, "time in seconds to wait for any individual Kubernetes operation when applying manifests")
+	f.BoolVar(&r.wait, "wait", false, "if set with --apply, will wait until all Pods, PVCs, Services, and minimum number of Pods of a Deployment are in a ready state. It will wait for as long as --timeout")
+	r.addFlags(f, "storage-namespace")
+
+	return cmd
+}
+
+func (r *restoreCmd) run() error {
+	in := r.in
+	if r.archive != "-" {
+		f, err := os.Open(r.archive)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		in = f
+	}
+	_, records, err := backup.Read(in)
+	if err != nil {
+		return err
+	}
+	if records, err = r.selectRecords(records); err != nil {
+		return err
+	}
+
+	d, err := r.newDriver(storageDriverName(r.storage))
+	if err != nil {
+		return err
+	}
+	res, err := backup.Restore(d, records, backup.RestoreOptions{
+		Conflict: backup.Conflict(r.conflict),
+		DryRun:   r.dryRun,
+		Log: func(format string, v ...interface{}) {
+			fmt.Fprintf(r.out, format+"\n", v...)
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	if r.apply {
+		for _, rls := range deployedReleases(res.Restored) {
+			if r.dryRun {
+				fmt.Fprintf(r.out, "would apply the manifest of %s.v%d\n", rls.Name, rls.Version)
+				continue
+			}
+			if err := r.applyRelease(rls); err != nil {
+				return fmt.Errorf("cannot apply the manifest of %s.v%d: %s", rls.Name, rls.Version, err)
+			}
+			fmt.Fprintf(r.out, "applied the manifest of %s.v%d\n", rls.Name, rls.Version)
+		}
+	}
+
+	if r.dryRun {
+		fmt.Fprintf(r.out, "Would restore %d revision(s), %d already stored\n", len(res.Restored), len(res.Skipped))
+		return nil
+	}
+	fmt.Fprintf(r.out, "Restored %d revision(s) to the %s driver, %d already stored\n", len(res.Restored), d.Name(), len(res.Skipped))
+	return nil
+}
+
+// selectRecords returns the records of the releases to restore.
+func (r *restoreCmd) selectRecords(records []*backup.Record) ([]*backup.Record, error) {
+	if len(r.releases) == 0 {
+		return records, nil
+	}
+	selected := map[string]bool{}
+	for _, name := range r.releases {
+		selected[name] = false
+	}
+	var rs []*backup.Record
+	for _, rec := range records {
+		if _, ok := selected[rec.Release.Name]; ok {
+			selected[rec.Release.Name] = true
+			rs = append(rs, rec)
+		}
+	}
+	for _, name := range r.releases {
+		if !selected[name] {
+			return nil, fmt.Errorf("release %q not found in %s", name, r.archive)
+		}
+	}
+	return rs, nil
+}
+
+// deployedReleases returns the latest deployed revision of every release.
+func deployedReleases(rels []*rspb.Release) []*rspb.Release {
+	latest := map[string]*rspb.Release{}
+	var names []string
+	for _, rls := range rels {
+		if rls.GetInfo().GetStatus().GetCode() != rspb.Status_DEPLOYED {
+			continue
+		}
+		cur, ok := latest[rls.Name]
+		if !ok {
+			names = append(names, rls.Name)
+		}
+		if !ok || rls.Version > cur.Version {
+			latest[rls.Name] = rls
+		}
+	}
+	deployed := make([]*rspb.Release, 0, len(names))
+	for _, name := range names {
+		deployed = append(deployed, latest[name])
+	}
+	return deployed
+}
+
+// applyManifest applies the manifest of a release to the cluster with the
+// credentials of the kubeconfig, creating the resources that are missing and
+// patching the others.
+func (r *restoreCmd) applyManifest(rls *rspb.Release) error {
+	flags := genericclioptions.NewConfigFlags()
+	flags.Context = &settings.KubeContext
+	flags.KubeConfig = &settings.KubeConfig
+	client := kube.New(flags)
+	client.Log = debug
+
+	return client.UpdateWithOptions(rls.Namespace, bytes.NewBufferString(rls.Manifest), bytes.NewBufferString(rls.Manifest), kube.UpdateOptions{
+		Timeout:       r.timeout,
+		ShouldWait:    r.wait,
+		ThreeWayMerge: true,
+	})
+}
@@ -0,0 +1,127 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/proto/hapi/release"
+	"k8s.io/helm/pkg/storage/driver"
+)
+
+func TestRestoreCmd(t *testing.T) {
+	var archive bytes.Buffer
+	b := &backupCmd{archive: "-", out: &archive}
+	b.newDriver = func(string) (driver.Driver, error) { return backupTestDriver(t), nil }
+	if err := b.run(); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		r        restoreCmd
+		expected string
+		applied  []string
+		stored   int
+		err      string
+	}{
+		{
+			name:     "restore",
+			expected: "restored angry-bird.v1\nskipping angry-bird.v2: already stored\nrestored happy-panda.v1\nRestored 2 revision(s) to the Memory driver, 1 already stored\n",
+			stored:   3,
+		},
+		{
+			name:     "restore a release and apply it",
+			r:        restoreCmd{releases: []string{"angry-bird"}, conflict: "overwrite", apply: true},
+			expected: "restored angry-bird.v1\noverwrote angry-bird.v2\napplied the manifest of angry-bird.v2\nRestored 2 revision(s) to the Memory driver, 0 already stored\n",
+			applied:  []string{"angry-bird.v2"},
+			stored:   2,
+		},
+		{
+			name:     "renumber",
+			r:        restoreCmd{conflict: "renumber", apply: true},
+			expected: "restored angry-bird.v1 as angry-bird.v3\nrestored angry-bird.v2 as angry-bird.v4\nsuperseded angry-bird.v2\nrestored happy-panda.v1\napplied the manifest of angry-bird.v4\nRestored 3 revision(s) to the Memory driver, 0 already stored\n",
+			applied:  []string{"angry-bird.v4"},
+			stored:   4,
+		},
+		{
+			name:     "dry run",
+			r:        restoreCmd{conflict: "renumber", dryRun: true, apply: true},
+			expected: "would restore angry-bird.v1 as angry-bird.v3\nwould restore angry-bird.v2 as angry-bird.v4\nwould supersede angry-bird.v2\nwould restore happy-panda.v1\nwould apply the manifest of angry-bird.v4\nWould restore 3 revision(s), 0 already stored\n",
+			stored:   1,
+		},
+		{
+			name: "unknown release",
+			r:    restoreCmd{releases: []string{"sad-panda"}},
+			err:  `release "sad-panda" not found in -`,
+		},
+		{
+			name: "unknown conflict handling",
+			r:    restoreCmd{conflict: "merge"},
+			err:  `unknown conflict handling "merge"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// The storage driver already holds the deployed revision of
+			// angry-bird.
+			d := driver.NewMemory()
+			if err := d.Create("angry-bird.v2", helm.ReleaseMock(&helm.MockReleaseOptions{Name: "angry-bird", Version: 2, Description: "Stored"})); err != nil {
+				t.Fatal(err)
+			}
+
+			var buf bytes.Buffer
+			var applied []string
+			r := tt.r
+			r.archive = "-"
+			r.in = bytes.NewReader(archive.Bytes())
+			r.out = &buf
+			r.newDriver = func(string) (driver.Driver, error) { return d, nil }
+			r.applyRelease = func(rls *release.Release) error {
+				applied = append(applied, fmt.Sprintf("%s.v%d", rls.Name, rls.Version))
+				return nil
+			}
+
+			err := r.run()
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("expected error %q, got %v", tt.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if buf.String() != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, buf.String())
+			}
+			if !reflect.DeepEqual(applied, tt.applied) {
+				t.Errorf("expected %v to be applied, got %v", tt.applied, applied)
+			}
+			rels, _ := d.List(func(*release.Release) bool { return true })
+			if len(rels) != tt.stored {
+				t.Errorf("expected %d revisions to be stored, got %d", tt.stored, len(rels))
+			}
+		})
+	}
+}
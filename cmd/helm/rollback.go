@@ -36,17 +36,19 @@ second is a revision (version) number. To see revision numbers, run
 `
 
 type rollbackCmd struct {
-	name         string
-	revision     int32
-	dryRun       bool
-	recreate     bool
-	force        bool
-	disableHooks bool
-	out          io.Writer
-	client       helm.Interface
-	timeout      int64
-	wait         bool
-	description  string
+	name          string
+	revision      int32
+	dryRun        bool
+	recreate      bool
+	force         bool
+	threeWayMerge bool
+	disableHooks  bool
+	out           io.Writer
+	client        helm.Interface
+	namespace     string
+	timeout       int64
+	wait          bool
+	description   string
 }
 
 func newRollbackCmd(c helm.Interface, out io.Writer) *cobra.Command {
@@ -73,16 +75,18 @@ func newRollbackCmd(c helm.Interface, out io.Writer) *cobra.Command {
 			}
 
 			rollback.revision = int32(v64)
-			rollback.client = ensureHelmClient(rollback.client)
+			rollback.client = ensureHelmClientInNamespace(rollback.client, rollback.namespace)
 			return rollback.run()
 		},
 	}
 
 	f := cmd.Flags()
 	settings.AddFlagsTLS(f)
+	addReleaseNamespaceFlag(f, &rollback.namespace)
 	f.BoolVar(&rollback.dryRun, "dry-run", false, "simulate a rollback")
 	f.BoolVar(&rollback.recreate, "recreate-pods", false, "performs pods restart for the resource if applicable")
 	f.BoolVar(&rollback.force, "force", false, "force resource update through delete/recreate if needed")
+	f.BoolVar(&rollback.threeWayMerge, "three-way-merge", false, "patch resources with a three-way merge of the current manifest, the target manifest and the live state of the resources")
 	f.BoolVar(&rollback.disableHooks, "no-hooks", false, "prevent hooks from running during rollback")
 	f.Int64Var(&rollback.timeout, "timeout", 300, "time in seconds to wait for any individual Kubernetes operation (like Jobs for hooks)")
 	f.BoolVar(&rollback.wait, "wait", false, "if set, will wait until all Pods, PVCs, Services, and minimum number of Pods of a Deployment are in a ready state before marking the release as successful. It will wait for as long as --timeout")
@@ -100,10 +104,12 @@ func (r *rollbackCmd) run() error {
 		helm.RollbackDryRun(r.dryRun),
 		helm.RollbackRecreate(r.recreate),
 		helm.RollbackForce(r.force),
+		helm.RollbackThreeWayMerge(r.threeWayMerge),
 		helm.RollbackDisableHooks(r.disableHooks),
 		helm.RollbackVersion(r.revision),
 		helm.RollbackTimeout(r.timeout),
 		helm.RollbackWait(r.wait),
+		helm.RollbackProgress(printProgress(r.out)),
 		helm.RollbackDescription(r.description))
 	if err != nil {
 		return prettyError(err)
@@ -48,10 +48,21 @@ type searchCmd struct {
 	regexp   bool
 	version  string
 	colWidth uint
+	output   outputFormat
+}
+
+// searchOutput is a chart as written by 'helm search' in the structured
+// output formats.
+type searchOutput struct {
+	// Name is the name of the chart, prefixed with the name of its repository.
+	Name        string `json:"name"`
+	Version     string `json:"version"`
+	AppVersion  string `json:"appVersion"`
+	Description string `json:"description"`
 }
 
 func newSearchCmd(out io.Writer) *cobra.Command {
-	sc := &searchCmd{out: out}
+	sc := &searchCmd{out: out, output: newOutputFormat("table")}
 
 	cmd := &cobra.Command{
 		Use:   "search [keyword]",
@@ -68,6 +79,7 @@ func newSearchCmd(out io.Writer) *cobra.Command {
 	f.BoolVarP(&sc.versions, "versions", "l", false, "show the long listing, with each version of each chart on its own line")
 	f.StringVarP(&sc.version, "version", "v", "", "search using semantic versioning constraints")
 	f.UintVar(&sc.colWidth, "col-width", 60, "specifies the max column width of output")
+	f.VarP(&sc.output, "output", "o", outputUsage)
 
 	return cmd
 }
@@ -95,9 +107,19 @@ func (s *searchCmd) run(args []string) error {
 		return err
 	}
 
-	fmt.Fprintln(s.out, s.formatSearchResults(data, s.colWidth))
-
-	return nil
+	results := make([]searchOutput, 0, len(data))
+	for _, r := range data {
+		results = append(results, searchOutput{
+			Name:        r.Name,
+			Version:     r.Chart.Version,
+			AppVersion:  r.Chart.AppVersion,
+			Description: r.Chart.Description,
+		})
+	}
+	return s.output.write(s.out, results, func() error {
+		fmt.Fprintln(s.out, s.formatSearchResults(data, s.colWidth))
+		return nil
+	})
 }
 
 func (s *searchCmd) applyConstraint(res []*search.Result) ([]*search.Result, error) {
@@ -154,7 +176,7 @@ func (s *searchCmd) buildIndex() (*search.Index, error) {
 		f := s.helmhome.CacheIndex(n)
 		ind, err := repo.LoadIndexFile(f)
 		if err != nil {
-			fmt.Fprintf(s.out, "WARNING: Repo %q is corrupt or missing. Try 'helm repo update'.\n", n)
+			fmt.Fprintf(s.output.messages(s.out), "WARNING: Repo %q is corrupt or missing. Try 'helm repo update'.\n", n)
 			continue
 		}
 
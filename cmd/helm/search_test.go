@@ -18,6 +18,7 @@ package main
 
 import (
 	"io"
+	"regexp"
 	"testing"
 
 	"github.com/spf13/cobra"
@@ -78,6 +79,24 @@ func TestSearchCmd(t *testing.T) {
 			flags:    []string{"--regexp"},
 			expected: "NAME          \tCHART VERSION\tAPP VERSION\tDESCRIPTION                    \ntesting/alpine\t0.2.0        \t2.3.4      \tDeploy a basic Alpine Linux pod",
 		},
+		{
+			name:     "search for 'alpine' with json output",
+			args:     []string{"alpine"},
+			flags:    []string{"--output", "json"},
+			expected: regexp.QuoteMeta(`[{"name":"testing/alpine","version":"0.2.0","appVersion":"2.3.4","description":"Deploy a basic Alpine Linux pod"}]`),
+		},
+		{
+			name:     "search for 'alpine' with versions and jsonpath output",
+			args:     []string{"alpine"},
+			flags:    []string{"--versions", "--output", "jsonpath={[*].version}"},
+			expected: "0.2.0 0.1.0",
+		},
+		{
+			name:     "search for 'syzygy' with yaml output, expect an empty list",
+			args:     []string{"syzygy"},
+			flags:    []string{"--output", "yaml"},
+			expected: `\[\]`,
+		},
 		{
 			name:  "search for 'alp[', expect failure to compile regexp",
 			args:  []string{"alp["},
@@ -17,13 +17,11 @@ limitations under the License.
 package main
 
 import (
-	"encoding/json"
 	"fmt"
 	"io"
 	"regexp"
 	"text/tabwriter"
 
-	"github.com/ghodss/yaml"
 	"github.com/gosuri/uitable"
 	"github.com/gosuri/uitable/util/strutil"
 	"github.com/spf13/cobra"
@@ -46,17 +44,19 @@ The status consists of:
 `
 
 type statusCmd struct {
-	release string
-	out     io.Writer
-	client  helm.Interface
-	version int32
-	outfmt  string
+	release   string
+	out       io.Writer
+	client    helm.Interface
+	namespace string
+	version   int32
+	output    outputFormat
 }
 
 func newStatusCmd(client helm.Interface, out io.Writer) *cobra.Command {
 	status := &statusCmd{
 		out:    out,
 		client: client,
+		output: newOutputFormat("table"),
 	}
 
 	cmd := &cobra.Command{
@@ -70,7 +70,7 @@ func newStatusCmd(client helm.Interface, out io.Writer) *cobra.Command {
 			}
 			status.release = args[0]
 			if status.client == nil {
-				status.client = newClient()
+				status.client = newClientInNamespace(status.namespace)
 			}
 			return status.run()
 		},
@@ -78,8 +78,9 @@ func newStatusCmd(client helm.Interface, out io.Writer) *cobra.Command {
 
 	f := cmd.Flags()
 	settings.AddFlagsTLS(f)
+	addReleaseNamespaceFlag(f, &status.namespace)
 	f.Int32Var(&status.version, "revision", 0, "if set, display the status of the named release with revision")
-	f.StringVarP(&status.outfmt, "output", "o", "", "output the status in the specified format (json or yaml)")
+	f.VarP(&status.output, "output", "o", outputUsage)
 
 	// set defaults from environment
 	settings.InitTLS(f)
@@ -93,27 +94,10 @@ func (s *statusCmd) run() error {
 		return prettyError(err)
 	}
 
-	switch s.outfmt {
-	case "":
+	return s.output.write(s.out, res, func() error {
 		PrintStatus(s.out, res)
 		return nil
-	case "json":
-		data, err := json.Marshal(res)
-		if err != nil {
-			return fmt.Errorf("Failed to Marshal JSON output: %s", err)
-		}
-		s.out.Write(data)
-		return nil
-	case "yaml":
-		data, err := yaml.Marshal(res)
-		if err != nil {
-			return fmt.Errorf("Failed to Marshal YAML output: %s", err)
-		}
-		s.out.Write(data)
-		return nil
-	}
-
-	return fmt.Errorf("Unknown output format %q", s.outfmt)
+	})
 }
 
 // PrintStatus prints out the status of a release. Shared because also used by
@@ -124,6 +108,13 @@ func PrintStatus(out io.Writer, res *services.GetReleaseStatusResponse) {
 	}
 	fmt.Fprintf(out, "NAMESPACE: %s\n", res.Namespace)
 	fmt.Fprintf(out, "STATUS: %s\n", res.Info.Status.Code)
+	if waves := res.Info.Waves; waves != nil && waves.Applied < waves.Total {
+		state := ""
+		if waves.Paused {
+			state = " (paused)"
+		}
+		fmt.Fprintf(out, "WAVES: %d of %d applied%s\n", waves.Applied, waves.Total, state)
+	}
 	fmt.Fprintf(out, "\n")
 	if len(res.Info.Status.Resources) > 0 {
 		re := regexp.MustCompile("  +")
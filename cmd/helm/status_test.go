@@ -125,6 +125,18 @@ func TestStatusCmd(t *testing.T) {
 				}),
 			},
 		},
+		{
+			name:     "get status of a paused upgrade",
+			args:     []string{"flummoxed-chickadee"},
+			expected: outputWithStatus("PENDING_UPGRADE\nWAVES: 1 of 3 applied \\(paused\\)\n\n"),
+			rels: []*release.Release{
+				func() *release.Release {
+					r := releaseMockWithStatus(&release.Status{Code: release.Status_PENDING_UPGRADE})
+					r.Info.Waves = &release.Waves{Applied: 1, Total: 3, Paused: true}
+					return r
+				}(),
+			},
+		},
 	}
 
 	runReleaseCases(t, tests, func(c *helm.FakeClient, out io.Writer) *cobra.Command {
@@ -0,0 +1,120 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/pflag"
+
+	"k8s.io/helm/pkg/storage/driver"
+)
+
+// storageDrivers are the names of the storage drivers the commands accessing
+// the storage of Tiller directly support.
+var storageDrivers = []string{"configmap", "secret", "sql"}
+
+// storageOptions are the options of the commands accessing the storage
+// drivers of Tiller directly.
+type storageOptions struct {
+	namespace           string
+	sqlDialect          string
+	sqlConnectionString string
+	encryptionKeysFile  string
+	dedup               bool
+}
+
+// addFlags adds the flags of the storage options, the namespace flag being
+// named namespaceFlag.
+func (o *storageOptions) addFlags(f *pflag.FlagSet, namespaceFlag string) {
+	f.StringVar(&o.namespace, namespaceFlag, "", "namespace of the ConfigMaps and Secrets holding releases. Defaults to the namespace of Tiller")
+	f.StringVar(&o.sqlDialect, "sql-dialect", "postgres", "SQL dialect of the 'sql' storage driver")
+	f.StringVar(&o.sqlConnectionString, "sql-connection-string", "", "connection string of the 'sql' storage driver")
+	f.StringVar(&o.encryptionKeysFile, "encryption-keys-file", "", "path to the YAML file listing the keys encrypting releases at rest, as given to Tiller")
+	f.BoolVar(&o.dedup, "storage-dedup", false, "read and write releases in the deduplicating encoding of Tiller run with --storage-dedup")
+}
+
+// storageDriverName returns name, or the storage driver used by Tiller by
+// default, or by the release server run with --local, if name is empty.
+func storageDriverName(name string) string {
+	switch {
+	case name != "":
+		return name
+	case settings.Local:
+		return "secret"
+	default:
+		return "configmap"
+	}
+}
+
+// storageDriver returns the storage driver named name, accessed with the
+// credentials of the kubeconfig or the SQL connection string.
+func (o *storageOptions) storageDriver(name string) (driver.Driver, error) {
+	d, err := o.baseStorageDriver(name)
+	if err != nil || !o.dedup {
+		return d, err
+	}
+	return driver.NewDedup(d), nil
+}
+
+// baseStorageDriver returns the storage driver named name, without the
+// deduplicating encoding.
+func (o *storageOptions) baseStorageDriver(name string) (driver.Driver, error) {
+	switch name {
+	case "configmap", "secret", "sql":
+	default:
+		return nil, fmt.Errorf("unknown storage driver %q: must be one of %s", name, strings.Join(storageDrivers, ", "))
+	}
+	var keys driver.KeyProvider
+	if o.encryptionKeysFile != "" {
+		k, err := driver.LoadKeyFile(o.encryptionKeysFile)
+		if err != nil {
+			return nil, err
+		}
+		keys = k
+	}
+	if name == "sql" {
+		d, err := driver.NewSQL(o.sqlDialect, o.sqlConnectionString)
+		if err != nil {
+			return nil, err
+		}
+		d.Keys = keys
+		return d, nil
+	}
+
+	namespace := o.namespace
+	switch {
+	case namespace != "":
+	case settings.Local:
+		namespace = defaultNamespace()
+	default:
+		namespace = settings.TillerNamespace
+	}
+	_, clientset, err := getKubeClient(settings.KubeContext, settings.KubeConfig)
+	if err != nil {
+		return nil, err
+	}
+	if name == "configmap" {
+		d := driver.NewConfigMaps(clientset.CoreV1().ConfigMaps(namespace))
+		d.Keys = keys
+		return d, nil
+	}
+	d := driver.NewSecrets(clientset.CoreV1().Secrets(namespace))
+	d.Keys = keys
+	return d, nil
+}
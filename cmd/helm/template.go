@@ -31,7 +31,9 @@ import (
 
 	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/helm/pkg/chartutil"
+	"k8s.io/helm/pkg/kubeschema"
 	"k8s.io/helm/pkg/manifest"
+	"k8s.io/helm/pkg/policy"
 	"k8s.io/helm/pkg/proto/hapi/chart"
 	"k8s.io/helm/pkg/proto/hapi/release"
 	"k8s.io/helm/pkg/renderutil"
@@ -59,6 +61,19 @@ is done.
 To render just one template in a chart, use '-x':
 
 	$ helm template mychart -x templates/deployment.yaml
+
+To check the rendered manifests against policy rules before they are
+displayed, use '--policy-config':
+
+	$ helm template mychart --policy-config policy.yaml
+
+To validate the rendered manifests against the Kubernetes schemas before they
+are displayed, use '--validate'. The manifests are validated offline, against
+the schemas bundled for '--kube-version' or read from '--schema-dir', and the
+custom resources against the custom resource definitions of the chart and of
+'--crd-dir':
+
+	$ helm template mychart --validate --kube-version 1.13 --crd-dir crds/
 `
 
 type templateCmd struct {
@@ -76,6 +91,10 @@ type templateCmd struct {
 	renderFiles      []string
 	kubeVersion      string
 	outputDir        string
+	policyConfig     string
+	validate         bool
+	schemaDir        string
+	crdDir           string
 }
 
 func newTemplateCmd(out io.Writer) *cobra.Command {
@@ -104,6 +123,10 @@ func newTemplateCmd(out io.Writer) *cobra.Command {
 	f.StringVar(&t.nameTemplate, "name-template", "", "specify template used to name the release")
 	f.StringVar(&t.kubeVersion, "kube-version", defaultKubeVersion, "kubernetes version used as Capabilities.KubeVersion.Major/Minor")
 	f.StringVar(&t.outputDir, "output-dir", "", "writes the executed templates to files in output-dir instead of stdout")
+	f.StringVar(&t.policyConfig, "policy-config", "", "check the rendered manifests against the policy rules configured in this file")
+	f.BoolVar(&t.validate, "validate", false, "validate the rendered manifests against the Kubernetes schemas of --kube-version, the latest bundled version by default")
+	f.StringVar(&t.schemaDir, "schema-dir", "", "with --validate, read the schemas from the OpenAPI document named after --kube-version in this directory, such as 1.13.json, if there is one")
+	f.StringVar(&t.crdDir, "crd-dir", "", "with --validate, validate custom resources against the custom resource definitions in this directory")
 
 	return cmd
 }
@@ -157,6 +180,16 @@ func (t *templateCmd) run(cmd *cobra.Command, args []string) error {
 		return prettyError(err)
 	}
 
+	var schemas *kubeschema.Validator
+	if t.validate {
+		if !cmd.Flags().Changed("kube-version") {
+			t.kubeVersion = kubeschema.DefaultVersion()
+		}
+		if schemas, err = loadKubeSchemas(t.kubeVersion, t.schemaDir, t.crdDir); err != nil {
+			return err
+		}
+	}
+
 	renderOpts := renderutil.Options{
 		ReleaseOptions: chartutil.ReleaseOptions{
 			Name:      t.releaseName,
@@ -173,6 +206,17 @@ func (t *templateCmd) run(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	if t.policyConfig != "" {
+		if err := checkPolicies(t.policyConfig, renderedTemplates); err != nil {
+			return err
+		}
+	}
+	if schemas != nil {
+		if err := validateManifests(schemas, renderedTemplates); err != nil {
+			return err
+		}
+	}
+
 	if settings.Debug {
 		rel := &release.Release{
 			Name:      t.releaseName,
@@ -253,6 +297,61 @@ func (t *templateCmd) run(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// checkPolicies checks the rendered templates against the policy rules
+// configured in the file configPath. Violations of rules that only warn are
+// printed to stderr, keeping them out of the rendered manifests.
+func checkPolicies(configPath string, rendered map[string]string) error {
+	checker, err := policy.LoadConfig(configPath)
+	if err != nil {
+		return err
+	}
+	objs, err := policy.ParseFiles(rendered)
+	if err != nil {
+		return err
+	}
+	report, err := checker.Check(objs)
+	if err != nil {
+		return err
+	}
+	for _, v := range report.Violations {
+		fmt.Fprintf(os.Stderr, "WARNING: %s\n", v)
+	}
+	return nil
+}
+
+// loadKubeSchemas returns a validator for the schemas of a Kubernetes
+// version, read from schemaDir or bundled, and for the custom resource
+// definitions in crdDir, if set.
+func loadKubeSchemas(kubeVersion, schemaDir, crdDir string) (*kubeschema.Validator, error) {
+	schemas, err := kubeschema.New(kubeVersion, schemaDir)
+	if err != nil {
+		return nil, err
+	}
+	if crdDir != "" {
+		if err := schemas.LoadCRDs(crdDir); err != nil {
+			return nil, err
+		}
+	}
+	return schemas, nil
+}
+
+// validateManifests validates the rendered templates against the Kubernetes
+// schemas, failing on any violation.
+func validateManifests(schemas *kubeschema.Validator, rendered map[string]string) error {
+	errs, err := schemas.ValidateFiles(rendered)
+	if err != nil {
+		return err
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	lines := make([]string, len(errs))
+	for i, e := range errs {
+		lines[i] = e.String()
+	}
+	return fmt.Errorf("manifests do not validate against the schemas of Kubernetes %s:\n- %s", schemas.KubeVersion, strings.Join(lines, "\n- "))
+}
+
 // write the <data> to <output-dir>/<name>
 func writeToFile(outputDir string, name string, data string) error {
 	outfileName := strings.Join([]string{outputDir, name}, string(filepath.Separator))
@@ -176,6 +176,38 @@ func TestTemplateCmd(t *testing.T) {
 			expectKey:   "subchart1/templates/service.yaml",
 			expectValue: "kube-version/major: \"1\"\n    kube-version/minor: \"6\"\n    kube-version/gitversion: \"v1.6.0\"",
 		},
+		{
+			name:        "check_policy_violation",
+			desc:        "verify --policy-config fails on violated rules",
+			args:        []string{"testdata/testcharts/alpine", "--set", "test.Name=policy", "--policy-config", "testdata/policy/enforce.yaml"},
+			expectError: `[resource-limits] Pod/release-name-my-alpine in alpine/templates/alpine-pod.yaml: container "waiter" has no cpu or memory limit`,
+		},
+		{
+			name:        "check_policy_warning",
+			desc:        "verify --policy-config renders the templates when the rules only warn",
+			args:        []string{"testdata/testcharts/alpine", "--set", "test.Name=policy", "--policy-config", "testdata/policy/warn.yaml"},
+			expectKey:   "alpine/templates/alpine-pod.yaml",
+			expectValue: "name: waiter",
+		},
+		{
+			name:        "check_validate",
+			desc:        "verify --validate renders valid manifests",
+			args:        []string{"testdata/testcharts/alpine", "--set", "test.Name=waiter", "--validate"},
+			expectKey:   "alpine/templates/alpine-pod.yaml",
+			expectValue: "name: waiter",
+		},
+		{
+			name:        "check_validate_error",
+			desc:        "verify --validate fails on manifests not matching the schemas",
+			args:        []string{"testdata/testcharts/alpine", "--set", "test.Name=1", "--validate"},
+			expectError: "alpine/templates/alpine-pod.yaml:15: Pod/release-name-my-alpine: metadata.labels.values: invalid type: got integer, expected string",
+		},
+		{
+			name:        "check_validate_kube_version",
+			desc:        "verify --validate fails for versions without schemas",
+			args:        []string{"testdata/testcharts/alpine", "--set", "test.Name=waiter", "--validate", "--kube-version", "1.6"},
+			expectError: "no schemas for Kubernetes 1.6",
+		},
 	}
 
 	var buf bytes.Buffer
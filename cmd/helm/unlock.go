@@ -0,0 +1,88 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/helm/pkg/helm"
+)
+
+const unlockDesc = `
+This command clears the lock held on a release.
+
+Tiller locks a release while it is installed, upgraded, rolled back or
+deleted, so that concurrent operations on the same release fail instead of
+corrupting its history. Locks expire on their own a minute after the Tiller
+holding them stopped renewing them; use this command to clear a lock left by
+an interrupted operation right away.
+
+Clearing the lock of an operation that is still running allows another
+operation to start concurrently. Tiller aborts the running operation before
+its next step once it fails to renew the lock.
+`
+
+type unlockCmd struct {
+	name      string
+	out       io.Writer
+	client    helm.Interface
+	namespace string
+}
+
+func newUnlockCmd(c helm.Interface, out io.Writer) *cobra.Command {
+	unlock := &unlockCmd{
+		out:    out,
+		client: c,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "unlock [flags] RELEASE",
+		Short:   "clear the lock held on a release",
+		Long:    unlockDesc,
+		PreRunE: func(_ *cobra.Command, _ []string) error { return setupConnection() },
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := checkArgsLength(len(args), "release name"); err != nil {
+				return err
+			}
+			unlock.name = args[0]
+			unlock.client = ensureHelmClientInNamespace(unlock.client, unlock.namespace)
+			return unlock.run()
+		},
+	}
+
+	f := cmd.Flags()
+	settings.AddFlagsTLS(f)
+	addReleaseNamespaceFlag(f, &unlock.namespace)
+
+	// set defaults from environment
+	settings.InitTLS(f)
+
+	return cmd
+}
+
+func (u *unlockCmd) run() error {
+	res, err := u.client.UnlockRelease(u.name)
+	if err != nil {
+		return prettyError(err)
+	}
+
+	fmt.Fprintf(u.out, "Cleared the lock on %s held by %s from %s\n", u.name, res.Operation, res.Holder)
+	return nil
+}
@@ -0,0 +1,53 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"io"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/proto/hapi/release"
+)
+
+func TestUnlockCmd(t *testing.T) {
+	tests := []releaseCase{
+		{
+			name:     "unlock a release",
+			args:     []string{"funny-honey"},
+			expected: "Cleared the lock on funny-honey held by upgrade from fake@localhost",
+			rels:     []*release.Release{helm.ReleaseMock(&helm.MockReleaseOptions{Name: "funny-honey"})},
+		},
+		{
+			name: "unlock a release that is not locked",
+			args: []string{"angry-bird"},
+			err:  true,
+		},
+		{
+			name: "unlock without release",
+			err:  true,
+		},
+	}
+
+	cmd := func(c *helm.FakeClient, out io.Writer) *cobra.Command {
+		return newUnlockCmd(c, out)
+	}
+
+	runReleaseCases(t, tests, cmd)
+}
@@ -25,6 +25,8 @@ import (
 
 	"k8s.io/helm/pkg/chartutil"
 	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/proto/hapi/release"
+	"k8s.io/helm/pkg/proto/hapi/services"
 	"k8s.io/helm/pkg/renderutil"
 	storageerrors "k8s.io/helm/pkg/storage/errors"
 )
@@ -44,6 +46,11 @@ To customize the chart values, use any of
  - '--set-string' to provide key=val forcing val to be stored as a string,
  - '--set-file' to provide key=path to read a single large value from a file at path.
 
+With '--steps', the resources are applied in waves, grouped by the value of their
+'helm.sh/wave' annotation in increasing order; resources without the annotation belong
+to wave 0. Each wave must be ready before the next one is applied. With '--pause', the
+upgrade pauses after each wave until it is continued with 'helm upgrade --continue RELEASE'.
+
 To edit or append to the existing customized values, add the
  '--reuse-values' flag, otherwise any existing customized values are ignored.
 
@@ -84,34 +91,40 @@ which results in "pwd: 3jk$o2z=f\30with'quote".
 `
 
 type upgradeCmd struct {
-	release      string
-	chart        string
-	out          io.Writer
-	client       helm.Interface
-	dryRun       bool
-	recreate     bool
-	force        bool
-	disableHooks bool
-	valueFiles   valueFiles
-	values       []string
-	stringValues []string
-	fileValues   []string
-	verify       bool
-	keyring      string
-	install      bool
-	namespace    string
-	version      string
-	timeout      int64
-	resetValues  bool
-	reuseValues  bool
-	wait         bool
-	atomic       bool
-	repoURL      string
-	username     string
-	password     string
-	devel        bool
-	subNotes     bool
-	description  string
+	release       string
+	chart         string
+	out           io.Writer
+	client        helm.Interface
+	dryRun        bool
+	recreate      bool
+	force         bool
+	threeWayMerge bool
+	disableHooks  bool
+	valueFiles    valueFiles
+	values        []string
+	stringValues  []string
+	fileValues    []string
+	verify        bool
+	keyring       string
+	install       bool
+	namespace     string
+	version       string
+	timeout       int64
+	resetValues   bool
+	reuseValues   bool
+	wait          bool
+	atomic        bool
+	testAfter     bool
+	atomicTests   bool
+	steps         bool
+	pause         bool
+	resume        bool
+	repoURL       string
+	username      string
+	password      string
+	devel         bool
+	subNotes      bool
+	description   string
 
 	certFile string
 	keyFile  string
@@ -131,6 +144,15 @@ func newUpgradeCmd(client helm.Interface, out io.Writer) *cobra.Command {
 		Long:    upgradeDesc,
 		PreRunE: func(_ *cobra.Command, _ []string) error { return setupConnection() },
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if upgrade.resume {
+				if err := checkArgsLength(len(args), "release name"); err != nil {
+					return err
+				}
+				upgrade.release = args[0]
+				upgrade.client = ensureHelmClientInNamespace(upgrade.client, upgrade.namespace)
+				upgrade.testAfter = upgrade.testAfter || upgrade.atomicTests
+				return upgrade.runContinue()
+			}
 			if err := checkArgsLength(len(args), "release name", "chart path"); err != nil {
 				return err
 			}
@@ -142,8 +164,9 @@ func newUpgradeCmd(client helm.Interface, out io.Writer) *cobra.Command {
 
 			upgrade.release = args[0]
 			upgrade.chart = args[1]
-			upgrade.client = ensureHelmClient(upgrade.client)
-			upgrade.wait = upgrade.wait || upgrade.atomic
+			upgrade.client = ensureHelmClientInNamespace(upgrade.client, upgrade.namespace)
+			upgrade.testAfter = upgrade.testAfter || upgrade.atomicTests
+			upgrade.wait = upgrade.wait || upgrade.atomic || upgrade.testAfter
 
 			return upgrade.run()
 		},
@@ -155,6 +178,7 @@ func newUpgradeCmd(client helm.Interface, out io.Writer) *cobra.Command {
 	f.BoolVar(&upgrade.dryRun, "dry-run", false, "simulate an upgrade")
 	f.BoolVar(&upgrade.recreate, "recreate-pods", false, "performs pods restart for the resource if applicable")
 	f.BoolVar(&upgrade.force, "force", false, "force resource update through delete/recreate if needed")
+	f.BoolVar(&upgrade.threeWayMerge, "three-way-merge", false, "patch resources with a three-way merge of the previous manifest, the new manifest and the live state of the resources")
 	f.StringArrayVar(&upgrade.values, "set", []string{}, "set values on the command line (can specify multiple or separate values with commas: key1=val1,key2=val2)")
 	f.StringArrayVar(&upgrade.stringValues, "set-string", []string{}, "set STRING values on the command line (can specify multiple or separate values with commas: key1=val1,key2=val2)")
 	f.StringArrayVar(&upgrade.fileValues, "set-file", []string{}, "set values from respective files specified via the command line (can specify multiple or separate values with commas: key1=path1,key2=path2)")
@@ -163,13 +187,18 @@ func newUpgradeCmd(client helm.Interface, out io.Writer) *cobra.Command {
 	f.BoolVar(&upgrade.verify, "verify", false, "verify the provenance of the chart before upgrading")
 	f.StringVar(&upgrade.keyring, "keyring", defaultKeyring(), "path to the keyring that contains public signing keys")
 	f.BoolVarP(&upgrade.install, "install", "i", false, "if a release by this name doesn't already exist, run an install")
-	f.StringVar(&upgrade.namespace, "namespace", "", "namespace to install the release into (only used if --install is set). Defaults to the current kube config namespace")
+	f.StringVar(&upgrade.namespace, "namespace", "", "namespace to install the release into (only used if --install is set, or to find the release with --local). Defaults to the current kube config namespace")
 	f.StringVar(&upgrade.version, "version", "", "specify the exact chart version to use. If this is not specified, the latest version is used")
 	f.Int64Var(&upgrade.timeout, "timeout", 300, "time in seconds to wait for any individual Kubernetes operation (like Jobs for hooks)")
 	f.BoolVar(&upgrade.resetValues, "reset-values", false, "when upgrading, reset the values to the ones built into the chart")
 	f.BoolVar(&upgrade.reuseValues, "reuse-values", false, "when upgrading, reuse the last release's values and merge in any overrides from the command line via --set and -f. If '--reset-values' is specified, this is ignored.")
 	f.BoolVar(&upgrade.wait, "wait", false, "if set, will wait until all Pods, PVCs, Services, and minimum number of Pods of a Deployment are in a ready state before marking the release as successful. It will wait for as long as --timeout")
 	f.BoolVar(&upgrade.atomic, "atomic", false, "if set, upgrade process rolls back changes made in case of failed upgrade, also sets --wait flag")
+	f.BoolVar(&upgrade.testAfter, "test-after", false, "if set, runs the tests of the release after a successful upgrade, also sets --wait flag")
+	f.BoolVar(&upgrade.atomicTests, "atomic-tests", false, "if set, rolls back to the previous revision if any test run after the upgrade fails, also sets --test-after flag")
+	f.BoolVar(&upgrade.steps, "steps", false, "apply the resources in waves grouped by their helm.sh/wave annotation, waiting for each wave to be ready")
+	f.BoolVar(&upgrade.pause, "pause", false, "with --steps, pause the upgrade after each wave until it is continued with --continue")
+	f.BoolVar(&upgrade.resume, "continue", false, "continue the paused upgrade of a release, given only the release name")
 	f.StringVar(&upgrade.repoURL, "repo", "", "chart repository url where to locate the requested chart")
 	f.StringVar(&upgrade.username, "username", "", "chart repository username where to locate the requested chart")
 	f.StringVar(&upgrade.password, "password", "", "chart repository password where to locate the requested chart")
@@ -267,28 +296,35 @@ func (u *upgradeCmd) run() error {
 		helm.UpgradeDryRun(u.dryRun),
 		helm.UpgradeRecreate(u.recreate),
 		helm.UpgradeForce(u.force),
+		helm.UpgradeThreeWayMerge(u.threeWayMerge),
 		helm.UpgradeDisableHooks(u.disableHooks),
 		helm.UpgradeTimeout(u.timeout),
 		helm.ResetValues(u.resetValues),
 		helm.ReuseValues(u.reuseValues),
 		helm.UpgradeSubNotes(u.subNotes),
 		helm.UpgradeWait(u.wait),
+		helm.UpgradeTestAfter(u.testAfter),
+		helm.UpgradeAtomicTests(u.atomicTests),
+		helm.UpgradeSteps(u.steps),
+		helm.UpgradePause(u.pause),
+		helm.UpgradeProgress(printProgress(u.out)),
 		helm.UpgradeDescription(u.description))
 	if err != nil {
 		fmt.Fprintf(u.out, "UPGRADE FAILED\nROLLING BACK\nError: %v\n", prettyError(err))
-		if u.atomic {
+		if u.atomic && !u.rolledBack(releaseHistory.Releases[0]) {
 			rollback := &rollbackCmd{
-				out:          u.out,
-				client:       u.client,
-				name:         u.release,
-				dryRun:       u.dryRun,
-				recreate:     u.recreate,
-				force:        u.force,
-				timeout:      u.timeout,
-				wait:         u.wait,
-				description:  "",
-				revision:     releaseHistory.Releases[0].Version,
-				disableHooks: u.disableHooks,
+				out:           u.out,
+				client:        u.client,
+				name:          u.release,
+				dryRun:        u.dryRun,
+				recreate:      u.recreate,
+				force:         u.force,
+				threeWayMerge: u.threeWayMerge,
+				timeout:       u.timeout,
+				wait:          u.wait,
+				description:   "",
+				revision:      releaseHistory.Releases[0].Version,
+				disableHooks:  u.disableHooks,
 			}
 			if err := rollback.run(); err != nil {
 				return err
@@ -297,11 +333,43 @@ func (u *upgradeCmd) run() error {
 		return fmt.Errorf("UPGRADE FAILED: %v", prettyError(err))
 	}
 
+	return u.printUpgraded(resp)
+}
+
+// runContinue continues the paused upgrade of a release.
+func (u *upgradeCmd) runContinue() error {
+	resp, err := u.client.UpdateReleaseFromChart(
+		u.release,
+		nil,
+		helm.UpgradeContinue(true),
+		helm.UpgradeDryRun(u.dryRun),
+		helm.UpgradeDisableHooks(u.disableHooks),
+		helm.UpgradeTimeout(u.timeout),
+		helm.UpgradeTestAfter(u.testAfter),
+		helm.UpgradeAtomicTests(u.atomicTests),
+		helm.UpgradePause(u.pause),
+		helm.UpgradeProgress(printProgress(u.out)),
+		helm.UpgradeDescription(u.description))
+	if err != nil {
+		return fmt.Errorf("UPGRADE FAILED: %v", prettyError(err))
+	}
+	return u.printUpgraded(resp)
+}
+
+// printUpgraded prints the outcome of an upgrade and the status of the release.
+func (u *upgradeCmd) printUpgraded(resp *services.UpdateReleaseResponse) error {
 	if settings.Debug {
 		printRelease(u.out, resp.Release)
 	}
 
-	fmt.Fprintf(u.out, "Release %q has been upgraded. Happy Helming!\n", u.release)
+	if waves := resp.Release.GetInfo().GetWaves(); waves.GetPaused() {
+		fmt.Fprintf(u.out, "Upgrade of release %q paused after wave %d of %d. Continue it with 'helm upgrade --continue %s'.\n", u.release, waves.Applied, waves.Total, u.release)
+	} else {
+		fmt.Fprintf(u.out, "Release %q has been upgraded. Happy Helming!\n", u.release)
+		if u.testAfter && !u.dryRun {
+			fmt.Fprintf(u.out, "Tests of release %q passed.\n", u.release)
+		}
+	}
 
 	// Print the status like status command does
 	status, err := u.client.ReleaseStatus(u.release)
@@ -312,3 +380,18 @@ func (u *upgradeCmd) run() error {
 
 	return nil
 }
+
+// rolledBack reports whether Tiller already rolled the release back to the
+// revision deployed before the upgrade, which it does when tests run with
+// --atomic-tests fail.
+func (u *upgradeCmd) rolledBack(previous *release.Release) bool {
+	if !u.atomicTests {
+		return false
+	}
+	h, err := u.client.ReleaseHistory(u.release, helm.WithMaxHistory(1))
+	if err != nil || len(h.Releases) == 0 {
+		return false
+	}
+	last := h.Releases[0]
+	return last.Version > previous.Version && last.Info.Status.Code == release.Status_DEPLOYED
+}
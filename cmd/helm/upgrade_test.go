@@ -131,6 +131,22 @@ func TestUpgradeCmd(t *testing.T) {
 			expected: "Release \"funny-bunny\" has been upgraded. Happy Helming!\n",
 			rels:     []*release.Release{helm.ReleaseMock(&helm.MockReleaseOptions{Name: "funny-bunny", Version: 6, Chart: ch})},
 		},
+		{
+			name:     "upgrade a release with --atomic-tests",
+			args:     []string{"funny-bunny", chartPath},
+			flags:    []string{"--atomic-tests"},
+			resp:     helm.ReleaseMock(&helm.MockReleaseOptions{Name: "funny-bunny", Version: 7, Chart: ch}),
+			expected: "Tests of release \"funny-bunny\" passed.\n",
+			rels:     []*release.Release{helm.ReleaseMock(&helm.MockReleaseOptions{Name: "funny-bunny", Version: 7, Chart: ch})},
+		},
+		{
+			name:     "continue a paused upgrade",
+			args:     []string{"funny-bunny"},
+			flags:    []string{"--continue"},
+			resp:     helm.ReleaseMock(&helm.MockReleaseOptions{Name: "funny-bunny", Version: 8, Chart: ch}),
+			expected: "Release \"funny-bunny\" has been upgraded. Happy Helming!\n",
+			rels:     []*release.Release{helm.ReleaseMock(&helm.MockReleaseOptions{Name: "funny-bunny", Version: 8, Chart: ch})},
+		},
 		{
 			name:     "install a release with 'upgrade --install'",
 			args:     []string{"zany-bunny", chartPath},
@@ -47,6 +47,9 @@ Server: &version.Version{SemVer:"v2.0.0", GitCommit:"b0c113dfb9f612a9add796549da
 
 To print just the client version, use '--client'. To print just the server version,
 use '--server'.
+
+The versions can be written in other formats with '--output', see
+'docs/output.md', or formatted with a Go template using '--template'.
 `
 
 type versionCmd struct {
@@ -56,12 +59,31 @@ type versionCmd struct {
 	showServer bool
 	short      bool
 	template   string
+	output     outputFormat
+}
+
+// versionOutput is the object written by 'helm version' in the structured
+// output formats. Only the versions requested are set.
+type versionOutput struct {
+	Client *versionInfo `json:"client,omitempty"`
+	Server *versionInfo `json:"server,omitempty"`
+}
+
+type versionInfo struct {
+	SemVer       string `json:"semVer"`
+	GitCommit    string `json:"gitCommit"`
+	GitTreeState string `json:"gitTreeState"`
+}
+
+func newVersionInfo(v *pb.Version) *versionInfo {
+	return &versionInfo{SemVer: v.SemVer, GitCommit: v.GitCommit, GitTreeState: v.GitTreeState}
 }
 
 func newVersionCmd(c helm.Interface, out io.Writer) *cobra.Command {
 	version := &versionCmd{
 		client: c,
 		out:    out,
+		output: newOutputFormat("table"),
 	}
 
 	cmd := &cobra.Command{
@@ -73,6 +95,9 @@ func newVersionCmd(c helm.Interface, out io.Writer) *cobra.Command {
 			if !version.showClient && !version.showServer {
 				version.showClient, version.showServer = true, true
 			}
+			if version.template != "" && !version.output.isTable() {
+				return errors.New("cannot use --template with --output")
+			}
 			return version.run()
 		},
 	}
@@ -82,6 +107,7 @@ func newVersionCmd(c helm.Interface, out io.Writer) *cobra.Command {
 	f.BoolVarP(&version.showServer, "server", "s", false, "server version only")
 	f.BoolVar(&version.short, "short", false, "print the version number")
 	f.StringVar(&version.template, "template", "", "template for version string format")
+	f.VarP(&version.output, "output", "o", outputUsage)
 
 	// set defaults from environment
 	settings.InitTLS(f)
@@ -92,18 +118,21 @@ func newVersionCmd(c helm.Interface, out io.Writer) *cobra.Command {
 func (v *versionCmd) run() error {
 	// Store map data for template rendering
 	data := map[string]interface{}{}
+	var versions versionOutput
+	// The table is written as the versions are retrieved.
+	table := v.template == "" && v.output.isTable()
 
 	if v.showClient {
 		cv := version.GetVersionProto()
-		if v.template != "" {
-			data["Client"] = cv
-		} else {
+		data["Client"] = cv
+		versions.Client = newVersionInfo(cv)
+		if table {
 			fmt.Fprintf(v.out, "Client: %s\n", formatVersion(cv, v.short))
 		}
 	}
 
 	if !v.showServer {
-		return tpl(v.template, data, v.out)
+		return v.writeVersions(data, versions)
 	}
 
 	// We do this manually instead of in PreRun because we only
@@ -118,7 +147,7 @@ func (v *versionCmd) run() error {
 		if err != nil {
 			return err
 		}
-		fmt.Fprintf(v.out, "Kubernetes: %#v\n", k8sVersion)
+		fmt.Fprintf(v.output.messages(v.out), "Kubernetes: %#v\n", k8sVersion)
 	}
 	resp, err := v.client.GetVersion()
 	if err != nil {
@@ -129,12 +158,21 @@ func (v *versionCmd) run() error {
 		return errors.New("cannot connect to Tiller")
 	}
 
-	if v.template != "" {
-		data["Server"] = resp.Version
-	} else {
+	data["Server"] = resp.Version
+	versions.Server = newVersionInfo(resp.Version)
+	if table {
 		fmt.Fprintf(v.out, "Server: %s\n", formatVersion(resp.Version, v.short))
 	}
-	return tpl(v.template, data, v.out)
+	return v.writeVersions(data, versions)
+}
+
+// writeVersions writes the versions with the template or in the structured
+// output format. The table is written by run.
+func (v *versionCmd) writeVersions(data map[string]interface{}, versions versionOutput) error {
+	if v.template != "" {
+		return tpl(v.template, data, v.out)
+	}
+	return v.output.write(v.out, versions, func() error { return nil })
 }
 
 func getK8sVersion() (*apiVersion.Info, error) {
@@ -57,6 +57,24 @@ func TestVersion(t *testing.T) {
 			flags:    []string{"--template", "{{ .Client.SemVer }} {{ .Server.SemVer }}"},
 			expected: lver + " " + sver,
 		},
+		{
+			name:     "client json",
+			args:     []string{},
+			flags:    []string{"-c", "--output", "json"},
+			expected: fmt.Sprintf(`^\{"client":\{"semVer":"%s","gitCommit":"","gitTreeState":""\}\}\n$`, lver),
+		},
+		{
+			name:     "jsonpath",
+			args:     []string{},
+			flags:    []string{"--output", "jsonpath={.client.semVer} {.server.semVer}"},
+			expected: lver + " " + sver,
+		},
+		{
+			name:  "template with output",
+			args:  []string{},
+			flags: []string{"--template", "{{ .Client.SemVer }}", "--output", "json"},
+			err:   true,
+		},
 		{
 			name:     "client short empty git",
 			args:     []string{},
@@ -0,0 +1,110 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main // import "k8s.io/helm/cmd/tiller"
+
+import (
+	"k8s.io/client-go/kubernetes"
+
+	"k8s.io/helm/pkg/kube"
+	"k8s.io/helm/pkg/storage"
+	"k8s.io/helm/pkg/storage/driver"
+)
+
+// newStorageDriver returns the storage driver selected by --storage,
+// encrypting releases with keys if not nil and deduplicating them with
+// --storage-dedup.
+func newStorageDriver(clientset kubernetes.Interface, keys driver.KeyProvider) driver.Driver {
+	d := newBaseStorageDriver(clientset, keys)
+	if !*storageDedup {
+		return d
+	}
+	dedup := driver.NewDedup(d)
+	dedup.Log = newLogger("storage/dedup").Printf
+	return dedup
+}
+
+// newBaseStorageDriver returns the storage driver selected by --storage,
+// encrypting releases with keys if not nil.
+func newBaseStorageDriver(clientset kubernetes.Interface, keys driver.KeyProvider) driver.Driver {
+	switch *store {
+	case storageConfigMap:
+		cfgmaps := driver.NewConfigMaps(clientset.CoreV1().ConfigMaps(namespace()))
+		cfgmaps.Log = newLogger("storage/driver").Printf
+		cfgmaps.Keys = keys
+		return cfgmaps
+	case storageSecret:
+		secrets := driver.NewSecrets(clientset.CoreV1().Secrets(namespace()))
+		secrets.Log = newLogger("storage/driver").Printf
+		secrets.Keys = keys
+		return secrets
+	case storageSQL:
+		sqlDriver, err := driver.NewSQL(*sqlDialect, *sqlConnectionString)
+		if err != nil {
+			logger.Fatalf("Cannot initialize SQL storage driver: %v", err)
+		}
+		sqlDriver.Log = newLogger("storage/driver").Printf
+		sqlDriver.Keys = keys
+		return sqlDriver
+	}
+	return driver.NewMemory()
+}
+
+// encryptionKeys loads the keys encrypting releases at rest, or returns nil
+// if releases are not encrypted.
+func encryptionKeys(clientset kubernetes.Interface) driver.KeyProvider {
+	var (
+		keys *driver.LocalKeys
+		err  error
+	)
+	switch {
+	case *encryptionKeysFile != "" && *encryptionKeysSecret != "":
+		logger.Fatalf("Only one of --encryption-keys-file and --encryption-keys-secret can be set")
+	case *encryptionKeysFile != "":
+		keys, err = driver.LoadKeyFile(*encryptionKeysFile)
+	case *encryptionKeysSecret != "":
+		keys, err = driver.LoadKeySecret(clientset.CoreV1().Secrets(namespace()), *encryptionKeysSecret)
+	default:
+		return nil
+	}
+	if err != nil {
+		logger.Fatalf("Cannot load encryption keys: %s", err)
+	}
+	if *store == storageMemory {
+		logger.Fatalf("The memory storage driver does not encrypt releases")
+	}
+	logger.Printf("Encrypting releases with key %s", keys.CurrentKeyID())
+	return keys
+}
+
+// rotateKeys re-encrypts with the current key the releases encrypted with
+// another key, or not encrypted.
+func rotateKeys() {
+	clientset, err := kube.New(nil).KubernetesClientSet()
+	if err != nil {
+		logger.Fatalf("Cannot initialize Kubernetes connection: %s", err)
+	}
+	keys := encryptionKeys(clientset)
+	if keys == nil {
+		logger.Fatalf("Rotating keys requires --encryption-keys-file or --encryption-keys-secret")
+	}
+
+	rotated, err := storage.RotateKeys(newStorageDriver(clientset, keys), keys.CurrentKeyID(), logger.Printf)
+	if err != nil {
+		logger.Fatalf("Cannot rotate keys: %s", err)
+	}
+	logger.Printf("Re-encrypted %d release record(s) with key %s", len(rotated), keys.CurrentKeyID())
+}
@@ -36,18 +36,21 @@ import (
 	"google.golang.org/grpc/health"
 	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/keepalive"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
 
 	// Import to initialize client auth plugins.
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 
+	"k8s.io/helm/pkg/audit"
 	"k8s.io/helm/pkg/kube"
+	"k8s.io/helm/pkg/policy"
 	"k8s.io/helm/pkg/proto/hapi/services"
 	"k8s.io/helm/pkg/storage"
-	"k8s.io/helm/pkg/storage/driver"
 	"k8s.io/helm/pkg/tiller"
 	"k8s.io/helm/pkg/tiller/environment"
 	"k8s.io/helm/pkg/tlsutil"
 	"k8s.io/helm/pkg/version"
+	"k8s.io/helm/pkg/webhook"
 )
 
 const (
@@ -65,6 +68,11 @@ const (
 	storageMemory    = "memory"
 	storageConfigMap = "configmap"
 	storageSecret    = "secret"
+	storageSQL       = "sql"
+
+	authorizationNone       = "none"
+	authorizationKubernetes = "kubernetes"
+	authorizationPolicy     = "policy"
 
 	probeAddr = ":44135"
 	traceAddr = ":44136"
@@ -74,17 +82,31 @@ const (
 )
 
 var (
-	grpcAddr             = flag.String("listen", ":44134", "address:port to listen on")
-	enableTracing        = flag.Bool("trace", false, "enable rpc tracing")
-	store                = flag.String("storage", storageConfigMap, "storage driver to use. One of 'configmap', 'memory', or 'secret'")
-	remoteReleaseModules = flag.Bool("experimental-release", false, "enable experimental release modules")
-	tlsEnable            = flag.Bool("tls", tlsEnableEnvVarDefault(), "enable TLS")
-	tlsVerify            = flag.Bool("tls-verify", tlsVerifyEnvVarDefault(), "enable TLS and verify remote certificate")
-	keyFile              = flag.String("tls-key", tlsDefaultsFromEnv("tls-key"), "path to TLS private key file")
-	certFile             = flag.String("tls-cert", tlsDefaultsFromEnv("tls-cert"), "path to TLS certificate file")
-	caCertFile           = flag.String("tls-ca-cert", tlsDefaultsFromEnv("tls-ca-cert"), "trust certificates signed by this CA")
-	maxHistory           = flag.Int("history-max", historyMaxFromEnv(), "maximum number of releases kept in release history, with 0 meaning no limit")
-	printVersion         = flag.Bool("version", false, "print the version number")
+	grpcAddr                = flag.String("listen", ":44134", "address:port to listen on")
+	enableTracing           = flag.Bool("trace", false, "enable rpc tracing")
+	store                   = flag.String("storage", storageConfigMap, "storage driver to use. One of 'configmap', 'memory', 'secret' or 'sql'")
+	sqlDialect              = flag.String("sql-dialect", "postgres", "SQL dialect to use with the 'sql' storage driver")
+	sqlConnectionString     = flag.String("sql-connection-string", "", "connection string of the database used by the 'sql' storage driver")
+	encryptionKeysFile      = flag.String("encryption-keys-file", "", "path to the YAML file listing the keys encrypting releases at rest")
+	encryptionKeysSecret    = flag.String("encryption-keys-secret", "", "name of the Secret, in the namespace of Tiller, listing the keys encrypting releases at rest in its keys.yaml entry")
+	storageDedup            = flag.Bool("storage-dedup", false, "store charts once per content digest and manifests as deltas from the previous revision. Releases stored this way can only be read with this flag")
+	remoteReleaseModules    = flag.Bool("experimental-release", false, "enable experimental release modules")
+	tlsEnable               = flag.Bool("tls", tlsEnableEnvVarDefault(), "enable TLS")
+	tlsVerify               = flag.Bool("tls-verify", tlsVerifyEnvVarDefault(), "enable TLS and verify remote certificate")
+	keyFile                 = flag.String("tls-key", tlsDefaultsFromEnv("tls-key"), "path to TLS private key file")
+	certFile                = flag.String("tls-cert", tlsDefaultsFromEnv("tls-cert"), "path to TLS certificate file")
+	caCertFile              = flag.String("tls-ca-cert", tlsDefaultsFromEnv("tls-ca-cert"), "trust certificates signed by this CA")
+	maxHistory              = flag.Int("history-max", historyMaxFromEnv(), "maximum number of releases kept in release history, with 0 meaning no limit")
+	pendingTimeout          = flag.Duration("pending-release-timeout", 10*time.Minute, "time after which a release left pending by an interrupted operation is marked failed, with 0 disabling the check")
+	authorizationMode       = flag.String("authorization-mode", authorizationNone, "how callers are authorized. One of 'none', 'kubernetes' (RBAC rules on releases.helm.sh) or 'policy'")
+	authorizationPolicyFile = flag.String("authorization-policy-file", "", "path to the policy file of the 'policy' authorization mode")
+	policyConfig            = flag.String("policy-config", "", "path to the configuration of the policy rules rendered manifests are checked against")
+	auditLog                = flag.String("audit-log", "", "path of a file the operations on releases are appended to as JSON lines")
+	auditKubeEvents         = flag.Bool("audit-kube-events", false, "record the operations on releases as Kubernetes events in the namespaces of the releases")
+	auditWebhook            = flag.String("audit-webhook", "", "URL the operations on releases are posted to as JSON")
+	webhookConfig           = flag.String("webhook-config", "", "path to the configuration of the webhooks notified of the status transitions of releases")
+	impersonate             = flag.Bool("impersonate", false, "perform operations on the cluster as the caller, identified by the authorization mode or a verified TLS client certificate")
+	printVersion            = flag.Bool("version", false, "print the version number")
 
 	// rootServer is the root gRPC server.
 	//
@@ -100,8 +122,15 @@ var (
 )
 
 func main() {
+	// The rotate-keys command re-encrypts the releases stored, then exits.
+	args := os.Args[1:]
+	rotate := len(args) > 0 && args[0] == "rotate-keys"
+	if rotate {
+		args = args[1:]
+	}
+
 	// TODO: use spf13/cobra for tiller instead of flags
-	flag.Parse()
+	flag.CommandLine.Parse(args)
 
 	if *printVersion {
 		fmt.Println(version.GetVersion())
@@ -113,6 +142,10 @@ func main() {
 	}
 	logger = newLogger("main")
 
+	if rotate {
+		rotateKeys()
+		return
+	}
 	start()
 }
 
@@ -126,20 +159,8 @@ func start() {
 		logger.Fatalf("Cannot initialize Kubernetes connection: %s", err)
 	}
 
-	switch *store {
-	case storageMemory:
-		env.Releases = storage.Init(driver.NewMemory())
-	case storageConfigMap:
-		cfgmaps := driver.NewConfigMaps(clientset.CoreV1().ConfigMaps(namespace()))
-		cfgmaps.Log = newLogger("storage/driver").Printf
-
-		env.Releases = storage.Init(cfgmaps)
-		env.Releases.Log = newLogger("storage").Printf
-	case storageSecret:
-		secrets := driver.NewSecrets(clientset.CoreV1().Secrets(namespace()))
-		secrets.Log = newLogger("storage/driver").Printf
-
-		env.Releases = storage.Init(secrets)
+	env.Releases = storage.Init(newStorageDriver(clientset, encryptionKeys(clientset)))
+	if *store != storageMemory {
 		env.Releases.Log = newLogger("storage").Printf
 	}
 
@@ -147,6 +168,43 @@ func start() {
 		env.Releases.MaxHistory = *maxHistory
 	}
 
+	if *impersonate && *authorizationMode == authorizationNone && !*tlsVerify {
+		logger.Fatalf("Impersonating callers requires an authorization mode or verified TLS client certificates to identify them")
+	}
+	if *impersonate && *remoteReleaseModules {
+		logger.Fatalf("Impersonating callers is not supported with --experimental-release: the remote release module applies manifests with its own credentials")
+	}
+
+	var policyChecker *policy.Checker
+	if *policyConfig != "" {
+		if policyChecker, err = policy.LoadConfig(*policyConfig); err != nil {
+			logger.Fatalf("Cannot load policy configuration: %s", err)
+		}
+	}
+
+	var auditSinks audit.Multi
+	if *auditLog != "" {
+		sink, err := audit.NewFileSink(*auditLog)
+		if err != nil {
+			logger.Fatalf("Cannot open audit log: %s", err)
+		}
+		auditSinks = append(auditSinks, sink)
+	}
+	if *auditKubeEvents {
+		auditSinks = append(auditSinks, audit.NewKubeEventSink(clientset.CoreV1()))
+	}
+	if *auditWebhook != "" {
+		auditSinks = append(auditSinks, audit.NewWebhookSink(*auditWebhook))
+	}
+
+	var webhooks *webhook.Notifier
+	if *webhookConfig != "" {
+		if webhooks, err = webhook.LoadConfig(*webhookConfig); err != nil {
+			logger.Fatalf("Cannot load webhook configuration: %s", err)
+		}
+		webhooks.Log = newLogger("webhook").Printf
+	}
+
 	kubeClient := kube.New(nil)
 	kubeClient.Log = newLogger("kube").Printf
 	env.KubeClient = kubeClient
@@ -175,7 +233,23 @@ func start() {
 		MinTime: time.Duration(20) * time.Second, // For compatibility with the client keepalive.ClientParameters
 	}))
 
-	rootServer = tiller.NewServer(opts...)
+	var authorizer *tiller.Authorizer
+	switch *authorizationMode {
+	case authorizationNone:
+		rootServer = tiller.NewServer(opts...)
+	case authorizationKubernetes, authorizationPolicy:
+		var authzPolicy tiller.Policy = tiller.NewKubePolicy(clientset)
+		if *authorizationMode == authorizationPolicy {
+			if authzPolicy, err = tiller.LoadPolicyFile(*authorizationPolicyFile); err != nil {
+				logger.Fatalf("Cannot load authorization policy: %s", err)
+			}
+		}
+		authorizer = tiller.NewAuthorizer(tiller.NewKubeAuthenticator(clientset), authzPolicy, env.Releases)
+		authorizer.Log = newLogger("authz").Printf
+		rootServer = tiller.NewAuthorizingServer(authorizer, opts...)
+	default:
+		logger.Fatalf("Unknown authorization mode %q", *authorizationMode)
+	}
 	healthpb.RegisterHealthServer(rootServer, healthSrv)
 
 	lstn, err := net.Listen("tcp", *grpcAddr)
@@ -188,6 +262,18 @@ func start() {
 	logger.Printf("Probes listening on %s", probeAddr)
 	logger.Printf("Storage driver is %s", env.Releases.Name())
 	logger.Printf("Max history per release is %d", *maxHistory)
+	logger.Printf("Pending release timeout is %s", *pendingTimeout)
+	logger.Printf("Authorization mode is %s", *authorizationMode)
+	logger.Printf("Impersonating callers is %t", *impersonate)
+	if len(auditSinks) > 0 {
+		logger.Printf("Recording release operations to %d audit sinks", len(auditSinks))
+	}
+	if webhooks != nil {
+		logger.Printf("Notifying %d webhooks of release status transitions", len(webhooks.Webhooks))
+	}
+	if policyChecker != nil {
+		logger.Printf("Checking manifests against %d policy rules (warn only: %t)", len(policyChecker.Validators), policyChecker.WarnOnly)
+	}
 
 	if *enableTracing {
 		startTracing(traceAddr)
@@ -198,6 +284,23 @@ func start() {
 	go func() {
 		svc := tiller.NewReleaseServer(env, clientset, *remoteReleaseModules)
 		svc.Log = newLogger("tiller").Printf
+		svc.PendingTimeout = *pendingTimeout
+		svc.Authorizer = authorizer
+		svc.PolicyChecker = policyChecker
+		svc.Webhooks = webhooks
+		if len(auditSinks) > 0 {
+			svc.Audit = auditSinks
+		}
+		if *impersonate {
+			svc.KubeClientForUser = impersonatingKubeClient
+		}
+		if *pendingTimeout > 0 {
+			if failed, err := svc.FailStalePendingReleases(*pendingTimeout); err != nil {
+				logger.Printf("Cannot check for stale pending releases: %s", err)
+			} else if len(failed) > 0 {
+				logger.Printf("Marked %d stale pending releases failed", len(failed))
+			}
+		}
 		services.RegisterReleaseServiceServer(rootServer, svc)
 		if err := rootServer.Serve(lstn); err != nil {
 			srvErrCh <- err
@@ -226,6 +329,17 @@ func start() {
 	}
 }
 
+// impersonatingKubeClient returns a KubeClient performing its operations as
+// user with the credentials of Tiller.
+func impersonatingKubeClient(user *tiller.UserInfo) environment.KubeClient {
+	flags := genericclioptions.NewConfigFlags()
+	flags.Impersonate = &user.Username
+	flags.ImpersonateGroup = &user.Groups
+	kubeClient := kube.New(flags)
+	kubeClient.Log = newLogger("kube").Printf
+	return kubeClient
+}
+
 func newLogger(prefix string) *log.Logger {
 	if len(prefix) > 0 {
 		prefix = fmt.Sprintf("[%s] ", prefix)
@@ -0,0 +1,125 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*Package audit records the operations performed on releases.
+
+Tiller writes an Event for every install, upgrade, rollback, uninstall and
+test of a release to a Sink, such as a file of JSON lines, Kubernetes events
+in the namespace of the release, or an HTTP webhook.
+*/
+package audit // import "k8s.io/helm/pkg/audit"
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// The operations recorded by Tiller.
+const (
+	OperationInstall   = "install"
+	OperationUpgrade   = "upgrade"
+	OperationRollback  = "rollback"
+	OperationUninstall = "uninstall"
+	OperationTest      = "test"
+)
+
+// The outcomes of operations.
+const (
+	OutcomeSuccess = "success"
+	OutcomeFailure = "failure"
+)
+
+// Event records an operation performed on a release.
+type Event struct {
+	// Time is when the operation started.
+	Time time.Time `json:"time"`
+	// Operation is the operation, e.g. "upgrade".
+	Operation string `json:"operation"`
+	// User and Groups identify the caller, when known.
+	User   string   `json:"user,omitempty"`
+	Groups []string `json:"groups,omitempty"`
+	// Subject is the subject of the verified TLS client certificate of the
+	// caller, if any.
+	Subject   string `json:"subject,omitempty"`
+	Release   string `json:"release"`
+	Namespace string `json:"namespace,omitempty"`
+	// Revision is the revision of the release the operation resulted in.
+	Revision     int32  `json:"revision,omitempty"`
+	Chart        string `json:"chart,omitempty"`
+	ChartVersion string `json:"chartVersion,omitempty"`
+	// ValuesDigest is the digest of the values of the release.
+	ValuesDigest string `json:"valuesDigest,omitempty"`
+	// DurationSeconds is how long the operation took.
+	DurationSeconds float64 `json:"durationSeconds"`
+	Outcome         string  `json:"outcome"`
+	// Error describes why the operation failed.
+	Error string `json:"error,omitempty"`
+}
+
+// Succeeded reports whether the operation succeeded.
+func (e *Event) Succeeded() bool {
+	return e.Outcome == OutcomeSuccess
+}
+
+// String describes the event in a sentence.
+func (e *Event) String() string {
+	who := e.User
+	if who == "" {
+		who = "unknown user"
+	}
+	msg := fmt.Sprintf("%s: %s of release %s", who, e.Operation, e.Release)
+	if e.Revision > 0 {
+		msg += fmt.Sprintf(" (revision %d)", e.Revision)
+	}
+	if e.Chart != "" {
+		msg += fmt.Sprintf(" with chart %s-%s", e.Chart, e.ChartVersion)
+	}
+	if e.Succeeded() {
+		return msg + " succeeded"
+	}
+	return msg + " failed: " + e.Error
+}
+
+// Digest returns the SHA-256 digest of values, as "sha256:<hex>".
+func Digest(values string) string {
+	sum := sha256.Sum256([]byte(values))
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// Sink receives audit events.
+type Sink interface {
+	Write(e *Event) error
+}
+
+// Multi writes events to several sinks.
+type Multi []Sink
+
+// Write writes the event to all sinks, even if some of them fail.
+func (m Multi) Write(e *Event) error {
+	var errs []string
+	for _, s := range m {
+		if err := s.Write(e); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("cannot write audit event: %s", strings.Join(errs, "; "))
+	}
+	return nil
+}
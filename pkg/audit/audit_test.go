@@ -0,0 +1,176 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func testEvent(outcome string) *Event {
+	e := &Event{
+		Time:         time.Date(2018, 12, 1, 10, 0, 0, 0, time.UTC),
+		Operation:    OperationUpgrade,
+		User:         "alice",
+		Release:      "web",
+		Namespace:    "team-a",
+		Revision:     3,
+		Chart:        "nginx",
+		ChartVersion: "1.2.0",
+		ValuesDigest: Digest("replicas: 2\n"),
+		Outcome:      outcome,
+	}
+	if outcome == OutcomeFailure {
+		e.Error = "timed out waiting for the condition"
+	}
+	return e
+}
+
+func TestEventString(t *testing.T) {
+	tests := []struct {
+		event    *Event
+		expected string
+	}{
+		{testEvent(OutcomeSuccess), "alice: upgrade of release web (revision 3) with chart nginx-1.2.0 succeeded"},
+		{testEvent(OutcomeFailure), "alice: upgrade of release web (revision 3) with chart nginx-1.2.0 failed: timed out waiting for the condition"},
+		{&Event{Operation: OperationUninstall, Release: "web", Outcome: OutcomeFailure, Error: "not found"}, "unknown user: uninstall of release web failed: not found"},
+	}
+	for _, tt := range tests {
+		if got := tt.event.String(); got != tt.expected {
+			t.Errorf("Expected %q, got %q", tt.expected, got)
+		}
+	}
+}
+
+func TestFileSink(t *testing.T) {
+	dir, err := ioutil.TempDir("", "helm-audit-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "audit.log")
+
+	// Events are appended to the existing log.
+	for _, outcome := range []string{OutcomeSuccess, OutcomeFailure} {
+		sink, err := NewFileSink(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := sink.Write(testEvent(outcome)); err != nil {
+			t.Fatal(err)
+		}
+		sink.Close()
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	var outcomes []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var e Event
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			t.Fatalf("Cannot parse line %q: %s", scanner.Text(), err)
+		}
+		outcomes = append(outcomes, e.Outcome)
+	}
+	if strings.Join(outcomes, ",") != "success,failure" {
+		t.Errorf("Expected a success and a failure, got %v", outcomes)
+	}
+}
+
+func TestKubeEventSink(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	sink := NewKubeEventSink(client.CoreV1())
+	if err := sink.Write(testEvent(OutcomeFailure)); err != nil {
+		t.Fatal(err)
+	}
+
+	events, err := client.CoreV1().Events("team-a").List(metav1.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events.Items) != 1 {
+		t.Fatalf("Expected one event, got %d", len(events.Items))
+	}
+	ev := events.Items[0]
+	if ev.Type != v1.EventTypeWarning || ev.Reason != "UpgradeFailed" || ev.InvolvedObject.Kind != "Release" || ev.InvolvedObject.Name != "web" {
+		t.Errorf("Unexpected event %s %s about %s %s", ev.Type, ev.Reason, ev.InvolvedObject.Kind, ev.InvolvedObject.Name)
+	}
+
+	if err := sink.Write(&Event{Operation: OperationInstall, Outcome: OutcomeFailure}); err == nil {
+		t.Error("Expected an error recording an event without a namespace")
+	}
+}
+
+func TestWebhookSink(t *testing.T) {
+	var received []*Event
+	status := http.StatusOK
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if ct := r.Header.Get("Content-Type"); ct != "application/json" {
+			t.Errorf("Expected JSON, got %s", ct)
+		}
+		var e Event
+		if err := json.NewDecoder(r.Body).Decode(&e); err != nil {
+			t.Error(err)
+		}
+		received = append(received, &e)
+		w.WriteHeader(status)
+	}))
+	defer srv.Close()
+
+	sink := NewWebhookSink(srv.URL)
+	if err := sink.Write(testEvent(OutcomeSuccess)); err != nil {
+		t.Fatal(err)
+	}
+	if len(received) != 1 || received[0].Release != "web" || received[0].ValuesDigest != Digest("replicas: 2\n") {
+		t.Errorf("Unexpected events %v", received)
+	}
+
+	status = http.StatusInternalServerError
+	if err := sink.Write(testEvent(OutcomeSuccess)); err == nil || !strings.Contains(err.Error(), "500") {
+		t.Errorf("Expected the failure of the webhook to be reported, got %v", err)
+	}
+}
+
+type failingSink struct{}
+
+func (failingSink) Write(e *Event) error { return errors.New("disk full") }
+
+func TestMulti(t *testing.T) {
+	ok := NewKubeEventSink(fake.NewSimpleClientset().CoreV1())
+	m := Multi{failingSink{}, ok, failingSink{}}
+	err := m.Write(testEvent(OutcomeSuccess))
+	if err == nil || err.Error() != "cannot write audit event: disk full; disk full" {
+		t.Errorf("Expected both failures to be reported, got %v", err)
+	}
+}
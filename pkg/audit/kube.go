@@ -0,0 +1,70 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+)
+
+// KubeEventSink records events as Kubernetes events in the namespace of the
+// release, involving the release.
+type KubeEventSink struct {
+	client corev1.EventsGetter
+}
+
+// NewKubeEventSink returns a sink creating events with client.
+func NewKubeEventSink(client corev1.EventsGetter) *KubeEventSink {
+	return &KubeEventSink{client: client}
+}
+
+// Write implements Sink.
+func (s *KubeEventSink) Write(e *Event) error {
+	if e.Namespace == "" {
+		return fmt.Errorf("cannot record %s of release %s as an event: unknown namespace", e.Operation, e.Release)
+	}
+
+	typ, reason := v1.EventTypeNormal, strings.Title(e.Operation)+"Succeeded"
+	if !e.Succeeded() {
+		typ, reason = v1.EventTypeWarning, strings.Title(e.Operation)+"Failed"
+	}
+	t := metav1.NewTime(e.Time)
+	_, err := s.client.Events(e.Namespace).Create(&v1.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s.%x", e.Release, e.Time.UnixNano()),
+			Namespace: e.Namespace,
+		},
+		InvolvedObject: v1.ObjectReference{
+			APIVersion: "helm.sh/v1",
+			Kind:       "Release",
+			Name:       e.Release,
+			Namespace:  e.Namespace,
+		},
+		Reason:         reason,
+		Message:        e.String(),
+		Type:           typ,
+		Source:         v1.EventSource{Component: "tiller"},
+		FirstTimestamp: t,
+		LastTimestamp:  t,
+		Count:          1,
+	})
+	return err
+}
@@ -0,0 +1,96 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chartutil
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/xeipuuv/gojsonschema"
+
+	"k8s.io/helm/pkg/proto/hapi/chart"
+)
+
+// ValidateValues checks coalesced values against the JSON Schema of the chart
+// (values.schema.json), and the values of each subchart, found under the key
+// of the subchart, against the schema of that subchart.
+//
+// Charts without a schema accept any values. Every violation is reported,
+// qualified by its path in the values of the top-level chart.
+func ValidateValues(chrt *chart.Chart, vals Values) error {
+	violations, err := validateValues(chrt, vals, "")
+	if err != nil {
+		return err
+	}
+	if len(violations) > 0 {
+		return fmt.Errorf("values don't meet the specifications of the schema(s):\n- %s", strings.Join(violations, "\n- "))
+	}
+	return nil
+}
+
+// validateValues validates vals against the schemas of chrt and its
+// subcharts, prefixing the path of the violations with prefix.
+func validateValues(chrt *chart.Chart, vals map[string]interface{}, prefix string) ([]string, error) {
+	if vals == nil {
+		vals = map[string]interface{}{}
+	}
+
+	var violations []string
+	if len(chrt.Schema) > 0 {
+		result, err := gojsonschema.Validate(gojsonschema.NewBytesLoader(chrt.Schema), gojsonschema.NewGoLoader(vals))
+		if err != nil {
+			return nil, fmt.Errorf("cannot validate values against %s of chart %s: %s", SchemafileName, chrt.Metadata.Name, err)
+		}
+		for _, re := range result.Errors() {
+			violations = append(violations, fmt.Sprintf("%s: %s", schemaPath(prefix, re.Field()), re.Description()))
+		}
+	}
+
+	for _, sub := range chrt.Dependencies {
+		name := sub.Metadata.Name
+		var subvals map[string]interface{}
+		switch v := vals[name].(type) {
+		case map[string]interface{}:
+			subvals = v
+		case Values:
+			subvals = v
+		}
+		subViolations, err := validateValues(sub, subvals, schemaPath(prefix, name))
+		if err != nil {
+			return nil, err
+		}
+		violations = append(violations, subViolations...)
+	}
+	return violations, nil
+}
+
+// schemaPath joins the path of a subchart's values to a field reported by
+// gojsonschema, which calls the top of the document "(root)".
+func schemaPath(prefix, field string) string {
+	if field == gojsonschema.STRING_ROOT_SCHEMA_PROPERTY {
+		field = ""
+	}
+	switch {
+	case prefix == "" && field == "":
+		return gojsonschema.STRING_ROOT_SCHEMA_PROPERTY
+	case prefix == "":
+		return field
+	case field == "":
+		return prefix
+	}
+	return prefix + "." + field
+}
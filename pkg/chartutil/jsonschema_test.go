@@ -0,0 +1,134 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chartutil
+
+import (
+	"strings"
+	"testing"
+
+	"k8s.io/helm/pkg/proto/hapi/chart"
+)
+
+func TestLoadSchema(t *testing.T) {
+	c, err := Load("testdata/chart-with-schema")
+	if err != nil {
+		t.Fatalf("Failed to load testdata: %s", err)
+	}
+	if len(c.Schema) == 0 {
+		t.Error("Expected values.schema.json to be loaded as the chart schema")
+	}
+	for _, f := range c.Files {
+		if f.TypeUrl == SchemafileName {
+			t.Errorf("Expected %s not to be loaded as a file", SchemafileName)
+		}
+	}
+	if len(c.Dependencies) != 1 || len(c.Dependencies[0].Schema) == 0 {
+		t.Error("Expected the schema of the subchart to be loaded")
+	}
+}
+
+func TestValidateValues(t *testing.T) {
+	c, err := Load("testdata/chart-with-schema")
+	if err != nil {
+		t.Fatalf("Failed to load testdata: %s", err)
+	}
+
+	tests := []struct {
+		name       string
+		values     string
+		violations []string
+	}{
+		{
+			name: "defaults",
+		},
+		{
+			name:   "overridden",
+			values: "replicaCount: 3\ndb:\n  port: 3306\n",
+		},
+		{
+			name:       "wrong type",
+			values:     "replicaCount: three\n",
+			violations: []string{"replicaCount: Invalid type. Expected: integer, given: string"},
+		},
+		{
+			name:       "nested field",
+			values:     "image:\n  tag: 1\n",
+			violations: []string{"image.tag: Invalid type. Expected: string, given: integer"},
+		},
+		{
+			name:       "subchart",
+			values:     "db:\n  port: 70000\n",
+			violations: []string{"db.port: Must be less than or equal to 65535"},
+		},
+		{
+			name:   "several",
+			values: "replicaCount: 0\ndb:\n  port: http\n",
+			violations: []string{
+				"replicaCount: Must be greater than or equal to 1",
+				"db.port: Invalid type. Expected: integer, given: string",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		vals, err := CoalesceValues(c, &chart.Config{Raw: tt.values})
+		if err != nil {
+			t.Fatalf("%s: failed to coalesce values: %s", tt.name, err)
+		}
+		err = ValidateValues(c, vals)
+		if len(tt.violations) == 0 {
+			if err != nil {
+				t.Errorf("%s: unexpected error: %s", tt.name, err)
+			}
+			continue
+		}
+		if err == nil {
+			t.Errorf("%s: expected values to be rejected", tt.name)
+			continue
+		}
+		for _, v := range tt.violations {
+			if !strings.Contains(err.Error(), "\n- "+v) {
+				t.Errorf("%s: expected %q to be reported, got %q", tt.name, v, err)
+			}
+		}
+	}
+}
+
+func TestValidateValues_InvalidSchema(t *testing.T) {
+	c := &chart.Chart{
+		Metadata: &chart.Metadata{Name: "broken"},
+		Schema:   []byte(`{"type": `),
+	}
+	err := ValidateValues(c, Values{})
+	if err == nil || !strings.Contains(err.Error(), "values.schema.json of chart broken") {
+		t.Errorf("Expected an invalid schema to be reported, got %v", err)
+	}
+}
+
+func TestValidateValues_RootViolation(t *testing.T) {
+	c := &chart.Chart{
+		Metadata: &chart.Metadata{Name: "parent"},
+		Dependencies: []*chart.Chart{{
+			Metadata: &chart.Metadata{Name: "child"},
+			Schema:   []byte(`{"required": ["name"]}`),
+		}},
+	}
+	err := ValidateValues(c, Values{"child": map[string]interface{}{}})
+	if err == nil || !strings.Contains(err.Error(), "- child: name is required") {
+		t.Errorf("Expected the subchart violation to be qualified by its key, got %v", err)
+	}
+}
@@ -45,6 +45,7 @@ func TestSave(t *testing.T) {
 		Values: &chart.Config{
 			Raw: "ship: Pequod",
 		},
+		Schema: []byte(`{"type": "object"}`),
 		Files: []*any.Any{
 			{TypeUrl: "scheherazade/shahryar.txt", Value: []byte("1,001 Nights")},
 		},
@@ -72,6 +73,9 @@ func TestSave(t *testing.T) {
 	if c2.Values.Raw != c.Values.Raw {
 		t.Fatal("Values data did not match")
 	}
+	if string(c2.Schema) != string(c.Schema) {
+		t.Fatal("Schema data did not match")
+	}
 	if len(c2.Files) != 1 || c2.Files[0].TypeUrl != "scheherazade/shahryar.txt" {
 		t.Fatal("Files data did not match")
 	}
@@ -168,6 +172,7 @@ func TestSaveDir(t *testing.T) {
 		Values: &chart.Config{
 			Raw: "ship: Pequod",
 		},
+		Schema: []byte(`{"type": "object"}`),
 		Files: []*any.Any{
 			{TypeUrl: "scheherazade/shahryar.txt", Value: []byte("1,001 Nights")},
 		},
@@ -188,6 +193,9 @@ func TestSaveDir(t *testing.T) {
 	if c2.Values.Raw != c.Values.Raw {
 		t.Fatal("Values data did not match")
 	}
+	if string(c2.Schema) != string(c.Schema) {
+		t.Fatal("Schema data did not match")
+	}
 	if len(c2.Files) != 1 || c2.Files[0].TypeUrl != "scheherazade/shahryar.txt" {
 		t.Fatal("Files data did not match")
 	}
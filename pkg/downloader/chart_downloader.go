@@ -28,6 +28,7 @@ import (
 	"k8s.io/helm/pkg/getter"
 	"k8s.io/helm/pkg/helm/helmpath"
 	"k8s.io/helm/pkg/provenance"
+	"k8s.io/helm/pkg/registry"
 	"k8s.io/helm/pkg/repo"
 	"k8s.io/helm/pkg/urlutil"
 )
@@ -96,6 +97,13 @@ func (c *ChartDownloader) DownloadTo(ref, version, dest string) (string, *proven
 	}
 
 	name := filepath.Base(u.Path)
+	if u.Scheme == registry.Scheme {
+		r, err := registry.ParseReference(u.String())
+		if err != nil {
+			return "", nil, err
+		}
+		name = r.ArchiveName()
+	}
 	destfile := filepath.Join(dest, name)
 	if err := ioutil.WriteFile(destfile, data.Bytes(), 0644); err != nil {
 		return destfile, nil, err
@@ -103,6 +111,14 @@ func (c *ChartDownloader) DownloadTo(ref, version, dest string) (string, *proven
 
 	// If provenance is requested, verify it.
 	ver := &provenance.Verification{}
+	if c.Verify > VerifyNever && u.Scheme == registry.Scheme {
+		// Registries store no provenance files alongside charts.
+		if c.Verify == VerifyAlways {
+			return destfile, ver, fmt.Errorf("cannot verify %s: provenance is not supported for charts stored in registries", ref)
+		}
+		fmt.Fprintf(c.Out, "WARNING: Verification not supported for %s\n", ref)
+		return destfile, ver, nil
+	}
 	if c.Verify > VerifyNever {
 		body, err := g.Get(u.String() + ".prov")
 		if err != nil {
@@ -134,11 +150,14 @@ func (c *ChartDownloader) DownloadTo(ref, version, dest string) (string, *proven
 // It returns the URL as well as a preconfigured repo.Getter that can fetch
 // the URL.
 //
-// A reference may be an HTTP URL, a 'reponame/chartname' reference, or a local path.
+// A reference may be an HTTP URL, a 'reponame/chartname' reference, an
+// oci://HOST/REPOSITORY[:TAG] reference to a chart stored in a registry, or a
+// local path.
 //
 // A version is a SemVer string (1.2.3-beta.1+f334a6789).
 //
 //	- For fully qualified URLs, the version will be ignored (since URLs aren't versioned)
+//	- For registry references, the version is used as the tag if the reference has none
 //	- For a chart reference
 //		* If version is non-empty, this will return the URL for that version
 //		* If version is empty, this will return the URL for the latest version
@@ -148,6 +167,9 @@ func (c *ChartDownloader) ResolveChartVersion(ref, version string) (*url.URL, ge
 	if err != nil {
 		return nil, nil, fmt.Errorf("invalid chart URL format: %s", ref)
 	}
+	if u.Scheme == registry.Scheme {
+		return c.resolveRegistryChartVersion(ref, version)
+	}
 
 	rf, err := repo.LoadRepositoriesFile(c.HelmHome.RepositoryFile())
 	if err != nil {
@@ -243,6 +265,34 @@ func (c *ChartDownloader) ResolveChartVersion(ref, version string) (*url.URL, ge
 	return u, r.Client, nil
 }
 
+// resolveRegistryChartVersion resolves a reference to a chart stored in a
+// registry to a URL carrying its tag.
+func (c *ChartDownloader) resolveRegistryChartVersion(ref, version string) (*url.URL, getter.Getter, error) {
+	r, err := registry.ParseReference(ref)
+	if err != nil {
+		return nil, nil, err
+	}
+	switch {
+	case r.Tag == "" && version == "":
+		return nil, nil, fmt.Errorf("a version is required to fetch %s", ref)
+	case r.Tag == "":
+		r.Tag = version
+	case version != "" && version != r.Tag:
+		return nil, nil, fmt.Errorf("version %s does not match the tag of %s", version, ref)
+	}
+
+	u, err := url.Parse(registry.Scheme + "://" + r.String())
+	if err != nil {
+		return nil, nil, err
+	}
+	getterConstructor, err := c.Getters.ByScheme(registry.Scheme)
+	if err != nil {
+		return u, nil, err
+	}
+	g, err := getterConstructor(u.String(), "", "", "")
+	return u, g, err
+}
+
 // setCredentials if HttpGetter is used, this method sets the configured repository credentials on the HttpGetter.
 func (c *ChartDownloader) setCredentials(r *repo.ChartRepository) {
 	if t, ok := r.Client.(*getter.HttpGetter); ok {
@@ -16,6 +16,7 @@ limitations under the License.
 package downloader
 
 import (
+	"bytes"
 	"fmt"
 	"io/ioutil"
 	"net/http"
@@ -28,6 +29,8 @@ import (
 	"k8s.io/helm/pkg/getter"
 	"k8s.io/helm/pkg/helm/environment"
 	"k8s.io/helm/pkg/helm/helmpath"
+	"k8s.io/helm/pkg/registry"
+	"k8s.io/helm/pkg/registry/registrytest"
 	"k8s.io/helm/pkg/repo"
 	"k8s.io/helm/pkg/repo/repotest"
 )
@@ -283,6 +286,54 @@ func TestDownloadTo_VerifyLater(t *testing.T) {
 	}
 }
 
+func TestDownloadTo_Registry(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "helm-downloadto-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+
+	srv := registrytest.NewServer()
+	defer srv.Stop()
+	archive, err := ioutil.ReadFile("testdata/signtest-0.1.0.tgz")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ref := &registry.Reference{Registry: srv.Host(), Repository: "charts/signtest", Tag: "0.1.0"}
+	if _, err := registry.NewClient(nil).Push(ref, archive); err != nil {
+		t.Fatalf("Failed to push: %s", err)
+	}
+
+	c := ChartDownloader{
+		HelmHome: helmpath.Home(tmp),
+		Out:      os.Stderr,
+		Getters:  getter.All(environment.EnvSettings{Home: helmpath.Home(tmp)}),
+	}
+	untagged := "oci://" + srv.Host() + "/charts/signtest"
+	where, _, err := c.DownloadTo(untagged, "0.1.0", tmp)
+	if err != nil {
+		t.Fatalf("Failed to download: %s", err)
+	}
+	if expect := filepath.Join(tmp, "signtest-0.1.0.tgz"); where != expect {
+		t.Errorf("Expected download to %s, got %s", expect, where)
+	}
+	if data, err := ioutil.ReadFile(where); err != nil || !bytes.Equal(data, archive) {
+		t.Errorf("Expected the downloaded archive to match the pushed one (%v)", err)
+	}
+
+	if _, _, err := c.DownloadTo(untagged, "", tmp); err == nil {
+		t.Error("Expected a reference without tag nor version to be rejected")
+	}
+	if _, _, err := c.DownloadTo("oci://"+ref.String(), "0.2.0", tmp); err == nil {
+		t.Error("Expected a version not matching the tag to be rejected")
+	}
+
+	c.Verify = VerifyAlways
+	if _, _, err := c.DownloadTo("oci://"+ref.String(), "", tmp); err == nil {
+		t.Error("Expected verification of a chart stored in a registry to fail")
+	}
+}
+
 func TestScanReposForURL(t *testing.T) {
 	hh := helmpath.Home("testdata/helmhome")
 	c := ChartDownloader{
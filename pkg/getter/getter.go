@@ -21,6 +21,7 @@ import (
 	"fmt"
 
 	"k8s.io/helm/pkg/helm/environment"
+	"k8s.io/helm/pkg/registry"
 )
 
 // Getter is an interface to support GET to the specified URL.
@@ -68,7 +69,7 @@ func (p Providers) ByScheme(scheme string) (Constructor, error) {
 }
 
 // All finds all of the registered getters as a list of Provider instances.
-// Currently the build-in http/https getter and the discovered
+// Currently the build-in http/https and oci getters and the discovered
 // plugins with downloader notations are collected.
 func All(settings environment.EnvSettings) Providers {
 	result := Providers{
@@ -76,6 +77,12 @@ func All(settings environment.EnvSettings) Providers {
 			Schemes: []string{"http", "https"},
 			New:     newHTTPGetter,
 		},
+		{
+			Schemes: []string{registry.Scheme},
+			New: func(URL, CertFile, KeyFile, CAFile string) (Getter, error) {
+				return NewOCIGetter(URL, CertFile, KeyFile, CAFile, settings.Home)
+			},
+		},
 	}
 	pluginDownloaders, _ := collectPlugins(settings)
 	result = append(result, pluginDownloaders...)
@@ -57,13 +57,16 @@ func TestAll(t *testing.T) {
 	env := hh(false)
 
 	all := All(env)
-	if len(all) != 3 {
-		t.Errorf("expected 3 providers (default plus two plugins), got %d", len(all))
+	if len(all) != 4 {
+		t.Errorf("expected 4 providers (http, oci plus two plugins), got %d", len(all))
 	}
 
 	if _, err := all.ByScheme("test2"); err != nil {
 		t.Error(err)
 	}
+	if _, err := all.ByScheme("oci"); err != nil {
+		t.Error(err)
+	}
 }
 
 func TestByScheme(t *testing.T) {
@@ -0,0 +1,66 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package getter
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+
+	"k8s.io/helm/pkg/helm/helmpath"
+	"k8s.io/helm/pkg/registry"
+	"k8s.io/helm/pkg/tlsutil"
+)
+
+// OCIGetter is the backend handler for charts stored in OCI registries.
+type OCIGetter struct {
+	client *registry.Client
+}
+
+// Get pulls the chart archive referenced by href, oci://HOST/REPOSITORY:TAG.
+func (g *OCIGetter) Get(href string) (*bytes.Buffer, error) {
+	ref, err := registry.ParseReference(href)
+	if err != nil {
+		return nil, err
+	}
+	data, err := g.client.Pull(ref)
+	if err != nil {
+		return nil, err
+	}
+	return bytes.NewBuffer(data), nil
+}
+
+// NewOCIGetter constructs a Getter pulling charts from OCI registries, with
+// the registry credentials stored under home.
+func NewOCIGetter(URL, CertFile, KeyFile, CAFile string, home helmpath.Home) (*OCIGetter, error) {
+	creds, err := registry.LoadCredentials(home.RegistryConfig())
+	if err != nil {
+		return nil, err
+	}
+	client := registry.NewClient(creds)
+	if (CertFile != "" && KeyFile != "") || CAFile != "" {
+		tlsConf, err := tlsutil.NewTLSConfig(URL, CertFile, KeyFile, CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("can't create TLS config: %s", err.Error())
+		}
+		client.HTTPClient = &http.Client{Transport: &http.Transport{
+			Proxy:           http.ProxyFromEnvironment,
+			TLSClientConfig: tlsConf,
+		}}
+	}
+	return &OCIGetter{client: client}, nil
+}
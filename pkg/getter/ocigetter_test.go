@@ -0,0 +1,78 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package getter
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"k8s.io/helm/pkg/helm/helmpath"
+	"k8s.io/helm/pkg/registry"
+	"k8s.io/helm/pkg/registry/registrytest"
+)
+
+func TestOCIGetter(t *testing.T) {
+	srv := registrytest.NewServer(registrytest.WithBasicAuth("user", "pass"))
+	defer srv.Stop()
+
+	tmp, err := ioutil.TempDir("", "helm-ocigetter-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+	home := helmpath.Home(tmp)
+
+	archive, err := ioutil.ReadFile("testdata/sssd-0.1.0.tgz")
+	if err != nil {
+		t.Fatal(err)
+	}
+	creds, err := registry.LoadCredentials(home.RegistryConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+	creds.Set(srv.Host(), "user", "pass")
+	ref := &registry.Reference{Registry: srv.Host(), Repository: "charts/sssd", Tag: "0.1.0"}
+	if _, err := registry.NewClient(creds).Push(ref, archive); err != nil {
+		t.Fatalf("Failed to push: %s", err)
+	}
+
+	href := "oci://" + ref.String()
+	g, err := NewOCIGetter(href, "", "", "", home)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := g.Get(href); err == nil {
+		t.Fatal("Expected pulling without stored credentials to fail")
+	}
+
+	if err := creds.Save(); err != nil {
+		t.Fatal(err)
+	}
+	g, err = NewOCIGetter(href, "", "", "", home)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := g.Get(href)
+	if err != nil {
+		t.Fatalf("Failed to pull: %s", err)
+	}
+	if !bytes.Equal(data.Bytes(), archive) {
+		t.Error("Expected the pulled archive to match the pushed one")
+	}
+}
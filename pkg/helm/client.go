@@ -17,18 +17,21 @@ limitations under the License.
 package helm // import "k8s.io/helm/pkg/helm"
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"time"
 
 	"golang.org/x/net/context"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/keepalive"
 
 	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"k8s.io/helm/pkg/chartutil"
 	"k8s.io/helm/pkg/proto/hapi/chart"
+	"k8s.io/helm/pkg/proto/hapi/release"
 	rls "k8s.io/helm/pkg/proto/hapi/services"
 )
 
@@ -115,6 +118,9 @@ func (h *Client) InstallReleaseFromChart(chart *chart.Chart, ns string, opts ...
 		return nil, err
 	}
 
+	if reqOpts.progress != nil {
+		return h.installStream(ctx, req, reqOpts.progress)
+	}
 	return h.install(ctx, req)
 }
 
@@ -182,15 +188,20 @@ func (h *Client) UpdateReleaseFromChart(rlsName string, chart *chart.Chart, opts
 			return nil, err
 		}
 	}
-	err := chartutil.ProcessRequirementsEnabled(req.Chart, req.Values)
-	if err != nil {
-		return nil, err
-	}
-	err = chartutil.ProcessRequirementsImportValues(req.Chart)
-	if err != nil {
-		return nil, err
+	if !req.Continue {
+		err := chartutil.ProcessRequirementsEnabled(req.Chart, req.Values)
+		if err != nil {
+			return nil, err
+		}
+		err = chartutil.ProcessRequirementsImportValues(req.Chart)
+		if err != nil {
+			return nil, err
+		}
 	}
 
+	if reqOpts.progress != nil && !req.Diff {
+		return h.updateStream(ctx, req, reqOpts.progress)
+	}
 	return h.update(ctx, req)
 }
 
@@ -230,6 +241,9 @@ func (h *Client) RollbackRelease(rlsName string, opts ...RollbackOption) (*rls.R
 			return nil, err
 		}
 	}
+	if reqOpts.progress != nil && !req.Diff {
+		return h.rollbackStream(ctx, req, reqOpts.progress)
+	}
 	return h.rollback(ctx, req)
 }
 
@@ -302,6 +316,44 @@ func (h *Client) RunReleaseTest(rlsName string, opts ...ReleaseTestOption) (<-ch
 	return h.test(ctx, req)
 }
 
+// UnlockRelease clears the lock held on a release.
+func (h *Client) UnlockRelease(rlsName string, opts ...UnlockOption) (*rls.UnlockReleaseResponse, error) {
+	reqOpts := h.opts
+	for _, opt := range opts {
+		opt(&reqOpts)
+	}
+
+	req := &rls.UnlockReleaseRequest{Name: rlsName}
+	ctx := NewContext()
+
+	if reqOpts.before != nil {
+		if err := reqOpts.before(ctx, req); err != nil {
+			return nil, err
+		}
+	}
+	return h.unlock(ctx, req)
+}
+
+// RepairRelease reconciles the status of a release left pending by an
+// interrupted operation with the cluster. Without a release name, Tiller
+// fails every release pending for longer than its pending timeout.
+func (h *Client) RepairRelease(rlsName string, opts ...RepairOption) (*rls.RepairReleaseResponse, error) {
+	reqOpts := h.opts
+	for _, opt := range opts {
+		opt(&reqOpts)
+	}
+
+	req := &rls.RepairReleaseRequest{Name: rlsName}
+	ctx := NewContext()
+
+	if reqOpts.before != nil {
+		if err := reqOpts.before(ctx, req); err != nil {
+			return nil, err
+		}
+	}
+	return h.repair(ctx, req)
+}
+
 // PingTiller pings the Tiller pod and ensures that it is up and running
 func (h *Client) PingTiller() error {
 	ctx := NewContext()
@@ -326,6 +378,9 @@ func (h *Client) connect(ctx context.Context) (conn *grpc.ClientConn, err error)
 	default:
 		opts = append(opts, grpc.WithInsecure())
 	}
+	if h.opts.bearerToken != "" {
+		opts = append(opts, grpc.WithPerRPCCredentials(bearerToken{h.opts.bearerToken, h.opts.bearerTokenInsecure}))
+	}
 	ctx, cancel := context.WithTimeout(ctx, h.opts.connectTimeout)
 	defer cancel()
 	if conn, err = grpc.DialContext(ctx, h.opts.host, opts...); err != nil {
@@ -334,15 +389,44 @@ func (h *Client) connect(ctx context.Context) (conn *grpc.ClientConn, err error)
 	return conn, nil
 }
 
+// releaseClient returns a client of the release service, and a function
+// closing its connection. The client calls the release server in-process
+// instead of connecting to Tiller if one is set.
+func (h *Client) releaseClient(ctx context.Context) (rls.ReleaseServiceClient, func(), error) {
+	if h.opts.local != nil {
+		return localClient{h.opts.local}, func() {}, nil
+	}
+	c, err := h.connect(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	return rls.NewReleaseServiceClient(c), func() { c.Close() }, nil
+}
+
+// bearerToken sends a bearer token in the metadata of every call.
+type bearerToken struct {
+	token    string
+	insecure bool
+}
+
+func (t bearerToken) GetRequestMetadata(context.Context, ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + t.token}, nil
+}
+
+// RequireTransportSecurity refuses to send the token without TLS, unless
+// the connection is known to be tunneled by the Kubernetes API server.
+func (t bearerToken) RequireTransportSecurity() bool {
+	return !t.insecure
+}
+
 // list executes tiller.ListReleases RPC.
 func (h *Client) list(ctx context.Context, req *rls.ListReleasesRequest) (*rls.ListReleasesResponse, error) {
-	c, err := h.connect(ctx)
+	rlc, closeConn, err := h.releaseClient(ctx)
 	if err != nil {
 		return nil, err
 	}
-	defer c.Close()
+	defer closeConn()
 
-	rlc := rls.NewReleaseServiceClient(c)
 	s, err := rlc.ListReleases(ctx, req)
 	if err != nil {
 		return nil, err
@@ -367,104 +451,205 @@ func (h *Client) list(ctx context.Context, req *rls.ListReleasesRequest) (*rls.L
 
 // install executes tiller.InstallRelease RPC.
 func (h *Client) install(ctx context.Context, req *rls.InstallReleaseRequest) (*rls.InstallReleaseResponse, error) {
-	c, err := h.connect(ctx)
+	rlc, closeConn, err := h.releaseClient(ctx)
 	if err != nil {
 		return nil, err
 	}
-	defer c.Close()
+	defer closeConn()
 
-	rlc := rls.NewReleaseServiceClient(c)
 	return rlc.InstallRelease(ctx, req)
 }
 
 // delete executes tiller.UninstallRelease RPC.
 func (h *Client) delete(ctx context.Context, req *rls.UninstallReleaseRequest) (*rls.UninstallReleaseResponse, error) {
-	c, err := h.connect(ctx)
+	rlc, closeConn, err := h.releaseClient(ctx)
 	if err != nil {
 		return nil, err
 	}
-	defer c.Close()
+	defer closeConn()
 
-	rlc := rls.NewReleaseServiceClient(c)
 	return rlc.UninstallRelease(ctx, req)
 }
 
 // update executes tiller.UpdateRelease RPC.
 func (h *Client) update(ctx context.Context, req *rls.UpdateReleaseRequest) (*rls.UpdateReleaseResponse, error) {
-	c, err := h.connect(ctx)
+	rlc, closeConn, err := h.releaseClient(ctx)
 	if err != nil {
 		return nil, err
 	}
-	defer c.Close()
+	defer closeConn()
 
-	rlc := rls.NewReleaseServiceClient(c)
 	return rlc.UpdateRelease(ctx, req)
 }
 
 // rollback executes tiller.RollbackRelease RPC.
 func (h *Client) rollback(ctx context.Context, req *rls.RollbackReleaseRequest) (*rls.RollbackReleaseResponse, error) {
-	c, err := h.connect(ctx)
+	rlc, closeConn, err := h.releaseClient(ctx)
 	if err != nil {
 		return nil, err
 	}
-	defer c.Close()
+	defer closeConn()
 
-	rlc := rls.NewReleaseServiceClient(c)
 	return rlc.RollbackRelease(ctx, req)
 }
 
+// installStream executes tiller.InstallReleaseStream RPC, falling back to
+// tiller.InstallRelease if Tiller cannot stream the progress of the release.
+func (h *Client) installStream(ctx context.Context, req *rls.InstallReleaseRequest, progress func(*rls.ReleaseEvent)) (*rls.InstallReleaseResponse, error) {
+	rlc, closeConn, err := h.releaseClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer closeConn()
+
+	s, err := rlc.InstallReleaseStream(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	r, err := recvProgress(s.Recv, progress)
+	if grpc.Code(err) == codes.Unimplemented {
+		return rlc.InstallRelease(ctx, req)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &rls.InstallReleaseResponse{Release: r}, nil
+}
+
+// updateStream executes tiller.UpdateReleaseStream RPC, falling back to
+// tiller.UpdateRelease if Tiller cannot stream the progress of the release.
+func (h *Client) updateStream(ctx context.Context, req *rls.UpdateReleaseRequest, progress func(*rls.ReleaseEvent)) (*rls.UpdateReleaseResponse, error) {
+	rlc, closeConn, err := h.releaseClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer closeConn()
+
+	s, err := rlc.UpdateReleaseStream(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	r, err := recvProgress(s.Recv, progress)
+	if grpc.Code(err) == codes.Unimplemented {
+		return rlc.UpdateRelease(ctx, req)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &rls.UpdateReleaseResponse{Release: r}, nil
+}
+
+// rollbackStream executes tiller.RollbackReleaseStream RPC, falling back to
+// tiller.RollbackRelease if Tiller cannot stream the progress of the release.
+func (h *Client) rollbackStream(ctx context.Context, req *rls.RollbackReleaseRequest, progress func(*rls.ReleaseEvent)) (*rls.RollbackReleaseResponse, error) {
+	rlc, closeConn, err := h.releaseClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer closeConn()
+
+	s, err := rlc.RollbackReleaseStream(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	r, err := recvProgress(s.Recv, progress)
+	if grpc.Code(err) == codes.Unimplemented {
+		return rlc.RollbackRelease(ctx, req)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &rls.RollbackReleaseResponse{Release: r}, nil
+}
+
+// recvProgress reports the events received from a release stream to progress
+// until the final event carrying the resulting release.
+func recvProgress(recv func() (*rls.ReleaseEvent, error), progress func(*rls.ReleaseEvent)) (*release.Release, error) {
+	for {
+		ev, err := recv()
+		if err == io.EOF {
+			return nil, errors.New("release stream closed before the operation completed")
+		}
+		if err != nil {
+			return nil, err
+		}
+		if ev.Type == rls.ReleaseEvent_COMPLETE {
+			return ev.Release, nil
+		}
+		progress(ev)
+	}
+}
+
+// unlock executes tiller.UnlockRelease RPC.
+func (h *Client) unlock(ctx context.Context, req *rls.UnlockReleaseRequest) (*rls.UnlockReleaseResponse, error) {
+	rlc, closeConn, err := h.releaseClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer closeConn()
+
+	return rlc.UnlockRelease(ctx, req)
+}
+
+// repair executes tiller.RepairRelease RPC.
+func (h *Client) repair(ctx context.Context, req *rls.RepairReleaseRequest) (*rls.RepairReleaseResponse, error) {
+	rlc, closeConn, err := h.releaseClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer closeConn()
+
+	return rlc.RepairRelease(ctx, req)
+}
+
 // status executes tiller.GetReleaseStatus RPC.
 func (h *Client) status(ctx context.Context, req *rls.GetReleaseStatusRequest) (*rls.GetReleaseStatusResponse, error) {
-	c, err := h.connect(ctx)
+	rlc, closeConn, err := h.releaseClient(ctx)
 	if err != nil {
 		return nil, err
 	}
-	defer c.Close()
+	defer closeConn()
 
-	rlc := rls.NewReleaseServiceClient(c)
 	return rlc.GetReleaseStatus(ctx, req)
 }
 
 // content executes tiller.GetReleaseContent RPC.
 func (h *Client) content(ctx context.Context, req *rls.GetReleaseContentRequest) (*rls.GetReleaseContentResponse, error) {
-	c, err := h.connect(ctx)
+	rlc, closeConn, err := h.releaseClient(ctx)
 	if err != nil {
 		return nil, err
 	}
-	defer c.Close()
+	defer closeConn()
 
-	rlc := rls.NewReleaseServiceClient(c)
 	return rlc.GetReleaseContent(ctx, req)
 }
 
 // version executes tiller.GetVersion RPC.
 func (h *Client) version(ctx context.Context, req *rls.GetVersionRequest) (*rls.GetVersionResponse, error) {
-	c, err := h.connect(ctx)
+	rlc, closeConn, err := h.releaseClient(ctx)
 	if err != nil {
 		return nil, err
 	}
-	defer c.Close()
+	defer closeConn()
 
-	rlc := rls.NewReleaseServiceClient(c)
 	return rlc.GetVersion(ctx, req)
 }
 
 // history executes tiller.GetHistory RPC.
 func (h *Client) history(ctx context.Context, req *rls.GetHistoryRequest) (*rls.GetHistoryResponse, error) {
-	c, err := h.connect(ctx)
+	rlc, closeConn, err := h.releaseClient(ctx)
 	if err != nil {
 		return nil, err
 	}
-	defer c.Close()
+	defer closeConn()
 
-	rlc := rls.NewReleaseServiceClient(c)
 	return rlc.GetHistory(ctx, req)
 }
 
 // test executes tiller.TestRelease RPC.
 func (h *Client) test(ctx context.Context, req *rls.TestReleaseRequest) (<-chan *rls.TestReleaseResponse, <-chan error) {
 	errc := make(chan error, 1)
-	c, err := h.connect(ctx)
+	rlc, closeConn, err := h.releaseClient(ctx)
 	if err != nil {
 		errc <- err
 		return nil, errc
@@ -474,9 +659,8 @@ func (h *Client) test(ctx context.Context, req *rls.TestReleaseRequest) (<-chan
 	go func() {
 		defer close(errc)
 		defer close(ch)
-		defer c.Close()
+		defer closeConn()
 
-		rlc := rls.NewReleaseServiceClient(c)
 		s, err := rlc.RunReleaseTest(ctx, req)
 		if err != nil {
 			errc <- err
@@ -499,8 +683,12 @@ func (h *Client) test(ctx context.Context, req *rls.TestReleaseRequest) (<-chan
 	return ch, errc
 }
 
-// ping executes tiller.Ping RPC.
+// ping executes tiller.Ping RPC. A release server called in-process is
+// always up.
 func (h *Client) ping(ctx context.Context) error {
+	if h.opts.local != nil {
+		return nil
+	}
 	c, err := h.connect(ctx)
 	if err != nil {
 		return err
@@ -76,6 +76,10 @@ type EnvSettings struct {
 	TLSCertFile string
 	// TLSKeyFile is the path to a TLS key file
 	TLSKeyFile string
+	// SendToken tells helm to send the bearer token of the kubeconfig to Tiller
+	SendToken bool
+	// Local tells helm to run the release server in-process instead of connecting to Tiller
+	Local bool
 }
 
 // AddFlags binds flags to the given flagset.
@@ -86,10 +90,11 @@ func (s *EnvSettings) AddFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&s.KubeConfig, "kubeconfig", "", "absolute path to the kubeconfig file to use")
 	fs.BoolVar(&s.Debug, "debug", false, "enable verbose output")
 	fs.StringVar(&s.TillerNamespace, "tiller-namespace", "kube-system", "namespace of Tiller")
+	fs.BoolVar(&s.Local, "local", false, "run the release server in-process with the kubeconfig credentials instead of connecting to Tiller. Overrides $HELM_TILLERLESS")
 	fs.Int64Var(&s.TillerConnectionTimeout, "tiller-connection-timeout", int64(300), "the duration (in seconds) Helm will wait to establish a connection to tiller")
 }
 
-// AddFlagsTLS adds the flags for supporting client side TLS to the given flagset.
+// AddFlagsTLS adds the flags for supporting client side TLS and authentication to the given flagset.
 func (s *EnvSettings) AddFlagsTLS(fs *pflag.FlagSet) {
 	fs.StringVar(&s.TLSServerName, "tls-hostname", s.TillerHost, "the server name used to verify the hostname on the returned certificates from the server")
 	fs.StringVar(&s.TLSCaCertFile, "tls-ca-cert", DefaultTLSCaCert, "path to TLS CA certificate file")
@@ -97,6 +102,7 @@ func (s *EnvSettings) AddFlagsTLS(fs *pflag.FlagSet) {
 	fs.StringVar(&s.TLSKeyFile, "tls-key", DefaultTLSKeyFile, "path to TLS key file")
 	fs.BoolVar(&s.TLSVerify, "tls-verify", DefaultTLSVerify, "enable TLS for request and verify remote")
 	fs.BoolVar(&s.TLSEnable, "tls", DefaultTLSEnable, "enable TLS for request")
+	fs.BoolVar(&s.SendToken, "send-token", false, "send the bearer token of the kubeconfig to Tiller to authenticate the user. Requires TLS unless Tiller is reached through the port forward")
 }
 
 // Init sets values from the environment.
@@ -119,6 +125,7 @@ var envMap = map[string]string{
 	"home":             "HELM_HOME",
 	"host":             "HELM_HOST",
 	"tiller-namespace": "TILLER_NAMESPACE",
+	"local":            "HELM_TILLERLESS",
 }
 
 var tlsEnvMap = map[string]string{
@@ -128,6 +135,7 @@ var tlsEnvMap = map[string]string{
 	"tls-key":      "HELM_TLS_KEY",
 	"tls-verify":   "HELM_TLS_VERIFY",
 	"tls":          "HELM_TLS_ENABLE",
+	"send-token":   "HELM_SEND_TOKEN",
 }
 
 // PluginDirs is the path to the plugin directories.
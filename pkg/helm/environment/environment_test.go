@@ -36,7 +36,7 @@ func TestEnvSettings(t *testing.T) {
 
 		// expected values
 		home, host, ns, kcontext, kconfig, plugins string
-		debug, tlsverify                           bool
+		debug, tlsverify, local, sendtoken         bool
 	}{
 		{
 			name:      "defaults",
@@ -48,7 +48,7 @@ func TestEnvSettings(t *testing.T) {
 		},
 		{
 			name:      "with flags set",
-			args:      []string{"--home", "/foo", "--host=here", "--debug", "--tiller-namespace=myns", "--kubeconfig", "/bar"},
+			args:      []string{"--home", "/foo", "--host=here", "--debug", "--tiller-namespace=myns", "--kubeconfig", "/bar", "--local"},
 			home:      "/foo",
 			plugins:   helmpath.Home("/foo").Plugins(),
 			host:      "here",
@@ -56,28 +56,31 @@ func TestEnvSettings(t *testing.T) {
 			kconfig:   "/bar",
 			debug:     true,
 			tlsverify: false,
+			local:     true,
 		},
 		{
 			name:      "with envvars set",
 			args:      []string{},
-			envars:    map[string]string{"HELM_HOME": "/bar", "HELM_HOST": "there", "HELM_DEBUG": "1", "TILLER_NAMESPACE": "yourns"},
+			envars:    map[string]string{"HELM_HOME": "/bar", "HELM_HOST": "there", "HELM_DEBUG": "1", "TILLER_NAMESPACE": "yourns", "HELM_TILLERLESS": "true"},
 			home:      "/bar",
 			plugins:   helmpath.Home("/bar").Plugins(),
 			host:      "there",
 			ns:        "yourns",
 			debug:     true,
 			tlsverify: false,
+			local:     true,
 		},
 		{
 			name:      "with TLS envvars set",
 			args:      []string{},
-			envars:    map[string]string{"HELM_HOME": "/bar", "HELM_HOST": "there", "HELM_DEBUG": "1", "TILLER_NAMESPACE": "yourns", "HELM_TLS_VERIFY": "1"},
+			envars:    map[string]string{"HELM_HOME": "/bar", "HELM_HOST": "there", "HELM_DEBUG": "1", "TILLER_NAMESPACE": "yourns", "HELM_TLS_VERIFY": "1", "HELM_SEND_TOKEN": "true"},
 			home:      "/bar",
 			plugins:   helmpath.Home("/bar").Plugins(),
 			host:      "there",
 			ns:        "yourns",
 			debug:     true,
 			tlsverify: true,
+			sendtoken: true,
 		},
 		{
 			name:      "with flags and envvars set",
@@ -104,6 +107,8 @@ func TestEnvSettings(t *testing.T) {
 		"HELM_TLS_KEY":      "",
 		"HELM_TLS_VERIFY":   "",
 		"HELM_TLS_ENABLE":   "",
+		"HELM_TILLERLESS":   "",
+		"HELM_SEND_TOKEN":   "",
 	}
 
 	resetEnv(allEnvvars)
@@ -134,6 +139,9 @@ func TestEnvSettings(t *testing.T) {
 			if settings.TillerHost != tt.host {
 				t.Errorf("expected host %q, got %q", tt.host, settings.TillerHost)
 			}
+			if settings.Local != tt.local {
+				t.Errorf("expected local %t, got %t", tt.local, settings.Local)
+			}
 			if settings.Debug != tt.debug {
 				t.Errorf("expected debug %t, got %t", tt.debug, settings.Debug)
 			}
@@ -149,6 +157,9 @@ func TestEnvSettings(t *testing.T) {
 			if settings.TLSVerify != tt.tlsverify {
 				t.Errorf("expected tls-verify %t, got %t", tt.tlsverify, settings.TLSVerify)
 			}
+			if settings.SendToken != tt.sendtoken {
+				t.Errorf("expected send-token %t, got %t", tt.sendtoken, settings.SendToken)
+			}
 
 			resetEnv(tt.envars)
 		})
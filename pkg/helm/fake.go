@@ -189,16 +189,16 @@ func (c *FakeClient) UpdateReleaseFromChart(rlsName string, newChart *chart.Char
 		}
 	}
 
-	if !c.Opts.dryRun {
+	if !c.Opts.dryRun && !c.Opts.updateReq.Diff {
 		*rel.Release = *newRelease
 	}
 
 	return &rls.UpdateReleaseResponse{Release: newRelease}, nil
 }
 
-// RollbackRelease returns nil, nil
+// RollbackRelease returns an empty RollbackReleaseResponse
 func (c *FakeClient) RollbackRelease(rlsName string, opts ...RollbackOption) (*rls.RollbackReleaseResponse, error) {
-	return nil, nil
+	return &rls.RollbackReleaseResponse{}, nil
 }
 
 // ReleaseStatus returns a release status response with info from the matching release name.
@@ -232,6 +232,43 @@ func (c *FakeClient) ReleaseHistory(rlsName string, opts ...HistoryOption) (*rls
 	return &rls.GetHistoryResponse{Releases: c.Rels}, nil
 }
 
+// UnlockRelease returns the lock of a release, as if it had been held by an upgrade.
+func (c *FakeClient) UnlockRelease(rlsName string, opts ...UnlockOption) (*rls.UnlockReleaseResponse, error) {
+	for _, rel := range c.Rels {
+		if rel.Name == rlsName {
+			return &rls.UnlockReleaseResponse{Holder: "fake@localhost", Operation: "upgrade"}, nil
+		}
+	}
+	return nil, storageerrors.ErrLockNotFound(rlsName)
+}
+
+// RepairRelease marks the matching pending release deployed, or every pending release failed
+// if no release name is given.
+func (c *FakeClient) RepairRelease(rlsName string, opts ...RepairOption) (*rls.RepairReleaseResponse, error) {
+	res := &rls.RepairReleaseResponse{}
+	for _, rel := range c.Rels {
+		if rlsName != "" && rel.Name != rlsName {
+			continue
+		}
+		switch rel.Info.Status.Code {
+		case release.Status_PENDING_INSTALL, release.Status_PENDING_UPGRADE, release.Status_PENDING_ROLLBACK:
+			if rlsName == "" {
+				rel.Info.Status.Code = release.Status_FAILED
+			} else {
+				rel.Info.Status.Code = release.Status_DEPLOYED
+			}
+			res.Releases = append(res.Releases, rel)
+		}
+		if rlsName != "" {
+			return res, nil
+		}
+	}
+	if rlsName != "" {
+		return nil, storageerrors.ErrReleaseNotFound(rlsName)
+	}
+	return res, nil
+}
+
 // RunReleaseTest executes a pre-defined tests on a release
 func (c *FakeClient) RunReleaseTest(rlsName string, opts ...ReleaseTestOption) (<-chan *rls.TestReleaseResponse, <-chan error) {
 
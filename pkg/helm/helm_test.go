@@ -284,6 +284,30 @@ func TestRollbackRelease_VerifyOptions(t *testing.T) {
 	assert(t, "", client.opts.rollbackReq.Name)
 }
 
+// Verify diffs are requested as dry runs, which older Tillers understand.
+func TestDiff_DryRun(t *testing.T) {
+	b4c := BeforeCall(func(_ context.Context, msg proto.Message) error {
+		switch act := msg.(type) {
+		case *tpb.UpdateReleaseRequest:
+			assert(t, true, act.Diff && act.DryRun)
+		case *tpb.RollbackReleaseRequest:
+			assert(t, true, act.Diff && act.DryRun)
+		default:
+			t.Fatalf("unexpected message of type %T\n", act)
+		}
+		return errSkip
+	})
+
+	client := NewClient(b4c)
+	chartPath := filepath.Join(chartsDir, "alpine")
+	if _, err := client.UpdateRelease("test", chartPath, UpgradeDiff(true)); err != errSkip {
+		t.Fatalf("did not expect error but got (%v)\n", err)
+	}
+	if _, err := client.RollbackRelease("test", RollbackDiff(true)); err != errSkip {
+		t.Fatalf("did not expect error but got (%v)\n", err)
+	}
+}
+
 // Verify each StatusOption is applied to a GetReleaseStatusRequest correctly.
 func TestReleaseStatus_VerifyOptions(t *testing.T) {
 	// Options testdata
@@ -87,6 +87,21 @@ func (h Home) Archive() string {
 	return h.Path("cache", "archive")
 }
 
+// Registry returns the path to the OCI registry configuration and cache.
+func (h Home) Registry() string {
+	return h.Path("registry")
+}
+
+// RegistryConfig returns the path to the file storing the registry credentials.
+func (h Home) RegistryConfig() string {
+	return h.Path("registry", "config.json")
+}
+
+// RegistryCache returns the path to the local cache of charts stored in registries.
+func (h Home) RegistryCache() string {
+	return h.Path("registry", "cache")
+}
+
 // TLSCaCert returns the path to fetch the CA certificate.
 func (h Home) TLSCaCert() string {
 	return h.Path("ca.pem")
@@ -38,6 +38,9 @@ func TestHelmHome(t *testing.T) {
 	isEq(t, hh.CacheIndex("t"), "/r/repository/cache/t-index.yaml")
 	isEq(t, hh.Starters(), "/r/starters")
 	isEq(t, hh.Archive(), "/r/cache/archive")
+	isEq(t, hh.Registry(), "/r/registry")
+	isEq(t, hh.RegistryConfig(), "/r/registry/config.json")
+	isEq(t, hh.RegistryCache(), "/r/registry/cache")
 	isEq(t, hh.TLSCaCert(), "/r/ca.pem")
 	isEq(t, hh.TLSCert(), "/r/cert.pem")
 	isEq(t, hh.TLSKey(), "/r/key.pem")
@@ -35,5 +35,7 @@ type Interface interface {
 	ReleaseHistory(rlsName string, opts ...HistoryOption) (*rls.GetHistoryResponse, error)
 	GetVersion(opts ...VersionOption) (*rls.GetVersionResponse, error)
 	RunReleaseTest(rlsName string, opts ...ReleaseTestOption) (<-chan *rls.TestReleaseResponse, <-chan error)
+	UnlockRelease(rlsName string, opts ...UnlockOption) (*rls.UnlockReleaseResponse, error)
+	RepairRelease(rlsName string, opts ...RepairOption) (*rls.RepairReleaseResponse, error)
 	PingTiller() error
 }
@@ -0,0 +1,214 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helm
+
+import (
+	"io"
+
+	"github.com/golang/protobuf/proto"
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	rls "k8s.io/helm/pkg/proto/hapi/services"
+)
+
+// localClient calls the methods of a release server in-process, as if it
+// were reached through gRPC.
+type localClient struct {
+	server rls.ReleaseServiceServer
+}
+
+var _ rls.ReleaseServiceClient = localClient{}
+
+// incoming passes the metadata the client sends with its calls to the server.
+func incoming(ctx context.Context) context.Context {
+	if md, ok := metadata.FromOutgoingContext(ctx); ok {
+		return metadata.NewIncomingContext(ctx, md)
+	}
+	return ctx
+}
+
+func (c localClient) ListReleases(ctx context.Context, in *rls.ListReleasesRequest, _ ...grpc.CallOption) (rls.ReleaseService_ListReleasesClient, error) {
+	s := listStream{newLocalStream(ctx)}
+	go s.serve(func() error { return c.server.ListReleases(in, s) })
+	return s, nil
+}
+
+func (c localClient) GetReleaseStatus(ctx context.Context, in *rls.GetReleaseStatusRequest, _ ...grpc.CallOption) (*rls.GetReleaseStatusResponse, error) {
+	return c.server.GetReleaseStatus(incoming(ctx), in)
+}
+
+func (c localClient) GetReleaseContent(ctx context.Context, in *rls.GetReleaseContentRequest, _ ...grpc.CallOption) (*rls.GetReleaseContentResponse, error) {
+	return c.server.GetReleaseContent(incoming(ctx), in)
+}
+
+func (c localClient) UpdateRelease(ctx context.Context, in *rls.UpdateReleaseRequest, _ ...grpc.CallOption) (*rls.UpdateReleaseResponse, error) {
+	return c.server.UpdateRelease(incoming(ctx), in)
+}
+
+func (c localClient) InstallRelease(ctx context.Context, in *rls.InstallReleaseRequest, _ ...grpc.CallOption) (*rls.InstallReleaseResponse, error) {
+	return c.server.InstallRelease(incoming(ctx), in)
+}
+
+func (c localClient) UninstallRelease(ctx context.Context, in *rls.UninstallReleaseRequest, _ ...grpc.CallOption) (*rls.UninstallReleaseResponse, error) {
+	return c.server.UninstallRelease(incoming(ctx), in)
+}
+
+func (c localClient) GetVersion(ctx context.Context, in *rls.GetVersionRequest, _ ...grpc.CallOption) (*rls.GetVersionResponse, error) {
+	return c.server.GetVersion(incoming(ctx), in)
+}
+
+func (c localClient) RollbackRelease(ctx context.Context, in *rls.RollbackReleaseRequest, _ ...grpc.CallOption) (*rls.RollbackReleaseResponse, error) {
+	return c.server.RollbackRelease(incoming(ctx), in)
+}
+
+func (c localClient) GetHistory(ctx context.Context, in *rls.GetHistoryRequest, _ ...grpc.CallOption) (*rls.GetHistoryResponse, error) {
+	return c.server.GetHistory(incoming(ctx), in)
+}
+
+func (c localClient) RunReleaseTest(ctx context.Context, in *rls.TestReleaseRequest, _ ...grpc.CallOption) (rls.ReleaseService_RunReleaseTestClient, error) {
+	s := testStream{newLocalStream(ctx)}
+	go s.serve(func() error { return c.server.RunReleaseTest(in, s) })
+	return s, nil
+}
+
+func (c localClient) InstallReleaseStream(ctx context.Context, in *rls.InstallReleaseRequest, _ ...grpc.CallOption) (rls.ReleaseService_InstallReleaseStreamClient, error) {
+	s := eventStream{newLocalStream(ctx)}
+	go s.serve(func() error { return c.server.InstallReleaseStream(in, s) })
+	return s, nil
+}
+
+func (c localClient) UpdateReleaseStream(ctx context.Context, in *rls.UpdateReleaseRequest, _ ...grpc.CallOption) (rls.ReleaseService_UpdateReleaseStreamClient, error) {
+	s := eventStream{newLocalStream(ctx)}
+	go s.serve(func() error { return c.server.UpdateReleaseStream(in, s) })
+	return s, nil
+}
+
+func (c localClient) RollbackReleaseStream(ctx context.Context, in *rls.RollbackReleaseRequest, _ ...grpc.CallOption) (rls.ReleaseService_RollbackReleaseStreamClient, error) {
+	s := eventStream{newLocalStream(ctx)}
+	go s.serve(func() error { return c.server.RollbackReleaseStream(in, s) })
+	return s, nil
+}
+
+func (c localClient) UnlockRelease(ctx context.Context, in *rls.UnlockReleaseRequest, _ ...grpc.CallOption) (*rls.UnlockReleaseResponse, error) {
+	return c.server.UnlockRelease(incoming(ctx), in)
+}
+
+func (c localClient) RepairRelease(ctx context.Context, in *rls.RepairReleaseRequest, _ ...grpc.CallOption) (*rls.RepairReleaseResponse, error) {
+	return c.server.RepairRelease(incoming(ctx), in)
+}
+
+// localStream carries the messages a server method streams to the client.
+// It is both the server and the client side of the stream.
+type localStream struct {
+	ctx  context.Context
+	msgs chan proto.Message
+	// done is closed with err set once the server method returned.
+	done chan struct{}
+	err  error
+}
+
+func newLocalStream(ctx context.Context) *localStream {
+	return &localStream{
+		ctx:  incoming(ctx),
+		msgs: make(chan proto.Message),
+		done: make(chan struct{}),
+	}
+}
+
+// serve runs the server method fn, and ends the stream when it returns.
+func (s *localStream) serve(fn func() error) {
+	defer close(s.done)
+	s.err = fn()
+}
+
+func (s *localStream) send(m proto.Message) error {
+	select {
+	case s.msgs <- m:
+		return nil
+	case <-s.ctx.Done():
+		return s.ctx.Err()
+	}
+}
+
+// recv returns the next message sent by the server, or io.EOF once the
+// server method returned successfully.
+func (s *localStream) recv() (proto.Message, error) {
+	select {
+	case m := <-s.msgs:
+		return m, nil
+	case <-s.done:
+		if s.err != nil {
+			return nil, s.err
+		}
+		return nil, io.EOF
+	}
+}
+
+func (s *localStream) Context() context.Context     { return s.ctx }
+func (s *localStream) SetHeader(metadata.MD) error  { return nil }
+func (s *localStream) SendHeader(metadata.MD) error { return nil }
+func (s *localStream) SetTrailer(metadata.MD)       {}
+func (s *localStream) Header() (metadata.MD, error) { return nil, nil }
+func (s *localStream) Trailer() metadata.MD         { return nil }
+func (s *localStream) CloseSend() error             { return nil }
+func (s *localStream) SendMsg(m interface{}) error  { return s.send(m.(proto.Message)) }
+func (s *localStream) RecvMsg(m interface{}) error {
+	msg, err := s.recv()
+	if err != nil {
+		return err
+	}
+	proto.Merge(m.(proto.Message), msg)
+	return nil
+}
+
+type listStream struct{ *localStream }
+
+func (s listStream) Send(m *rls.ListReleasesResponse) error { return s.send(m) }
+
+func (s listStream) Recv() (*rls.ListReleasesResponse, error) {
+	m, err := s.recv()
+	if err != nil {
+		return nil, err
+	}
+	return m.(*rls.ListReleasesResponse), nil
+}
+
+type testStream struct{ *localStream }
+
+func (s testStream) Send(m *rls.TestReleaseResponse) error { return s.send(m) }
+
+func (s testStream) Recv() (*rls.TestReleaseResponse, error) {
+	m, err := s.recv()
+	if err != nil {
+		return nil, err
+	}
+	return m.(*rls.TestReleaseResponse), nil
+}
+
+type eventStream struct{ *localStream }
+
+func (s eventStream) Send(m *rls.ReleaseEvent) error { return s.send(m) }
+
+func (s eventStream) Recv() (*rls.ReleaseEvent, error) {
+	m, err := s.recv()
+	if err != nil {
+		return nil, err
+	}
+	return m.(*rls.ReleaseEvent), nil
+}
@@ -0,0 +1,107 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helm
+
+import (
+	"errors"
+	"testing"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/metadata"
+
+	"k8s.io/helm/pkg/proto/hapi/release"
+	rls "k8s.io/helm/pkg/proto/hapi/services"
+)
+
+// releaseServer serves a few methods of the release service in-process.
+type releaseServer struct {
+	rls.ReleaseServiceServer
+}
+
+func (s *releaseServer) ListReleases(req *rls.ListReleasesRequest, stream rls.ReleaseService_ListReleasesServer) error {
+	for _, name := range []string{"one", "two"} {
+		if err := stream.Send(&rls.ListReleasesResponse{Count: 2, Releases: []*release.Release{{Name: name}}}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *releaseServer) GetHistory(ctx context.Context, req *rls.GetHistoryRequest) (*rls.GetHistoryResponse, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if len(md["x-helm-api-client"]) == 0 {
+		return nil, errors.New("missing client version")
+	}
+	return &rls.GetHistoryResponse{Releases: []*release.Release{{Name: req.Name, Version: 1}}}, nil
+}
+
+func (s *releaseServer) InstallReleaseStream(req *rls.InstallReleaseRequest, stream rls.ReleaseService_InstallReleaseStreamServer) error {
+	stream.Send(&rls.ReleaseEvent{Type: rls.ReleaseEvent_RESOURCE_CREATED, Message: "created"})
+	return stream.Send(&rls.ReleaseEvent{Type: rls.ReleaseEvent_COMPLETE, Release: &release.Release{Name: req.Name}})
+}
+
+func (s *releaseServer) RunReleaseTest(req *rls.TestReleaseRequest, stream rls.ReleaseService_RunReleaseTestServer) error {
+	stream.Send(&rls.TestReleaseResponse{Msg: "RUNNING: " + req.Name})
+	return errors.New("test failed")
+}
+
+func TestLocalClient(t *testing.T) {
+	c := NewClient(Local(&releaseServer{}))
+
+	if err := c.PingTiller(); err != nil {
+		t.Errorf("expected the in-process server to be up, got %s", err)
+	}
+
+	list, err := c.ListReleases()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Releases) != 2 || list.Releases[1].Name != "two" {
+		t.Errorf("expected the releases streamed by the server, got %v", list.Releases)
+	}
+
+	hist, err := c.ReleaseHistory("angry-bird")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(hist.Releases) != 1 || hist.Releases[0].Name != "angry-bird" {
+		t.Errorf("expected the history of angry-bird, got %v", hist.Releases)
+	}
+
+	var events []string
+	inst, err := c.InstallReleaseFromChart(loadChart(t, "alpine"), "default", ReleaseName("angry-bird"), InstallProgress(func(ev *rls.ReleaseEvent) {
+		events = append(events, ev.Message)
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if inst.Release.Name != "angry-bird" || len(events) != 1 || events[0] != "created" {
+		t.Errorf("expected angry-bird installed after one event, got %v after %v", inst.Release, events)
+	}
+
+	msgs, errc := c.RunReleaseTest("angry-bird")
+	var got []string
+	for msg := range msgs {
+		got = append(got, msg.Msg)
+	}
+	if len(got) != 1 || got[0] != "RUNNING: angry-bird" {
+		t.Errorf("expected the test messages of angry-bird, got %v", got)
+	}
+	if err := <-errc; err == nil || err.Error() != "test failed" {
+		t.Errorf("expected the test to fail, got %v", err)
+	}
+}
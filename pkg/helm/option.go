@@ -18,6 +18,8 @@ package helm
 
 import (
 	"crypto/tls"
+	"os"
+	"os/user"
 	"time"
 
 	"github.com/golang/protobuf/proto"
@@ -57,6 +59,10 @@ type options struct {
 	releaseName string
 	// tls.Config to use for rpc if tls enabled
 	tlsConfig *tls.Config
+	// bearer token authenticating the user to Tiller
+	bearerToken string
+	// whether the bearer token may be sent without TLS
+	bearerTokenInsecure bool
 	// release list options are applied directly to the list releases request
 	listReq rls.ListReleasesRequest
 	// release install options are applied directly to the install release request
@@ -83,6 +89,10 @@ type options struct {
 	testReq rls.TestReleaseRequest
 	// connectTimeout specifies the time duration Helm will wait to establish a connection to tiller
 	connectTimeout time.Duration
+	// progress receives the progress events of an install, upgrade or rollback
+	progress func(*rls.ReleaseEvent)
+	// local is the release server called in-process instead of Tiller
+	local rls.ReleaseServiceServer
 }
 
 // Host specifies the host address of the Tiller release server, (default = ":44134").
@@ -100,6 +110,32 @@ func WithTLS(cfg *tls.Config) Option {
 	}
 }
 
+// WithBearerToken specifies the Kubernetes bearer token the client sends to
+// Tiller to authenticate the user. The token is only sent over TLS.
+func WithBearerToken(token string) Option {
+	return func(opts *options) {
+		opts.bearerToken = token
+	}
+}
+
+// WithInsecureBearerToken is like WithBearerToken but also sends the token
+// without TLS. It is meant for connections already secured otherwise, such
+// as port forwards tunneled by the Kubernetes API server.
+func WithInsecureBearerToken(token string) Option {
+	return func(opts *options) {
+		opts.bearerToken = token
+		opts.bearerTokenInsecure = true
+	}
+}
+
+// Local specifies a release server the client calls in-process instead of
+// connecting to Tiller, such as a tiller.ReleaseServer embedded in the client.
+func Local(server rls.ReleaseServiceServer) Option {
+	return func(opts *options) {
+		opts.local = server
+	}
+}
+
 // BeforeCall returns an option that allows intercepting a helm client rpc
 // before being sent OTA to tiller. The intercepting function should return
 // an error to indicate that the call should not proceed or nil otherwise.
@@ -353,6 +389,13 @@ func InstallSubNotes(enable bool) InstallOption {
 	}
 }
 
+// InstallProgress streams the progress of the install to fn.
+func InstallProgress(fn func(*rls.ReleaseEvent)) InstallOption {
+	return func(opts *options) {
+		opts.progress = fn
+	}
+}
+
 // UpgradeSubNotes will (if true) instruct Tiller to render SubChart Notes
 func UpgradeSubNotes(enable bool) UpdateOption {
 	return func(opts *options) {
@@ -360,6 +403,18 @@ func UpgradeSubNotes(enable bool) UpdateOption {
 	}
 }
 
+// UpgradeDiff will (if true) instruct Tiller to return the difference with
+// the deployed release instead of upgrading it. The upgrade is also requested
+// as a dry run, so that versions of Tiller unaware of diffs do not upgrade.
+func UpgradeDiff(diff bool) UpdateOption {
+	return func(opts *options) {
+		opts.updateReq.Diff = diff
+		if diff {
+			opts.dryRun = true
+		}
+	}
+}
+
 // RollbackDisableHooks will disable hooks for a rollback operation
 func RollbackDisableHooks(disable bool) RollbackOption {
 	return func(opts *options) {
@@ -374,6 +429,19 @@ func RollbackDryRun(dry bool) RollbackOption {
 	}
 }
 
+// RollbackDiff will (if true) instruct Tiller to return the difference with
+// the current release instead of rolling it back. The rollback is also
+// requested as a dry run, so that versions of Tiller unaware of diffs do not
+// roll back.
+func RollbackDiff(diff bool) RollbackOption {
+	return func(opts *options) {
+		opts.rollbackReq.Diff = diff
+		if diff {
+			opts.dryRun = true
+		}
+	}
+}
+
 // RollbackRecreate will (if true) recreate pods after rollback.
 func RollbackRecreate(recreate bool) RollbackOption {
 	return func(opts *options) {
@@ -388,6 +456,21 @@ func RollbackForce(force bool) RollbackOption {
 	}
 }
 
+// RollbackThreeWayMerge will (if true) patch resources with a three-way merge
+// that takes their live state into account.
+func RollbackThreeWayMerge(threeWayMerge bool) RollbackOption {
+	return func(opts *options) {
+		opts.rollbackReq.ThreeWayMerge = threeWayMerge
+	}
+}
+
+// RollbackProgress streams the progress of the rollback to fn.
+func RollbackProgress(fn func(*rls.ReleaseEvent)) RollbackOption {
+	return func(opts *options) {
+		opts.progress = fn
+	}
+}
+
 // RollbackVersion sets the version of the release to deploy.
 func RollbackVersion(ver int32) RollbackOption {
 	return func(opts *options) {
@@ -438,6 +521,61 @@ func UpgradeForce(force bool) UpdateOption {
 	}
 }
 
+// UpgradeThreeWayMerge will (if true) patch resources with a three-way merge
+// that takes their live state into account.
+func UpgradeThreeWayMerge(threeWayMerge bool) UpdateOption {
+	return func(opts *options) {
+		opts.updateReq.ThreeWayMerge = threeWayMerge
+	}
+}
+
+// UpgradeTestAfter will (if true) run the tests of the release once it is
+// upgraded.
+func UpgradeTestAfter(testAfter bool) UpdateOption {
+	return func(opts *options) {
+		opts.updateReq.TestAfter = testAfter
+	}
+}
+
+// UpgradeAtomicTests will (if true) roll back the release to its previous
+// revision if any test run after the upgrade fails.
+func UpgradeAtomicTests(atomicTests bool) UpdateOption {
+	return func(opts *options) {
+		opts.updateReq.AtomicTests = atomicTests
+	}
+}
+
+// UpgradeSteps will (if true) apply the resources in waves grouped by their
+// helm.sh/wave annotation, waiting for each wave to be ready.
+func UpgradeSteps(steps bool) UpdateOption {
+	return func(opts *options) {
+		opts.updateReq.Steps = steps
+	}
+}
+
+// UpgradePause will (if true) pause an upgrade with steps after each wave but
+// the last one.
+func UpgradePause(pause bool) UpdateOption {
+	return func(opts *options) {
+		opts.updateReq.Pause = pause
+	}
+}
+
+// UpgradeContinue will (if true) continue the paused upgrade of the release.
+// No chart is needed to continue an upgrade.
+func UpgradeContinue(cont bool) UpdateOption {
+	return func(opts *options) {
+		opts.updateReq.Continue = cont
+	}
+}
+
+// UpgradeProgress streams the progress of the upgrade to fn.
+func UpgradeProgress(fn func(*rls.ReleaseEvent)) UpdateOption {
+	return func(opts *options) {
+		opts.progress = fn
+	}
+}
+
 // ContentOption allows setting optional attributes when
 // performing a GetReleaseContent tiller rpc.
 type ContentOption func(*options)
@@ -491,12 +629,33 @@ func WithMaxHistory(max int32) HistoryOption {
 	}
 }
 
-// NewContext creates a versioned context.
+// NewContext creates a versioned context, identifying the user running the client.
 func NewContext() context.Context {
-	md := metadata.Pairs("x-helm-api-client", version.GetVersion())
+	md := metadata.Pairs("x-helm-api-client", version.GetVersion(), "x-helm-client-user", clientUser())
 	return metadata.NewOutgoingContext(context.TODO(), md)
 }
 
+// clientUser returns "user@host" for the user running the client.
+func clientUser() string {
+	name := "unknown"
+	if u, err := user.Current(); err == nil {
+		name = u.Username
+	}
+	host, err := os.Hostname()
+	if err != nil {
+		return name
+	}
+	return name + "@" + host
+}
+
+// UnlockOption allows setting optional attributes when
+// performing an UnlockRelease tiller rpc.
+type UnlockOption func(*options)
+
+// RepairOption allows setting optional attributes when
+// performing a RepairRelease tiller rpc.
+type RepairOption func(*options)
+
 // ReleaseTestOption allows configuring optional request data for
 // issuing a TestRelease rpc.
 type ReleaseTestOption func(*options)
@@ -43,6 +43,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/jsonmergepatch"
 	"k8s.io/apimachinery/pkg/util/strategicpatch"
 	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
@@ -86,10 +87,28 @@ var nopLogger = func(_ string, _ ...interface{}) {}
 // ResourceActorFunc performs an action on a single resource.
 type ResourceActorFunc func(*resource.Info) error
 
+// CreateOptions provides options to control create behavior
+type CreateOptions struct {
+	Timeout    int64
+	ShouldWait bool
+	// Progress, if set, receives the progress of the creation.
+	Progress ProgressFunc
+}
+
 // Create creates Kubernetes resources from an io.reader.
 //
 // Namespace will set the namespace.
 func (c *Client) Create(namespace string, reader io.Reader, timeout int64, shouldWait bool) error {
+	return c.CreateWithOptions(namespace, reader, CreateOptions{
+		Timeout:    timeout,
+		ShouldWait: shouldWait,
+	})
+}
+
+// CreateWithOptions creates Kubernetes resources from an io.reader.
+//
+// Namespace will set the namespace.
+func (c *Client) CreateWithOptions(namespace string, reader io.Reader, opts CreateOptions) error {
 	client, err := c.KubernetesClientSet()
 	if err != nil {
 		return err
@@ -103,11 +122,18 @@ func (c *Client) Create(namespace string, reader io.Reader, timeout int64, shoul
 		return buildErr
 	}
 	c.Log("creating %d resource(s)", len(infos))
-	if err := perform(infos, createResource); err != nil {
+	err = perform(infos, func(info *resource.Info) error {
+		if err := createResource(info); err != nil {
+			return err
+		}
+		opts.Progress.send(EventResourceCreated, info)
+		return nil
+	})
+	if err != nil {
 		return err
 	}
-	if shouldWait {
-		return c.waitForResources(time.Duration(timeout)*time.Second, infos)
+	if opts.ShouldWait {
+		return c.waitForResources(time.Duration(opts.Timeout)*time.Second, infos, opts.Progress)
 	}
 	return nil
 }
@@ -289,6 +315,20 @@ func (c *Client) Get(namespace string, reader io.Reader) (string, error) {
 	return buf.String(), nil
 }
 
+// UpdateOptions provides options to control update behavior
+type UpdateOptions struct {
+	Force      bool
+	Recreate   bool
+	Timeout    int64
+	ShouldWait bool
+	// ThreeWayMerge computes the patches from the original configuration,
+	// the target configuration and the live state of the resources, so that
+	// changes made outside of Helm to fields it does not manage are kept.
+	ThreeWayMerge bool
+	// Progress, if set, receives the progress of the update.
+	Progress ProgressFunc
+}
+
 // Update reads in the current configuration and a target configuration from io.reader
 // and creates resources that don't already exists, updates resources that have been modified
 // in the target configuration and deletes resources from the current configuration that are
@@ -296,6 +336,21 @@ func (c *Client) Get(namespace string, reader io.Reader) (string, error) {
 //
 // Namespace will set the namespaces.
 func (c *Client) Update(namespace string, originalReader, targetReader io.Reader, force bool, recreate bool, timeout int64, shouldWait bool) error {
+	return c.UpdateWithOptions(namespace, originalReader, targetReader, UpdateOptions{
+		Force:      force,
+		Recreate:   recreate,
+		Timeout:    timeout,
+		ShouldWait: shouldWait,
+	})
+}
+
+// UpdateWithOptions reads in the current configuration and a target configuration from io.reader
+// and creates resources that don't already exists, updates resources that have been modified
+// in the target configuration and deletes resources from the current configuration that are
+// not present in the target configuration.
+//
+// Namespace will set the namespaces.
+func (c *Client) UpdateWithOptions(namespace string, originalReader, targetReader io.Reader, opts UpdateOptions) error {
 	original, err := c.BuildUnstructured(namespace, originalReader)
 	if err != nil {
 		return fmt.Errorf("failed decoding reader into objects: %s", err)
@@ -316,7 +371,8 @@ func (c *Client) Update(namespace string, originalReader, targetReader io.Reader
 		}
 
 		helper := resource.NewHelper(info.Client, info.Mapping)
-		if _, err := helper.Get(info.Namespace, info.Name, info.Export); err != nil {
+		liveObj, err := helper.Get(info.Namespace, info.Name, info.Export)
+		if err != nil {
 			if !errors.IsNotFound(err) {
 				return fmt.Errorf("Could not get information about the resource: %s", err)
 			}
@@ -328,6 +384,7 @@ func (c *Client) Update(namespace string, originalReader, targetReader io.Reader
 
 			kind := info.Mapping.GroupVersionKind.Kind
 			c.Log("Created a new %s called %q\n", kind, info.Name)
+			opts.Progress.send(EventResourceCreated, info)
 			return nil
 		}
 
@@ -337,9 +394,14 @@ func (c *Client) Update(namespace string, originalReader, targetReader io.Reader
 			return fmt.Errorf("no %s with the name %q found", kind, info.Name)
 		}
 
-		if err := updateResource(c, info, originalInfo.Object, force, recreate); err != nil {
+		if !opts.ThreeWayMerge {
+			liveObj = nil
+		}
+		if err := updateResource(c, info, originalInfo.Object, liveObj, opts.Force, opts.Recreate); err != nil {
 			c.Log("error updating the resource %q:\n\t %v", info.Name, err)
 			updateErrors = append(updateErrors, err.Error())
+		} else {
+			opts.Progress.send(EventResourceUpdated, info)
 		}
 
 		return nil
@@ -369,10 +431,12 @@ func (c *Client) Update(namespace string, originalReader, targetReader io.Reader
 
 		if err := deleteResource(info); err != nil {
 			c.Log("Failed to delete %q, err: %s", info.Name, err)
+			continue
 		}
+		opts.Progress.send(EventResourceDeleted, info)
 	}
-	if shouldWait {
-		return c.waitForResources(time.Duration(timeout)*time.Second, target)
+	if opts.ShouldWait {
+		return c.waitForResources(time.Duration(opts.Timeout)*time.Second, target, opts.Progress)
 	}
 	return nil
 }
@@ -456,8 +520,12 @@ func deleteResource(info *resource.Info) error {
 	return err
 }
 
-func createPatch(target *resource.Info, current runtime.Object) ([]byte, types.PatchType, error) {
-	oldData, err := json.Marshal(current)
+// createPatch returns the patch to apply to the live resource to reach the
+// target configuration. When live is nil, the patch is computed from the
+// original configuration only, otherwise it is a three-way patch that also
+// takes the live state of the resource into account.
+func createPatch(target *resource.Info, original, live runtime.Object) ([]byte, types.PatchType, error) {
+	oldData, err := json.Marshal(original)
 	if err != nil {
 		return nil, types.StrategicMergePatchType, fmt.Errorf("serializing current configuration: %s", err)
 	}
@@ -466,11 +534,22 @@ func createPatch(target *resource.Info, current runtime.Object) ([]byte, types.P
 		return nil, types.StrategicMergePatchType, fmt.Errorf("serializing target configuration: %s", err)
 	}
 
+	var liveData []byte
+	if live != nil {
+		liveData, err = json.Marshal(live)
+		if err != nil {
+			return nil, types.StrategicMergePatchType, fmt.Errorf("serializing live configuration: %s", err)
+		}
+	}
+
 	// While different objects need different merge types, the parent function
 	// that calls this does not try to create a patch when the data (first
 	// returned object) is nil. We can skip calculating the merge type as
 	// the returned merge type is ignored.
-	if apiequality.Semantic.DeepEqual(oldData, newData) {
+	//
+	// A three-way patch is computed even if the configuration did not change,
+	// so that changes made to the live resource are reverted.
+	if live == nil && apiequality.Semantic.DeepEqual(oldData, newData) {
 		return nil, types.StrategicMergePatchType, nil
 	}
 
@@ -484,24 +563,35 @@ func createPatch(target *resource.Info, current runtime.Object) ([]byte, types.P
 	_, isUnstructured := versionedObject.(runtime.Unstructured)
 
 	switch {
+	case (runtime.IsNotRegisteredError(err) || isUnstructured) && live != nil:
+		// fall back to generic JSON merge patch
+		patch, err := jsonmergepatch.CreateThreeWayJSONMergePatch(oldData, newData, liveData)
+		return patch, types.MergePatchType, err
 	case runtime.IsNotRegisteredError(err), isUnstructured:
 		// fall back to generic JSON merge patch
 		patch, err := jsonpatch.CreateMergePatch(oldData, newData)
 		return patch, types.MergePatchType, err
 	case err != nil:
 		return nil, types.StrategicMergePatchType, fmt.Errorf("failed to get versionedObject: %s", err)
+	case live != nil:
+		patchMeta, err := strategicpatch.NewPatchMetaFromStruct(versionedObject)
+		if err != nil {
+			return nil, types.StrategicMergePatchType, fmt.Errorf("failed to get patch metadata: %s", err)
+		}
+		patch, err := strategicpatch.CreateThreeWayMergePatch(oldData, newData, liveData, patchMeta, true)
+		return patch, types.StrategicMergePatchType, err
 	default:
 		patch, err := strategicpatch.CreateTwoWayMergePatch(oldData, newData, versionedObject)
 		return patch, types.StrategicMergePatchType, err
 	}
 }
 
-func updateResource(c *Client, target *resource.Info, currentObj runtime.Object, force bool, recreate bool) error {
-	patch, patchType, err := createPatch(target, currentObj)
+func updateResource(c *Client, target *resource.Info, currentObj, liveObj runtime.Object, force bool, recreate bool) error {
+	patch, patchType, err := createPatch(target, currentObj, liveObj)
 	if err != nil {
 		return fmt.Errorf("failed to create patch: %s", err)
 	}
-	if patch == nil {
+	if patch == nil || string(patch) == "{}" {
 		c.Log("Looks like there are no changes for %s %q", target.Mapping.GroupVersionKind.Kind, target.Name)
 		// This needs to happen to make sure that tiller has the latest info from the API
 		// Otherwise there will be no labels and other functions that use labels will panic
@@ -26,9 +26,12 @@ import (
 	"testing"
 
 	"k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/cli-runtime/pkg/genericclioptions/resource"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest/fake"
@@ -224,6 +227,122 @@ func TestUpdate(t *testing.T) {
 	}
 }
 
+func TestUpdateThreeWayMerge(t *testing.T) {
+	original := newPodList("starfish")
+	target := newPodList("starfish")
+	target.Items[0].Spec.Containers[0].Ports = []v1.ContainerPort{{Name: "https", ContainerPort: 443}}
+
+	// the live object was edited outside of Helm
+	live := newPod("starfish")
+	live.ObjectMeta.Labels = map[string]string{"edited": "true"}
+	live.Spec.Containers[0].Image = "abc/app:v5"
+
+	var patch string
+
+	tf := cmdtesting.NewTestFactory()
+	defer tf.Cleanup()
+
+	tf.UnstructuredClient = &fake.RESTClient{
+		NegotiatedSerializer: unstructuredSerializer,
+		Client: fake.CreateHTTPClient(func(req *http.Request) (*http.Response, error) {
+			p, m := req.URL.Path, req.Method
+			switch {
+			case p == "/namespaces/default/pods/starfish" && m == "GET":
+				return newResponse(200, &live)
+			case p == "/namespaces/default/pods/starfish" && m == "PATCH":
+				data, err := ioutil.ReadAll(req.Body)
+				if err != nil {
+					t.Fatalf("could not dump request: %s", err)
+				}
+				req.Body.Close()
+				patch = string(data)
+				return newResponse(200, &target.Items[0])
+			default:
+				t.Fatalf("unexpected request: %s %s", req.Method, req.URL.Path)
+				return nil, nil
+			}
+		}),
+	}
+
+	c := &Client{
+		Factory: tf,
+		Log:     nopLogger,
+	}
+
+	opts := UpdateOptions{ThreeWayMerge: true}
+	if err := c.UpdateWithOptions(v1.NamespaceDefault, objBody(&original), objBody(&target), opts); err != nil {
+		t.Fatal(err)
+	}
+
+	// the image is reverted to the one of the manifest, the label added
+	// outside of Helm is kept
+	expected := `{"spec":{"$setElementOrder/containers":[{"name":"app:v4"}],"containers":[{"$setElementOrder/ports":[{"containerPort":443}],"image":"abc/app:v4","name":"app:v4","ports":[{"containerPort":443,"name":"https"},{"$patch":"delete","containerPort":80}]}]}}`
+	if patch != expected {
+		t.Errorf("expected patch\n%s\ngot\n%s", expected, patch)
+	}
+
+	// without changes to the manifest, the live object is still patched
+	patch = ""
+	if err := c.UpdateWithOptions(v1.NamespaceDefault, objBody(&original), objBody(&original), opts); err != nil {
+		t.Fatal(err)
+	}
+	expected = `{"spec":{"$setElementOrder/containers":[{"name":"app:v4"}],"containers":[{"image":"abc/app:v4","name":"app:v4"}]}}`
+	if patch != expected {
+		t.Errorf("expected patch\n%s\ngot\n%s", expected, patch)
+	}
+}
+
+func TestCreatePatchThreeWayUnstructured(t *testing.T) {
+	newWidget := func(fields map[string]interface{}) *unstructured.Unstructured {
+		obj := map[string]interface{}{
+			"apiVersion": "example.com/v1",
+			"kind":       "Widget",
+			"metadata":   map[string]interface{}{"name": "gadget", "namespace": "default"},
+		}
+		for k, v := range fields {
+			obj[k] = v
+		}
+		return &unstructured.Unstructured{Object: obj}
+	}
+
+	original := newWidget(map[string]interface{}{"spec": map[string]interface{}{"size": "small", "color": "red"}})
+	target := newWidget(map[string]interface{}{"spec": map[string]interface{}{"size": "large"}})
+	live := newWidget(map[string]interface{}{
+		"spec":   map[string]interface{}{"size": "medium", "color": "red", "owner": "controller"},
+		"status": map[string]interface{}{"ready": true},
+	})
+
+	info := &resource.Info{
+		Name:      "gadget",
+		Namespace: "default",
+		Object:    target,
+		Mapping: &meta.RESTMapping{
+			GroupVersionKind: schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Widget"},
+		},
+	}
+
+	patch, patchType, err := createPatch(info, original, live)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if patchType != types.MergePatchType {
+		t.Errorf("expected patch type %s, got %s", types.MergePatchType, patchType)
+	}
+	expected := `{"spec":{"color":null,"size":"large"}}`
+	if string(patch) != expected {
+		t.Errorf("expected patch\n%s\ngot\n%s", expected, string(patch))
+	}
+
+	// the two-way patch ignores the live object
+	patch, _, err = createPatch(info, original, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(patch) != expected {
+		t.Errorf("expected patch\n%s\ngot\n%s", expected, string(patch))
+	}
+}
+
 func TestBuild(t *testing.T) {
 	tests := []struct {
 		name      string
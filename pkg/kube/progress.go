@@ -0,0 +1,65 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kube // import "k8s.io/helm/pkg/kube"
+
+import (
+	"k8s.io/cli-runtime/pkg/genericclioptions/resource"
+)
+
+// EventType is the type of a progress Event.
+type EventType int
+
+const (
+	// EventResourceCreated is sent when a resource has been created.
+	EventResourceCreated EventType = iota
+	// EventResourceUpdated is sent when a resource has been patched.
+	EventResourceUpdated
+	// EventResourceDeleted is sent when a resource has been deleted.
+	EventResourceDeleted
+	// EventWaiting is sent while waiting for a resource to be ready.
+	EventWaiting
+)
+
+// Event reports the progress of an operation on resources.
+type Event struct {
+	Type      EventType
+	Kind      string
+	Namespace string
+	Name      string
+	// Ready and Total count the ready and expected replicas of the resource
+	// waited for, when applicable.
+	Ready   int32
+	Total   int32
+	Message string
+}
+
+// ProgressFunc receives the progress Events of an operation. It is called
+// synchronously and must not block.
+type ProgressFunc func(Event)
+
+// send sends the event for the resource described by info, if p is set.
+func (p ProgressFunc) send(t EventType, info *resource.Info) {
+	if p == nil {
+		return
+	}
+	p(Event{
+		Type:      t,
+		Kind:      info.Mapping.GroupVersionKind.Kind,
+		Namespace: info.Namespace,
+		Name:      info.Name,
+	})
+}
@@ -17,17 +17,22 @@ limitations under the License.
 package kube // import "k8s.io/helm/pkg/kube"
 
 import (
+	"fmt"
 	"time"
 
 	appsv1 "k8s.io/api/apps/v1"
 	appsv1beta1 "k8s.io/api/apps/v1beta1"
 	appsv1beta2 "k8s.io/api/apps/v1beta2"
+	batchv1 "k8s.io/api/batch/v1"
 	"k8s.io/api/core/v1"
 	extensions "k8s.io/api/extensions/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/cli-runtime/pkg/genericclioptions/resource"
 	"k8s.io/client-go/kubernetes"
 	deploymentutil "k8s.io/kubernetes/pkg/controller/deployment/util"
 )
@@ -38,20 +43,57 @@ type deployment struct {
 	deployment  *appsv1.Deployment
 }
 
-// waitForResources polls to get the current status of all pods, PVCs, and Services
-// until all are ready or a timeout is reached
-func (c *Client) waitForResources(timeout time.Duration, created Result) error {
+// waiter checks the readiness of resources, remembering the last resource
+// found not ready so that a timeout can be explained.
+type waiter struct {
+	log func(string, ...interface{})
+	// blocking describes the last resource found not ready.
+	blocking string
+	// waiting is the progress event describing the last resource found not ready.
+	waiting Event
+}
+
+// notReady records that the resource of the given kind, described by format
+// and args, is not ready. ready and total count its ready and expected
+// replicas, or are 0 when not applicable.
+func (w *waiter) notReady(kind string, obj metav1.Object, ready, total int32, format string, args ...interface{}) bool {
+	w.blocking = fmt.Sprintf(format, args...)
+	w.log("%s", w.blocking)
+	w.waiting = Event{
+		Type:      EventWaiting,
+		Kind:      kind,
+		Namespace: obj.GetNamespace(),
+		Name:      obj.GetName(),
+		Ready:     ready,
+		Total:     total,
+		Message:   w.blocking,
+	}
+	return false
+}
+
+// waitForResources polls to get the current status of all pods, PVCs, Services,
+// Deployments, StatefulSets, DaemonSets, Jobs, Ingresses and custom resources
+// until all are ready or a timeout is reached. The resource being waited for
+// is reported to progress, if set, each time it changes.
+func (c *Client) waitForResources(timeout time.Duration, created Result, progress ProgressFunc) error {
 	c.Log("beginning wait for %d resources with timeout of %v", len(created), timeout)
 
 	kcs, err := c.KubernetesClientSet()
 	if err != nil {
 		return err
 	}
-	return wait.Poll(2*time.Second, timeout, func() (bool, error) {
+	w := &waiter{log: c.Log}
+	var reported Event
+	err = wait.Poll(2*time.Second, timeout, func() (bool, error) {
 		pods := []v1.Pod{}
 		services := []v1.Service{}
 		pvc := []v1.PersistentVolumeClaim{}
 		deployments := []deployment{}
+		statefulSets := []appsv1.StatefulSet{}
+		daemonSets := []appsv1.DaemonSet{}
+		jobs := []batchv1.Job{}
+		ingresses := []extensions.Ingress{}
+		customResources := []*unstructured.Unstructured{}
 		for _, v := range created {
 			switch value := asVersioned(v).(type) {
 			case *v1.ReplicationController:
@@ -127,41 +169,41 @@ func (c *Client) waitForResources(timeout time.Duration, created Result) error {
 				}
 				deployments = append(deployments, newDeployment)
 			case *extensions.DaemonSet:
-				list, err := getPods(kcs, value.Namespace, value.Spec.Selector.MatchLabels)
+				ds, err := kcs.AppsV1().DaemonSets(value.Namespace).Get(value.Name, metav1.GetOptions{})
 				if err != nil {
 					return false, err
 				}
-				pods = append(pods, list...)
+				daemonSets = append(daemonSets, *ds)
 			case *appsv1.DaemonSet:
-				list, err := getPods(kcs, value.Namespace, value.Spec.Selector.MatchLabels)
+				ds, err := kcs.AppsV1().DaemonSets(value.Namespace).Get(value.Name, metav1.GetOptions{})
 				if err != nil {
 					return false, err
 				}
-				pods = append(pods, list...)
+				daemonSets = append(daemonSets, *ds)
 			case *appsv1beta2.DaemonSet:
-				list, err := getPods(kcs, value.Namespace, value.Spec.Selector.MatchLabels)
+				ds, err := kcs.AppsV1().DaemonSets(value.Namespace).Get(value.Name, metav1.GetOptions{})
 				if err != nil {
 					return false, err
 				}
-				pods = append(pods, list...)
+				daemonSets = append(daemonSets, *ds)
 			case *appsv1.StatefulSet:
-				list, err := getPods(kcs, value.Namespace, value.Spec.Selector.MatchLabels)
+				sts, err := kcs.AppsV1().StatefulSets(value.Namespace).Get(value.Name, metav1.GetOptions{})
 				if err != nil {
 					return false, err
 				}
-				pods = append(pods, list...)
+				statefulSets = append(statefulSets, *sts)
 			case *appsv1beta1.StatefulSet:
-				list, err := getPods(kcs, value.Namespace, value.Spec.Selector.MatchLabels)
+				sts, err := kcs.AppsV1().StatefulSets(value.Namespace).Get(value.Name, metav1.GetOptions{})
 				if err != nil {
 					return false, err
 				}
-				pods = append(pods, list...)
+				statefulSets = append(statefulSets, *sts)
 			case *appsv1beta2.StatefulSet:
-				list, err := getPods(kcs, value.Namespace, value.Spec.Selector.MatchLabels)
+				sts, err := kcs.AppsV1().StatefulSets(value.Namespace).Get(value.Name, metav1.GetOptions{})
 				if err != nil {
 					return false, err
 				}
-				pods = append(pods, list...)
+				statefulSets = append(statefulSets, *sts)
 			case *extensions.ReplicaSet:
 				list, err := getPods(kcs, value.Namespace, value.Spec.Selector.MatchLabels)
 				if err != nil {
@@ -192,24 +234,56 @@ func (c *Client) waitForResources(timeout time.Duration, created Result) error {
 					return false, err
 				}
 				services = append(services, *svc)
+			case *batchv1.Job:
+				job, err := kcs.BatchV1().Jobs(value.Namespace).Get(value.Name, metav1.GetOptions{})
+				if err != nil {
+					return false, err
+				}
+				jobs = append(jobs, *job)
+			case *extensions.Ingress:
+				ing, err := kcs.ExtensionsV1beta1().Ingresses(value.Namespace).Get(value.Name, metav1.GetOptions{})
+				if err != nil {
+					return false, err
+				}
+				ingresses = append(ingresses, *ing)
+			case *unstructured.Unstructured:
+				obj, err := resource.NewHelper(v.Client, v.Mapping).Get(v.Namespace, v.Name, false)
+				if err != nil {
+					return false, err
+				}
+				if u, ok := obj.(*unstructured.Unstructured); ok {
+					customResources = append(customResources, u)
+				}
 			}
 		}
-		isReady := c.podsReady(pods) && c.servicesReady(services) && c.volumesReady(pvc) && c.deploymentsReady(deployments)
+		if err := w.jobsFailed(jobs); err != nil {
+			return false, err
+		}
+		isReady := w.podsReady(pods) && w.servicesReady(services) && w.volumesReady(pvc) && w.deploymentsReady(deployments) &&
+			w.statefulSetsReady(statefulSets) && w.daemonSetsReady(daemonSets) && w.jobsReady(jobs) &&
+			w.ingressesReady(ingresses) && w.customResourcesReady(customResources)
+		if !isReady && progress != nil && w.waiting != reported {
+			reported = w.waiting
+			progress(reported)
+		}
 		return isReady, nil
 	})
+	if err == wait.ErrWaitTimeout && w.blocking != "" {
+		return fmt.Errorf("%s: %s", err, w.blocking)
+	}
+	return err
 }
 
-func (c *Client) podsReady(pods []v1.Pod) bool {
+func (w *waiter) podsReady(pods []v1.Pod) bool {
 	for _, pod := range pods {
 		if !isPodReady(&pod) {
-			c.Log("Pod is not ready: %s/%s", pod.GetNamespace(), pod.GetName())
-			return false
+			return w.notReady("Pod", &pod, 0, 0, "Pod is not ready: %s/%s", pod.GetNamespace(), pod.GetName())
 		}
 	}
 	return true
 }
 
-func (c *Client) servicesReady(svc []v1.Service) bool {
+func (w *waiter) servicesReady(svc []v1.Service) bool {
 	for _, s := range svc {
 		// ExternalName Services are external to cluster so helm shouldn't be checking to see if they're 'ready' (i.e. have an IP Set)
 		if s.Spec.Type == v1.ServiceTypeExternalName {
@@ -218,33 +292,150 @@ func (c *Client) servicesReady(svc []v1.Service) bool {
 
 		// Make sure the service is not explicitly set to "None" before checking the IP
 		if s.Spec.ClusterIP != v1.ClusterIPNone && s.Spec.ClusterIP == "" {
-			c.Log("Service is not ready: %s/%s", s.GetNamespace(), s.GetName())
-			return false
+			return w.notReady("Service", &s, 0, 0, "Service is not ready: %s/%s", s.GetNamespace(), s.GetName())
 		}
 		// This checks if the service has a LoadBalancer and that balancer has an Ingress defined
 		if s.Spec.Type == v1.ServiceTypeLoadBalancer && s.Status.LoadBalancer.Ingress == nil {
-			c.Log("Service is not ready: %s/%s", s.GetNamespace(), s.GetName())
-			return false
+			return w.notReady("Service", &s, 0, 0, "Service is not ready: %s/%s", s.GetNamespace(), s.GetName())
 		}
 	}
 	return true
 }
 
-func (c *Client) volumesReady(vols []v1.PersistentVolumeClaim) bool {
+func (w *waiter) volumesReady(vols []v1.PersistentVolumeClaim) bool {
 	for _, v := range vols {
 		if v.Status.Phase != v1.ClaimBound {
-			c.Log("PersistentVolumeClaim is not ready: %s/%s", v.GetNamespace(), v.GetName())
-			return false
+			return w.notReady("PersistentVolumeClaim", &v, 0, 0, "PersistentVolumeClaim is not ready: %s/%s", v.GetNamespace(), v.GetName())
 		}
 	}
 	return true
 }
 
-func (c *Client) deploymentsReady(deployments []deployment) bool {
+func (w *waiter) deploymentsReady(deployments []deployment) bool {
 	for _, v := range deployments {
 		if !(v.replicaSets.Status.ReadyReplicas >= *v.deployment.Spec.Replicas-deploymentutil.MaxUnavailable(*v.deployment)) {
-			c.Log("Deployment is not ready: %s/%s", v.deployment.GetNamespace(), v.deployment.GetName())
-			return false
+			return w.notReady("Deployment", v.deployment, v.replicaSets.Status.ReadyReplicas, *v.deployment.Spec.Replicas, "Deployment is not ready: %s/%s", v.deployment.GetNamespace(), v.deployment.GetName())
+		}
+	}
+	return true
+}
+
+func (w *waiter) statefulSetsReady(statefulSets []appsv1.StatefulSet) bool {
+	for _, sts := range statefulSets {
+		if sts.Status.ObservedGeneration < sts.Generation {
+			return w.notReady("StatefulSet", &sts, 0, 0, "StatefulSet is not ready: %s/%s. Update has not been observed yet", sts.GetNamespace(), sts.GetName())
+		}
+		replicas := int32(1)
+		if sts.Spec.Replicas != nil {
+			replicas = *sts.Spec.Replicas
+		}
+		// Pods of StatefulSets using the OnDelete strategy are only updated
+		// when deleted, there is no rollout to wait for, only ready pods.
+		rollingUpdate := sts.Spec.UpdateStrategy.Type == appsv1.RollingUpdateStatefulSetStrategyType
+		partition := int32(0)
+		if ru := sts.Spec.UpdateStrategy.RollingUpdate; rollingUpdate && ru != nil && ru.Partition != nil {
+			partition = *ru.Partition
+		}
+
+		// Only the Pods with an ordinal greater than or equal to the
+		// partition are updated.
+		if expected := replicas - partition; rollingUpdate && sts.Status.UpdatedReplicas < expected {
+			return w.notReady("StatefulSet", &sts, sts.Status.UpdatedReplicas, expected, "StatefulSet is not ready: %s/%s. %d out of %d expected pods have been updated", sts.GetNamespace(), sts.GetName(), sts.Status.UpdatedReplicas, expected)
+		}
+		if sts.Status.ReadyReplicas != replicas {
+			return w.notReady("StatefulSet", &sts, sts.Status.ReadyReplicas, replicas, "StatefulSet is not ready: %s/%s. %d out of %d expected pods are ready", sts.GetNamespace(), sts.GetName(), sts.Status.ReadyReplicas, replicas)
+		}
+		if rollingUpdate && partition == 0 && sts.Status.CurrentRevision != sts.Status.UpdateRevision {
+			return w.notReady("StatefulSet", &sts, sts.Status.UpdatedReplicas, replicas, "StatefulSet is not ready: %s/%s. Rolling update to revision %s is in progress", sts.GetNamespace(), sts.GetName(), sts.Status.UpdateRevision)
+		}
+	}
+	return true
+}
+
+func (w *waiter) daemonSetsReady(daemonSets []appsv1.DaemonSet) bool {
+	for _, ds := range daemonSets {
+		if ds.Status.ObservedGeneration < ds.Generation {
+			return w.notReady("DaemonSet", &ds, 0, 0, "DaemonSet is not ready: %s/%s. Update has not been observed yet", ds.GetNamespace(), ds.GetName())
+		}
+		// Pods of DaemonSets using the OnDelete strategy are only updated
+		// when deleted, there is no rollout to wait for, only ready pods.
+		rollingUpdate := ds.Spec.UpdateStrategy.Type == appsv1.RollingUpdateDaemonSetStrategyType
+
+		desired := ds.Status.DesiredNumberScheduled
+		if rollingUpdate && ds.Status.UpdatedNumberScheduled != desired {
+			return w.notReady("DaemonSet", &ds, ds.Status.UpdatedNumberScheduled, desired, "DaemonSet is not ready: %s/%s. %d out of %d expected pods have been scheduled", ds.GetNamespace(), ds.GetName(), ds.Status.UpdatedNumberScheduled, desired)
+		}
+
+		maxUnavailable := int32(0)
+		if ru := ds.Spec.UpdateStrategy.RollingUpdate; rollingUpdate && ru != nil && ru.MaxUnavailable != nil {
+			v, err := intstr.GetValueFromIntOrPercent(ru.MaxUnavailable, int(desired), true)
+			if err == nil {
+				maxUnavailable = int32(v)
+			}
+		}
+		if expected := desired - maxUnavailable; ds.Status.NumberReady < expected {
+			return w.notReady("DaemonSet", &ds, ds.Status.NumberReady, expected, "DaemonSet is not ready: %s/%s. %d out of %d expected pods are ready", ds.GetNamespace(), ds.GetName(), ds.Status.NumberReady, expected)
+		}
+	}
+	return true
+}
+
+// jobsFailed returns an error if one of the jobs failed, as waiting longer
+// would not make it complete.
+func (w *waiter) jobsFailed(jobs []batchv1.Job) error {
+	for _, job := range jobs {
+		for _, c := range job.Status.Conditions {
+			if c.Type == batchv1.JobFailed && c.Status == v1.ConditionTrue {
+				return fmt.Errorf("Job failed: %s/%s: %s", job.GetNamespace(), job.GetName(), c.Reason)
+			}
+		}
+	}
+	return nil
+}
+
+func (w *waiter) jobsReady(jobs []batchv1.Job) bool {
+	for _, job := range jobs {
+		completions := int32(1)
+		if job.Spec.Completions != nil {
+			completions = *job.Spec.Completions
+		}
+		if job.Status.Succeeded < completions {
+			return w.notReady("Job", &job, job.Status.Succeeded, completions, "Job is not ready: %s/%s. %d out of %d expected completions", job.GetNamespace(), job.GetName(), job.Status.Succeeded, completions)
+		}
+	}
+	return true
+}
+
+func (w *waiter) ingressesReady(ingresses []extensions.Ingress) bool {
+	for _, ing := range ingresses {
+		if len(ing.Status.LoadBalancer.Ingress) == 0 {
+			return w.notReady("Ingress", &ing, 0, 0, "Ingress is not ready: %s/%s. No load balancer address has been assigned", ing.GetNamespace(), ing.GetName())
+		}
+	}
+	return true
+}
+
+// customResourcesReady waits for the resources reporting a Ready condition in
+// their status to have it set to True. Resources without such a condition are
+// considered ready.
+func (w *waiter) customResourcesReady(resources []*unstructured.Unstructured) bool {
+	for _, u := range resources {
+		conditions, found, err := unstructured.NestedSlice(u.Object, "status", "conditions")
+		if err != nil || !found {
+			continue
+		}
+		for _, c := range conditions {
+			condition, ok := c.(map[string]interface{})
+			if !ok || condition["type"] != "Ready" {
+				continue
+			}
+			if condition["status"] != string(v1.ConditionTrue) {
+				msg := ""
+				if m, ok := condition["message"].(string); ok && m != "" {
+					msg = ": " + m
+				}
+				return w.notReady(u.GetKind(), u, 0, 0, "%s is not ready: %s/%s%s", u.GetKind(), u.GetNamespace(), u.GetName(), msg)
+			}
 		}
 	}
 	return true
@@ -0,0 +1,303 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kube
+
+import (
+	"strings"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	"k8s.io/api/core/v1"
+	extensions "k8s.io/api/extensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func int32Ptr(i int32) *int32 { return &i }
+
+func newTestWaiter() *waiter {
+	return &waiter{log: nopLogger}
+}
+
+func newStatefulSet(replicas, partition int32, status appsv1.StatefulSetStatus) appsv1.StatefulSet {
+	return appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "default", Generation: 2},
+		Spec: appsv1.StatefulSetSpec{
+			Replicas: int32Ptr(replicas),
+			UpdateStrategy: appsv1.StatefulSetUpdateStrategy{
+				Type:          appsv1.RollingUpdateStatefulSetStrategyType,
+				RollingUpdate: &appsv1.RollingUpdateStatefulSetStrategy{Partition: int32Ptr(partition)},
+			},
+		},
+		Status: status,
+	}
+}
+
+func TestStatefulSetsReady(t *testing.T) {
+	onDelete := func(sts appsv1.StatefulSet) appsv1.StatefulSet {
+		sts.Spec.UpdateStrategy = appsv1.StatefulSetUpdateStrategy{Type: appsv1.OnDeleteStatefulSetStrategyType}
+		return sts
+	}
+	tests := []struct {
+		name  string
+		sts   appsv1.StatefulSet
+		ready bool
+	}{
+		{
+			name:  "rolled out",
+			sts:   newStatefulSet(3, 0, appsv1.StatefulSetStatus{ObservedGeneration: 2, UpdatedReplicas: 3, ReadyReplicas: 3, CurrentRevision: "r2", UpdateRevision: "r2"}),
+			ready: true,
+		},
+		{
+			name: "generation not observed",
+			sts:  newStatefulSet(3, 0, appsv1.StatefulSetStatus{ObservedGeneration: 1, UpdatedReplicas: 3, ReadyReplicas: 3, CurrentRevision: "r2", UpdateRevision: "r2"}),
+		},
+		{
+			name: "pods not updated",
+			sts:  newStatefulSet(3, 0, appsv1.StatefulSetStatus{ObservedGeneration: 2, UpdatedReplicas: 2, ReadyReplicas: 3, CurrentRevision: "r1", UpdateRevision: "r2"}),
+		},
+		{
+			name: "pods not ready",
+			sts:  newStatefulSet(3, 0, appsv1.StatefulSetStatus{ObservedGeneration: 2, UpdatedReplicas: 3, ReadyReplicas: 2, CurrentRevision: "r2", UpdateRevision: "r2"}),
+		},
+		{
+			name: "revision not rolled out",
+			sts:  newStatefulSet(3, 0, appsv1.StatefulSetStatus{ObservedGeneration: 2, UpdatedReplicas: 3, ReadyReplicas: 3, CurrentRevision: "r1", UpdateRevision: "r2"}),
+		},
+		{
+			name:  "partitioned rollout",
+			sts:   newStatefulSet(3, 2, appsv1.StatefulSetStatus{ObservedGeneration: 2, UpdatedReplicas: 1, ReadyReplicas: 3, CurrentRevision: "r1", UpdateRevision: "r2"}),
+			ready: true,
+		},
+		{
+			name:  "on delete with stale pods",
+			sts:   onDelete(newStatefulSet(3, 0, appsv1.StatefulSetStatus{ObservedGeneration: 2, UpdatedReplicas: 0, ReadyReplicas: 3, CurrentRevision: "r1", UpdateRevision: "r2"})),
+			ready: true,
+		},
+		{
+			name: "on delete with pods not ready",
+			sts:  onDelete(newStatefulSet(3, 0, appsv1.StatefulSetStatus{ObservedGeneration: 2, UpdatedReplicas: 3, ReadyReplicas: 2, CurrentRevision: "r2", UpdateRevision: "r2"})),
+		},
+	}
+
+	for _, tt := range tests {
+		w := newTestWaiter()
+		if got := w.statefulSetsReady([]appsv1.StatefulSet{tt.sts}); got != tt.ready {
+			t.Errorf("%s: expected ready to be %t, got %t (%s)", tt.name, tt.ready, got, w.blocking)
+		}
+		if !tt.ready && !strings.Contains(w.blocking, "StatefulSet is not ready: default/db") {
+			t.Errorf("%s: expected the StatefulSet to be reported, got %q", tt.name, w.blocking)
+		}
+	}
+}
+
+func TestDaemonSetsReady(t *testing.T) {
+	maxUnavailable := intstr.FromInt(1)
+	newDaemonSet := func(status appsv1.DaemonSetStatus) appsv1.DaemonSet {
+		return appsv1.DaemonSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "agent", Namespace: "default", Generation: 2},
+			Spec: appsv1.DaemonSetSpec{
+				UpdateStrategy: appsv1.DaemonSetUpdateStrategy{
+					Type:          appsv1.RollingUpdateDaemonSetStrategyType,
+					RollingUpdate: &appsv1.RollingUpdateDaemonSet{MaxUnavailable: &maxUnavailable},
+				},
+			},
+			Status: status,
+		}
+	}
+	onDelete := func(ds appsv1.DaemonSet) appsv1.DaemonSet {
+		ds.Spec.UpdateStrategy = appsv1.DaemonSetUpdateStrategy{Type: appsv1.OnDeleteDaemonSetStrategyType}
+		return ds
+	}
+
+	tests := []struct {
+		name  string
+		ds    appsv1.DaemonSet
+		ready bool
+	}{
+		{
+			name:  "rolled out",
+			ds:    newDaemonSet(appsv1.DaemonSetStatus{ObservedGeneration: 2, DesiredNumberScheduled: 3, UpdatedNumberScheduled: 3, NumberReady: 3}),
+			ready: true,
+		},
+		{
+			name:  "within max unavailable",
+			ds:    newDaemonSet(appsv1.DaemonSetStatus{ObservedGeneration: 2, DesiredNumberScheduled: 3, UpdatedNumberScheduled: 3, NumberReady: 2}),
+			ready: true,
+		},
+		{
+			name: "generation not observed",
+			ds:   newDaemonSet(appsv1.DaemonSetStatus{ObservedGeneration: 1, DesiredNumberScheduled: 3, UpdatedNumberScheduled: 3, NumberReady: 3}),
+		},
+		{
+			name: "pods not updated",
+			ds:   newDaemonSet(appsv1.DaemonSetStatus{ObservedGeneration: 2, DesiredNumberScheduled: 3, UpdatedNumberScheduled: 1, NumberReady: 3}),
+		},
+		{
+			name: "pods not ready",
+			ds:   newDaemonSet(appsv1.DaemonSetStatus{ObservedGeneration: 2, DesiredNumberScheduled: 3, UpdatedNumberScheduled: 3, NumberReady: 1}),
+		},
+		{
+			name:  "on delete with stale pods",
+			ds:    onDelete(newDaemonSet(appsv1.DaemonSetStatus{ObservedGeneration: 2, DesiredNumberScheduled: 3, UpdatedNumberScheduled: 0, NumberReady: 3})),
+			ready: true,
+		},
+		{
+			name: "on delete with pods not ready",
+			ds:   onDelete(newDaemonSet(appsv1.DaemonSetStatus{ObservedGeneration: 2, DesiredNumberScheduled: 3, UpdatedNumberScheduled: 3, NumberReady: 2})),
+		},
+	}
+
+	for _, tt := range tests {
+		w := newTestWaiter()
+		if got := w.daemonSetsReady([]appsv1.DaemonSet{tt.ds}); got != tt.ready {
+			t.Errorf("%s: expected ready to be %t, got %t (%s)", tt.name, tt.ready, got, w.blocking)
+		}
+		if !tt.ready && !strings.Contains(w.blocking, "DaemonSet is not ready: default/agent") {
+			t.Errorf("%s: expected the DaemonSet to be reported, got %q", tt.name, w.blocking)
+		}
+	}
+}
+
+func TestJobsReady(t *testing.T) {
+	newJob := func(completions *int32, status batchv1.JobStatus) batchv1.Job {
+		return batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{Name: "migrate", Namespace: "default"},
+			Spec:       batchv1.JobSpec{Completions: completions},
+			Status:     status,
+		}
+	}
+
+	tests := []struct {
+		name   string
+		job    batchv1.Job
+		ready  bool
+		failed bool
+	}{
+		{
+			name:  "completed",
+			job:   newJob(nil, batchv1.JobStatus{Succeeded: 1}),
+			ready: true,
+		},
+		{
+			name: "running",
+			job:  newJob(nil, batchv1.JobStatus{Active: 1}),
+		},
+		{
+			name: "partially completed",
+			job:  newJob(int32Ptr(3), batchv1.JobStatus{Succeeded: 2}),
+		},
+		{
+			name: "failed",
+			job: newJob(nil, batchv1.JobStatus{
+				Failed:     1,
+				Conditions: []batchv1.JobCondition{{Type: batchv1.JobFailed, Status: v1.ConditionTrue, Reason: "BackoffLimitExceeded"}},
+			}),
+			failed: true,
+		},
+	}
+
+	for _, tt := range tests {
+		w := newTestWaiter()
+		jobs := []batchv1.Job{tt.job}
+		err := w.jobsFailed(jobs)
+		if tt.failed {
+			if err == nil || !strings.Contains(err.Error(), "default/migrate") {
+				t.Errorf("%s: expected the failed Job to be reported, got %v", tt.name, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", tt.name, err)
+		}
+		if got := w.jobsReady(jobs); got != tt.ready {
+			t.Errorf("%s: expected ready to be %t, got %t (%s)", tt.name, tt.ready, got, w.blocking)
+		}
+	}
+}
+
+func TestIngressesReady(t *testing.T) {
+	ing := extensions.Ingress{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"}}
+
+	w := newTestWaiter()
+	if w.ingressesReady([]extensions.Ingress{ing}) {
+		t.Error("expected Ingress without load balancer address not to be ready")
+	}
+	if !strings.Contains(w.blocking, "Ingress is not ready: default/web") {
+		t.Errorf("expected the Ingress to be reported, got %q", w.blocking)
+	}
+
+	ing.Status.LoadBalancer.Ingress = []v1.LoadBalancerIngress{{IP: "10.0.0.1"}}
+	if !w.ingressesReady([]extensions.Ingress{ing}) {
+		t.Error("expected Ingress with load balancer address to be ready")
+	}
+}
+
+func TestCustomResourcesReady(t *testing.T) {
+	newResource := func(status map[string]interface{}) *unstructured.Unstructured {
+		obj := map[string]interface{}{
+			"apiVersion": "example.com/v1",
+			"kind":       "Database",
+			"metadata":   map[string]interface{}{"name": "db", "namespace": "default"},
+		}
+		if status != nil {
+			obj["status"] = status
+		}
+		return &unstructured.Unstructured{Object: obj}
+	}
+	condition := func(typ, status string) map[string]interface{} {
+		return map[string]interface{}{"type": typ, "status": status, "message": "provisioning"}
+	}
+
+	tests := []struct {
+		name  string
+		obj   *unstructured.Unstructured
+		ready bool
+	}{
+		{
+			name:  "without status",
+			obj:   newResource(nil),
+			ready: true,
+		},
+		{
+			name:  "without ready condition",
+			obj:   newResource(map[string]interface{}{"conditions": []interface{}{condition("Synced", "False")}}),
+			ready: true,
+		},
+		{
+			name:  "ready",
+			obj:   newResource(map[string]interface{}{"conditions": []interface{}{condition("Ready", "True")}}),
+			ready: true,
+		},
+		{
+			name: "not ready",
+			obj:  newResource(map[string]interface{}{"conditions": []interface{}{condition("Ready", "False")}}),
+		},
+	}
+
+	for _, tt := range tests {
+		w := newTestWaiter()
+		if got := w.customResourcesReady([]*unstructured.Unstructured{tt.obj}); got != tt.ready {
+			t.Errorf("%s: expected ready to be %t, got %t", tt.name, tt.ready, got)
+		}
+		if !tt.ready && w.blocking != "Database is not ready: default/db: provisioning" {
+			t.Errorf("%s: expected the resource to be reported, got %q", tt.name, w.blocking)
+		}
+	}
+}
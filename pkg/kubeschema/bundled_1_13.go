@@ -0,0 +1,346 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by kubeschema/generate. DO NOT EDIT.
+
+package kubeschema
+
+func init() {
+	bundled["1.13"] = `
+H4sIAAAAAAAC/+y9yXIkOY4A+i96c1RpXmnaxp7VTSkps9SVi1qhzDqM1YHhToU48iC96fRIRY3p
+35/R94Ub6IxVcemuVDgWAiAIgiD4f2cxfiKUCMJodvbb/50RdvHy/2UXKCUXKF6SLCOMcrwgmeBI
+fnSx+hUl6TP69eJOQqGE/I25BEw5SzEXBBdoKFpi+f9ineKz384ywQldnL2dn/E8Kb8gAi+L//gP
+jp/Ofjv7f/6zw8l/urPxkCdYIq4oIc7R+uxNUsL/zgnH8dlv/1Oy89fbud/wrhl9Iou8/GI8VpSS
+H5hn1W+jEZMWUdCBd+U/HP/52esvL/kcc4oFzn5JkYief1livsC/vOD12W+lQJRfFYTwQn5UAEjc
+L4TGyrEtsUAxEshhOEsUPROK+foifVnIP2QXEvpi9evFt/n/4kh8wQIViusxteAsT39ZlfL9pWTk
+f/7vrPizHK5SRpIkYWc142daXZ6frWrNndWCPXsLYyifSSbgxlIbxwaspD/00ZTZuJqlQBold2dn
+Oda/tqn5QjkhtF+4H5WWP0k++6ocyXWogNY4gIAcZyznEQaBvbkNdI4F+vXiSy6QIHTxJ54/M/Yy
+zSVu0Z+cn/0sOQ4zq0phVELYrNvdiCc0qrE/H4qhOk8Hu5Xs2B/aGXwnLtGqo+lmIJ3in0Q8f0tx
++Uu2exfJerzsk2udYb4iEX7AT5hjGmFANC1/yFIUqX9NkXhW/KCIjUsc56A4uWT+B0pIfFoZDn9l
+sChyulMwE9iL1cEig/exPjjoaboxVKjHGo8SgqkoSQabjtddpG/nZ0+IJDnH9ywh0VrrWM0ed4YT
+HAnGJyoYzXHSoAqaH9EuxQozzkiMb5+ecCQytwXj7LyvKR/lXw9UPTAE9CGncbm5emJ8icTZb2fz
+tZCkR1rJyiV0urhGa/Hb+VnOE5VQBiNO00wq9JpRwVmSYP6AVyTzWQO93AbPqSBLfPGAft6+Ckyz
+ymFtdUHlnSE3OiNU/Pc/WqURKvAC85FVNbBQp5amWeu8FOLvO6uxn9IqbltLkt5yDn7BsehGsZho
+9XOD8JLRGRb7HVNmKY4gWm+GNZOAEoFAIs/8UJSgb29TtNTKGaqYa0Zjoo76E5SJR45oVvz+SJZ4
+mswLDIUGswwtNCcNGGUai2hlrNnH2ZbA4tcGj00wW3YkrQaPy3/05Qm1zlk1MftKWBL6gFG8nuGI
+0TgbLlz/dalYuNp17neSCcbXn8mSCEfQbDNho8DLNEHCKQKKGMcSyT2LHyuw2vnkaSz/1ew4PYzu
+ex/F0A6a8Xd4tqquma6DEJElSaGHa5ZTVwVEtZeaOLlabwfe61few/HwLco5x1R8zZdzzGfRM47z
+BMeOo41xJiXvB0wLqKsVIgmaJxgE9YVkmRe5YjqCIL5TBGSRzeWGAcefMMVtosoasdbzw0ucg3mg
+0apSfFo19mVmm0bfR5O7P51kbEboovwMMiceuoCK1cd173SD04Stl5geW4zXjGtCkNfiCBLltaJ2
+XUgbiB3EeRJvaV7vJXZspL3t4LEhfGzRY1+iYKsPGT+mKM9wV5BzxhKMilAi5WzBcZbdYBQnhGJo
+ZJomJEKHG8hmPrFnxzdW0LboimOBCP0Dr7Ng0fOEQHfo28fTvQ5wHmD63UmErFgoNhwie8dzXM7d
+B+icAX2eU1/lVcEmCOrNbmEbDv8U64dr/FeN9OhyfO24/OO/Do4Q8V+LznUlbCFOeT6dZLYcq7WE
+jyxWG0gUbKFhc30gp73P+T1dhGKXZ/CwZFKEoXJFG44wnvIkWRdaAS7I+xycjE5gK1idPajTPON5
+hl4HKTnQRMgFSS4IFZngF3dUfOMzS9SgCT9UfM1yvgjI0flOxypnJX7Kk2KC6qK6FHFBVEYHCR87
+lI4rMuuK0Ds06+khQGzWlbbj0tcBOUVnWtFsOTzr6vG44rOhTOFWqo7QUhZ/QRQtsHTghuq8w89y
+lWVmX3VVhjs/zu2oanige362Ykm+xNcJIsuaImyGNCxLq8kEpuJHi9J6mVOVWeuL1MH+9uhIWem8
+t3OoDI3ba6i2zG9kunudiytM2ci+V9INFkfr59aGcnKqQBGanCvLY0/Vrftd3aopxTercLthkdaQ
+3mGxq4O6jrQmYjg4793XCNFGqyNACjvVSWx8dzcU+S5c2fusmADNBBmNzFH0Es6F6fvLVKQeGViR
+NZftPbEqGLyilIn27jCKy2mNkvveWDR2fsYKR6m7RtVhOJC2GmlP0Nip2mUAGtKs9qd0Zrx67k39
+jKVoRrPuH3LljD5+OJXP7LR8RjtLgu3XW08RopBG6XfGvIK2sxZC7+QATjHgrZ3ClbRnEUpwuDhq
+b7aCxbgm7gJLHEGO4Aopg+OndhCK2RbIDXUHOY1KPxDxjWrPzwTiCyy6/RDMGztLfrQa5pEeOY9G
+N9Xmt3b47GL/p2NoV6PeRabivR5Iwyz3dDS910fTI33t3fk06FBa58X34WTa4NRPx9PHejxtm1+b
+3POGOqi+PB1UH8BB9aVpSb7ci4Pqy9NBtau6jrM/02BskzZrl1vq1ARR1mmbZpLOLhzOe+zeBLHY
+Ux+nTe+uLjfQzEnt//Zlk3N5aut0aut0WG2dzBM16CbtMlSDp4rn461ovAxV0Xi51YpG29J7qmjc
+egS6w4rGy3dc0eg8E071cfvaDWrsPg+wJZRmDTj06rbLU3Xbfla3XW68uu0yaHXb5bH2ihoObmIU
+ua2uUQ5r56l/1JmbeHYR8b3PTlIgqz31lArQU0rjlfaksZTeUZ26S226u5Qx0bTjFlPm+OVIy9wv
+d1jmfnmkZe6XAcrcL7dQ5m5ZFDdf5n75PsrcL4+6zP0yWJn75ZbL3G32fypzdzXqXWxm3nOZu7vl
+nsrc977M/fIo27DpHPr+FIOcKt7fXcX75bYq3i9DVrznMRGDZ4xRkj6jXy+u5E8zQl+OJKp0GGmT
+jwKtryPEw+fQW0n2V9uS/Hi5deB0W0GRi3kcfIDkrkBFyDRBibr4qQ6ZJminirvezs9+ts/kT8BX
+P7Y/FHjFbEvGXQxtaDjY9OAVTnT7j8UgPtHtudXxRonancXRU+4jZu2P/Ct/TZF4dnslv8RR4gNw
+/mer9UFENHgxf7pN9N7glxp45kyIBIfB/lhhq/EPiyW7xMHiuR4IYyAq9CGn8aB0dL4uYtSxbZam
+MnHQI4OT0QtPJq3jakGORjvPeSYcw7p/p5lXy5dcPGMqSFRzePHIXjCVsRv+eSxRhmGIHgksI7o2
+i9XbVEkq4CWwR2e4/nXVZGvUbhXAWM95TKSxgzz7+ZmQeF1mhl2EoVjq0NJV42HOlZnf87M8w9zP
+Jr5nmN/RJ+Yw9ubT0ZDxq+DIlN4GaGaY9y7MDIgkJ7FWTJpV1zb68rrw8fuc0TgDOJ4xzj3wPpqu
+HG7y2LUf0kn0sJ1ROSp3jzT4/p24JcbJ341P/swilMzygq2rKMJZdmzeqTdcxUj9/JMVa1gP1ZIb
+OiitAh1ipd4gvjL6gDOW8whfCcHJPK/y8cMaCeXusaA29zDADtmHXFUzQdsPvj98Bs4YydSUTXsJ
+bxOdi9wqbUJ6QZu38bwiqvwxy+fG3zXK6tgMWJFmLaKUfPLwePUg5HmMJ+heWswMJ0/vyOuqR3vg
+nlenQ6jjNUnH5A373gYsOrW/70ycifhVyN/cRSHdyLuZFZ3BhpsUXaQ7mBNdBU6YEkPRKLPgulXS
+am+nwPdw3W8I1+vqdvdtJ3jYy4B5JxtmHmsTGknCfupyEjGmRPcbXqEkLyjeajMX2vrFwWyomXC0
+z7EnHxuoA3eERmyZJlhg9QhpfysGPa23bOwMIXoAamZSo4KaLl3FwHuysiipTCK9rzxKOeaNrCl6
+1HuWUdHnf8fD2U1uZUT7UBMsVavVU5ZlIIlTqsXVdt5VvsU45GPw0G6ZF4iD3k0OxrQ4BIvAtc7z
+7Q0omaNPyZhGHHje7G1yxnPWbDJNo3U4p+D68Fx3WLe9N/mbHaVvdryChE7k2OzzoLM5Fv+//ykd
+9X5yo3kdxX5n08kdlkVIXnCSDv2asyyrFpTSuRvK8QO/BT0YVgGuK8XvMf27FCCjAiX3LL6qfsM8
+HMM7Xiwdxuq1Urrg9by73yJvV0KdmuxnGA6Mbu2KlpPlHf4lLXf9KW5peepQ07ELvQLvtBY9vkAQ
+Bf3Hol/IA35y0ATEbzatSK7vv38XJKmc/T3mEaai6gwBvSI74Pi8JyY/6etudZdXlicx73tduuq3
+DoRKUCaKdjThenh43slWN5tvhjMeoFlzx9RkSTGyyYvYpEZLKpen6rdkc26barakH2rIdkueLZK6
+zF2WMewBhpIV5/IhOE5R8gULTqJZc9wxWJeKX7X9SMqfZxvqBFk4/qsV5miBf6Ak93JzF/XO4eJf
+OaKCiHWLOyjSgTo6koNrw7xKbUYkFfLAWHdnQHp9DMbqoJ73s/kyD3ji4mVBvu1t2KU2KenK8qnR
+Glhku9jDWmbx+97ITp8Fwba0hYuerudqEa181VC1W984O4SKwTa+Th5W0dPMrz2Zj6NUaKRajb+E
+VX+1mmjJnXbp5l16r2udg811Zp3qoK4Ib31VqtysFLKQ88gXaxMu9XCmLPY2vXsWZ0N83VoyH5z1
+MccQL2DZdtee7tAqrP6aqRlQfw3OQPpr8AXW38AthdKfwpYV7xdsYt9o2eFlm0wObGQt3kZ2oGL/
+vL8t7dKF6lz7ZsXR5AoOz5KclA7NRYzWGGjSLju0dJ0pk6OgCpPhLlJtu1CQQzoMKkVlZOLez7Cn
+us6xm2NovTV7c82oKxd6i2HBh71Rw3Q7aaATrObyYI9MLkFHJn7ryWUVh9/FmAryRPDkFarGWCYT
+NG6g8aMeUjCb+TSmC5tqI+bQktXJoubdQRjv7GDgcpMHA5f7dTBw6ZASvTwdDEAPBi737mDg8nQw
+oD8YmDQL9utg4HIvDwYut3gwcLmzg4HL3R8MXJ4OBnZxMKAIvNy3iNvYCbtG/5s737jcyPnGZfDz
+jcsNnG9cbuV843Kj5xuXGznfuAx+vnG5gfONy62cbwx2sbosNzybspn0uGbo52erTR4pgCTZ3Vrv
+uzjDSs0uIZeztBhnESdzHH+bNMUtB1EHlUsaiuR8eODhYp0uZ1qbyC0dpj5tGazxuBxU4HrAdLwp
+TufjoWNPbwY54dmIVmn3+M9jKFvUq8+hynBEc/lEp9zL/JPNjyO53B0RPHvcg/ZLDxco2rSYFKzl
+DlSXqCXBe8/ZPODW/l3ljLti3lJSuEvy4LO+Y8N2ub08mo9jqUeCrPANRnFCKJ5hmQXKHJ93m6Po
+hT09QZ7Yrpo51MlHB4glojlKZuOLfp3WGSniKElwQrLlbp/6DvSUtxBJpYmrJ4H5R0JJ9oxjp7EN
+J2XNkckydDvGwjbAmg3nyTwz1WqXvuFXtZ8QSRxVVPhILsLJKcujCOPY1ULUltCUvtGjC0d6Q/OP
+S/poggQotbhd+mop2djyWtqjfWyLalek3gpRL7MRo+UmIFq37xmPZFT6kH+yefY7yQTja8jS+r9s
+/ghYfvrM/7MFbuZH9IzjqmutKljkgtCFX+BQ+Ksse8oTz7FmeZZiquwXNjpbrUbRF5CTJq3LovP0
+qdd6VVn6YAKVZ4gl06FWCKPHH6pelRjaow2leiyX1SPGx7h8DcbmvX4N8WxuAbvUPHavZmS7S9iA
++LtZw2A6ObBVrOb+tIzpJ/rRrmM65R/WQhZJPp+KV3izNhJp/zgjC0ro4gH/O8ce/nIvFzjYmOEL
+HxC/34LYIzLsoaxXoMsGA8a/JXv9PY2RwDtPM/tXkMCksaWoAjhtDz3a8DN25x21h0PY+/ffeOux
+m5Bgvhb47HwMq2/RjRZQ5k0vjQ9745UcTlOG7vC1BXASgWfO19dVjgQ3XJYZ4zGhgxfEMMqOpJul
+fnxVlAL0D110o9e6CrE5+QEtV9ty63q1H7wLt6rI3V2bjUex4yh4nx6DfCERZ3Ug8sySGPOySEao
+t6SJ5OkmLwvoNZs8XU2/BG2P6V3BOKb4Z9CBjhxTuRW7+nN2K8M7En1IWPQyE4zjHyzJl1hX2fOU
+PepKWVPEBQGUhnKM4m80WauPZVcFG3c39hWo+fIvzSCfCrmtVQ88xbj7q+sO9msXrizA9kFzz+Ih
+FiqIL6YuqFbfQiC5rS51rKphXZEI3+ueJwTVLXVw6RTzd87xDclezCYXFRa++MJitd3FJHvRdk6Q
+P35/uFP+ZrBlrVM2Ge2w+rXmq+XCJIiPJMH30mtmQjb/MIrEPHUyHHEsDM0k6p/1zyFmz4jjr07q
+7lDrwtmGuskBbor5D4TGEsteB2/uJZbahJy6Sw0wVGjjglpslnqjmp3r2Z3rPIgYFZwlCeb3+Twh
+2fOs0Kfj1cuaYAPUJiRjTlaYQ72GXE8CMyJRzgRa4FAIXVbd/jNSuq2wbhPZ7nhLbL8jGicOc7GS
++QBMZyQoRXOSkJqZwXyMY9h+N+YM9LSXZn29xunzx5mr9S4ZJYJx4NZc+3iwi8+UntewKEw3L917
+YIPK83rkf5nkeOTSK96fVhyGBBIhoTHmrqZocGougpluN+HC/XLg+zRcnaIDjjkhmIq7+2tGn4gi
+PhFkiVkuIJtXnYdjy5RRTDtZsBE1rH3GzpT232wJ+Yh/7cGmOZTzzTDqxbfh0tItxp5vvgHiUCmu
+gWIfbGvZPSX1g0/tadXhclegFYp0QF9QClbEnFDE1zeVUHQxp/UEYBSDxhaMdgyHMYNquUMVdUtX
+4Ft8LC0lqUyCWOj9gdfd+xh9ioWDc088WTgZtPbD6zObMLbuQmqtHZPz6MoSao0ys1r+Q2eUkqcE
+i/KjP6D2Qo1Zr7o5gEnb6lNWRQq0JHNep0NHfNtEcc+ZdAzKCMvf5P7A60dWJGUVJreRCW+OxGP8
+hPJE1Mldh2ODwxi7QHLeKXwJXwB3rBFbLhEF5jMwXXmJ6JaufiAOj0krI3eMSTFdfeRs6cuhhB12
+BWqHTpa6HUbxy32eJIb604Q84WgdJaBbeJ8boALDClOcZcWFX9AhTgFgMsWUceG90pQWec+4sKk3
+IZn4ZYlSqdus6ADWgz6XJi1YxJKzv1SQFeplEYxY7GaI2dGA5CadTBNy7etBYmweGC99/RJTkVVp
+gZwTsZZixq8CmAvpgUpsIiZUk3+QP32jkeYZeIH5sjpS/1JutbVneYpP9bNCCGO++KY46vOzzB8d
+DHC/0zlkdDaekucvLKdiCssFAjjHSwkGY/gn4y+yzpxwx8PXvyzL0l3tIsfxPnBxysjf+MNa4Myn
+sWBJz8Zs4RmUJz+9nx0ih2eWibt75bjkTwBMeg9de0arovoDsAlB7sQVGuM5pdWBJHglKFA+VAg6
+DgHH/ugeWxzSbhERk7j7s0JgjbN6YxlJqbiogeMrsYFrBFY56C1Xmfg9P8OvRFy7h8NP1f3+MKPz
+bwBCFlWM7HqDPZhC+vOqEZ/TlPqzNdHhdQ8PMbjYhqaprtEkLCGtBqq4RCOADSUGHsfk62QQpgkL
+OC5UfF0skc42MY1TdRVSyeSAo1qirfw0xnKD8JLRWxqnjFDFKuS8ZAyYM/n8G/aT/kQ8vrq/20wK
+oEOgjGSKY03XI2419PiEjeAkBp6TlZnQjxKy2xdl6e4MDae7ZeD+0YOthy6stmlwaih0G8lsH7Ih
+08zgdpmK9Q2xnLMucUzypWbB+Bs31x030MazYbSavFdxzHGmcL4y9NP6OJJqC32+mnvcedm+vgaL
+pGd/mQeojpONuQTXWeUc1KYGv1bzOcvnmbJjb6kgz23kUM2qDB8TD3I1uNo0If80TU+XzlOxAsr2
+/FJJofdpcqlsZywZ39OKVnaOJxUNwJaPilpGj+eoqC9LZwV087+qULo8dgD6X8V5aOH58BN5DV0w
+1yGhndJFGt7dmzcNsdW/1Nl22DFAw6R7iqkHqFfOH3jtq5/u+XGx/Q0WZ24gRqzNBD7eWQ3XItPZ
+ygpToW7QoPFC1hIn910blsQDX8l6IjwrcGYCLdMwWQ1CVyxZQXp5G7pVaN1tgoIzbspDbGKVNqR4
+OE6g2UGF8DhOWdE2paky0hCrPrujmUBUd00FcwI7wSlmy6wEkwicH7roIwC+SdJoamSJ/ovnqmzn
+7rZoyo+3HbEUDB5RtNLIECL0WWOhw6XQ3clKt/KtesopsK9tsm5umcyu8SuGZJrQcpsNIPSKoytN
+5sujIEJD5eO1d5l4koe55lomC/788yvw4O3nTxJnIUSQ4FfXSwJ+l6LK+pmJN4m2cQlBfR3pL73c
+3pO01HcYgCJj0YvtZob0/5nhbmf1+/fv6msTasqfrm9bG7dfOA54xz2Nv5qOTxxvEldYNHL9ROTb
+fMwiV8KLbcRaG21lxPDzimgihFE3mAaRjtskzwTmT5mr18Hd9NaIteZX831mr2tfg7HhTq7IlO1v
+RjhlXNtk+PfHx/tPWOjWW83KfX72LET6O0Yx5n4RoqRbwoNu6tWZa1AIlAuSXEhZCH5xR8U3Pmvw
+yfaILnfFTcntzlCmJ23UJ5kr1XP6Df3isixXNbRybeUzCrwqDX+CXSbvG5PUa5TOpOMHYXm8vi+B
+ajwaz/47y8RVQpDhXAcYWClPfAzUZSmXeaJrDdlt72iauXczyGX5Z5Re5eL5hmQRW2GuCQ/qz2Y4
+G/j8zkeGJbJQKxKMa1c+8m/NXjOLMnJHpe9E0dSwW85WlIDbrW3jrmsZ898XDNr13/u6FF4pB5NN
+nCxh/yxBdw14s+bQXnpwvm01veBCcfXKHIJ87pbdD9wnK8qYOGwVqtbD8gxnJljqBf2m43ZJxAOi
+i6Pp4zccl1f/vk4nuFZAjsmyFuJO4KW2Msa0Lw75Um/3AmlJudM7ecsMLNHrbqiWOinH/YAEYbtg
+g9AdUNVFZjbb3XKKvSV8RHn2gTTB/kPdPTKRv0+VcuGaVMUn3eFXlHR8MhR/QIk80eJ3dBGqJO3N
+Tk1XfExaLuCiUYzGNfOsDIdcN88mnBOSey7BjCmM+WprWuTdfoivXJoCVd+ZY61epux4gpdmWB6t
+/kco/Lr5t07sa+cCuJP/agC2vIS0jB7PCtKXJVQB6vXjiVCUkL8xD3HoNrSzsZt4rnqEu7m9r9WG
+7Ygms2x76D+PWRxgCkuhuhqPbAisKzBH7Q/TOlvViExMaHsdp1wushzHN7mkUb2uIwtsFpQ1f5bp
+37w+BgA7k/uaRotd3oTTPWxAQNyAlG8rmqv6jJiePPkdIy7mGInTo92TOq7ZW7pE3aZR4ILQEX6L
+zuWH1ketIMbWJ35+hjJ5GRLHU/Ho2+hJO/qDsp/0E2MTyRhk1b/8lmlb8XSvxzlfPurhNrKx7YBI
+uv0jioVqCQJWMn1/Ltr5VXp2fxF3sVi31GOyDtx3enS4p77lN0hopl1xAguL/1R58IaGwyAK8Yy4
+X8pWEbevKS8PZqZroSsrhe0X9IrS9g2TMrgB7aOSvYVlkr99FZhTlGiuU6csvr67eVD/xtmKxNr7
+2wIR314jj4iodZLT6llHNE8woDdVJzYOfAWvGwRvuKkpShIWIVEPfcvZ4QilKKqC7G2TntZ+th/2
+blhJUdOM2GNWNj1V43EUAkE3DGLqngkT22iVHXSUl0xjfEefGNg9rjOBlwXk27l2/183L8rqh1y8
+RjF4BUYxiorKHf2e4RD5jv4Ax36HR89E4EjkXD3qOWNC41ub3hkPORVkaezd+II5xYnxi3yO7zl7
+Xds+SrAwfVLNbw3PZQQg972FUNTfZHfarh9ZAaargR1cPWk46cE1Mh1KpaWsl+1IBArBjUd53tez
+JvpRXc6DRv/FpcB7p2x++6mRH8MJxQReDLuK7XcwVQ9/WFx2XGnGUemcd8pxhGlq+nEkeMcN3BDu
+OkFkedxaK4YYTnUlutD6K/UwRYmWfGXR2fKUq5yUq1TKfcsJKLXhHE9GSi/jKZND92ZohLNMtlMC
+ln9K8cGzCrKyINaVeQZuONuGRhNUjOY46XUrEIyjhZRolmmLduv+qbHp56+gug2TCw6n1MPdt1vW
+gw1v5IGn0UpmLQXp8pMQN+VaRI7OY8fO/Yj9+hSXHtibo5/Z6JljUMLE9kyypFE/ZgtCrHwBt8ZW
+d1YEYTM8I7tjF4jT5ydYts30mqLEWLz0BsJoehTv7bx0H1M7952fRRkBcaW9wyXzB6Ct0cfrEXxz
+UR2ER98WoMBZ3OSGIRxf/n47P1tEuH8zG4LTfK1bYq9v4oKwWi4o193Cq5SO850S1V3B+qoTBJHp
+zp/ct8nwE3wtaYil6FL/LR1HLFZfS2HSHlbMVil0n0fQSzyjt9kHknrAxSQ3PHaQPjPBqL9Z3ivg
+h2NMGRc/GfeYmfc9yBbjv3NWvIkGQPWvEmSIic9BNSUPH2701phFKMF33yD4ZiWIAafLJqX6iIGM
+cVYCfTOsObbNT5Y+4zo4ABlvBUi4yFEyNhnHWFu3ZzJlXfSHTdBG51bLB1Xjxy7HG8VXuqiSxUeW
++GTxhDQni6cnNVnsHNKzeLfFqH9isngWOO4wEq4YFb7xs3HxZhWjuhYo6eVwwqaDmrMmYAwgWMoS
+tlirn+cbJmY7HxtsiQpysqcg9nQ6R9j8OQKLb77OdK9xF9OquKkFnFcsnZBC7LBUBvYqChmWZ/Q4
+xCUXBcEArXG0tLadyGPxMeXuKvm5r+0P9Qt8n5QPUzX57kenSdX/XE91Nn5nbxhPfioH5/Aa2fkZ
+z+kVHOArow+MCc3LN/KL7xnmjhgz/JnQ/LWz1XberNz2ICWuPE2T4owIJcWo+qbtwM3IHayzSCR+
+M2RWwELchS7rK8gK32AUJ4TiGZa24joe5JFG6CYPUC5YkQqZYb4iEb6Kir6tj+wFa9rhNCVTEwsM
+N/0Ea0yza3B9Zm9ZK5EY8iiYyvrcSnKfCX3J1CJ7rnt4eRZltj3AwDIjqbvEytcDr/Vj+IplYuZF
+/8H93Y3+R/19//rV2rKTlO9FfU3voQ2amOTn+kCmg/FBHzq4E+PfmDXlhPHKHzk9vFN+bk518e5S
+7B3N9Bd05aameEHNMOF5WSVq5ra6tYD1TcEmPKarCBGq7gmt89bQ7H6iZ+0ZcXzPWYQzZcPRzpzO
+8nnMlohQ2+u7nzgqDkEIi2GLm2BJUWDruyN4bOANJeATHsjd5Jw951g6hj/wOtO/rGranhmeYpxU
+qNHdXm/+fkX3WcmpVxraKxdDUzC8m9vz8Zvmw7Tlp6x6cPWryZPrU90pizVD/DfLCo/mkWngwe6O
+64PmR7xME+UebJ8y3aLDJWAu1YOb2I6uKyb3LW4Nsv3MQsPsUWUYevKEq0G9O9yrYxntLFUc2UKO
+wszNocqV2uWwrPlSJ21N/w5Nvr2+DzPl3vfPIqvu84ZuBXneZUY7LjbHoXtjPyGS5Bw/PnOcPbMk
+dn2EPkBL7eJjlNzgBK01MaOGemqKMzUwWV7U3UEHGqbv9/mZIEvMcgHh+U1rBnKa49g8EeGP8HYK
+uz1j5c5Lz7YmAjUxja2rijpGQ1zUOU+gq+F4QTKheSUizzTPnayaagjb4xEV9gZEM0Z9yQnEq+pf
+VX/Ba169Cj/6bckoEQx6cpMy5fO52+o4rlHN8KpoPbRaNnrxn4TumGALLHmcJiQq9uxyv8RZonz0
+4ZBrXJRD9K96UaObWgej1oNjTKsEthzKv7sTdKWQtrwTU2v5ePZkehlPsWTNPo3Q4vF1WOjJSwKu
+n2dBkvRhchUQiekuuq0QKdr7PMDEMDGHafFPG85qPuVJsi4q0XAMHDer3sj8hGmd2HY81peWCSQG
+Ms3xK2klrHZeqV6TVuWqy5Sp/rU8siIZ4+Fu9dR/dAnmqy8tY/xXzgQaj+2wQ5jO0KaELl0000OW
+rrydHXwHaOurb5fhY1p1hzL1UYZ6lX1GPN7B1b4sYikG1CB37np0AWtMIaoPVZNnX8SVZ3j7ZC1y
+6jUYMLyesWVR8fL1mWxfxDUouhsLCq+wJufAEgzZNOlTBxrOjNemICmaBRL4J1rrGl2KMk95oy9u
+2E5OK8uS26LCLNbQKS9zaa9qVb/f65JEmb43m7nFRX+1qKXZIOxK4C+jLo9Kg7oE2VGrcbAsbqCL
+b0Ejrr34lCa7dkyjERi7JRdL+Vdjgf+UXsotentH5dJ7gGPXOobULTzNtq+4eHvukFrY7ralwH5j
+GYSdaX1Wzy8ArrThGPWWX9/Slc4Pagtny9syKFH5lTcTsT/wWj9rdZ3D/dgY9wc3imHLW7BKU8ez
+9+pIEWR+nePZkfT9xds+zaqQcHizhr+yZu42aiQW+oh9Q1I2CbNe4WGtzKxXlGTv8p/3nKxIghf4
+VnZIaHKVYxZkw545SYjjZqctp+zClaXkJUFNmJVyFn3RlkfX8aC89yQbDI2CquENqGO5VKVVclEu
+flxpy2pQ/gnLGsHUVGUtXWcXPSzvB+kEfsdrezdytm4yEwa01dtFU22rNhYvE9t6FNZj+ZiisZFU
+vfRRTFVTiIbymNSBz0hG+DUl5Wkh7PrN9Ndim6uRuzCn47MjDwOSldmQaJjFDYhLsS3gU84EizQp
+LIH4AouaMEj+uSDJBaEiE/zijopvfKYxU4ncLCvNA0xltzzN3ZnmSaV7YOleDajNH9UfPHJ5xzsy
+3Ep8xigRz9fPOHr5CtNf0n3dWj3A7iflZqd4KBxcqMjFpMlcDAq84BZKd+8CnM8Tkj1/ZaIo5Lnq
+vhWl2jqFKMXJytRot/HPCGjwDfxW+0yJwJD4Mk8TzVlj11J8n1hvImsdBxpJDKcswbS60Oe8naxg
+auHoOLA00At36cXrDEIRohrPALo/Q7MgtST2afy6PcfGhFC2AAnQ9mfwHmS5Uyi/1qxaw5sk48N/
+lgnNVdAdLbTlW38jRvHTE46ErmZe+XdBlvIpPhyDx6GsFHZUS/m8ZcWuboztpfMQAzWeQLUX5GGR
+PbD51GPVwK4+MyiKF71eHw30wGiF5i83dg1PjBYjmXpCaRWP6wGl/nUN1ebpk/aKE/TNraGMJXj1
+sUbE2qey3lkf+iE23xbwoRq/j/BMenh8iC1mP+lPxOOr+zvQU9gt2BAjXqZifUNAI72tYPanj/zB
+do8n4gGnoJc8P5UgYfvQ71/3+SEWfcJkcjP4VPec3aSnc0ZUDrLle1rfZwYhU16C3nED+XBt40eY
+mtIfWNGDpum8Zz/5IbbNtYlXbJSMQcoNVh9kxsXfte+WuoVK1T6tg8vIyxf1+V1xOKflpPyVsxQt
+hsfoDsU5lk12Pnd7RbYaaMupcZxfBw9c9Ifb4vXrrKGNn0edBjSv6fvGQ/0eBtOjoT4+34k8xKI8
+4/U7ihueO2kFb560kKRQU+0q0913N/ZvLEkdN/PufKsxbF1z9NHY0vEHE1qeh+geM8A6GCBeYSqy
+i9WvcyzQrxe3K+WGHkVa12OrMcUpxxESOL6uvZ/DyUQL9ZHwrOgylgm0TMOke1rsn9EGkcPf1yyk
+39keyX9Ovw//hUSc1exttdyCMgG+fs/xAvG4apQxIfHNcYKAoaMSS8q4IHTRb0eh4Lr67o5mAumO
+4jPMHcvcVNNyVkI79yFozQd48lwRl7wQ1h5DF0wMDqAL9kan0Crut1QFoCJ98KUAJoUoqgLclTJr
+DHIYKLn7apmw+1bdTA/srDKBhFN3/bLUacRJjWEoiVeBqZRWK40rWbSK44+9JNBgx8DJyqXXTfWd
+M83fO+mOPkVZWyNb1pFX+LOxVuo3CC/lwYE4jiJP0wjhFZ9GbH7lny3KdgK3OnCawAamTu117DLa
+1gpkmmsHvxKZrNh9KbJN1mDtdVZEkvmdZILx9WeyJMKj1U7AF8eC9N5p0YA7weRpLNE05U/TzPl7
+H9toptaDhahf27c8SQpdQraRno2C3Bztpruf55xjKr7myznmVb9aHDvvnzOpBD9gWkBd1c2ZQFBf
+SJZ5kStmNwjiO0VAFr37J5Wzxkucw1BVrVWl+LRq7MsMMLm+j2b/IDUrJxldlJ95zpSHLg7Fwmer
+OlHxj9OErdWlJscSqzZDDBOstugCRqutGnwX+QbDDuJVibe0yncYAzeC32EQ3JrPUUbBfRFPniHS
+j85R9BLO5ekPCStSj2yCz5cI2tLyasW8opSJ9gkf30J59Wlkh+2g+mskP1mHIfcyKarbaikvNy84
+zjLLI4JhmpBO2FRtwNA2tE/LJu6QOutvhcgW+HcffAr3nozvdmwYPwTs3brbfZwiAtnwRm5/u7ae
+n+XUV4+VgwdBvYHsbnubFEVg4rFL+TgrSrJrxrUt9Pj4Ap3HCO5uiot4yocM88RvBPIdljtarCTq
+AwnpejGNfVkuUX+okDhfMa6p/gUZwEOe4B/1PYfxscpk+Q9lZbu5UBJ1GII8DWJclNpVXFt4dZyg
+S0J9EhQS7LwgY+f17sadS42PU3D53/8IzOV9cZlgzGVEYq65exvhVEy4plJgduCstJ+jTWpU4wuS
+0ahxhUtn1NL3jPIH3mykw6oSz/AKbO+G/kYu43V56FN0Ht/u8gW1fo4xWdAV7jT7e6gWe8e7n/J1
+tukrXru4OkQVXS+w8YhCxj6hLK8QrcL6RBKKwuPnmfV2oNUXbvMmvnkoSjMEtoqAtKRTcFO9Ud+2
+yzjKhbU3Sq+3Y1VOqS87T7fUQ3JrcFHwniA2asomIfLfLCwZrHokCWiaRu/9xNlyCyyfb00HQOHs
+LuboT4FjjDzGgg4x0QsDG2mMpM3Oy2eNrPZt3S6ts81ke1MWbwQz0O7V3brCtuswtuIC8qsO6XCz
+qQ02E2+N8Rih4SlaQsBNGYzELMnL+1FT+mR0+bNPZfm+d9XV98gDp9FIgwVPYxl6+tURot2thuMx
+HeOKqBZ4KO2pfSSsRzYqLwpcD1plu2+q0PB2w2TTGN+X0JOtLziEIlrjM5C8r7t9gx1pg+I7zdAT
+Ljt8ATFU3d+v4gkqq3GADOWJ8TmJY0y92H5qu5p7KEdz/FX187i7v1azLH+sVl79B/d3N4YfQ2xi
++scuqhXf0l8e1EG+cmM3nKX+FtLvQ+9zCNogUCit15LeF7mEV+Cu2td7Yq5a2Kvw5mmaFD23UFKM
+y9caZiNECmorhScFPvFTyaErbeUo2rlpX4GqE/ljvtjVDjHI0VIHXbjTpRapbyjRYjhd7nIQ0u7C
+5I6qjzE+Hoh4sjXvyfvp+3ap6w0iwv14UN3RaZ0eVN/Yg+r2EllF+6WyfNdppG9O5BTXfVQlQYML
+W4EqHoAcGq4SLdHrLOcLHDLZusNha6P7/alIHBi5/Oovt4GpdhYHPi7NvmY8qjzRVRQFexXMbwCy
+Q+HRbjqK0QXZb5SYwm01Srl7xmXtsBQrRdDa7u6wp9EK9fpG+e5M9yDHMpHdV2F7BmFf68JpmYwj
+dHGxag9hd1w822fqCIt8DAP0OqLq4Bt2jTKV+Yw8hoGvsMU9BkKB6npMFFxKegzwYat5wIyeb0Pe
+7tLYUibGwMHB52Fcp6/Lk3A2c5pStqNeLI6nYsc2S/aoWMe2goSo03FbDQKV6Dj62+OqzikJbK8t
+oZreNloSDijfrojucVNbg+EEC+y+6TWo+6aHaruhIjC+S+vorWkFWonPZRs4EP0GL64OKE3enAzw
+7e1lSc249+ii5IDDexbfkIznhe4/5PHiWI6P7eOE53QccPpld4azWqUVjwmuQLOlGN3OyMGH6g46
+cz43dTTWrR2uFG7nahN4sy0E787TdJzcKrv0/V68rLyG9VwEApUcFQ9LGFskTS/pGKU/40YaWRX3
+OTKNX1McdXje3EnrMIYdc3w+VNZIEQN+rVPuGEvhbaP0yjEqPJ9HCbyNtZ2tVEdW+m7V1oRV6vhK
+3q378NDl7paN+KnUfVel7m6b5X0sczdulo+wxH0w3nDl7SrE00vbB1hDl7UP0R9KSbuzFvcjP2Wv
+lHG1ngMdz2GVLYFnxT5mQfkcRcU75IsFx+WroOqj56h8YvmBJc05HIhzUBG06bS45vi6ZWjMLRoP
+xyZZnSTs7xJudZsG70pUD6yMqNUHXQpzB+8KCjooF8+Mk78L+Y0OnLs6sxw1K9T8gdD6Mb/93UZz
+JkvmnyCKeahAyld0JTI/Bc/ythzNqN2K3kb1WyvLW81b2qwb7OzQt+leCnMpA1HIbHfaemdqguin
+4/FVqmk3AO7bKMroA86KZ2W/P3wGAvMKUrZk8wQFgq0wn0/Zg5TwGvFqQo9TsBDG6CFRwik8OITw
+wCMu2F1A8M4iAc8QYAdr/3tZ9KGr/UPrWtRLfZAnfgaDb3BXmCo4DYu1C9osi52SYfsA7FyjJH1G
+B5ghqfjeUJ5EI5VjyZZUw9vrnEnJo84fjNV/pAFSNdBAYVKF7QByKZ7q3+5qrbfCU3bFT4271t8p
+3+KksVPuZZO5l47PP9IMzP4EIL6RxynkOLSQY2Kssesg45Sx8dHYzlT1jnM4zsrZ73zOwHFt/nXp
+oHmdqjr20NI6JdsbyuqoZXIsSZ26yHyPczrqMnmd6o80vGqa7YWIrurqvL3P53ipfrurt9YAT8kc
+Hx3uWHmnTI6Duk55nE3mcVpPf6RpnL0JOfxijVOQcVhBxqToYsdhxSl9A1fXrvT0jnM3jprZ78xN
+318dRjlOFj3jOE/KJm718QgnjBOxvk5Qlnn0+8oiTlKh+32RsDlKbso7xerLqVtd5FbFO+kezaVK
+QOAU6ch7ODX6YnfKbVq1tyVXZreiQ3dtrnpzz0v3ZFZFtKeJt28TT70w2XS3i2mnMqF3POs0isNC
+ELrIurUA8T3H2bG00zMM0KuHUINvJP9Gbm7eTs/XtiaLQfUHP0+sWgKsSxYLGmkK0xVIEXV3gFu6
++oGU3dIxXX2ENmXvYJWwsyIpp0K+oTfGytYcRaOfzIvxHy0CZcLQo0lSH7X1LDQTjKNFATEr/1MX
+iMhOQCXS29cU0czWJuqRpSxhC+LJfwW+ruX9iPlSJaN9cthLqchOkwr31HCKOFpigXk27fWUlLPy
+JTGsfgiE4yhBZNm20Rt9UdpclU/5wmKHrWaXKNSJVeY39GE9W7Rc29GY8LaWGDX1w19eHDTjcqmq
+I5/Se1wJgaJn9WtvBxl96QcI72JsQta2L+4qt2AzkG5HCvLX7fZn34j5Y52BSlH7a6qKmka6SiW+
+TGAqSoivmuSpOyF1C8rid81yRVmso3t+ljWMT5lVVcw4UFjDVUOmwwxAtro3YYsvbjl3CknHFErI
+t/MKka45IGoY+dKxb//oIsZB2FbLOrbKtSHcF6bpWWwR6PFtrZVX26VjX9o0w5yywOlQ7nyZ0+2R
+bZxvfcnT2d47Xvh8dbepRdA0b3a8FJolEWZBNM/xcMtij86hLY4q5sFLpALJfi2U1UH5Kcd0yjHt
+f45Jc5BkMOatL/5jFt5ryslNWcceoatHOSVA12DceXzup+8dTdBTcD5VcZsKzQ0TZseRuVEOYQJz
+49wOF5d3yRxaWK7gHRyVj3HsQVCOXwUuQuvsF/kt5ivMW8jeB+1lpzwTbFlftrmWA6I3DTPjUf1z
+9u1r/VgtuGitrg2DVMamVWmQ49Nn5V9sQWdBrfr4vB3TX8HFSVftItQXZFY9faAc9E88f2bs5Toh
+mIprRp/IwsFcfBj+U0FpFALUrIaWj8nQDjBoCykQcHwXlHi4ULBPeHT3UGcODvFFyAFfMxpr7DBB
+mXjkiGbF74+BHPe5cXngGGUau2/tQbNC2lxf5fMqPJsT6XbC8pAcH3wE7zfXXOP5kKJuruYOGonI
+ZYZx8MtlWi0lJBN/6H5Mk5yjRPlT9sy48Lg/nBG6yBPEHdJeJfGK981JWrPt6UTdhArMy8Bvy1Nv
+FG0qJBr1QqgtsNSQezuvJ5buvtaW/VNpj9LKIpZqNqX5vHeFfePczboEy2sgJG4ektw4+R8tubeO
+C1Nlpsvfdra4/GitynjxeVFdUCwNrNb1Bv2DLjMQRTgVOP66S0OP6rBsZ2prA0OVtxeM4/iHyrJg
+/R864zwfSH5EZXOm8EO3VT2c1UKbRZB3j5ZoBz6pwKDJiFXpJM2Pu3Xl6oRJNZqW9dDW2GFiFiFV
+z5Oke1dDm4mSW9UHnCYkQpn+o8L3WT5T7IF7EEo0mxRL7bA3R0KxHGS1MrZphqUFbDcJMpZ08CTr
+j16M1JczSzG9ur/78V+zTTosmfAsCcike5Ah3r4KzClKbliUFy+8qsdnyxDnPLEe9fiOOMScGUpu
+NMBSW4qR/Ue7BI1+axfYuw0upwPev/EPjCV98veKsWyJhyT59rTpgGJk94qKILreCz7i9mb/hhio
+qNQYTYdxWxVB94QvxTTGNCJ4x+x947Nisl7V6lHwanZsmObLbZiVypbwK1qmCd4wVfwq+wCTFf6C
+XsmyHO04rG2/ItT0VbOabEpY6gXLfEhJYnPWehuG2JjgEr02q0X3YPS//6E8GF2i18+YLsSz+/f9
+WeYGU+u0+Tpm+TzB7ec0X86rrwkFDYBQ2AAIhQ+gNUqXAeSJIGmCvz05AlAmtudOGcV7sZKlSMiZ
+pk7Bl7/da9MNe7AKpfvMXLs1hRxTCCISDDlDPD/LKfl3ju8GJBqX/RY6sq4dXfiYvYo5N4G4GyQE
+wD/DfEUi2ScPc0xVpXJe/eSKaffsViRT4lB2mwtVbaI4BfyQ0zjpd5Oar0XHpbZDyUoZbWgKjjTg
+uEUdHfle1EmFi3/liIqqnmk0GqdD46v7u6b14IZfk0i5HHmbfp522l1wXWH6yPgNySK2wnxd6xHz
+qzjmOMs+rCsLubt5CPWSxExHQNPQGXxa5Dlw48FAlXZt2AEWBLSH/43NmK+TO1idV13HYtwD3F+a
+zVjcKwFUh2wBpOlwQd88jjolGK4UYrGJpqS684uJVRLa2mpwP3XT2a/mJKPLQ2+oTXNYVVd2N3X6
+zxEv963uWj9pgjWG6XReXXPdZWXK5OrJccr86p7NhlkqD2CdAr89kFUnz4axTdHmj5aCpyZvcIIF
+7tyNBOky5uuHnELdKIrwPeaExTMsj+ddMwhaw2E8fUb0pkppikztUlOO+0UP/oZ030NVbWRR+WaV
+9grnm6+e+yoaavq8BUHxkmSZqpu4CUVVkajCw/GCFBXqUJx1S4GgSBWMGqsywbi8OQuGSMVWmnkx
+kWabJylhL/uweUxEULPJxTOmgkRTteKPRyEhY+t+EE9+aJQsMVlJUL694cEJCPpyOgOXY+OZIxE9
+e3APgBvz7Q58qTDPCHNBnqRV4akOKGKMx4SGMAa8ksvgVCSNZ/XFQJZogdNiPZzqBigWPxl/UTW4
+djaTkhPfwTi82eHMyURcCvlMxDgerqGjOJA1T0wKlnTNm6EMaW5jO6vPB0EgPpTXR3zSY6NI37pH
+dk4DDPatNZwzz3eUCIIS8jfmzml5VSbCh2CmujPfvEAWYA/aHdxwczTYIKRyZfplifkC//KC12e/
+1QkWxVftldezAqDKXbiVuZi2zOrrirVMnEXcbwM+vtAtB3H7mnKchUwO96g+lPzrGkoULBQAk1r9
+vHlJpMvbSDiF7oeUrbYi/9fZVCQ5VGlmPPHlMyJT8iAlKw0Nd6Opr/CNU7iMCkJzbMzZmZxZhpOn
+z4S+wI+bWva+kIiz+tZqW6KABP6laEJw7nkI1bkgPRo4opSV9TQTG1JVD4tr88QRx6i+lZsJtEzD
+XM2N5dpGGP3kmwSqEQTm64nQzgLgaOfO02uBqbR9fceT6oPKWh3EQAZLVpAFqUhlJZNd4Lnv2Tn7
+STFvDoRDLQLfeljhi25OYtCaO8X3nBfUJvikwWCh+dx5wqKXAsdNNc/UuVTpfzlLEszVv8MPxDTj
+Hr0y2MaV3cf6SnjnheVe6m9YRuKc7x0JdaLO9McS43Wv95v5BMUuzg62IayzKLWXHM2GFlUNDB3a
+zsRYIJJkIeLYmwqVufuAvnlE6M4Efu0ovA8SKl15nxWV8NcozxSe5YngBC5R7fjfYEzdtEYyPOzP
+s2BLSVcAGy4Q4Fjw9dWTwFwTH2lmy0RftIFw9s9ib7dSbm1Y8wQsSCk8p5Kliwf087bOUoLbtFS0
+vY9fO+PyOpPTwvseyGkRep/GQVg0HsXBEPnxFAaL7TjMlXyabZKYz8EbyDzMp2WOQvBEAj1vc+fG
+A4fjQZczD+6glxNJ2w/Y3Jh2BbIerWkZhZ6rAVgwHqoB8GhO1CAYFMdpAHDTWRpkZhsO0tzMYXiK
+BhiDwyGVGw9TEPkdngFGaTjvgjDlgwZwZgZiRXNQ5aYsMHQIDhzOyZRB5ChCfUA/HS5LDCJMCWQi
+nQuSXBAqMsEv7qiob7cMQ/xfGP+lSfc7B9+VGC7u6BMbj2eekyS+QUKT9WXLlCSans0LIq7ZckmE
+7tdHjovODlj3gbFGl5l+XaL/1ZxOLAnV/JImSEhx2ncEJfYaV4/V7rAHgzzvCLPLf0eMHSY69iB3
+G7+gxYLjBRKsf1OmF8KVlbaz9h7OYfdx9Rs3rF2rJ432mBPYFdIYcrcETEkXP5ZPfVV9JbeF9qme
+M/wIuqQ6zgfb9YMJrmLapUd9Dq/45b5qmf5FcZtdn5QjNMNRzvHshaSPn2c/MCdPa83FH7dLlxD5
+qG5aOvRzvId0hx9YT9Z4PaXUxjSmq15zDuHR53CqPwafMFb+zemI8c1PUBu67+zHTXkB+J1GNsPB
+byy8GRHacowz3oJNYP4U7UwS336FPEPu3nfcE3SenCIgrZCONwzSePotx0J6l72HAdFGu8AMWCoH
+UHNTRI1/zm7lskWiD7LgaiYYx+WDVzPnp8/q91ituKzMPBX41yCiNYwVefXcV8kRiEQf0kro75zj
+G5K9eMtRicCJ7EeS4ElkRwhsZKtnYCGEahAb6muUojlJiGP3rQZ/D85KBKfPH2e+MlNAWwkSGmPu
+TXAMbSXIlimjZSumNnJ1JjiGdiY4c362dEStAgWSqoNLT3IFuJ2kbGj1BaUwQjWQM/pbuvIwjzG0
+M8E/8Lp7LQZMsgvvTBSusS6gM5l7zuRGGmz7Y3Bnkt4zXInAgaxApHrTG0CqAnJGf7esNqJgGiWk
+M6F7xoUXnQLQmUxzSAimU0LCCD3klAIXSzUCGFn5MD6hSODYn3IHB4z4n4iISWOuEYDIQpedPqiN
+1A3CS0ZvaZwyQkFmOoC0EmI/6U/E46v7Oz8XpkYAIFt6IRkWepLtIACThXtPHQob6dtlKtY3xDsu
+U8JbiVZm0Lkm4UxvAOpKCupWe3CuRGb5PMNeZCpIV0JeInMXFjie7APayaw+crb0MLYeoAOZH4gD
+8UsIN8Re7LdwViJ1ub4z9hV2cKzFV2D1NkBO6D0k0wGzknjF0RV4PehA2Qh8vPZ1hgNIK6EEv3qT
+GsLaibHoxX//rQK3kfx0fXuPeUYygamYkhgyI7KyQcQDTpk3cQW4lWR5tfsp8yaqRGAj+/vj4/0n
+LOCzow/oQuZ3jGLYpqsDZSWA5FEJDHsFYkXNMnGVEARaQVsgF/TyjTRfvSvhbUTvZtcz78BxDGwj
+9wdeP7L6PTlXMi2QDf1n8oSjdQSLu1sgO/olEQ+ILoD4Gyh3AvI9AT8iBaQ7Ieh6PoB0JzRzKMRQ
+E6prLMyEGIo/oATRCPM7uoDuDVTgEJLw/bMC2k4wQklZ3tI79HInqYB3IurrIcbANnJf/Q8WvgJP
+Fb52DwGdiTRAzuihU6wP6EwGOsH6gO5kwJY+BLWSqroLOOOX37sg9cgZdMGcSHgcx/bgXIh4HUr1
+AV3I9PNuYLENwV1IgqdKDeOC3OekpgcHITLoCeZDb9DyzJm0TDv70ixgnYhBvU0N44Qc7mNaKCcC
+60zgZX3HCkSkhbQRKlfaj7Kzho/pqcDdSHoFB9C4oN1Vw6tDRrBQYtcJIsspFEsEXmShPkqPxIs8
+dOLpkfiRB09NExovFnzjQzsyKDtTTcHLCqYagJfup6vdVePPTDAaJvdnxWVlhoFOf+XnDih9YsQu
+GIAENBQYgrqQooL4jqgL6kDKK+7twTkQAc/pCsQB9UwWUROxlkfn+BVKZQjtQhDqKioQF9Rwh9AA
+OaB/xEt59xoDCTRgABIeCu+BAkh56KMHaifFZX8Q75MhJbyVaP0U4qzpeQF2OxoUdtJsDhtgAeCA
+VsbCOO7JAUZGgcBG9l85k7c6fImqwG0kHz7c+JIbglpJ4TSpmkld9/qPOhNUIvAi67WQWDB5MQL1
+PXokXuSh/kiPxI88eA0xobGzUDb39d6QqxG4kv1XzgTyIVcCgsjAzWoIDCIHN6MhMIych9mMwV1J
+drJwXjR78Dais9vPhOavnQf9XMkNIK2EIpTgu2++zl8FbiWJIw6rcKsg3BB71f4PQd1IeVb9j4Hd
+yEFncwfKjYBfmewI1o2Yt8mNoR0I+u63oJutmfNr6x0SzfVpF9RXUcRyKjwo1JAwQnCrG0E7EvSk
+BCEBLeHtgjmSgC6CgwYSTiTAC18f0Eqm7NP3bTbK3cFnqwWVMyuTGQCRfby+n7HoxacObghqJYWA
+1yBKACtalnTeInHG3ULZCMBPexzPeMrPvkCdXBfMjYTfcjeCtRLL0mfM8Q/CRY6SKXlsCyYbI39i
+sngWOJ6QF9ahMJHOLtRtyusWocOX4my8ALCFYqu8V5i7zydf1Bth2HVdnYJ+EuMPuVulpgsaCyNp
+mjV9I9rMwQNekcxVtRYUkxhwVpUDGgAjNzhN2Nq1RkUH6kUQlIKz4vBiwUvoA3Avwg8sSeYoeplA
+vEHhxYBrrGoC9yPsHMGaEXgSr7vPTCBfoQAwUKuqdJ5g4gNwIGFCF9/TGAk8Yarr8PiyInWIn/Jk
+hv1VYkUIYK5IZoE5KKGgZLxmXgsJJuc337qwEJKtHuAkO7B+JL3c+RDej7SfVgfwnqQ9NTzC4Ee+
+noCe01iPycJO/3GaYgf7gqmMg/BPJy6MCCYQdzYFK5IpTLgbhQMaKCPfM8xdS2sN0ECypUFNNYMx
+lqlseBuEGtNkdvxNQ4fLi6VJRjJAYWegfRqmvpQ0y4t656sowlkGMxg3ZECmvjJanxheCcHJPBc4
+8+JIjQnITiBepjMyw8lTKF3pcIVhCTLRnfFBWQslqQBSCimhjUkH5g4dscHYKp1ZSKdkwejDXjD3
+ZELnw1hIrgKxFNBlmREGZM53atqRejEZVHqhJBdcapuVmL9rs6G0Mlg/c1n0ieMsy6pG9R7X0wDY
+QGz9LgfMqECJPNOqfnM8iHLDFIId55yGO7YQbAHs3hVbELYgFu+OD8SaezJRBQcn5akK55SiCsxX
+zO5pxeLtWQnyTzZ3IdT73h016BBKDehOzHUuj2DcSbgawwgGQMJZ+QooJzLVg8LS1VOY+oeQPuSA
+WlJB+5AFak4F7UUWqk01PIj0P9kceh/KgsFMvvcYdXN23v5xRhaU0IWs08aOhYgwjCHZg91c8UQd
+kmHn4k441pBsOldvwrEGZdO9AtQHr5nV9g309mS86PHieN5nhPcn7WphVhz+LLhajxXHBBacLcMB
+iz8b8PNAADY4W6CiBzOCCcRBTtsR0wR2pkwYSPWTER5SA+WGaAIzU+YvpCrKDD9pBsMqpCwYps1e
+aLWUAsfH2SfO8rRGALgP544MzJTsQlv1hnRtYeqAZQobsvL1B0pyPJ2XFhWcIZYVve6dO6NacYBZ
+uLuZQvzuxpese6NRA7Qv2Q8oesE0nka9RuLLxITFpIvBl7xrCbkFgy/5CQtHF4M3+SlLRh+HLwuP
+n2fT6EsEYOJfsexT8nLPEhL5rlN9HNNYmG6NWnTTGJswPcd4prFyj92OV5zwTGTF8TqoE55prEzw
+IGM8YFY6baUmzaUxnumsTDBdNa7pLE1QlhoXmKWqAYp/xqWDYALxqTtaFaYJ7EwwlQGSCUxMMI4B
+kilMTIkIRmjgjIDvutiReDHR3leZmKHUIJvI1NS8lN+VHBWinF5lspA2zFZbiw7MWNXPJgxbGmRw
+plwP97WwfiQnuBbXg34t6BSH4n7grwLO0zQp+iahpEjgZIGMwYrXzCotw66qpgG8EzGBexMG7j8c
+MXmz47oe21B4M+C617Ch8GfAcYdhQ+HNgKvLsKEwM5AWHzZT63ZFnPtq6EBBBO9ZfEMynhcT90Me
+L9zWeQcsU9lwnQKOmKay42oOjpgms+O8qjjjMrPE5yhqO0Zcl0/kPTA3d6kH9iT6gdDY8X1nK45p
+LLiaqRMeT1Ym8wAmXvo319VSCwsh6WdrYCObZF2+ZjXZnqYYkr8FeZmOBHrAT37UJCCEWFWCDydW
+AzoRq2qDvHziGNaPJNxmtSgmMQC0JCMaP0amcgAl7eULR6AAgl4WBjWtKTblaUxTrWiC+XjbjY/B
+wD1gHw5ACu7/+nBmUhkWgtBF1l3b43uOHd/hN4F7E3bVog2FNwOuGwUbCgsDZXfOTqPO6wS5FVLo
+QL0IOovbAO5KuDJN7+Eq4P1JwweuwfEmeaj6OAgipGs/+6Nh5uz8bFVe1jv77Wz168Wv/3XxD8l1
+isSzHP7b+Vn2Ey0WMltzdnnx/569/f8DAFznHEuCzgMA
+`
+}
@@ -0,0 +1,129 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubeschema
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// crdGroup is the API group of custom resource definitions.
+const crdGroup = "apiextensions.k8s.io"
+
+// customResourceDefinition holds the fields of a custom resource definition
+// needed to validate its resources.
+type customResourceDefinition struct {
+	Spec struct {
+		Group   string `json:"group"`
+		Version string `json:"version"`
+		Names   struct {
+			Kind string `json:"kind"`
+		} `json:"names"`
+		Validation *crdValidation `json:"validation"`
+		Versions   []struct {
+			Name   string         `json:"name"`
+			Schema *crdValidation `json:"schema"`
+		} `json:"versions"`
+	} `json:"spec"`
+}
+
+type crdValidation struct {
+	OpenAPIV3Schema map[string]interface{} `json:"openAPIV3Schema"`
+}
+
+func isCRD(obj map[string]interface{}) bool {
+	apiVersion, _ := obj["apiVersion"].(string)
+	kind, _ := obj["kind"].(string)
+	return kind == "CustomResourceDefinition" && strings.HasPrefix(apiVersion, crdGroup+"/")
+}
+
+// AddCRD adds the schemas of the custom resources defined by a
+// CustomResourceDefinition object. Versions without a schema accept any
+// resource.
+func (v *Validator) AddCRD(obj map[string]interface{}) error {
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return err
+	}
+	var crd customResourceDefinition
+	if err := json.Unmarshal(data, &crd); err != nil {
+		return fmt.Errorf("invalid custom resource definition: %s", err)
+	}
+	spec := crd.Spec
+	if spec.Group == "" || spec.Names.Kind == "" {
+		return errors.New("invalid custom resource definition: group and kind must be set")
+	}
+
+	add := func(version string, validation *crdValidation) {
+		key := kindKey{spec.Group + "/" + version, spec.Names.Kind}
+		var schema map[string]interface{}
+		if validation != nil && validation.OpenAPIV3Schema != nil {
+			schema = validation.OpenAPIV3Schema
+			convert(schema, false)
+		}
+		v.crds[key] = schema
+		delete(v.compiled, key)
+	}
+	if len(spec.Versions) == 0 {
+		add(spec.Version, spec.Validation)
+	}
+	for _, version := range spec.Versions {
+		validation := spec.Validation
+		if version.Schema != nil {
+			validation = version.Schema
+		}
+		add(version.Name, validation)
+	}
+	return nil
+}
+
+// LoadCRDs adds the custom resource definitions found in the YAML and JSON
+// files of a directory and its subdirectories. Other objects are ignored.
+func (v *Validator) LoadCRDs(dir string) error {
+	return filepath.Walk(dir, func(path string, fi os.FileInfo, err error) error {
+		if err != nil || fi.IsDir() {
+			return err
+		}
+		switch filepath.Ext(path) {
+		case ".yaml", ".yml", ".json":
+		default:
+			return nil
+		}
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		docs, err := splitDocuments(path, string(data))
+		if err != nil {
+			return err
+		}
+		for _, doc := range docs {
+			if !isCRD(doc.object) {
+				continue
+			}
+			if err := v.AddCRD(doc.object); err != nil {
+				return fmt.Errorf("%s:%d: %s", path, doc.line, err)
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,26 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*Package kubeschema validates rendered manifests offline against the
+OpenAPI schemas of a Kubernetes version, and against the schemas of custom
+resources.
+
+The schemas of the built-in kinds come from the OpenAPI document served by
+the API server. Documents are bundled for some versions, and can be supplied
+for others. Objects are reported for unknown fields, fields of the wrong type
+and missing required fields, located by line in the rendered templates.
+*/
+package kubeschema // import "k8s.io/helm/pkg/kubeschema"
@@ -0,0 +1,131 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// generate bundles the OpenAPI document of a Kubernetes version, as found in
+// api/openapi-spec/swagger.json of the Kubernetes sources. It is run from the
+// kubeschema package:
+//
+//	go run ./generate 1.13 ../../vendor/k8s.io/kubernetes/api/openapi-spec/swagger.json
+//
+// Only the definitions are kept, without their descriptions.
+package main
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"strings"
+)
+
+const header = `/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by kubeschema/generate. DO NOT EDIT.
+
+package kubeschema
+`
+
+func main() {
+	if len(os.Args) != 3 {
+		log.Fatal("usage: go run ./generate VERSION SWAGGER_JSON")
+	}
+	version, path := os.Args[1], os.Args[2]
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		log.Fatal(err)
+	}
+	var doc struct {
+		Swagger     string                            `json:"swagger"`
+		Info        map[string]interface{}            `json:"info"`
+		Definitions map[string]map[string]interface{} `json:"definitions"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		log.Fatal(err)
+	}
+	for _, def := range doc.Definitions {
+		stripDescriptions(def)
+	}
+	out, err := json.Marshal(map[string]interface{}{
+		"swagger":     doc.Swagger,
+		"info":        doc.Info,
+		"paths":       map[string]interface{}{},
+		"definitions": doc.Definitions,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var gz bytes.Buffer
+	w, _ := gzip.NewWriterLevel(&gz, gzip.BestCompression)
+	w.Write(out)
+	w.Close()
+	encoded := base64.StdEncoding.EncodeToString(gz.Bytes())
+
+	var src bytes.Buffer
+	src.WriteString(header)
+	fmt.Fprintf(&src, "\nfunc init() {\n\tbundled[%q] = `\n", version)
+	for len(encoded) > 0 {
+		n := 76
+		if n > len(encoded) {
+			n = len(encoded)
+		}
+		src.WriteString(encoded[:n] + "\n")
+		encoded = encoded[n:]
+	}
+	src.WriteString("`\n}\n")
+
+	name := fmt.Sprintf("bundled_%s.go", strings.Replace(version, ".", "_", -1))
+	if err := ioutil.WriteFile(name, src.Bytes(), 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// stripDescriptions removes the descriptions of a schema and of its
+// subschemas.
+func stripDescriptions(schema map[string]interface{}) {
+	delete(schema, "description")
+	if props, ok := schema["properties"].(map[string]interface{}); ok {
+		for _, p := range props {
+			if p, ok := p.(map[string]interface{}); ok {
+				stripDescriptions(p)
+			}
+		}
+	}
+	for _, key := range []string{"items", "additionalProperties"} {
+		if s, ok := schema[key].(map[string]interface{}); ok {
+			stripDescriptions(s)
+		}
+	}
+}
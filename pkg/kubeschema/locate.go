@@ -0,0 +1,169 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubeschema
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// document is an object rendered in a template.
+type document struct {
+	source string
+	// line is the first line of the document in the template.
+	line   int
+	lines  []yamlLine
+	object map[string]interface{}
+}
+
+// yamlLine is a line of a document holding YAML, other than a comment.
+type yamlLine struct {
+	// n is the number of the line in the template.
+	n      int
+	indent int
+	// text is the line without its indentation.
+	text string
+}
+
+// splitDocuments decodes the documents of a rendered template, separated by
+// "---" lines. Empty documents are skipped.
+func splitDocuments(source, content string) ([]*document, error) {
+	var docs []*document
+	doc := &document{source: source, line: 1}
+	var raw []string
+	flush := func() error {
+		obj, err := decode([]byte(strings.Join(raw, "\n")))
+		if err != nil {
+			return fmt.Errorf("cannot parse %s:%d: %s", source, doc.line, err)
+		}
+		if len(obj) > 0 {
+			doc.object = obj
+			docs = append(docs, doc)
+		}
+		return nil
+	}
+
+	for i, line := range strings.Split(content, "\n") {
+		n := i + 1
+		if strings.HasPrefix(line, "---") {
+			if err := flush(); err != nil {
+				return nil, err
+			}
+			doc = &document{source: source, line: n + 1}
+			raw = nil
+			continue
+		}
+		raw = append(raw, line)
+		text := strings.TrimLeft(line, " ")
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		if len(doc.lines) == 0 {
+			doc.line = n
+		}
+		doc.lines = append(doc.lines, yamlLine{n: n, indent: len(line) - len(text), text: strings.TrimRight(text, " \r")})
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+	return docs, nil
+}
+
+// locate returns the line of the field at fieldPath in the document, or of
+// its closest parent found. Fields are found in block YAML; the first line of
+// the document is returned for objects written in flow style or JSON.
+func (d *document) locate(fieldPath []string) int {
+	found := d.line
+	scope := d.lines
+	for _, p := range fieldPath {
+		if len(scope) == 0 {
+			break
+		}
+		indent := scope[0].indent
+
+		if index, err := strconv.Atoi(p); err == nil && isItem(scope[0].text) {
+			i := nthItem(scope, indent, index)
+			if i < 0 {
+				break
+			}
+			item := scope[i]
+			found = item.n
+			end := i + 1
+			for end < len(scope) && scope[end].indent > indent {
+				end++
+			}
+			// The first field of an item follows the dash.
+			var fields []yamlLine
+			if rest := strings.TrimLeft(item.text[1:], " "); rest != "" {
+				fields = append(fields, yamlLine{n: item.n, indent: indent + len(item.text) - len(rest), text: rest})
+			}
+			scope = append(fields, scope[i+1:end]...)
+			continue
+		}
+
+		i := -1
+		for j, l := range scope {
+			if l.indent == indent && isKey(l.text, p) {
+				i = j
+				break
+			}
+		}
+		if i < 0 {
+			break
+		}
+		found = scope[i].n
+		// The value of a key is indented further, or is a sequence whose
+		// items are indented as the key.
+		end := i + 1
+		for end < len(scope) && (scope[end].indent > indent || scope[end].indent == indent && isItem(scope[end].text)) {
+			end++
+		}
+		scope = scope[i+1 : end]
+	}
+	return found
+}
+
+// nthItem returns the position in lines of the item at index of the sequence
+// indented by indent, or -1.
+func nthItem(lines []yamlLine, indent, index int) int {
+	count := 0
+	for j, l := range lines {
+		if l.indent != indent || !isItem(l.text) {
+			continue
+		}
+		if count == index {
+			return j
+		}
+		count++
+	}
+	return -1
+}
+
+func isItem(text string) bool {
+	return text == "-" || strings.HasPrefix(text, "- ")
+}
+
+func isKey(text, key string) bool {
+	for _, k := range []string{key, `"` + key + `"`, `'` + key + `'`} {
+		if strings.HasPrefix(text, k+":") {
+			rest := text[len(k)+1:]
+			return rest == "" || rest[0] == ' '
+		}
+	}
+	return false
+}
@@ -0,0 +1,554 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubeschema
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/xeipuuv/gojsonschema"
+)
+
+// bundled holds the OpenAPI documents bundled by generate, gzipped and
+// base64-encoded, keyed by Kubernetes version.
+var bundled = map[string]string{}
+
+// definitionPrefix prefixes the references to definitions in OpenAPI
+// documents.
+const definitionPrefix = "#/definitions/"
+
+// Versions returns the Kubernetes versions with bundled schemas.
+func Versions() []string {
+	versions := make([]string, 0, len(bundled))
+	for v := range bundled {
+		versions = append(versions, v)
+	}
+	sort.Strings(versions)
+	return versions
+}
+
+// DefaultVersion returns the latest Kubernetes version with bundled schemas.
+func DefaultVersion() string {
+	versions := Versions()
+	latest := versions[0]
+	for _, v := range versions[1:] {
+		if compareVersions(v, latest) > 0 {
+			latest = v
+		}
+	}
+	return latest
+}
+
+// ParseVersion returns the major and minor version of a Kubernetes version
+// such as "1.13" or "v1.13.2", as "1.13".
+func ParseVersion(version string) (string, error) {
+	parts := strings.SplitN(strings.TrimPrefix(version, "v"), ".", 3)
+	if len(parts) < 2 {
+		return "", fmt.Errorf("invalid Kubernetes version %q", version)
+	}
+	for _, p := range parts[:2] {
+		if _, err := strconv.Atoi(p); err != nil {
+			return "", fmt.Errorf("invalid Kubernetes version %q", version)
+		}
+	}
+	return parts[0] + "." + parts[1], nil
+}
+
+func compareVersions(a, b string) int {
+	pa, pb := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < 2; i++ {
+		na, _ := strconv.Atoi(pa[i])
+		nb, _ := strconv.Atoi(pb[i])
+		if na != nb {
+			return na - nb
+		}
+	}
+	return 0
+}
+
+// kindKey identifies the schema of a kind.
+type kindKey struct {
+	apiVersion string
+	kind       string
+}
+
+// Validator validates objects against the schemas of their kinds.
+type Validator struct {
+	// KubeVersion is the Kubernetes version of the built-in kinds.
+	KubeVersion string
+
+	definitions map[string]interface{}
+	// kinds maps the built-in kinds to their definitions.
+	kinds map[kindKey]string
+	// groups and apiVersions list the built-in API groups and versions.
+	groups      map[string]bool
+	apiVersions map[string]bool
+	// crds holds the schemas of custom resources. Custom resources without
+	// a schema are accepted as they are.
+	crds     map[kindKey]map[string]interface{}
+	compiled map[kindKey]*gojsonschema.Schema
+}
+
+// New returns a validator for a Kubernetes version, such as "1.13". The
+// OpenAPI document of the version is read from dir if it is set and holds a
+// file named after the version, such as 1.13.json, and is bundled otherwise.
+func New(kubeVersion, dir string) (*Validator, error) {
+	version, err := ParseVersion(kubeVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc []byte
+	if dir != "" {
+		doc, err = ioutil.ReadFile(filepath.Join(dir, version+".json"))
+		if err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+	}
+	if doc == nil {
+		encoded, ok := bundled[version]
+		if !ok {
+			return nil, fmt.Errorf("no schemas for Kubernetes %s: schemas are bundled for %s, and others can be supplied as OpenAPI documents", version, strings.Join(Versions(), ", "))
+		}
+		if doc, err = decodeBundled(encoded); err != nil {
+			return nil, err
+		}
+	}
+
+	v := &Validator{
+		KubeVersion: version,
+		kinds:       map[kindKey]string{},
+		groups:      map[string]bool{},
+		apiVersions: map[string]bool{},
+		crds:        map[kindKey]map[string]interface{}{},
+		compiled:    map[kindKey]*gojsonschema.Schema{},
+	}
+	if err := v.loadOpenAPI(doc); err != nil {
+		return nil, fmt.Errorf("cannot load the schemas of Kubernetes %s: %s", version, err)
+	}
+	return v, nil
+}
+
+func decodeBundled(encoded string) ([]byte, error) {
+	data, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, err
+	}
+	r, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return ioutil.ReadAll(r)
+}
+
+// loadOpenAPI loads the definitions of an OpenAPI v2 document, converted to
+// JSON schemas.
+func (v *Validator) loadOpenAPI(data []byte) error {
+	var doc struct {
+		Definitions map[string]map[string]interface{} `json:"definitions"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return err
+	}
+	if len(doc.Definitions) == 0 {
+		return fmt.Errorf("no definitions found")
+	}
+
+	v.definitions = make(map[string]interface{}, len(doc.Definitions))
+	for name, def := range doc.Definitions {
+		switch name {
+		case "io.k8s.apimachinery.pkg.util.intstr.IntOrString":
+			def = map[string]interface{}{"type": []interface{}{"integer", "string"}}
+		case "io.k8s.apimachinery.pkg.api.resource.Quantity":
+			def = map[string]interface{}{"type": []interface{}{"integer", "number", "string"}}
+		case "io.k8s.apimachinery.pkg.runtime.RawExtension":
+			// Raw extensions hold whole objects, such as the items of lists.
+			def = map[string]interface{}{}
+		default:
+			for _, gvk := range groupVersionKinds(def) {
+				apiVersion := gvk.version
+				if gvk.group != "" {
+					apiVersion = gvk.group + "/" + gvk.version
+				}
+				v.kinds[kindKey{apiVersion, gvk.kind}] = name
+				v.groups[gvk.group] = true
+				v.apiVersions[apiVersion] = true
+			}
+			convert(def, true)
+		}
+		v.definitions[name] = def
+	}
+	return nil
+}
+
+type groupVersionKind struct {
+	group, version, kind string
+}
+
+// groupVersionKinds returns the kinds a definition is the schema of.
+func groupVersionKinds(def map[string]interface{}) []groupVersionKind {
+	list, _ := def["x-kubernetes-group-version-kind"].([]interface{})
+	var gvks []groupVersionKind
+	for _, item := range list {
+		m, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		var gvk groupVersionKind
+		gvk.group, _ = m["group"].(string)
+		gvk.version, _ = m["version"].(string)
+		gvk.kind, _ = m["kind"].(string)
+		gvks = append(gvks, gvk)
+	}
+	return gvks
+}
+
+// convert adapts an OpenAPI schema to the JSON schema validator. Formats it
+// does not know are dropped, and integers or strings are allowed where
+// Kubernetes takes either. Objects with properties take no other fields if
+// strict.
+func convert(schema map[string]interface{}, strict bool) {
+	if format, ok := schema["format"].(string); ok {
+		if format == "int-or-string" {
+			schema["type"] = []interface{}{"integer", "string"}
+		}
+		if !gojsonschema.FormatCheckers.Has(format) {
+			delete(schema, "format")
+		}
+	}
+	if intOrString, _ := schema["x-kubernetes-int-or-string"].(bool); intOrString {
+		schema["type"] = []interface{}{"integer", "string"}
+	}
+
+	if props, ok := schema["properties"].(map[string]interface{}); ok {
+		if _, set := schema["additionalProperties"]; strict && !set {
+			schema["additionalProperties"] = false
+		}
+		for _, p := range props {
+			if p, ok := p.(map[string]interface{}); ok {
+				convert(p, strict)
+			}
+		}
+	}
+	// The validator locates the violations of additionalProperties in the
+	// object rather than in its field, unlike those of patternProperties.
+	if additional, ok := schema["additionalProperties"].(map[string]interface{}); ok && schema["properties"] == nil {
+		if _, set := schema["patternProperties"]; !set {
+			schema["patternProperties"] = map[string]interface{}{"": additional}
+			delete(schema, "additionalProperties")
+		}
+	}
+	for _, key := range []string{"items", "additionalProperties", "not"} {
+		if s, ok := schema[key].(map[string]interface{}); ok {
+			convert(s, strict)
+		}
+	}
+	if props, ok := schema["patternProperties"].(map[string]interface{}); ok {
+		for _, p := range props {
+			if p, ok := p.(map[string]interface{}); ok {
+				convert(p, strict)
+			}
+		}
+	}
+	for _, key := range []string{"allOf", "anyOf", "oneOf"} {
+		list, _ := schema[key].([]interface{})
+		for _, s := range list {
+			if s, ok := s.(map[string]interface{}); ok {
+				convert(s, strict)
+			}
+		}
+	}
+}
+
+// schema returns the compiled schema of a kind. It returns nil if the kind
+// has no schema.
+func (v *Validator) schema(key kindKey) (*gojsonschema.Schema, error) {
+	if s, ok := v.compiled[key]; ok {
+		return s, nil
+	}
+
+	var root map[string]interface{}
+	if crd, ok := v.crds[key]; ok {
+		root = crd
+	} else if name, ok := v.kinds[key]; ok {
+		root = map[string]interface{}{
+			"$ref":        definitionPrefix + name,
+			"definitions": v.definitions,
+		}
+	}
+	var s *gojsonschema.Schema
+	if root != nil {
+		var err error
+		if s, err = gojsonschema.NewSchema(gojsonschema.NewGoLoader(root)); err != nil {
+			return nil, fmt.Errorf("invalid schema for %s %s: %s", key.apiVersion, key.kind, err)
+		}
+	}
+	v.compiled[key] = s
+	return s, nil
+}
+
+// Error is a violation of the schema of its kind by an object.
+type Error struct {
+	// Source is the template the object was rendered from, and Line the
+	// line of the violation in the rendered template.
+	Source string
+	Line   int
+	// Kind and Name identify the object.
+	Kind string
+	Name string
+	// Field is the path of the field the violation is found in, empty for
+	// the top of the object.
+	Field   string
+	Message string
+}
+
+func (e Error) String() string {
+	return fmt.Sprintf("%s:%d: %s", e.Source, e.Line, e.Describe())
+}
+
+// Describe describes the violation without its location in the templates.
+func (e Error) Describe() string {
+	field := ""
+	if e.Field != "" {
+		field = e.Field + ": "
+	}
+	return fmt.Sprintf("%s/%s: %s%s", e.Kind, e.Name, field, e.Message)
+}
+
+// ValidateFiles validates the objects of rendered templates, keyed by the
+// path of the template. Partials and NOTES.txt are skipped.
+//
+// The custom resources of a chart are validated against the custom resource
+// definitions of the chart, which are not kept by the validator.
+func (v *Validator) ValidateFiles(files map[string]string) ([]Error, error) {
+	names := make([]string, 0, len(files))
+	for name := range files {
+		base := path.Base(name)
+		if strings.HasPrefix(base, "_") || base == "NOTES.txt" {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var docs []*document
+	for _, name := range names {
+		found, err := splitDocuments(name, files[name])
+		if err != nil {
+			return nil, err
+		}
+		docs = append(docs, found...)
+	}
+
+	orig := v
+	for _, doc := range docs {
+		if isCRD(doc.object) {
+			if v == orig {
+				v = v.copy()
+			}
+			if err := v.AddCRD(doc.object); err != nil {
+				return nil, fmt.Errorf("%s:%d: %s", doc.source, doc.line, err)
+			}
+		}
+	}
+
+	var errs []Error
+	for _, doc := range docs {
+		found, err := v.validate(doc)
+		if err != nil {
+			return nil, err
+		}
+		errs = append(errs, found...)
+	}
+	return errs, nil
+}
+
+// copy returns a copy of the validator, to which custom resource definitions
+// can be added.
+func (v *Validator) copy() *Validator {
+	c := *v
+	c.crds = make(map[kindKey]map[string]interface{}, len(v.crds))
+	for k, s := range v.crds {
+		c.crds[k] = s
+	}
+	c.compiled = make(map[kindKey]*gojsonschema.Schema, len(v.compiled))
+	for k, s := range v.compiled {
+		c.compiled[k] = s
+	}
+	return &c
+}
+
+// validate validates the object of a document against the schema of its
+// kind.
+func (v *Validator) validate(doc *document) ([]Error, error) {
+	errs, err := v.validateObject(doc, doc.object, nil)
+	sort.Slice(errs, func(i, j int) bool {
+		if errs[i].Line != errs[j].Line {
+			return errs[i].Line < errs[j].Line
+		}
+		return errs[i].Message < errs[j].Message
+	})
+	return errs, err
+}
+
+// validateObject validates an object found at objPath in a document. Objects
+// of kinds without a schema are accepted, unless their API version is built
+// in. The items of lists are validated as objects.
+func (v *Validator) validateObject(doc *document, obj map[string]interface{}, objPath []string) ([]Error, error) {
+	apiVersion, _ := obj["apiVersion"].(string)
+	kind, _ := obj["kind"].(string)
+	name := ""
+	if metadata, ok := obj["metadata"].(map[string]interface{}); ok {
+		name, _ = metadata["name"].(string)
+	}
+	newError := func(fieldPath []string, locate []string, message string) Error {
+		return Error{
+			Source:  doc.source,
+			Line:    doc.locate(append(append([]string(nil), objPath...), locate...)),
+			Kind:    kind,
+			Name:    name,
+			Field:   fieldString(append(append([]string(nil), objPath...), fieldPath...)),
+			Message: message,
+		}
+	}
+	report := func(format string, args ...interface{}) []Error {
+		return []Error{newError(nil, nil, fmt.Sprintf(format, args...))}
+	}
+
+	if apiVersion == "" || kind == "" {
+		return report("apiVersion and kind must be set"), nil
+	}
+	if apiVersion == "v1" && kind == "List" {
+		items, _ := obj["items"].([]interface{})
+		var errs []Error
+		for i, item := range items {
+			item, ok := item.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			found, err := v.validateObject(doc, item, append(append([]string(nil), objPath...), "items", strconv.Itoa(i)))
+			if err != nil {
+				return nil, err
+			}
+			errs = append(errs, found...)
+		}
+		return errs, nil
+	}
+
+	key := kindKey{apiVersion, kind}
+	_, custom := v.crds[key]
+	if _, builtin := v.kinds[key]; !builtin && !custom {
+		group := ""
+		if i := strings.Index(apiVersion, "/"); i >= 0 {
+			group = apiVersion[:i]
+		}
+		switch {
+		case v.apiVersions[apiVersion]:
+			return report("unknown kind %s in %s for Kubernetes %s", kind, apiVersion, v.KubeVersion), nil
+		case v.groups[group]:
+			return report("unknown API version %s for Kubernetes %s", apiVersion, v.KubeVersion), nil
+		}
+		return nil, nil
+	}
+
+	s, err := v.schema(key)
+	if err != nil || s == nil {
+		return nil, err
+	}
+	result, err := s.Validate(gojsonschema.NewGoLoader(withoutNulls(obj)))
+	if err != nil {
+		return nil, fmt.Errorf("%s:%d: cannot validate %s/%s: %s", doc.source, doc.line, kind, name, err)
+	}
+
+	var errs []Error
+	for _, re := range result.Errors() {
+		fieldPath := strings.Split(re.Context().String("\x00"), "\x00")[1:]
+		locate := fieldPath
+		var message string
+		switch re.Type() {
+		case "additional_property_not_allowed":
+			property, _ := re.Details()["property"].(string)
+			locate = append(append([]string(nil), fieldPath...), property)
+			message = fmt.Sprintf("unknown field %q", property)
+		case "required":
+			message = fmt.Sprintf("missing required field %q", re.Details()["property"])
+		case "invalid_type":
+			message = fmt.Sprintf("invalid type: got %s, expected %s", re.Details()["given"], re.Details()["expected"])
+		default:
+			message = re.Description()
+		}
+		errs = append(errs, newError(fieldPath, locate, message))
+	}
+	return errs, nil
+}
+
+// fieldString formats the path of a field, with array indexes in brackets.
+func fieldString(fieldPath []string) string {
+	var b strings.Builder
+	for _, p := range fieldPath {
+		if _, err := strconv.Atoi(p); err == nil {
+			fmt.Fprintf(&b, "[%s]", p)
+			continue
+		}
+		if b.Len() > 0 {
+			b.WriteString(".")
+		}
+		b.WriteString(p)
+	}
+	return b.String()
+}
+
+// withoutNulls returns a copy of an object without its null fields, which
+// Kubernetes takes as unset.
+func withoutNulls(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for k, e := range v {
+			if e != nil {
+				m[k] = withoutNulls(e)
+			}
+		}
+		return m
+	case []interface{}:
+		l := make([]interface{}, len(v))
+		for i, e := range v {
+			l[i] = withoutNulls(e)
+		}
+		return l
+	}
+	return v
+}
+
+// decode decodes a YAML or JSON document.
+func decode(data []byte) (map[string]interface{}, error) {
+	var obj map[string]interface{}
+	err := yaml.Unmarshal(data, &obj)
+	return obj, err
+}
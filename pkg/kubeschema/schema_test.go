@@ -0,0 +1,218 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubeschema
+
+import (
+	"reflect"
+	"testing"
+)
+
+const deployment = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+  creationTimestamp: null
+spec:
+  replicas: "3"
+  selector:
+    matchLabels:
+      app: web
+  template:
+    metadata:
+      labels:
+        app: web
+    spec:
+      containers:
+      - name: web
+        image: nginx:1.15
+        ports:
+        - containerPort: 80
+        resources:
+          limits:
+            cpu: 1
+            memory: 64Mi
+      - image: busybox
+        livenessProbe:
+          httpGet:
+            port: http
+      contianers: []
+`
+
+const crontab = `apiVersion: apiextensions.k8s.io/v1beta1
+kind: CustomResourceDefinition
+metadata:
+  name: backups.stable.example.com
+spec:
+  group: stable.example.com
+  names:
+    kind: Backup
+    plural: backups
+  scope: Namespaced
+  versions:
+  - name: v1
+    served: true
+    storage: true
+  validation:
+    openAPIV3Schema:
+      properties:
+        spec:
+          properties:
+            schedule:
+              type: string
+---
+apiVersion: stable.example.com/v1
+kind: Backup
+metadata:
+  name: nightly
+spec:
+  schedule: 1
+---
+apiVersion: stable.example.com/v1
+kind: CronTab
+metadata:
+  name: hourly
+spec:
+  replicas: 1
+`
+
+func TestValidateFiles(t *testing.T) {
+	v, err := New("v1.13.2", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := v.LoadCRDs("testdata/crds"); err != nil {
+		t.Fatal(err)
+	}
+
+	files := map[string]string{
+		"mychart/templates/NOTES.txt":       "Thank you for installing mychart.",
+		"mychart/templates/_helpers.tpl":    `{{ define "name" }}web{{ end }}`,
+		"mychart/templates/deployment.yaml": deployment,
+		"mychart/templates/crds.yaml":       crontab,
+		"mychart/templates/other.yaml": `---
+apiVersion: apps/v1
+kind: Deploymnet
+metadata:
+  name: typo
+---
+apiVersion: apps/v2
+kind: Deployment
+metadata:
+  name: future
+---
+apiVersion: unknown.example.com/v1
+kind: Anything
+metadata:
+  name: ignored
+---
+metadata:
+  name: bare
+---
+apiVersion: v1
+kind: List
+items:
+- apiVersion: v1
+  kind: ConfigMap
+  metadata:
+    name: listed
+  data:
+    enabled: true
+`,
+	}
+	errs, err := v.ValidateFiles(files)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	for _, e := range errs {
+		got = append(got, e.String())
+	}
+	expected := []string{
+		`mychart/templates/crds.yaml:28: Backup/nightly: spec.schedule: invalid type: got integer, expected string`,
+		`mychart/templates/crds.yaml:34: CronTab/hourly: spec: missing required field "cronSpec"`,
+		`mychart/templates/deployment.yaml:7: Deployment/web: spec.replicas: invalid type: got string, expected integer`,
+		`mychart/templates/deployment.yaml:25: Deployment/web: spec.template.spec.containers[1]: missing required field "name"`,
+		`mychart/templates/deployment.yaml:29: Deployment/web: spec.template.spec: unknown field "contianers"`,
+		`mychart/templates/other.yaml:2: Deploymnet/typo: unknown kind Deploymnet in apps/v1 for Kubernetes 1.13`,
+		`mychart/templates/other.yaml:7: Deployment/future: unknown API version apps/v2 for Kubernetes 1.13`,
+		`mychart/templates/other.yaml:17: /bare: apiVersion and kind must be set`,
+		`mychart/templates/other.yaml:28: ConfigMap/listed: items[0].data.enabled: invalid type: got boolean, expected string`,
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected errors\n%v\ngot\n%v", expected, got)
+	}
+
+	if _, ok := v.crds[kindKey{"stable.example.com/v1", "Backup"}]; ok {
+		t.Error("Expected the custom resource definitions of the chart not to be kept")
+	}
+
+	if _, err := v.ValidateFiles(map[string]string{"mychart/templates/bad.yaml": "kind: [Pod"}); err == nil {
+		t.Error("Expected an error parsing invalid YAML")
+	}
+}
+
+func TestNew(t *testing.T) {
+	if _, err := New("1.9", ""); err == nil {
+		t.Error("Expected an error for a version without schemas")
+	}
+	if _, err := New("latest", ""); err == nil {
+		t.Error("Expected an error for an invalid version")
+	}
+
+	v, err := New("1.99", "testdata/schemas")
+	if err != nil {
+		t.Fatal(err)
+	}
+	errs, err := v.ValidateFiles(map[string]string{
+		"mychart/templates/cm.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: cm\ndata:\n  enabled: true\n",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(errs) != 1 || errs[0].Field != "data.enabled" || errs[0].Line != 6 {
+		t.Errorf("Expected an invalid type in data.enabled on line 6, got %v", errs)
+	}
+}
+
+func TestLocate(t *testing.T) {
+	docs, err := splitDocuments("deployment.yaml", "# comment\n---\n"+deployment)
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc := docs[0]
+
+	tests := []struct {
+		path []string
+		line int
+	}{
+		{nil, 3},
+		{[]string{"metadata", "name"}, 6},
+		{[]string{"spec", "template", "spec", "containers"}, 18},
+		{[]string{"spec", "template", "spec", "containers", "0", "image"}, 20},
+		{[]string{"spec", "template", "spec", "containers", "0", "ports", "0", "containerPort"}, 22},
+		{[]string{"spec", "template", "spec", "containers", "1"}, 27},
+		{[]string{"spec", "template", "spec", "containers", "1", "livenessProbe", "httpGet", "port"}, 30},
+		{[]string{"spec", "template", "spec", "volumes"}, 17},
+		{[]string{"spec", "template", "spec", "containers", "2"}, 18},
+	}
+	for _, tt := range tests {
+		if line := doc.locate(tt.path); line != tt.line {
+			t.Errorf("Expected %v on line %d, got %d", tt.path, tt.line, line)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lint
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/ghodss/yaml"
+
+	"k8s.io/helm/pkg/lint/rules"
+	"k8s.io/helm/pkg/lint/support"
+)
+
+// ConfigFileName is the name of the lint configuration file of a chart.
+const ConfigFileName = ".helmlint.yaml"
+
+// severities are the severities rules can be configured with.
+var severities = map[string]int{
+	"disabled": support.DisabledSev,
+	"info":     support.InfoSev,
+	"warning":  support.WarningSev,
+	"error":    support.ErrorSev,
+}
+
+// Config configures the lint rules. It is read from a YAML file like
+//
+//	rules:
+//	  chartfile-icon: disabled
+//	  chartfile-name-format: error
+//
+// Rules are keyed by ID, and set to "disabled", "info", "warning" or "error".
+// The other rules keep their default severity.
+type Config struct {
+	Rules map[string]string `json:"rules"`
+}
+
+// LoadConfig loads the lint configuration in the YAML file at path.
+func LoadConfig(path string) (*Config, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var config Config
+	if err := yaml.Unmarshal(b, &config); err != nil {
+		return nil, fmt.Errorf("cannot parse lint config %s: %s", path, err)
+	}
+	if _, err := config.Severities(); err != nil {
+		return nil, fmt.Errorf("invalid lint config %s: %s", path, err)
+	}
+	return &config, nil
+}
+
+// Severities returns the severities of the rules configured by c, by rule ID.
+func (c *Config) Severities() (map[string]int, error) {
+	ids := make([]string, 0, len(c.Rules))
+	for id := range c.Rules {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	sevs := make(map[string]int, len(c.Rules))
+	for _, id := range ids {
+		if _, ok := rules.Find(id); !ok {
+			return nil, fmt.Errorf("unknown rule %q", id)
+		}
+		sev, ok := severities[c.Rules[id]]
+		if !ok {
+			return nil, fmt.Errorf("rule %s: unknown severity %q, expected disabled, info, warning or error", id, c.Rules[id])
+		}
+		sevs[id] = sev
+	}
+	return sevs, nil
+}
+
+// chartConfig loads the lint configuration of the chart in chartDir. It
+// returns nil if the chart has none.
+func chartConfig(chartDir string) (*Config, error) {
+	path := filepath.Join(chartDir, ConfigFileName)
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return nil, nil
+	}
+	config, err := LoadConfig(path)
+	if err != nil {
+		return nil, fmt.Errorf("%s, using the default severities", err)
+	}
+	return config, nil
+}
@@ -0,0 +1,69 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lint
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"k8s.io/helm/pkg/lint/support"
+)
+
+func TestLoadConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", "helm-lint-config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	tests := []struct {
+		config   string
+		expected map[string]int
+		err      string
+	}{
+		{"rules:\n  chartfile-icon: disabled\n  templates-dir: error\n", map[string]int{"chartfile-icon": support.DisabledSev, "templates-dir": support.ErrorSev}, ""},
+		{"", map[string]int{}, ""},
+		{"rules:\n  no-such-rule: error\n", nil, `unknown rule "no-such-rule"`},
+		{"rules:\n  chartfile-icon: fatal\n", nil, `unknown severity "fatal"`},
+		{"rules: [", nil, "cannot parse lint config"},
+	}
+	for _, tt := range tests {
+		path := filepath.Join(dir, ConfigFileName)
+		if err := ioutil.WriteFile(path, []byte(tt.config), 0644); err != nil {
+			t.Fatal(err)
+		}
+		config, err := LoadConfig(path)
+		if tt.err != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("%q: expected error %q, got %v", tt.config, tt.err, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%q: %s", tt.config, err)
+			continue
+		}
+		sevs, _ := config.Severities()
+		if !reflect.DeepEqual(sevs, tt.expected) {
+			t.Errorf("%q: expected severities %v, got %v", tt.config, tt.expected, sevs)
+		}
+	}
+}
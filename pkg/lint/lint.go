@@ -19,18 +19,63 @@ package lint // import "k8s.io/helm/pkg/lint"
 import (
 	"path/filepath"
 
+	"k8s.io/helm/pkg/kubeschema"
 	"k8s.io/helm/pkg/lint/rules"
 	"k8s.io/helm/pkg/lint/support"
+	"k8s.io/helm/pkg/policy"
 )
 
 // All runs all of the available linters on the given base directory.
 func All(basedir string, values []byte, namespace string, strict bool) support.Linter {
+	return AllWithPolicies(basedir, values, namespace, strict, nil)
+}
+
+// AllWithPolicies runs all of the available linters on the given base
+// directory, and checks the rendered manifests against the policy rules of
+// checker, if any.
+func AllWithPolicies(basedir string, values []byte, namespace string, strict bool, checker *policy.Checker) support.Linter {
+	return AllWithOptions(basedir, values, Options{Namespace: namespace, Strict: strict, Checker: checker})
+}
+
+// Options configure the linters run by AllWithOptions.
+type Options struct {
+	Namespace string
+	Strict    bool
+	// Checker checks the rendered manifests against policy rules, if set.
+	Checker *policy.Checker
+	// Config configures the rules. If nil, the configuration file of the
+	// chart is used, if it has one.
+	Config *Config
+	// Schemas validates the rendered manifests against the Kubernetes
+	// schemas, if set.
+	Schemas *kubeschema.Validator
+}
+
+// AllWithOptions runs all of the available linters on the given base
+// directory.
+func AllWithOptions(basedir string, values []byte, opts Options) support.Linter {
 	// Using abs path to get directory context
 	chartDir, _ := filepath.Abs(basedir)
 
 	linter := support.Linter{ChartDir: chartDir}
+	config := opts.Config
+	if config == nil {
+		var err error
+		config, err = chartConfig(chartDir)
+		linter.RunRule(rules.LintConfig, ConfigFileName, err)
+	}
+	if config != nil {
+		// Configurations are checked when loaded.
+		linter.Severities, _ = config.Severities()
+	}
+
 	rules.Chartfile(&linter)
 	rules.Values(&linter)
-	rules.Templates(&linter, values, namespace, strict)
+	rules.TemplatesWithOptions(&linter, values, rules.TemplateOptions{
+		Namespace: opts.Namespace,
+		Strict:    opts.Strict,
+		Checker:   opts.Checker,
+		Schemas:   opts.Schemas,
+	})
 	return linter
 }
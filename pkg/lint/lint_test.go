@@ -33,6 +33,8 @@ const badChartDir = "rules/testdata/badchartfile"
 const badValuesFileDir = "rules/testdata/badvaluesfile"
 const badYamlFileDir = "rules/testdata/albatross"
 const goodChartDir = "rules/testdata/goodone"
+const lintConfigDir = "rules/testdata/lintconfig"
+const badLintConfigDir = "rules/testdata/badlintconfig"
 
 func TestBadChart(t *testing.T) {
 	m := All(badChartDir, values, namespace, strict).Messages
@@ -96,3 +98,30 @@ func TestGoodChart(t *testing.T) {
 		t.Errorf("All failed but shouldn't have: %#v", m)
 	}
 }
+
+func TestChartLintConfig(t *testing.T) {
+	m := All(lintConfigDir, values, namespace, strict).Messages
+	if len(m) != 1 {
+		t.Fatalf("Expected the icon to be ignored and a missing templates error, got %#v", m)
+	}
+	if m[0].RuleID() != "templates-dir" || m[0].Severity != support.ErrorSev {
+		t.Errorf("Expected a templates-dir error, got %#v", m[0])
+	}
+
+	// A configuration passed in options replaces the one of the chart.
+	config := &Config{Rules: map[string]string{"templates-dir": "disabled"}}
+	m = AllWithOptions(lintConfigDir, values, Options{Namespace: namespace, Config: config}).Messages
+	if len(m) != 1 || m[0].RuleID() != "chartfile-icon" || m[0].Severity != support.InfoSev {
+		t.Errorf("Expected only the chartfile-icon info, got %#v", m)
+	}
+}
+
+func TestBadChartLintConfig(t *testing.T) {
+	m := All(badLintConfigDir, values, namespace, strict).Messages
+	if len(m) != 3 {
+		t.Fatalf("Expected the lint config error and the default messages, got %#v", m)
+	}
+	if m[0].RuleID() != "lint-config" || m[0].Severity != support.ErrorSev || !strings.Contains(m[0].Err.Error(), `unknown rule "no-such-rule"`) {
+		t.Errorf("Expected a lint-config error, got %#v", m[0])
+	}
+}
@@ -36,27 +36,27 @@ func Chartfile(linter *support.Linter) {
 	chartFileName := "Chart.yaml"
 	chartPath := filepath.Join(linter.ChartDir, chartFileName)
 
-	linter.RunLinterRule(support.ErrorSev, chartFileName, validateChartYamlNotDirectory(chartPath))
+	linter.RunRule(ChartfileIsFile, chartFileName, validateChartYamlNotDirectory(chartPath))
 
 	chartFile, err := chartutil.LoadChartfile(chartPath)
-	validChartFile := linter.RunLinterRule(support.ErrorSev, chartFileName, validateChartYamlFormat(err))
+	validChartFile := linter.RunRule(ChartfileYAML, chartFileName, validateChartYamlFormat(err))
 
 	// Guard clause. Following linter rules require a parseable ChartFile
 	if !validChartFile {
 		return
 	}
 
-	linter.RunLinterRule(support.ErrorSev, chartFileName, validateChartNamePresence(chartFile))
-	linter.RunLinterRule(support.WarningSev, chartFileName, validateChartNameFormat(chartFile))
-	linter.RunLinterRule(support.ErrorSev, chartFileName, validateChartNameDirMatch(linter.ChartDir, chartFile))
+	linter.RunRule(ChartfileName, chartFileName, validateChartNamePresence(chartFile))
+	linter.RunRule(ChartfileNameFormat, chartFileName, validateChartNameFormat(chartFile))
+	linter.RunRule(ChartfileNameDirMatch, chartFileName, validateChartNameDirMatch(linter.ChartDir, chartFile))
 
 	// Chart metadata
-	linter.RunLinterRule(support.ErrorSev, chartFileName, validateChartVersion(chartFile))
-	linter.RunLinterRule(support.ErrorSev, chartFileName, validateChartEngine(chartFile))
-	linter.RunLinterRule(support.ErrorSev, chartFileName, validateChartMaintainer(chartFile))
-	linter.RunLinterRule(support.ErrorSev, chartFileName, validateChartSources(chartFile))
-	linter.RunLinterRule(support.InfoSev, chartFileName, validateChartIconPresence(chartFile))
-	linter.RunLinterRule(support.ErrorSev, chartFileName, validateChartIconURL(chartFile))
+	linter.RunRule(ChartfileVersion, chartFileName, validateChartVersion(chartFile))
+	linter.RunRule(ChartfileEngine, chartFileName, validateChartEngine(chartFile))
+	linter.RunRule(ChartfileMaintainer, chartFileName, validateChartMaintainer(chartFile))
+	linter.RunRule(ChartfileSources, chartFileName, validateChartSources(chartFile))
+	linter.RunRule(ChartfileIcon, chartFileName, validateChartIconPresence(chartFile))
+	linter.RunRule(ChartfileIconURL, chartFileName, validateChartIconURL(chartFile))
 }
 
 func validateChartYamlNotDirectory(chartPath string) error {
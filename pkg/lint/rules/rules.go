@@ -0,0 +1,96 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rules
+
+import "k8s.io/helm/pkg/lint/support"
+
+// The lint rules. Their IDs are stable: they are used to configure the rules
+// and to identify the messages in reports.
+var (
+	ChartfileIsFile       = support.Rule{ID: "chartfile-is-file", Severity: support.ErrorSev, Description: "Chart.yaml must be a file"}
+	ChartfileYAML         = support.Rule{ID: "chartfile-yaml", Severity: support.ErrorSev, Description: "Chart.yaml must be valid YAML"}
+	ChartfileName         = support.Rule{ID: "chartfile-name", Severity: support.ErrorSev, Description: "Chart.yaml must have a name"}
+	ChartfileNameFormat   = support.Rule{ID: "chartfile-name-format", Severity: support.WarningSev, Description: "The chart name should be lowercase letters, numbers and dashes"}
+	ChartfileNameDirMatch = support.Rule{ID: "chartfile-name-dir-match", Severity: support.ErrorSev, Description: "The chart name must match the name of the chart directory"}
+	ChartfileVersion      = support.Rule{ID: "chartfile-version", Severity: support.ErrorSev, Description: "The chart version must be a SemVer 2 version greater than 0"}
+	ChartfileEngine       = support.Rule{ID: "chartfile-engine", Severity: support.ErrorSev, Description: "The template engine must be supported"}
+	ChartfileMaintainer   = support.Rule{ID: "chartfile-maintainer", Severity: support.ErrorSev, Description: "Maintainers must have a name and valid emails and URLs"}
+	ChartfileSources      = support.Rule{ID: "chartfile-sources", Severity: support.ErrorSev, Description: "Sources must be valid URLs"}
+	ChartfileIcon         = support.Rule{ID: "chartfile-icon", Severity: support.InfoSev, Description: "Charts should have an icon"}
+	ChartfileIconURL      = support.Rule{ID: "chartfile-icon-url", Severity: support.ErrorSev, Description: "The icon must be a valid URL"}
+
+	ValuesFile = support.Rule{ID: "values-file", Severity: support.InfoSev, Description: "Charts should have a values.yaml file"}
+	ValuesYAML = support.Rule{ID: "values-yaml", Severity: support.ErrorSev, Description: "values.yaml must be valid YAML"}
+
+	TemplatesDir       = support.Rule{ID: "templates-dir", Severity: support.WarningSev, Description: "Charts should have a templates/ directory"}
+	ChartLoad          = support.Rule{ID: "chart-load", Severity: support.ErrorSev, Description: "The chart must load"}
+	ValuesSchema       = support.Rule{ID: "values-schema", Severity: support.ErrorSev, Description: "The values must validate against values.schema.json"}
+	TemplatesRender    = support.Rule{ID: "templates-render", Severity: support.ErrorSev, Description: "The templates must render"}
+	TemplatesExtension = support.Rule{ID: "templates-extension", Severity: support.ErrorSev, Description: "Templates must be .yaml, .yml, .tpl or .txt files"}
+	TemplatesYAML      = support.Rule{ID: "templates-yaml", Severity: support.ErrorSev, Description: "Rendered templates must be valid YAML"}
+
+	// Policy reports the violations of policy rules, as errors or as warnings
+	// if the rules only warn.
+	Policy = support.Rule{ID: "policy", Severity: support.ErrorSev, Description: "Rendered manifests must follow the policy rules"}
+
+	// KubeSchema reports the rendered manifests that do not validate against
+	// the Kubernetes schemas, when they are given.
+	KubeSchema = support.Rule{ID: "kube-schema", Severity: support.ErrorSev, Description: "Rendered manifests must validate against the Kubernetes schemas"}
+
+	// LintConfig reports invalid lint configurations in charts.
+	LintConfig = support.Rule{ID: "lint-config", Severity: support.ErrorSev, Description: "The lint configuration of the chart must be valid"}
+)
+
+var all = []support.Rule{
+	ChartfileIsFile,
+	ChartfileYAML,
+	ChartfileName,
+	ChartfileNameFormat,
+	ChartfileNameDirMatch,
+	ChartfileVersion,
+	ChartfileEngine,
+	ChartfileMaintainer,
+	ChartfileSources,
+	ChartfileIcon,
+	ChartfileIconURL,
+	ValuesFile,
+	ValuesYAML,
+	TemplatesDir,
+	ChartLoad,
+	ValuesSchema,
+	TemplatesRender,
+	TemplatesExtension,
+	TemplatesYAML,
+	Policy,
+	KubeSchema,
+	LintConfig,
+}
+
+// All returns all the lint rules.
+func All() []support.Rule {
+	return append([]support.Rule(nil), all...)
+}
+
+// Find returns the lint rule with the given ID.
+func Find(id string) (support.Rule, bool) {
+	for _, r := range all {
+		if r.ID == id {
+			return r, true
+		}
+	}
+	return support.Rule{}, false
+}
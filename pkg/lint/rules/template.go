@@ -21,11 +21,14 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/ghodss/yaml"
 	"k8s.io/helm/pkg/chartutil"
 	"k8s.io/helm/pkg/engine"
+	"k8s.io/helm/pkg/kubeschema"
 	"k8s.io/helm/pkg/lint/support"
+	"k8s.io/helm/pkg/policy"
 	cpb "k8s.io/helm/pkg/proto/hapi/chart"
 	"k8s.io/helm/pkg/timeconv"
 	tversion "k8s.io/helm/pkg/version"
@@ -33,10 +36,37 @@ import (
 
 // Templates lints the templates in the Linter.
 func Templates(linter *support.Linter, values []byte, namespace string, strict bool) {
+	TemplatesWithPolicies(linter, values, namespace, strict, nil)
+}
+
+// TemplatesWithPolicies lints the templates in the Linter like Templates, and
+// checks the rendered manifests against the policy rules of checker, if any.
+//
+// Violations are reported as errors, or as warnings if the rules only warn.
+func TemplatesWithPolicies(linter *support.Linter, values []byte, namespace string, strict bool, checker *policy.Checker) {
+	TemplatesWithOptions(linter, values, TemplateOptions{Namespace: namespace, Strict: strict, Checker: checker})
+}
+
+// TemplateOptions configure the linting of templates by TemplatesWithOptions.
+type TemplateOptions struct {
+	Namespace string
+	Strict    bool
+	// Checker checks the rendered manifests against policy rules, if set.
+	Checker *policy.Checker
+	// Schemas validates the rendered manifests against the Kubernetes
+	// schemas, if set. The templates are then rendered for the Kubernetes
+	// version of the schemas.
+	Schemas *kubeschema.Validator
+}
+
+// TemplatesWithOptions lints the templates in the Linter like
+// TemplatesWithPolicies, and validates the rendered manifests against the
+// Kubernetes schemas of opts, if any.
+func TemplatesWithOptions(linter *support.Linter, values []byte, opts TemplateOptions) {
 	path := "templates/"
 	templatesPath := filepath.Join(linter.ChartDir, path)
 
-	templatesDirExist := linter.RunLinterRule(support.WarningSev, path, validateTemplatesDir(templatesPath))
+	templatesDirExist := linter.RunRule(TemplatesDir, path, validateTemplatesDir(templatesPath))
 
 	// Templates directory is optional for now
 	if !templatesDirExist {
@@ -46,22 +76,31 @@ func Templates(linter *support.Linter, values []byte, namespace string, strict b
 	// Load chart and parse templates, based on tiller/release_server
 	chart, err := chartutil.Load(linter.ChartDir)
 
-	chartLoaded := linter.RunLinterRule(support.ErrorSev, path, err)
+	chartLoaded := linter.RunRule(ChartLoad, path, err)
 
 	if !chartLoaded {
 		return
 	}
 
-	options := chartutil.ReleaseOptions{Name: "testRelease", Time: timeconv.Now(), Namespace: namespace}
+	options := chartutil.ReleaseOptions{Name: "testRelease", Time: timeconv.Now(), Namespace: opts.Namespace}
 	caps := &chartutil.Capabilities{
 		APIVersions:   chartutil.DefaultVersionSet,
 		KubeVersion:   chartutil.DefaultKubeVersion,
 		TillerVersion: tversion.GetVersionProto(),
 	}
+	if opts.Schemas != nil {
+		kubeVersion := *chartutil.DefaultKubeVersion
+		parts := strings.SplitN(opts.Schemas.KubeVersion, ".", 2)
+		kubeVersion.Major, kubeVersion.Minor = parts[0], parts[1]
+		kubeVersion.GitVersion = fmt.Sprintf("v%s.0", opts.Schemas.KubeVersion)
+		caps.KubeVersion = &kubeVersion
+	}
 	cvals, err := chartutil.CoalesceValues(chart, &cpb.Config{Raw: string(values)})
 	if err != nil {
 		return
 	}
+	linter.RunRule(ValuesSchema, chartutil.SchemafileName, chartutil.ValidateValues(chart, cvals))
+
 	// convert our values back into config
 	yvals, err := cvals.YAML()
 	if err != nil {
@@ -77,12 +116,12 @@ func Templates(linter *support.Linter, values []byte, namespace string, strict b
 	}
 	e := engine.New()
 	e.LintMode = true
-	if strict {
+	if opts.Strict {
 		e.Strict = true
 	}
 	renderedContentMap, err := e.Render(chart, valuesToRender)
 
-	renderOk := linter.RunLinterRule(support.ErrorSev, path, err)
+	renderOk := linter.RunRule(TemplatesRender, path, err)
 
 	if !renderOk {
 		return
@@ -99,7 +138,7 @@ func Templates(linter *support.Linter, values []byte, namespace string, strict b
 		fileName, _ := template.Name, template.Data
 		path = fileName
 
-		linter.RunLinterRule(support.ErrorSev, path, validateAllowedExtension(fileName))
+		linter.RunRule(TemplatesExtension, path, validateAllowedExtension(fileName))
 
 		// We only apply the following lint rules to yaml files
 		if filepath.Ext(fileName) != ".yaml" || filepath.Ext(fileName) == ".yml" {
@@ -119,12 +158,49 @@ func Templates(linter *support.Linter, values []byte, namespace string, strict b
 		// key will be raised as well
 		err := yaml.Unmarshal([]byte(renderedContent), &yamlStruct)
 
-		validYaml := linter.RunLinterRule(support.ErrorSev, path, validateYamlContent(err))
+		validYaml := linter.RunRule(TemplatesYAML, path, validateYamlContent(err))
 
 		if !validYaml {
 			continue
 		}
 	}
+
+	if opts.Checker != nil {
+		lintPolicies(linter, chart.GetMetadata().Name, renderedContentMap, opts.Checker)
+	}
+	if opts.Schemas != nil {
+		lintSchemas(linter, chart.GetMetadata().Name, renderedContentMap, opts.Schemas)
+	}
+}
+
+func lintPolicies(linter *support.Linter, chartName string, rendered map[string]string, checker *policy.Checker) {
+	// Invalid manifests have been reported above.
+	objs, err := policy.ParseFiles(rendered)
+	if err != nil {
+		return
+	}
+	violations, err := checker.Run(objs)
+	if !linter.RunRule(Policy, "templates/", err) {
+		return
+	}
+	rule := Policy
+	if checker.WarnOnly {
+		rule.Severity = support.WarningSev
+	}
+	for _, v := range violations {
+		linter.RunRule(rule, strings.TrimPrefix(v.Source, chartName+"/"), errors.New(v.String()))
+	}
+}
+
+func lintSchemas(linter *support.Linter, chartName string, rendered map[string]string, schemas *kubeschema.Validator) {
+	// Invalid manifests have been reported above.
+	errs, err := schemas.ValidateFiles(rendered)
+	if err != nil {
+		return
+	}
+	for _, e := range errs {
+		linter.RunRule(KubeSchema, strings.TrimPrefix(e.Source, chartName+"/"), fmt.Errorf("line %d: %s", e.Line, e.Describe()))
+	}
 }
 
 // Validation functions
@@ -22,7 +22,9 @@ import (
 	"strings"
 	"testing"
 
+	"k8s.io/helm/pkg/kubeschema"
 	"k8s.io/helm/pkg/lint/support"
+	"k8s.io/helm/pkg/policy"
 )
 
 const templateTestBasedir = "./testdata/albatross"
@@ -81,3 +83,80 @@ func TestTemplateIntegrationHappyPath(t *testing.T) {
 		t.Fatalf("Expected no error, got %d, %v", len(res), res)
 	}
 }
+
+func TestTemplateValuesSchema(t *testing.T) {
+	linter := support.Linter{ChartDir: "./testdata/badvaluesschema"}
+	Templates(&linter, []byte{}, namespace, strict)
+	res := linter.Messages
+
+	if len(res) != 1 {
+		t.Fatalf("Expected one error, got %d, %v", len(res), res)
+	}
+	if res[0].Path != "values.schema.json" || !strings.Contains(res[0].Err.Error(), "httpPort: Invalid type") {
+		t.Errorf("Unexpected error: %s", res[0])
+	}
+
+	linter = support.Linter{ChartDir: "./testdata/badvaluesschema"}
+	Templates(&linter, []byte("httpPort: 8080"), namespace, strict)
+	if len(linter.Messages) != 0 {
+		t.Errorf("Expected valid values to pass, got %v", linter.Messages)
+	}
+}
+
+func TestTemplatePolicies(t *testing.T) {
+	checker := &policy.Checker{Validators: []policy.Validator{policy.NoPrivileged{}}}
+
+	linter := support.Linter{ChartDir: "./testdata/privileged"}
+	TemplatesWithPolicies(&linter, []byte{}, namespace, strict, checker)
+	res := linter.Messages
+
+	if len(res) != 1 {
+		t.Fatalf("Expected one error, got %d, %v", len(res), res)
+	}
+	if res[0].Severity != support.ErrorSev || res[0].Path != "templates/pod.yaml" || !strings.Contains(res[0].Err.Error(), `[no-privileged] Pod/testRelease-debug in privileged/templates/pod.yaml: container "debug" is privileged`) {
+		t.Errorf("Unexpected error: %s", res[0])
+	}
+
+	checker.WarnOnly = true
+	linter = support.Linter{ChartDir: "./testdata/privileged"}
+	TemplatesWithPolicies(&linter, []byte{}, namespace, strict, checker)
+	if len(linter.Messages) != 1 || linter.Messages[0].Severity != support.WarningSev {
+		t.Errorf("Expected one warning, got %v", linter.Messages)
+	}
+
+	linter = support.Linter{ChartDir: "./testdata/privileged"}
+	Templates(&linter, []byte{}, namespace, strict)
+	if len(linter.Messages) != 0 {
+		t.Errorf("Expected no policy checks without a checker, got %v", linter.Messages)
+	}
+}
+
+func TestTemplateKubeSchemas(t *testing.T) {
+	schemas, err := kubeschema.New("1.13", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	linter := support.Linter{ChartDir: "./testdata/badkubeschema"}
+	TemplatesWithOptions(&linter, []byte{}, TemplateOptions{Namespace: namespace, Schemas: schemas})
+	res := linter.Messages
+
+	if len(res) != 2 {
+		t.Fatalf("Expected two errors, got %d, %v", len(res), res)
+	}
+	expected := []string{
+		`line 13: Deployment/testRelease-web: spec.template.spec: missing required field "containers"`,
+		`line 14: Deployment/testRelease-web: spec.template.spec: unknown field "contianers"`,
+	}
+	for i, msg := range res {
+		if msg.RuleID() != KubeSchema.ID || msg.Path != "templates/deployment.yaml" || msg.Err.Error() != expected[i] {
+			t.Errorf("Expected %q, got %s", expected[i], msg)
+		}
+	}
+
+	linter = support.Linter{ChartDir: "./testdata/badkubeschema"}
+	Templates(&linter, []byte{}, namespace, strict)
+	if len(linter.Messages) != 0 {
+		t.Errorf("Expected no validation without schemas, got %v", linter.Messages)
+	}
+}
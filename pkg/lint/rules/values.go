@@ -29,13 +29,13 @@ import (
 func Values(linter *support.Linter) {
 	file := "values.yaml"
 	vf := filepath.Join(linter.ChartDir, file)
-	fileExists := linter.RunLinterRule(support.InfoSev, file, validateValuesFileExistence(linter, vf))
+	fileExists := linter.RunRule(ValuesFile, file, validateValuesFileExistence(linter, vf))
 
 	if !fileExists {
 		return
 	}
 
-	linter.RunLinterRule(support.ErrorSev, file, validateValuesFile(linter, vf))
+	linter.RunRule(ValuesYAML, file, validateValuesFile(linter, vf))
 }
 
 func validateValuesFileExistence(linter *support.Linter, valuesPath string) error {
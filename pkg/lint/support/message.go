@@ -30,15 +30,38 @@ const (
 	ErrorSev
 )
 
+// DisabledSev disables a rule when set as its severity in Linter.Severities.
+const DisabledSev = -1
+
 // sev matches the *Sev states.
 var sev = []string{"UNKNOWN", "INFO", "WARNING", "ERROR"}
 
+// SeverityString returns the name of a severity, as in "WARNING".
+func SeverityString(severity int) string {
+	if severity < 0 || severity >= len(sev) {
+		return sev[UnknownSev]
+	}
+	return sev[severity]
+}
+
+// Rule is a lint rule. Its ID is stable, and identifies the rule in lint
+// configurations and reports.
+type Rule struct {
+	ID string
+	// Severity is the default severity of the messages of the rule, one of
+	// the *Sev constants.
+	Severity    int
+	Description string
+}
+
 // Linter encapsulates a linting run of a particular chart.
 type Linter struct {
 	Messages []Message
 	// The highest severity of all the failing lint rules
 	HighestSeverity int
 	ChartDir        string
+	// Severities overrides the default severities of rules, by rule ID.
+	Severities map[string]int
 }
 
 // Message describes an error encountered while linting.
@@ -53,6 +76,21 @@ func (m Message) Error() string {
 	return fmt.Sprintf("[%s] %s: %s", sev[m.Severity], m.Path, m.Err.Error())
 }
 
+// RuleID returns the ID of the rule reporting the message, if any.
+func (m Message) RuleID() string {
+	if err, ok := m.Err.(ruleError); ok {
+		return err.rule
+	}
+	return ""
+}
+
+// ruleError is the error of a message reported by a rule. It reads as the
+// error of the failed validation.
+type ruleError struct {
+	error
+	rule string
+}
+
 // NewMessage creates a new Message struct
 func NewMessage(severity int, path string, err error) Message {
 	return Message{Severity: severity, Path: path, Err: err}
@@ -60,12 +98,33 @@ func NewMessage(severity int, path string, err error) Message {
 
 // RunLinterRule returns true if the validation passed
 func (l *Linter) RunLinterRule(severity int, path string, err error) bool {
+	return l.run("", severity, path, err)
+}
+
+// RunRule reports a failed validation of rule with the severity configured
+// for the rule, unless the rule is disabled. It returns true if the
+// validation passed.
+func (l *Linter) RunRule(rule Rule, path string, err error) bool {
+	severity := rule.Severity
+	if s, ok := l.Severities[rule.ID]; ok {
+		severity = s
+	}
+	if severity == DisabledSev {
+		return err == nil
+	}
+	return l.run(rule.ID, severity, path, err)
+}
+
+func (l *Linter) run(rule string, severity int, path string, err error) bool {
 	// severity is out of bound
 	if severity < 0 || severity >= len(sev) {
 		return false
 	}
 
 	if err != nil {
+		if rule != "" {
+			err = ruleError{error: err, rule: rule}
+		}
 		l.Messages = append(l.Messages, NewMessage(severity, path, err))
 
 		if severity > l.HighestSeverity {
@@ -77,3 +77,39 @@ func TestMessage(t *testing.T) {
 		t.Errorf("Unexpected output: %s", m.Error())
 	}
 }
+
+func TestRunRule(t *testing.T) {
+	rule := Rule{ID: "test-rule", Severity: WarningSev}
+	var tests = []struct {
+		severities       map[string]int
+		expectedMessages int
+		expectedSeverity int
+	}{
+		{nil, 1, WarningSev},
+		{map[string]int{"test-rule": ErrorSev}, 1, ErrorSev},
+		{map[string]int{"other-rule": ErrorSev}, 1, WarningSev},
+		{map[string]int{"test-rule": DisabledSev}, 0, UnknownSev},
+	}
+
+	for _, test := range tests {
+		l := Linter{Severities: test.severities}
+		if l.RunRule(rule, "chart", errLint) {
+			t.Errorf("RunRule with %v should have returned false for a failed validation", test.severities)
+		}
+		if len(l.Messages) != test.expectedMessages {
+			t.Fatalf("RunRule with %v, expected %d messages, got %v", test.severities, test.expectedMessages, l.Messages)
+		}
+		if test.expectedMessages > 0 && (l.Messages[0].RuleID() != "test-rule" || l.Messages[0].Severity != test.expectedSeverity) {
+			t.Errorf("RunRule with %v, expected a test-rule message of severity %d, got %v", test.severities, test.expectedSeverity, l.Messages[0])
+		}
+		if test.expectedMessages > 0 && l.Messages[0].Error() != "["+SeverityString(test.expectedSeverity)+"] chart: lint failed" {
+			t.Errorf("RunRule with %v, expected the message not to name the rule, got %s", test.severities, l.Messages[0].Error())
+		}
+		if l.HighestSeverity != test.expectedSeverity {
+			t.Errorf("RunRule with %v, expected highest severity %d, got %d", test.severities, test.expectedSeverity, l.HighestSeverity)
+		}
+		if !l.RunRule(rule, "chart", nil) {
+			t.Errorf("RunRule with %v should have returned true for a passed validation", test.severities)
+		}
+	}
+}
@@ -197,4 +197,7 @@ func SetupPluginEnv(settings helm_env.EnvSettings,
 	if settings.Debug {
 		os.Setenv("HELM_DEBUG", "1")
 	}
+	if settings.Local {
+		os.Setenv("HELM_TILLERLESS", "1")
+	}
 }
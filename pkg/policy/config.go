@@ -0,0 +1,117 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"fmt"
+	"io/ioutil"
+	"time"
+
+	"github.com/ghodss/yaml"
+)
+
+// Config configures a Checker. It is read from a YAML file like
+//
+//	warnOnly: false
+//	validators:
+//	- name: no-privileged
+//	- name: allowed-registries
+//	  registries: [registry.example.com]
+//	- name: resource-limits
+//	- name: company-rules
+//	  command: /usr/local/bin/check-manifests
+//	  args: [--strict]
+//	  timeout: 10s
+type Config struct {
+	// WarnOnly reports violations without failing the operation.
+	WarnOnly   bool              `json:"warnOnly"`
+	Validators []ValidatorConfig `json:"validators"`
+}
+
+// ValidatorConfig configures a validator.
+type ValidatorConfig struct {
+	// Name is the name of a built-in rule, or names the rule checked by
+	// Command.
+	Name string `json:"name"`
+	// Registries are the registries of the allowed-registries rule.
+	Registries []string `json:"registries,omitempty"`
+	// Command and Args run an external validator.
+	Command string   `json:"command,omitempty"`
+	Args    []string `json:"args,omitempty"`
+	// Timeout is the time Command has to check the objects, as a duration
+	// like "10s". It defaults to DefaultExecTimeout.
+	Timeout string `json:"timeout,omitempty"`
+}
+
+// LoadConfig loads the Checker configured by the YAML file at path.
+func LoadConfig(path string) (*Checker, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var config Config
+	if err := yaml.Unmarshal(b, &config); err != nil {
+		return nil, fmt.Errorf("cannot parse policy config %s: %s", path, err)
+	}
+	checker, err := config.Checker()
+	if err != nil {
+		return nil, fmt.Errorf("invalid policy config %s: %s", path, err)
+	}
+	return checker, nil
+}
+
+// Checker returns the Checker configured by c.
+func (c *Config) Checker() (*Checker, error) {
+	checker := &Checker{WarnOnly: c.WarnOnly}
+	for i, vc := range c.Validators {
+		v, err := vc.validator()
+		if err != nil {
+			return nil, fmt.Errorf("validator %d: %s", i+1, err)
+		}
+		checker.Validators = append(checker.Validators, v)
+	}
+	return checker, nil
+}
+
+func (vc ValidatorConfig) validator() (Validator, error) {
+	if vc.Command != "" {
+		if vc.Name == "" {
+			return nil, fmt.Errorf("command %s needs a name", vc.Command)
+		}
+		e := &Exec{RuleName: vc.Name, Command: vc.Command, Args: vc.Args}
+		if vc.Timeout != "" {
+			timeout, err := time.ParseDuration(vc.Timeout)
+			if err != nil || timeout <= 0 {
+				return nil, fmt.Errorf("invalid timeout %q of command %s", vc.Timeout, vc.Command)
+			}
+			e.Timeout = timeout
+		}
+		return e, nil
+	}
+	switch vc.Name {
+	case RuleNoPrivileged:
+		return NoPrivileged{}, nil
+	case RuleAllowedRegistries:
+		if len(vc.Registries) == 0 {
+			return nil, fmt.Errorf("%s needs registries", vc.Name)
+		}
+		return AllowedRegistries{Registries: vc.Registries}, nil
+	case RuleResourceLimits:
+		return ResourceLimits{}, nil
+	}
+	return nil, fmt.Errorf("unknown rule %q, set a command to run an external validator", vc.Name)
+}
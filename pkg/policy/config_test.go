@@ -0,0 +1,111 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLoadConfig(t *testing.T) {
+	checker, err := LoadConfig("testdata/policy.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !checker.WarnOnly {
+		t.Error("Expected warn-only mode")
+	}
+	var names []string
+	for _, v := range checker.Validators {
+		names = append(names, v.Name())
+	}
+	if got := strings.Join(names, ","); got != "no-privileged,allowed-registries,resource-limits,no-default-namespace" {
+		t.Errorf("Unexpected validators %s", got)
+	}
+	if e := checker.Validators[3].(*Exec); e.Timeout != 10*time.Second {
+		t.Errorf("Expected a timeout of 10s, got %s", e.Timeout)
+	}
+}
+
+func TestConfig_Invalid(t *testing.T) {
+	tests := []struct {
+		config Config
+		err    string
+	}{
+		{
+			config: Config{Validators: []ValidatorConfig{{Name: "no-latest-tag"}}},
+			err:    `validator 1: unknown rule "no-latest-tag"`,
+		},
+		{
+			config: Config{Validators: []ValidatorConfig{{Name: RuleNoPrivileged}, {Name: RuleAllowedRegistries}}},
+			err:    "validator 2: allowed-registries needs registries",
+		},
+		{
+			config: Config{Validators: []ValidatorConfig{{Command: "/bin/true"}}},
+			err:    "validator 1: command /bin/true needs a name",
+		},
+		{
+			config: Config{Validators: []ValidatorConfig{{Name: "true", Command: "/bin/true", Timeout: "10"}}},
+			err:    `validator 1: invalid timeout "10" of command /bin/true`,
+		},
+	}
+	for _, tt := range tests {
+		if _, err := tt.config.Checker(); err == nil || !strings.HasPrefix(err.Error(), tt.err) {
+			t.Errorf("Expected error %q, got %v", tt.err, err)
+		}
+	}
+}
+
+func TestExec(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the test validator is a shell script")
+	}
+	v := &Exec{RuleName: "no-default-namespace", Command: "testdata/no-default-namespace.sh"}
+
+	objs, err := ParseFiles(map[string]string{
+		"mychart/templates/configmap.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: settings\n  namespace: default\n",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	checker := &Checker{Validators: []Validator{v}}
+	violations, err := checker.Run(objs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(violations) != 1 || violations[0].Rule != "no-default-namespace" || violations[0].Name != "settings" {
+		t.Errorf("Unexpected violations %v", violations)
+	}
+
+	violations, err = checker.Run(nil)
+	if err != nil || len(violations) != 0 {
+		t.Errorf("Expected no violations, got %v (%v)", violations, err)
+	}
+
+	v.Command = "testdata/missing.sh"
+	if _, err := checker.Run(objs); err == nil || !strings.HasPrefix(err.Error(), "policy rule no-default-namespace:") {
+		t.Errorf("Expected a failure running the validator, got %v", err)
+	}
+
+	v.Command = "testdata/sleep.sh"
+	v.Timeout = 100 * time.Millisecond
+	if _, err := checker.Run(objs); err == nil || err.Error() != "policy rule no-default-namespace: testdata/sleep.sh timed out after 100ms" {
+		t.Errorf("Expected the validator to time out, got %v", err)
+	}
+}
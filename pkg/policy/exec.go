@@ -0,0 +1,98 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"golang.org/x/net/context"
+)
+
+// DefaultExecTimeout is the time an external validator has to check the
+// objects if its Exec sets no timeout.
+const DefaultExecTimeout = 30 * time.Second
+
+// Exec runs an external command as a validator.
+//
+// The command reads the objects to check from stdin as
+//
+//	{"objects": [{"source": "...", "hook": "...", "kind": "...", "name": "...", "object": {...}}]}
+//
+// and writes the violations it finds to stdout as
+//
+//	{"violations": [{"source": "...", "kind": "...", "name": "...", "message": "..."}]}
+//
+// A command exiting with a non-zero status, or running longer than the
+// timeout, fails the check.
+type Exec struct {
+	// RuleName names the rule in violations not naming one.
+	RuleName string
+	Command  string
+	Args     []string
+	// Timeout is the time the command has to check the objects. It defaults
+	// to DefaultExecTimeout.
+	Timeout time.Duration
+}
+
+// Name implements Validator.
+func (e *Exec) Name() string { return e.RuleName }
+
+// Validate implements Validator.
+func (e *Exec) Validate(objs []*Object) ([]Violation, error) {
+	if objs == nil {
+		objs = []*Object{}
+	}
+	in, err := json.Marshal(map[string]interface{}{"objects": objs})
+	if err != nil {
+		return nil, err
+	}
+
+	timeout := e.Timeout
+	if timeout <= 0 {
+		timeout = DefaultExecTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, e.Command, e.Args...)
+	cmd.Stdin = bytes.NewReader(in)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return nil, fmt.Errorf("%s timed out after %s", e.Command, timeout)
+		}
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("%s: %s", err, msg)
+		}
+		return nil, err
+	}
+
+	var out struct {
+		Violations []Violation `json:"violations"`
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &out); err != nil {
+		return nil, fmt.Errorf("cannot parse the output of %s: %s", e.Command, err)
+	}
+	return out.Violations, nil
+}
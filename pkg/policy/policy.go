@@ -0,0 +1,180 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/ghodss/yaml"
+
+	"k8s.io/helm/pkg/releaseutil"
+)
+
+// hookAnnotation is the annotation marking an object as a hook.
+const hookAnnotation = "helm.sh/hook"
+
+// Object is a rendered Kubernetes object checked by validators.
+type Object struct {
+	// Source is the template the object was rendered from.
+	Source string `json:"source"`
+	// Hook lists the hook events of the object, if it is a hook.
+	Hook string `json:"hook,omitempty"`
+	// Kind and Name identify the object.
+	Kind string `json:"kind"`
+	Name string `json:"name"`
+	// Content is the object, as decoded from YAML.
+	Content map[string]interface{} `json:"object"`
+}
+
+// ParseObject decodes the object rendered from source. It returns nil for
+// empty manifests.
+func ParseObject(source, manifest string) (*Object, error) {
+	var content map[string]interface{}
+	if err := yaml.Unmarshal([]byte(manifest), &content); err != nil {
+		return nil, fmt.Errorf("cannot parse %s: %s", source, err)
+	}
+	if len(content) == 0 {
+		return nil, nil
+	}
+	obj := &Object{Source: source, Content: content}
+	obj.Kind, _ = content["kind"].(string)
+	if metadata, ok := content["metadata"].(map[string]interface{}); ok {
+		obj.Name, _ = metadata["name"].(string)
+		if annotations, ok := metadata["annotations"].(map[string]interface{}); ok {
+			obj.Hook, _ = annotations[hookAnnotation].(string)
+		}
+	}
+	return obj, nil
+}
+
+// ParseFiles decodes the objects of rendered templates, keyed by the path of
+// the template. Partials and NOTES.txt are skipped.
+func ParseFiles(files map[string]string) ([]*Object, error) {
+	names := make([]string, 0, len(files))
+	for name := range files {
+		base := path.Base(name)
+		if strings.HasPrefix(base, "_") || base == "NOTES.txt" {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var objs []*Object
+	for _, name := range names {
+		docs := releaseutil.SplitManifests(files[name])
+		for i := 0; i < len(docs); i++ {
+			obj, err := ParseObject(name, docs[fmt.Sprintf("manifest-%d", i)])
+			if err != nil {
+				return nil, err
+			}
+			if obj != nil {
+				objs = append(objs, obj)
+			}
+		}
+	}
+	return objs, nil
+}
+
+// Violation is an object breaking a rule.
+type Violation struct {
+	// Rule is the name of the rule broken.
+	Rule    string `json:"rule"`
+	Source  string `json:"source"`
+	Kind    string `json:"kind"`
+	Name    string `json:"name"`
+	Message string `json:"message"`
+}
+
+func (v Violation) String() string {
+	return fmt.Sprintf("[%s] %s/%s in %s: %s", v.Rule, v.Kind, v.Name, v.Source, v.Message)
+}
+
+// violation returns a violation of rule by obj.
+func (obj *Object) violation(rule, format string, args ...interface{}) Violation {
+	return Violation{
+		Rule:    rule,
+		Source:  obj.Source,
+		Kind:    obj.Kind,
+		Name:    obj.Name,
+		Message: fmt.Sprintf(format, args...),
+	}
+}
+
+// Validator checks objects against a rule.
+type Validator interface {
+	// Name names the rule in violations.
+	Name() string
+	// Validate returns the violations of the rule by objs.
+	Validate(objs []*Object) ([]Violation, error)
+}
+
+// Report lists the violations found by a Checker. It is returned as an error
+// when violations fail the operation.
+type Report struct {
+	Violations []Violation
+}
+
+func (r *Report) Error() string {
+	lines := make([]string, len(r.Violations))
+	for i, v := range r.Violations {
+		lines[i] = v.String()
+	}
+	return fmt.Sprintf("manifests violate %d policy rule(s):\n- %s", len(r.Violations), strings.Join(lines, "\n- "))
+}
+
+// Checker runs validators over rendered objects.
+type Checker struct {
+	Validators []Validator
+	// WarnOnly reports violations without failing the operation.
+	WarnOnly bool
+}
+
+// Run returns the violations found by every validator.
+func (c *Checker) Run(objs []*Object) ([]Violation, error) {
+	var violations []Violation
+	for _, v := range c.Validators {
+		found, err := v.Validate(objs)
+		if err != nil {
+			return nil, fmt.Errorf("policy rule %s: %s", v.Name(), err)
+		}
+		for _, f := range found {
+			if f.Rule == "" {
+				f.Rule = v.Name()
+			}
+			violations = append(violations, f)
+		}
+	}
+	return violations, nil
+}
+
+// Check runs the validators over objs. The report is also returned as the
+// error if it lists violations and the checker is not in warn-only mode.
+func (c *Checker) Check(objs []*Object) (*Report, error) {
+	violations, err := c.Run(objs)
+	if err != nil {
+		return nil, err
+	}
+	report := &Report{Violations: violations}
+	if len(violations) > 0 && !c.WarnOnly {
+		return report, report
+	}
+	return report, nil
+}
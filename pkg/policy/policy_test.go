@@ -0,0 +1,120 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const deployment = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+spec:
+  template:
+    spec:
+      initContainers:
+      - name: init
+        image: registry.example.com/init:1.0
+        resources:
+          limits: {cpu: 100m, memory: 64Mi}
+      containers:
+      - name: web
+        image: nginx:1.15
+        securityContext:
+          privileged: true
+`
+
+const hook = `apiVersion: batch/v1
+kind: Job
+metadata:
+  name: migrate
+  annotations:
+    "helm.sh/hook": pre-install,pre-upgrade
+spec:
+  template:
+    spec:
+      containers:
+      - name: migrate
+        image: registry.example.com/migrate:1.0
+        resources:
+          limits: {cpu: 100m, memory: 64Mi}
+`
+
+func TestParseFiles(t *testing.T) {
+	files := map[string]string{
+		"mychart/templates/NOTES.txt":       "Thank you for installing mychart.",
+		"mychart/templates/_helpers.tpl":    `{{ define "name" }}web{{ end }}`,
+		"mychart/templates/deployment.yaml": deployment,
+		"mychart/templates/empty.yaml":      "\n# nothing rendered\n",
+		"mychart/templates/jobs.yaml":       "---\n" + hook + "---\napiVersion: v1\nkind: Service\nmetadata:\n  name: web\n",
+	}
+	objs, err := ParseFiles(files)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	for _, obj := range objs {
+		got = append(got, strings.Join([]string{obj.Source, obj.Kind, obj.Name, obj.Hook}, "|"))
+	}
+	expected := []string{
+		"mychart/templates/deployment.yaml|Deployment|web|",
+		"mychart/templates/jobs.yaml|Job|migrate|pre-install,pre-upgrade",
+		"mychart/templates/jobs.yaml|Service|web|",
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected objects %v, got %v", expected, got)
+	}
+
+	if _, err := ParseFiles(map[string]string{"mychart/templates/bad.yaml": "kind: [Pod"}); err == nil {
+		t.Error("Expected an error parsing invalid YAML")
+	}
+}
+
+func TestChecker(t *testing.T) {
+	objs, err := ParseFiles(map[string]string{"mychart/templates/deployment.yaml": deployment})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checker := &Checker{Validators: []Validator{NoPrivileged{}, ResourceLimits{}}}
+	report, err := checker.Check(objs)
+	if err == nil {
+		t.Fatal("Expected violations to fail the check")
+	}
+	if len(report.Violations) != 2 {
+		t.Fatalf("Expected 2 violations, got %v", report.Violations)
+	}
+	expected := `manifests violate 2 policy rule(s):
+- [no-privileged] Deployment/web in mychart/templates/deployment.yaml: container "web" is privileged
+- [resource-limits] Deployment/web in mychart/templates/deployment.yaml: container "web" has no cpu or memory limit`
+	if err.Error() != expected {
+		t.Errorf("Expected error\n%s\ngot\n%s", expected, err)
+	}
+
+	checker.WarnOnly = true
+	report, err = checker.Check(objs)
+	if err != nil {
+		t.Errorf("Expected warn-only check to pass, got %s", err)
+	}
+	if len(report.Violations) != 2 {
+		t.Errorf("Expected 2 violations, got %v", report.Violations)
+	}
+}
@@ -0,0 +1,147 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"strings"
+)
+
+// Names of the built-in rules.
+const (
+	RuleNoPrivileged      = "no-privileged"
+	RuleAllowedRegistries = "allowed-registries"
+	RuleResourceLimits    = "resource-limits"
+)
+
+// NoPrivileged forbids privileged containers.
+type NoPrivileged struct{}
+
+// Name implements Validator.
+func (NoPrivileged) Name() string { return RuleNoPrivileged }
+
+// Validate implements Validator.
+func (NoPrivileged) Validate(objs []*Object) ([]Violation, error) {
+	var violations []Violation
+	for _, obj := range objs {
+		for _, c := range containers(obj) {
+			if privileged, _ := field(c, "securityContext", "privileged").(bool); privileged {
+				violations = append(violations, obj.violation(RuleNoPrivileged, "container %q is privileged", c["name"]))
+			}
+		}
+	}
+	return violations, nil
+}
+
+// AllowedRegistries requires the images of containers to come from one of
+// the registries, given as hosts optionally followed by a path prefix.
+type AllowedRegistries struct {
+	Registries []string
+}
+
+// Name implements Validator.
+func (AllowedRegistries) Name() string { return RuleAllowedRegistries }
+
+// Validate implements Validator.
+func (a AllowedRegistries) Validate(objs []*Object) ([]Violation, error) {
+	var violations []Violation
+	for _, obj := range objs {
+		for _, c := range containers(obj) {
+			image, _ := c["image"].(string)
+			if !a.allowed(image) {
+				violations = append(violations, obj.violation(RuleAllowedRegistries, "container %q uses image %q, which is not from the allowed registries %s", c["name"], image, strings.Join(a.Registries, ", ")))
+			}
+		}
+	}
+	return violations, nil
+}
+
+func (a AllowedRegistries) allowed(image string) bool {
+	for _, r := range a.Registries {
+		if strings.HasPrefix(image, strings.TrimSuffix(r, "/")+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// ResourceLimits requires containers to set CPU and memory limits.
+type ResourceLimits struct{}
+
+// Name implements Validator.
+func (ResourceLimits) Name() string { return RuleResourceLimits }
+
+// Validate implements Validator.
+func (ResourceLimits) Validate(objs []*Object) ([]Violation, error) {
+	var violations []Violation
+	for _, obj := range objs {
+		for _, c := range containers(obj) {
+			var missing []string
+			for _, resource := range []string{"cpu", "memory"} {
+				if field(c, "resources", "limits", resource) == nil {
+					missing = append(missing, resource)
+				}
+			}
+			if len(missing) > 0 {
+				violations = append(violations, obj.violation(RuleResourceLimits, "container %q has no %s limit", c["name"], strings.Join(missing, " or ")))
+			}
+		}
+	}
+	return violations, nil
+}
+
+// containers returns the containers and init containers of the pod spec of
+// obj, if it has one.
+func containers(obj *Object) []map[string]interface{} {
+	var spec interface{}
+	switch obj.Kind {
+	case "Pod":
+		spec = obj.Content["spec"]
+	case "CronJob":
+		spec = field(obj.Content, "spec", "jobTemplate", "spec", "template", "spec")
+	default:
+		// Deployments, StatefulSets, DaemonSets, Jobs and the like.
+		spec = field(obj.Content, "spec", "template", "spec")
+	}
+	podSpec, ok := spec.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	var list []map[string]interface{}
+	for _, key := range []string{"initContainers", "containers"} {
+		items, _ := podSpec[key].([]interface{})
+		for _, item := range items {
+			if c, ok := item.(map[string]interface{}); ok {
+				list = append(list, c)
+			}
+		}
+	}
+	return list
+}
+
+// field returns the value at path in m, or nil.
+func field(m map[string]interface{}, path ...string) interface{} {
+	var v interface{} = m
+	for _, key := range path {
+		obj, ok := v.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		v = obj[key]
+	}
+	return v
+}
@@ -0,0 +1,87 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"testing"
+)
+
+func TestRules(t *testing.T) {
+	cronJob := &Object{Kind: "CronJob", Name: "backup", Content: map[string]interface{}{
+		"spec": map[string]interface{}{"jobTemplate": map[string]interface{}{"spec": map[string]interface{}{"template": map[string]interface{}{"spec": map[string]interface{}{
+			"containers": []interface{}{
+				map[string]interface{}{"name": "backup", "image": "registry.example.com/team/backup:1.0"},
+			},
+		}}}}},
+	}}
+	pod := &Object{Kind: "Pod", Name: "debug", Content: map[string]interface{}{
+		"spec": map[string]interface{}{
+			"containers": []interface{}{
+				map[string]interface{}{
+					"name":            "debug",
+					"image":           "registry.example.com.evil.io/debug",
+					"securityContext": map[string]interface{}{"privileged": false},
+					"resources":       map[string]interface{}{"limits": map[string]interface{}{"cpu": "1"}},
+				},
+			},
+		},
+	}}
+	configMap := &Object{Kind: "ConfigMap", Name: "settings", Content: map[string]interface{}{"data": map[string]interface{}{}}}
+	objs := []*Object{cronJob, pod, configMap}
+
+	tests := []struct {
+		validator Validator
+		expected  []string
+	}{
+		{
+			validator: NoPrivileged{},
+		},
+		{
+			validator: AllowedRegistries{Registries: []string{"registry.example.com/"}},
+			expected:  []string{`container "debug" uses image "registry.example.com.evil.io/debug", which is not from the allowed registries registry.example.com/`},
+		},
+		{
+			validator: AllowedRegistries{Registries: []string{"registry.example.com/team", "registry.example.com.evil.io"}},
+		},
+		{
+			validator: ResourceLimits{},
+			expected: []string{
+				`container "backup" has no cpu or memory limit`,
+				`container "debug" has no memory limit`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		violations, err := tt.validator.Validate(objs)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(violations) != len(tt.expected) {
+			t.Errorf("%s: expected %d violations, got %v", tt.validator.Name(), len(tt.expected), violations)
+			continue
+		}
+		for i, v := range violations {
+			if v.Message != tt.expected[i] {
+				t.Errorf("%s: expected %q, got %q", tt.validator.Name(), tt.expected[i], v.Message)
+			}
+			if v.Rule != tt.validator.Name() {
+				t.Errorf("%s: expected rule %s, got %s", tt.validator.Name(), tt.validator.Name(), v.Rule)
+			}
+		}
+	}
+}
@@ -0,0 +1,7 @@
+#!/bin/sh
+# Reports every object of the "default" namespace.
+if grep -q '"namespace":"default"' ; then
+  echo '{"violations": [{"kind": "ConfigMap", "name": "settings", "source": "mychart/templates/configmap.yaml", "message": "objects must not be created in the default namespace"}]}'
+else
+  echo '{"violations": []}'
+fi
@@ -15,6 +15,7 @@ It is generated from these files:
 It has these top-level messages:
 	Hook
 	Info
+	Waves
 	Release
 	Status
 	TestRun
@@ -22,6 +22,8 @@ type Info struct {
 	Deleted *google_protobuf.Timestamp `protobuf:"bytes,4,opt,name=deleted" json:"deleted,omitempty"`
 	// Description is human-friendly "log entry" about this release.
 	Description string `protobuf:"bytes,5,opt,name=Description" json:"Description,omitempty"`
+	// Waves tracks the progress of an upgrade applied in waves.
+	Waves *Waves `protobuf:"bytes,6,opt,name=waves" json:"waves,omitempty"`
 }
 
 func (m *Info) Reset()                    { *m = Info{} }
@@ -64,27 +66,76 @@ func (m *Info) GetDescription() string {
 	return ""
 }
 
+func (m *Info) GetWaves() *Waves {
+	if m != nil {
+		return m.Waves
+	}
+	return nil
+}
+
+// Waves is the progress of an upgrade applying the resources of a release in
+// waves, grouped by their helm.sh/wave annotation.
+type Waves struct {
+	// Applied is the number of waves applied so far.
+	Applied int32 `protobuf:"varint,1,opt,name=applied" json:"applied,omitempty"`
+	// Total is the number of waves of the upgrade.
+	Total int32 `protobuf:"varint,2,opt,name=total" json:"total,omitempty"`
+	// Paused is true when the upgrade waits to be continued.
+	Paused bool `protobuf:"varint,3,opt,name=paused" json:"paused,omitempty"`
+}
+
+func (m *Waves) Reset()                    { *m = Waves{} }
+func (m *Waves) String() string            { return proto.CompactTextString(m) }
+func (*Waves) ProtoMessage()               {}
+func (*Waves) Descriptor() ([]byte, []int) { return fileDescriptor1, []int{1} }
+
+func (m *Waves) GetApplied() int32 {
+	if m != nil {
+		return m.Applied
+	}
+	return 0
+}
+
+func (m *Waves) GetTotal() int32 {
+	if m != nil {
+		return m.Total
+	}
+	return 0
+}
+
+func (m *Waves) GetPaused() bool {
+	if m != nil {
+		return m.Paused
+	}
+	return false
+}
+
 func init() {
 	proto.RegisterType((*Info)(nil), "hapi.release.Info")
+	proto.RegisterType((*Waves)(nil), "hapi.release.Waves")
 }
 
 func init() { proto.RegisterFile("hapi/release/info.proto", fileDescriptor1) }
 
 var fileDescriptor1 = []byte{
-	// 235 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x8f, 0x31, 0x4f, 0xc3, 0x30,
-	0x10, 0x85, 0x95, 0x52, 0x5a, 0xd5, 0x6d, 0x19, 0x2c, 0x24, 0x42, 0x16, 0x22, 0xa6, 0x0e, 0xc8,
-	0x91, 0x80, 0x1d, 0x81, 0xba, 0xb0, 0x06, 0x26, 0x16, 0xe4, 0xe2, 0x73, 0xb1, 0xe4, 0xe6, 0x2c,
-	0xfb, 0x3a, 0xf0, 0x2f, 0xf8, 0xc9, 0xa8, 0xb6, 0x83, 0xd2, 0xa9, 0xab, 0xbf, 0xf7, 0x3e, 0xbf,
-	0x63, 0x57, 0xdf, 0xd2, 0x99, 0xc6, 0x83, 0x05, 0x19, 0xa0, 0x31, 0x9d, 0x46, 0xe1, 0x3c, 0x12,
-	0xf2, 0xc5, 0x01, 0x88, 0x0c, 0xaa, 0x9b, 0x2d, 0xe2, 0xd6, 0x42, 0x13, 0xd9, 0x66, 0xaf, 0x1b,
-	0x32, 0x3b, 0x08, 0x24, 0x77, 0x2e, 0xc5, 0xab, 0xeb, 0x23, 0x4f, 0x20, 0x49, 0xfb, 0x90, 0xd0,
-	0xed, 0xef, 0x88, 0x8d, 0x5f, 0x3b, 0x8d, 0xfc, 0x8e, 0x4d, 0x12, 0x28, 0x8b, 0xba, 0x58, 0xcd,
-	0xef, 0x2f, 0xc5, 0xf0, 0x0f, 0xf1, 0x16, 0x59, 0x9b, 0x33, 0xfc, 0x99, 0x5d, 0x68, 0xe3, 0x03,
-	0x7d, 0x2a, 0x70, 0x16, 0x7f, 0x40, 0x95, 0xa3, 0xd8, 0xaa, 0x44, 0xda, 0x22, 0xfa, 0x2d, 0xe2,
-	0xbd, 0xdf, 0xd2, 0x2e, 0x63, 0x63, 0x9d, 0x0b, 0xfc, 0x89, 0x2d, 0xad, 0x1c, 0x1a, 0xce, 0x4e,
-	0x1a, 0x16, 0x87, 0xc2, 0xbf, 0xe0, 0x91, 0x4d, 0x15, 0x58, 0x20, 0x50, 0xe5, 0xf8, 0x64, 0xb5,
-	0x8f, 0xf2, 0x9a, 0xcd, 0xd7, 0x10, 0xbe, 0xbc, 0x71, 0x64, 0xb0, 0x2b, 0xcf, 0xeb, 0x62, 0x35,
-	0x6b, 0x87, 0x4f, 0x2f, 0xb3, 0x8f, 0x69, 0xbe, 0x7a, 0x33, 0x89, 0xa6, 0x87, 0xbf, 0x00, 0x00,
-	0x00, 0xff, 0xff, 0x1a, 0x52, 0x8f, 0x9c, 0x89, 0x01, 0x00, 0x00,
+	// 291 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x90, 0x41, 0x4b, 0x03, 0x31,
+	0x10, 0x85, 0x69, 0x6d, 0xb6, 0x76, 0xda, 0x7a, 0x88, 0x45, 0x63, 0x2f, 0x96, 0x9e, 0x2a, 0x48,
+	0x16, 0xd4, 0xbb, 0x28, 0xbd, 0x78, 0x12, 0xa2, 0x20, 0x78, 0x91, 0xd4, 0x9d, 0xad, 0x81, 0xb4,
+	0x09, 0x9b, 0xac, 0xe2, 0x6f, 0xf3, 0xcf, 0x49, 0x93, 0x2c, 0x6c, 0x4f, 0x3d, 0xbe, 0x7c, 0xef,
+	0xbd, 0xcc, 0x0c, 0x9c, 0x7f, 0x49, 0xab, 0xf2, 0x0a, 0x35, 0x4a, 0x87, 0xb9, 0xda, 0x96, 0x86,
+	0xdb, 0xca, 0x78, 0x43, 0x47, 0x3b, 0xc0, 0x13, 0x98, 0x5e, 0xae, 0x8d, 0x59, 0x6b, 0xcc, 0x03,
+	0x5b, 0xd5, 0x65, 0xee, 0xd5, 0x06, 0x9d, 0x97, 0x1b, 0x1b, 0xed, 0xd3, 0x8b, 0xbd, 0x1e, 0xe7,
+	0xa5, 0xaf, 0x5d, 0x44, 0xf3, 0xbf, 0x2e, 0xf4, 0x9e, 0xb6, 0xa5, 0xa1, 0xd7, 0x90, 0x45, 0xc0,
+	0x3a, 0xb3, 0xce, 0x62, 0x78, 0x33, 0xe1, 0xed, 0x3f, 0xf8, 0x4b, 0x60, 0x22, 0x79, 0xe8, 0x03,
+	0x9c, 0x94, 0xaa, 0x72, 0xfe, 0xa3, 0x40, 0xab, 0xcd, 0x2f, 0x16, 0xac, 0x1b, 0x52, 0x53, 0x1e,
+	0x67, 0xe1, 0xcd, 0x2c, 0xfc, 0xb5, 0x99, 0x45, 0x8c, 0x43, 0x62, 0x99, 0x02, 0xf4, 0x1e, 0xc6,
+	0x5a, 0xb6, 0x1b, 0x8e, 0x0e, 0x36, 0x8c, 0xb4, 0x6c, 0x15, 0xdc, 0x41, 0xbf, 0x40, 0x8d, 0x1e,
+	0x0b, 0xd6, 0x3b, 0x18, 0x6d, 0xac, 0x74, 0x06, 0xc3, 0x25, 0xba, 0xcf, 0x4a, 0x59, 0xaf, 0xcc,
+	0x96, 0x91, 0x59, 0x67, 0x31, 0x10, 0xed, 0x27, 0x7a, 0x05, 0xe4, 0x47, 0x7e, 0xa3, 0x63, 0x59,
+	0x68, 0x3d, 0xdd, 0x3f, 0xc4, 0xdb, 0x0e, 0x89, 0xe8, 0x98, 0x3f, 0x03, 0x09, 0x9a, 0x32, 0xe8,
+	0x4b, 0x6b, 0xb5, 0xc2, 0x22, 0x9c, 0x8f, 0x88, 0x46, 0xd2, 0x09, 0x10, 0x6f, 0xbc, 0xd4, 0xe1,
+	0x40, 0x44, 0x44, 0x41, 0xcf, 0x20, 0xb3, 0xb2, 0x76, 0x69, 0xeb, 0x63, 0x91, 0xd4, 0xe3, 0xe0,
+	0xbd, 0x9f, 0x3e, 0x5a, 0x65, 0x61, 0x8b, 0xdb, 0xff, 0x01, 0x00, 0x57, 0x86, 0x24, 0xfa, 0x05,
+	0x02, 0x00, 0x00,
 }
@@ -19,6 +19,7 @@ It has these top-level messages:
 	UpdateReleaseResponse
 	RollbackReleaseRequest
 	RollbackReleaseResponse
+	ResourceDiff
 	InstallReleaseRequest
 	InstallReleaseResponse
 	UninstallReleaseRequest
@@ -29,6 +30,11 @@ It has these top-level messages:
 	GetHistoryResponse
 	TestReleaseRequest
 	TestReleaseResponse
+	ReleaseEvent
+	UnlockReleaseRequest
+	UnlockReleaseResponse
+	RepairReleaseRequest
+	RepairReleaseResponse
 */
 package services
 
@@ -109,6 +115,89 @@ func (x ListSort_SortOrder) String() string {
 }
 func (ListSort_SortOrder) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{1, 1} }
 
+// Change describes how the resource is changed.
+type ResourceDiff_Change int32
+
+const (
+	ResourceDiff_MODIFIED ResourceDiff_Change = 0
+	ResourceDiff_ADDED    ResourceDiff_Change = 1
+	ResourceDiff_REMOVED  ResourceDiff_Change = 2
+)
+
+var ResourceDiff_Change_name = map[int32]string{
+	0: "MODIFIED",
+	1: "ADDED",
+	2: "REMOVED",
+}
+var ResourceDiff_Change_value = map[string]int32{
+	"MODIFIED": 0,
+	"ADDED":    1,
+	"REMOVED":  2,
+}
+
+func (x ResourceDiff_Change) String() string {
+	return proto.EnumName(ResourceDiff_Change_name, int32(x))
+}
+func (ResourceDiff_Change) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{11, 0} }
+
+type ReleaseEvent_Type int32
+
+const (
+	ReleaseEvent_UNKNOWN ReleaseEvent_Type = 0
+	// A hook resource is about to be created.
+	ReleaseEvent_HOOK_STARTED ReleaseEvent_Type = 1
+	// A hook resource is ready.
+	ReleaseEvent_HOOK_FINISHED ReleaseEvent_Type = 2
+	// A resource has been created.
+	ReleaseEvent_RESOURCE_CREATED ReleaseEvent_Type = 3
+	// A resource has been patched.
+	ReleaseEvent_RESOURCE_UPDATED ReleaseEvent_Type = 4
+	// A resource has been deleted.
+	ReleaseEvent_RESOURCE_DELETED ReleaseEvent_Type = 5
+	// A resource is not ready yet.
+	ReleaseEvent_WAITING ReleaseEvent_Type = 6
+	// The operation completed. The release is set.
+	ReleaseEvent_COMPLETE ReleaseEvent_Type = 7
+	// A policy rule was violated without failing the operation.
+	ReleaseEvent_WARNING ReleaseEvent_Type = 8
+	// A test of the release is running or completed.
+	ReleaseEvent_TEST ReleaseEvent_Type = 9
+	// A wave of an upgrade with steps is applied.
+	ReleaseEvent_WAVE ReleaseEvent_Type = 10
+)
+
+var ReleaseEvent_Type_name = map[int32]string{
+	0:  "UNKNOWN",
+	1:  "HOOK_STARTED",
+	2:  "HOOK_FINISHED",
+	3:  "RESOURCE_CREATED",
+	4:  "RESOURCE_UPDATED",
+	5:  "RESOURCE_DELETED",
+	6:  "WAITING",
+	7:  "COMPLETE",
+	8:  "WARNING",
+	9:  "TEST",
+	10: "WAVE",
+}
+var ReleaseEvent_Type_value = map[string]int32{
+	"UNKNOWN":          0,
+	"HOOK_STARTED":     1,
+	"HOOK_FINISHED":    2,
+	"RESOURCE_CREATED": 3,
+	"RESOURCE_UPDATED": 4,
+	"RESOURCE_DELETED": 5,
+	"WAITING":          6,
+	"COMPLETE":         7,
+	"WARNING":          8,
+	"TEST":             9,
+	"WAVE":             10,
+}
+
+func (x ReleaseEvent_Type) String() string {
+	return proto.EnumName(ReleaseEvent_Type_name, int32(x))
+}
+func (ReleaseEvent_Type) EnumDescriptor() ([]byte, []int) { return fileDescriptor0, []int{22, 0} }
+
 // ListReleasesRequest requests a list of releases.
 //
 // Releases can be retrieved in chunks by setting limit and offset.
@@ -383,6 +472,29 @@ type UpdateReleaseRequest struct {
 	Description string `protobuf:"bytes,12,opt,name=description" json:"description,omitempty"`
 	// Render subchart notes if enabled
 	SubNotes bool `protobuf:"varint,13,opt,name=subNotes" json:"subNotes,omitempty"`
+	// diff, if true, will render the release and compute its difference with
+	// the deployed release, without creating a release object nor deploying
+	// to Kubernetes.
+	Diff bool `protobuf:"varint,14,opt,name=diff" json:"diff,omitempty"`
+	// three_way_merge, if true, will patch the resources with a three-way merge
+	// of the current manifest, the target manifest and the live state of the
+	// resources, instead of a two-way merge ignoring the live state.
+	ThreeWayMerge bool `protobuf:"varint,15,opt,name=three_way_merge,json=threeWayMerge" json:"three_way_merge,omitempty"`
+	// test_after, if true, will run the tests of the release once it is
+	// upgraded, recording their results in the status of the release.
+	TestAfter bool `protobuf:"varint,16,opt,name=test_after,json=testAfter" json:"test_after,omitempty"`
+	// atomic_tests, if true, will roll back to the previous revision if any
+	// test run after the upgrade fails.
+	AtomicTests bool `protobuf:"varint,17,opt,name=atomic_tests,json=atomicTests" json:"atomic_tests,omitempty"`
+	// steps, if true, will apply the resources in waves grouped by their
+	// helm.sh/wave annotation, waiting for each wave to be ready.
+	Steps bool `protobuf:"varint,18,opt,name=steps" json:"steps,omitempty"`
+	// pause, if true, will pause the upgrade after each wave but the last
+	// one, until it is continued.
+	Pause bool `protobuf:"varint,19,opt,name=pause" json:"pause,omitempty"`
+	// continue, if true, will continue the paused upgrade of the release.
+	// The chart and values of the paused upgrade are used.
+	Continue bool `protobuf:"varint,20,opt,name=continue" json:"continue,omitempty"`
 }
 
 func (m *UpdateReleaseRequest) Reset()                    { *m = UpdateReleaseRequest{} }
@@ -481,9 +593,61 @@ func (m *UpdateReleaseRequest) GetSubNotes() bool {
 	return false
 }
 
+func (m *UpdateReleaseRequest) GetDiff() bool {
+	if m != nil {
+		return m.Diff
+	}
+	return false
+}
+
+func (m *UpdateReleaseRequest) GetThreeWayMerge() bool {
+	if m != nil {
+		return m.ThreeWayMerge
+	}
+	return false
+}
+
+func (m *UpdateReleaseRequest) GetTestAfter() bool {
+	if m != nil {
+		return m.TestAfter
+	}
+	return false
+}
+
+func (m *UpdateReleaseRequest) GetAtomicTests() bool {
+	if m != nil {
+		return m.AtomicTests
+	}
+	return false
+}
+
+func (m *UpdateReleaseRequest) GetSteps() bool {
+	if m != nil {
+		return m.Steps
+	}
+	return false
+}
+
+func (m *UpdateReleaseRequest) GetPause() bool {
+	if m != nil {
+		return m.Pause
+	}
+	return false
+}
+
+func (m *UpdateReleaseRequest) GetContinue() bool {
+	if m != nil {
+		return m.Continue
+	}
+	return false
+}
+
 // UpdateReleaseResponse is the response to an update request.
 type UpdateReleaseResponse struct {
 	Release *hapi_release5.Release `protobuf:"bytes,1,opt,name=release" json:"release,omitempty"`
+	// Diffs is the per-resource difference with the deployed release. It is
+	// only set when diff was requested.
+	Diffs []*ResourceDiff `protobuf:"bytes,2,rep,name=diffs" json:"diffs,omitempty"`
 }
 
 func (m *UpdateReleaseResponse) Reset()                    { *m = UpdateReleaseResponse{} }
@@ -498,6 +662,13 @@ func (m *UpdateReleaseResponse) GetRelease() *hapi_release5.Release {
 	return nil
 }
 
+func (m *UpdateReleaseResponse) GetDiffs() []*ResourceDiff {
+	if m != nil {
+		return m.Diffs
+	}
+	return nil
+}
+
 type RollbackReleaseRequest struct {
 	// The name of the release
 	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
@@ -518,6 +689,14 @@ type RollbackReleaseRequest struct {
 	Force bool `protobuf:"varint,8,opt,name=force" json:"force,omitempty"`
 	// Description, if set, will set the description for the rollback
 	Description string `protobuf:"bytes,9,opt,name=description" json:"description,omitempty"`
+	// diff, if true, will compute the difference between the current and the
+	// target release, without creating a release object nor deploying to
+	// Kubernetes.
+	Diff bool `protobuf:"varint,10,opt,name=diff" json:"diff,omitempty"`
+	// three_way_merge, if true, will patch the resources with a three-way merge
+	// of the current manifest, the target manifest and the live state of the
+	// resources, instead of a two-way merge ignoring the live state.
+	ThreeWayMerge bool `protobuf:"varint,11,opt,name=three_way_merge,json=threeWayMerge" json:"three_way_merge,omitempty"`
 }
 
 func (m *RollbackReleaseRequest) Reset()                    { *m = RollbackReleaseRequest{} }
@@ -588,9 +767,26 @@ func (m *RollbackReleaseRequest) GetDescription() string {
 	return ""
 }
 
+func (m *RollbackReleaseRequest) GetDiff() bool {
+	if m != nil {
+		return m.Diff
+	}
+	return false
+}
+
+func (m *RollbackReleaseRequest) GetThreeWayMerge() bool {
+	if m != nil {
+		return m.ThreeWayMerge
+	}
+	return false
+}
+
 // RollbackReleaseResponse is the response to an update request.
 type RollbackReleaseResponse struct {
 	Release *hapi_release5.Release `protobuf:"bytes,1,opt,name=release" json:"release,omitempty"`
+	// Diffs is the per-resource difference with the current release. It is
+	// only set when diff was requested.
+	Diffs []*ResourceDiff `protobuf:"bytes,2,rep,name=diffs" json:"diffs,omitempty"`
 }
 
 func (m *RollbackReleaseResponse) Reset()                    { *m = RollbackReleaseResponse{} }
@@ -605,6 +801,69 @@ func (m *RollbackReleaseResponse) GetRelease() *hapi_release5.Release {
 	return nil
 }
 
+func (m *RollbackReleaseResponse) GetDiffs() []*ResourceDiff {
+	if m != nil {
+		return m.Diffs
+	}
+	return nil
+}
+
+// ResourceDiff is the difference between two revisions of the manifest of a
+// single Kubernetes resource.
+type ResourceDiff struct {
+	// Kind is the kind of the resource.
+	Kind string `protobuf:"bytes,1,opt,name=kind" json:"kind,omitempty"`
+	// Namespace is the namespace of the resource.
+	Namespace string `protobuf:"bytes,2,opt,name=namespace" json:"namespace,omitempty"`
+	// Name is the name of the resource.
+	Name string `protobuf:"bytes,3,opt,name=name" json:"name,omitempty"`
+	// Change describes how the resource is changed.
+	Change ResourceDiff_Change `protobuf:"varint,4,opt,name=change,enum=hapi.services.tiller.ResourceDiff_Change" json:"change,omitempty"`
+	// Diff is the unified diff of the resource manifests. The values of
+	// Secrets are masked.
+	Diff string `protobuf:"bytes,5,opt,name=diff" json:"diff,omitempty"`
+}
+
+func (m *ResourceDiff) Reset()                    { *m = ResourceDiff{} }
+func (m *ResourceDiff) String() string            { return proto.CompactTextString(m) }
+func (*ResourceDiff) ProtoMessage()               {}
+func (*ResourceDiff) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
+
+func (m *ResourceDiff) GetKind() string {
+	if m != nil {
+		return m.Kind
+	}
+	return ""
+}
+
+func (m *ResourceDiff) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *ResourceDiff) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *ResourceDiff) GetChange() ResourceDiff_Change {
+	if m != nil {
+		return m.Change
+	}
+	return ResourceDiff_MODIFIED
+}
+
+func (m *ResourceDiff) GetDiff() string {
+	if m != nil {
+		return m.Diff
+	}
+	return ""
+}
+
 // InstallReleaseRequest is the request for an installation of a chart.
 type InstallReleaseRequest struct {
 	// Chart is the protobuf representation of a chart.
@@ -639,7 +898,7 @@ type InstallReleaseRequest struct {
 func (m *InstallReleaseRequest) Reset()                    { *m = InstallReleaseRequest{} }
 func (m *InstallReleaseRequest) String() string            { return proto.CompactTextString(m) }
 func (*InstallReleaseRequest) ProtoMessage()               {}
-func (*InstallReleaseRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
+func (*InstallReleaseRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
 
 func (m *InstallReleaseRequest) GetChart() *hapi_chart3.Chart {
 	if m != nil {
@@ -733,7 +992,7 @@ type InstallReleaseResponse struct {
 func (m *InstallReleaseResponse) Reset()                    { *m = InstallReleaseResponse{} }
 func (m *InstallReleaseResponse) String() string            { return proto.CompactTextString(m) }
 func (*InstallReleaseResponse) ProtoMessage()               {}
-func (*InstallReleaseResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{12} }
+func (*InstallReleaseResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
 
 func (m *InstallReleaseResponse) GetRelease() *hapi_release5.Release {
 	if m != nil {
@@ -754,12 +1013,15 @@ type UninstallReleaseRequest struct {
 	Timeout int64 `protobuf:"varint,4,opt,name=timeout" json:"timeout,omitempty"`
 	// Description, if set, will set the description for the uninstalled release
 	Description string `protobuf:"bytes,5,opt,name=description" json:"description,omitempty"`
+	// dry_run, if true, will check that the release can be uninstalled
+	// without deleting it.
+	DryRun bool `protobuf:"varint,6,opt,name=dry_run,json=dryRun" json:"dry_run,omitempty"`
 }
 
 func (m *UninstallReleaseRequest) Reset()                    { *m = UninstallReleaseRequest{} }
 func (m *UninstallReleaseRequest) String() string            { return proto.CompactTextString(m) }
 func (*UninstallReleaseRequest) ProtoMessage()               {}
-func (*UninstallReleaseRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{13} }
+func (*UninstallReleaseRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
 
 func (m *UninstallReleaseRequest) GetName() string {
 	if m != nil {
@@ -796,6 +1058,13 @@ func (m *UninstallReleaseRequest) GetDescription() string {
 	return ""
 }
 
+func (m *UninstallReleaseRequest) GetDryRun() bool {
+	if m != nil {
+		return m.DryRun
+	}
+	return false
+}
+
 // UninstallReleaseResponse represents a successful response to an uninstall request.
 type UninstallReleaseResponse struct {
 	// Release is the release that was marked deleted.
@@ -807,7 +1076,7 @@ type UninstallReleaseResponse struct {
 func (m *UninstallReleaseResponse) Reset()                    { *m = UninstallReleaseResponse{} }
 func (m *UninstallReleaseResponse) String() string            { return proto.CompactTextString(m) }
 func (*UninstallReleaseResponse) ProtoMessage()               {}
-func (*UninstallReleaseResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{14} }
+func (*UninstallReleaseResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
 
 func (m *UninstallReleaseResponse) GetRelease() *hapi_release5.Release {
 	if m != nil {
@@ -830,7 +1099,7 @@ type GetVersionRequest struct {
 func (m *GetVersionRequest) Reset()                    { *m = GetVersionRequest{} }
 func (m *GetVersionRequest) String() string            { return proto.CompactTextString(m) }
 func (*GetVersionRequest) ProtoMessage()               {}
-func (*GetVersionRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{15} }
+func (*GetVersionRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
 
 type GetVersionResponse struct {
 	Version *hapi_version.Version `protobuf:"bytes,1,opt,name=Version" json:"Version,omitempty"`
@@ -839,7 +1108,7 @@ type GetVersionResponse struct {
 func (m *GetVersionResponse) Reset()                    { *m = GetVersionResponse{} }
 func (m *GetVersionResponse) String() string            { return proto.CompactTextString(m) }
 func (*GetVersionResponse) ProtoMessage()               {}
-func (*GetVersionResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{16} }
+func (*GetVersionResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
 
 func (m *GetVersionResponse) GetVersion() *hapi_version.Version {
 	if m != nil {
@@ -859,7 +1128,7 @@ type GetHistoryRequest struct {
 func (m *GetHistoryRequest) Reset()                    { *m = GetHistoryRequest{} }
 func (m *GetHistoryRequest) String() string            { return proto.CompactTextString(m) }
 func (*GetHistoryRequest) ProtoMessage()               {}
-func (*GetHistoryRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{17} }
+func (*GetHistoryRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
 
 func (m *GetHistoryRequest) GetName() string {
 	if m != nil {
@@ -883,7 +1152,7 @@ type GetHistoryResponse struct {
 func (m *GetHistoryResponse) Reset()                    { *m = GetHistoryResponse{} }
 func (m *GetHistoryResponse) String() string            { return proto.CompactTextString(m) }
 func (*GetHistoryResponse) ProtoMessage()               {}
-func (*GetHistoryResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{18} }
+func (*GetHistoryResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
 
 func (m *GetHistoryResponse) GetReleases() []*hapi_release5.Release {
 	if m != nil {
@@ -907,7 +1176,7 @@ type TestReleaseRequest struct {
 func (m *TestReleaseRequest) Reset()                    { *m = TestReleaseRequest{} }
 func (m *TestReleaseRequest) String() string            { return proto.CompactTextString(m) }
 func (*TestReleaseRequest) ProtoMessage()               {}
-func (*TestReleaseRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{19} }
+func (*TestReleaseRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
 
 func (m *TestReleaseRequest) GetName() string {
 	if m != nil {
@@ -946,7 +1215,7 @@ type TestReleaseResponse struct {
 func (m *TestReleaseResponse) Reset()                    { *m = TestReleaseResponse{} }
 func (m *TestReleaseResponse) String() string            { return proto.CompactTextString(m) }
 func (*TestReleaseResponse) ProtoMessage()               {}
-func (*TestReleaseResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{20} }
+func (*TestReleaseResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{21} }
 
 func (m *TestReleaseResponse) GetMsg() string {
 	if m != nil {
@@ -962,6 +1231,186 @@ func (m *TestReleaseResponse) GetStatus() hapi_release1.TestRun_Status {
 	return hapi_release1.TestRun_UNKNOWN
 }
 
+// ReleaseEvent reports the progress of an install, upgrade or rollback.
+type ReleaseEvent struct {
+	Type ReleaseEvent_Type `protobuf:"varint,1,opt,name=type,enum=hapi.services.tiller.ReleaseEvent_Type" json:"type,omitempty"`
+	// The resource the event is about.
+	Kind      string `protobuf:"bytes,2,opt,name=kind" json:"kind,omitempty"`
+	Namespace string `protobuf:"bytes,3,opt,name=namespace" json:"namespace,omitempty"`
+	Name      string `protobuf:"bytes,4,opt,name=name" json:"name,omitempty"`
+	// Hook is the hook event, e.g. "pre-install", for hook events.
+	Hook string `protobuf:"bytes,5,opt,name=hook" json:"hook,omitempty"`
+	// Ready and total count the ready and expected replicas of a resource
+	// that is waited for, when applicable.
+	Ready int32 `protobuf:"varint,6,opt,name=ready" json:"ready,omitempty"`
+	Total int32 `protobuf:"varint,7,opt,name=total" json:"total,omitempty"`
+	// Message describes the event. For test events, it is the message of
+	// the test, e.g. "PASSED: smoke-test".
+	Message string `protobuf:"bytes,8,opt,name=message" json:"message,omitempty"`
+	// Release is the resulting release, set on the COMPLETE event.
+	Release *hapi_release5.Release `protobuf:"bytes,9,opt,name=release" json:"release,omitempty"`
+}
+
+func (m *ReleaseEvent) Reset()                    { *m = ReleaseEvent{} }
+func (m *ReleaseEvent) String() string            { return proto.CompactTextString(m) }
+func (*ReleaseEvent) ProtoMessage()               {}
+func (*ReleaseEvent) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{22} }
+
+func (m *ReleaseEvent) GetType() ReleaseEvent_Type {
+	if m != nil {
+		return m.Type
+	}
+	return ReleaseEvent_UNKNOWN
+}
+
+func (m *ReleaseEvent) GetKind() string {
+	if m != nil {
+		return m.Kind
+	}
+	return ""
+}
+
+func (m *ReleaseEvent) GetNamespace() string {
+	if m != nil {
+		return m.Namespace
+	}
+	return ""
+}
+
+func (m *ReleaseEvent) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *ReleaseEvent) GetHook() string {
+	if m != nil {
+		return m.Hook
+	}
+	return ""
+}
+
+func (m *ReleaseEvent) GetReady() int32 {
+	if m != nil {
+		return m.Ready
+	}
+	return 0
+}
+
+func (m *ReleaseEvent) GetTotal() int32 {
+	if m != nil {
+		return m.Total
+	}
+	return 0
+}
+
+func (m *ReleaseEvent) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+func (m *ReleaseEvent) GetRelease() *hapi_release5.Release {
+	if m != nil {
+		return m.Release
+	}
+	return nil
+}
+
+// UnlockReleaseRequest is a request to clear the lock held on a release.
+type UnlockReleaseRequest struct {
+	// Name is the name of the release.
+	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+}
+
+func (m *UnlockReleaseRequest) Reset()                    { *m = UnlockReleaseRequest{} }
+func (m *UnlockReleaseRequest) String() string            { return proto.CompactTextString(m) }
+func (*UnlockReleaseRequest) ProtoMessage()               {}
+func (*UnlockReleaseRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{23} }
+
+func (m *UnlockReleaseRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+// UnlockReleaseResponse describes the lock that was cleared.
+type UnlockReleaseResponse struct {
+	// Holder identifies the client that held the lock.
+	Holder string `protobuf:"bytes,1,opt,name=holder" json:"holder,omitempty"`
+	// Operation is the operation the lock was taken for.
+	Operation string `protobuf:"bytes,2,opt,name=operation" json:"operation,omitempty"`
+}
+
+func (m *UnlockReleaseResponse) Reset()                    { *m = UnlockReleaseResponse{} }
+func (m *UnlockReleaseResponse) String() string            { return proto.CompactTextString(m) }
+func (*UnlockReleaseResponse) ProtoMessage()               {}
+func (*UnlockReleaseResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{24} }
+
+func (m *UnlockReleaseResponse) GetHolder() string {
+	if m != nil {
+		return m.Holder
+	}
+	return ""
+}
+
+func (m *UnlockReleaseResponse) GetOperation() string {
+	if m != nil {
+		return m.Operation
+	}
+	return ""
+}
+
+// RepairReleaseRequest is a request to repair the status of a release.
+type RepairReleaseRequest struct {
+	// Name is the name of the release. If empty, every release left pending
+	// for longer than the pending timeout of Tiller is marked failed.
+	Name string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+}
+
+func (m *RepairReleaseRequest) Reset()                    { *m = RepairReleaseRequest{} }
+func (m *RepairReleaseRequest) String() string            { return proto.CompactTextString(m) }
+func (*RepairReleaseRequest) ProtoMessage()               {}
+func (*RepairReleaseRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{25} }
+
+func (m *RepairReleaseRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+// RepairReleaseResponse describes the repaired releases.
+type RepairReleaseResponse struct {
+	// Releases are the revisions whose status was repaired.
+	Releases []*hapi_release5.Release `protobuf:"bytes,1,rep,name=releases" json:"releases,omitempty"`
+	// Missing lists the resources of the last revision of the release
+	// which are missing from the cluster.
+	Missing []string `protobuf:"bytes,2,rep,name=missing" json:"missing,omitempty"`
+}
+
+func (m *RepairReleaseResponse) Reset()                    { *m = RepairReleaseResponse{} }
+func (m *RepairReleaseResponse) String() string            { return proto.CompactTextString(m) }
+func (*RepairReleaseResponse) ProtoMessage()               {}
+func (*RepairReleaseResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{26} }
+
+func (m *RepairReleaseResponse) GetReleases() []*hapi_release5.Release {
+	if m != nil {
+		return m.Releases
+	}
+	return nil
+}
+
+func (m *RepairReleaseResponse) GetMissing() []string {
+	if m != nil {
+		return m.Missing
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*ListReleasesRequest)(nil), "hapi.services.tiller.ListReleasesRequest")
 	proto.RegisterType((*ListSort)(nil), "hapi.services.tiller.ListSort")
@@ -974,6 +1423,7 @@ func init() {
 	proto.RegisterType((*UpdateReleaseResponse)(nil), "hapi.services.tiller.UpdateReleaseResponse")
 	proto.RegisterType((*RollbackReleaseRequest)(nil), "hapi.services.tiller.RollbackReleaseRequest")
 	proto.RegisterType((*RollbackReleaseResponse)(nil), "hapi.services.tiller.RollbackReleaseResponse")
+	proto.RegisterType((*ResourceDiff)(nil), "hapi.services.tiller.ResourceDiff")
 	proto.RegisterType((*InstallReleaseRequest)(nil), "hapi.services.tiller.InstallReleaseRequest")
 	proto.RegisterType((*InstallReleaseResponse)(nil), "hapi.services.tiller.InstallReleaseResponse")
 	proto.RegisterType((*UninstallReleaseRequest)(nil), "hapi.services.tiller.UninstallReleaseRequest")
@@ -984,8 +1434,15 @@ func init() {
 	proto.RegisterType((*GetHistoryResponse)(nil), "hapi.services.tiller.GetHistoryResponse")
 	proto.RegisterType((*TestReleaseRequest)(nil), "hapi.services.tiller.TestReleaseRequest")
 	proto.RegisterType((*TestReleaseResponse)(nil), "hapi.services.tiller.TestReleaseResponse")
+	proto.RegisterType((*ReleaseEvent)(nil), "hapi.services.tiller.ReleaseEvent")
+	proto.RegisterType((*UnlockReleaseRequest)(nil), "hapi.services.tiller.UnlockReleaseRequest")
+	proto.RegisterType((*UnlockReleaseResponse)(nil), "hapi.services.tiller.UnlockReleaseResponse")
+	proto.RegisterType((*RepairReleaseRequest)(nil), "hapi.services.tiller.RepairReleaseRequest")
+	proto.RegisterType((*RepairReleaseResponse)(nil), "hapi.services.tiller.RepairReleaseResponse")
 	proto.RegisterEnum("hapi.services.tiller.ListSort_SortBy", ListSort_SortBy_name, ListSort_SortBy_value)
 	proto.RegisterEnum("hapi.services.tiller.ListSort_SortOrder", ListSort_SortOrder_name, ListSort_SortOrder_value)
+	proto.RegisterEnum("hapi.services.tiller.ResourceDiff_Change", ResourceDiff_Change_name, ResourceDiff_Change_value)
+	proto.RegisterEnum("hapi.services.tiller.ReleaseEvent_Type", ReleaseEvent_Type_name, ReleaseEvent_Type_value)
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -1022,6 +1479,21 @@ type ReleaseServiceClient interface {
 	GetHistory(ctx context.Context, in *GetHistoryRequest, opts ...grpc.CallOption) (*GetHistoryResponse, error)
 	// RunReleaseTest executes the tests defined of a named release
 	RunReleaseTest(ctx context.Context, in *TestReleaseRequest, opts ...grpc.CallOption) (ReleaseService_RunReleaseTestClient, error)
+	// InstallReleaseStream installs a release like InstallRelease, streaming
+	// the progress of the installation. The last event holds the release.
+	InstallReleaseStream(ctx context.Context, in *InstallReleaseRequest, opts ...grpc.CallOption) (ReleaseService_InstallReleaseStreamClient, error)
+	// UpdateReleaseStream updates a release like UpdateRelease, streaming
+	// the progress of the upgrade. The last event holds the release.
+	UpdateReleaseStream(ctx context.Context, in *UpdateReleaseRequest, opts ...grpc.CallOption) (ReleaseService_UpdateReleaseStreamClient, error)
+	// RollbackReleaseStream rolls back a release like RollbackRelease,
+	// streaming the progress of the rollback. The last event holds the release.
+	RollbackReleaseStream(ctx context.Context, in *RollbackReleaseRequest, opts ...grpc.CallOption) (ReleaseService_RollbackReleaseStreamClient, error)
+	// UnlockRelease clears the lock held on a release, e.g. when the
+	// operation holding it was interrupted.
+	UnlockRelease(ctx context.Context, in *UnlockReleaseRequest, opts ...grpc.CallOption) (*UnlockReleaseResponse, error)
+	// RepairRelease reconciles the status of the last revision of a release
+	// left pending by an interrupted operation with the cluster.
+	RepairRelease(ctx context.Context, in *RepairReleaseRequest, opts ...grpc.CallOption) (*RepairReleaseResponse, error)
 }
 
 type releaseServiceClient struct {
@@ -1168,6 +1640,120 @@ func (x *releaseServiceRunReleaseTestClient) Recv() (*TestReleaseResponse, error
 	return m, nil
 }
 
+func (c *releaseServiceClient) InstallReleaseStream(ctx context.Context, in *InstallReleaseRequest, opts ...grpc.CallOption) (ReleaseService_InstallReleaseStreamClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_ReleaseService_serviceDesc.Streams[2], c.cc, "/hapi.services.tiller.ReleaseService/InstallReleaseStream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &releaseServiceInstallReleaseStreamClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ReleaseService_InstallReleaseStreamClient interface {
+	Recv() (*ReleaseEvent, error)
+	grpc.ClientStream
+}
+
+type releaseServiceInstallReleaseStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *releaseServiceInstallReleaseStreamClient) Recv() (*ReleaseEvent, error) {
+	m := new(ReleaseEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *releaseServiceClient) UpdateReleaseStream(ctx context.Context, in *UpdateReleaseRequest, opts ...grpc.CallOption) (ReleaseService_UpdateReleaseStreamClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_ReleaseService_serviceDesc.Streams[3], c.cc, "/hapi.services.tiller.ReleaseService/UpdateReleaseStream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &releaseServiceUpdateReleaseStreamClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ReleaseService_UpdateReleaseStreamClient interface {
+	Recv() (*ReleaseEvent, error)
+	grpc.ClientStream
+}
+
+type releaseServiceUpdateReleaseStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *releaseServiceUpdateReleaseStreamClient) Recv() (*ReleaseEvent, error) {
+	m := new(ReleaseEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *releaseServiceClient) RollbackReleaseStream(ctx context.Context, in *RollbackReleaseRequest, opts ...grpc.CallOption) (ReleaseService_RollbackReleaseStreamClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_ReleaseService_serviceDesc.Streams[4], c.cc, "/hapi.services.tiller.ReleaseService/RollbackReleaseStream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &releaseServiceRollbackReleaseStreamClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ReleaseService_RollbackReleaseStreamClient interface {
+	Recv() (*ReleaseEvent, error)
+	grpc.ClientStream
+}
+
+type releaseServiceRollbackReleaseStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *releaseServiceRollbackReleaseStreamClient) Recv() (*ReleaseEvent, error) {
+	m := new(ReleaseEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *releaseServiceClient) UnlockRelease(ctx context.Context, in *UnlockReleaseRequest, opts ...grpc.CallOption) (*UnlockReleaseResponse, error) {
+	out := new(UnlockReleaseResponse)
+	err := grpc.Invoke(ctx, "/hapi.services.tiller.ReleaseService/UnlockRelease", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *releaseServiceClient) RepairRelease(ctx context.Context, in *RepairReleaseRequest, opts ...grpc.CallOption) (*RepairReleaseResponse, error) {
+	out := new(RepairReleaseResponse)
+	err := grpc.Invoke(ctx, "/hapi.services.tiller.ReleaseService/RepairRelease", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for ReleaseService service
 
 type ReleaseServiceServer interface {
@@ -1194,6 +1780,21 @@ type ReleaseServiceServer interface {
 	GetHistory(context.Context, *GetHistoryRequest) (*GetHistoryResponse, error)
 	// RunReleaseTest executes the tests defined of a named release
 	RunReleaseTest(*TestReleaseRequest, ReleaseService_RunReleaseTestServer) error
+	// InstallReleaseStream installs a release like InstallRelease, streaming
+	// the progress of the installation. The last event holds the release.
+	InstallReleaseStream(*InstallReleaseRequest, ReleaseService_InstallReleaseStreamServer) error
+	// UpdateReleaseStream updates a release like UpdateRelease, streaming
+	// the progress of the upgrade. The last event holds the release.
+	UpdateReleaseStream(*UpdateReleaseRequest, ReleaseService_UpdateReleaseStreamServer) error
+	// RollbackReleaseStream rolls back a release like RollbackRelease,
+	// streaming the progress of the rollback. The last event holds the release.
+	RollbackReleaseStream(*RollbackReleaseRequest, ReleaseService_RollbackReleaseStreamServer) error
+	// UnlockRelease clears the lock held on a release, e.g. when the
+	// operation holding it was interrupted.
+	UnlockRelease(context.Context, *UnlockReleaseRequest) (*UnlockReleaseResponse, error)
+	// RepairRelease reconciles the status of the last revision of a release
+	// left pending by an interrupted operation with the cluster.
+	RepairRelease(context.Context, *RepairReleaseRequest) (*RepairReleaseResponse, error)
 }
 
 func RegisterReleaseServiceServer(s *grpc.Server, srv ReleaseServiceServer) {
@@ -1386,6 +1987,105 @@ func (x *releaseServiceRunReleaseTestServer) Send(m *TestReleaseResponse) error
 	return x.ServerStream.SendMsg(m)
 }
 
+func _ReleaseService_InstallReleaseStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(InstallReleaseRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ReleaseServiceServer).InstallReleaseStream(m, &releaseServiceInstallReleaseStreamServer{stream})
+}
+
+type ReleaseService_InstallReleaseStreamServer interface {
+	Send(*ReleaseEvent) error
+	grpc.ServerStream
+}
+
+type releaseServiceInstallReleaseStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *releaseServiceInstallReleaseStreamServer) Send(m *ReleaseEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _ReleaseService_UpdateReleaseStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(UpdateReleaseRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ReleaseServiceServer).UpdateReleaseStream(m, &releaseServiceUpdateReleaseStreamServer{stream})
+}
+
+type ReleaseService_UpdateReleaseStreamServer interface {
+	Send(*ReleaseEvent) error
+	grpc.ServerStream
+}
+
+type releaseServiceUpdateReleaseStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *releaseServiceUpdateReleaseStreamServer) Send(m *ReleaseEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _ReleaseService_RollbackReleaseStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(RollbackReleaseRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ReleaseServiceServer).RollbackReleaseStream(m, &releaseServiceRollbackReleaseStreamServer{stream})
+}
+
+type ReleaseService_RollbackReleaseStreamServer interface {
+	Send(*ReleaseEvent) error
+	grpc.ServerStream
+}
+
+type releaseServiceRollbackReleaseStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *releaseServiceRollbackReleaseStreamServer) Send(m *ReleaseEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _ReleaseService_UnlockRelease_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnlockReleaseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReleaseServiceServer).UnlockRelease(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hapi.services.tiller.ReleaseService/UnlockRelease",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReleaseServiceServer).UnlockRelease(ctx, req.(*UnlockReleaseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ReleaseService_RepairRelease_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RepairReleaseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ReleaseServiceServer).RepairRelease(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/hapi.services.tiller.ReleaseService/RepairRelease",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ReleaseServiceServer).RepairRelease(ctx, req.(*RepairReleaseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _ReleaseService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "hapi.services.tiller.ReleaseService",
 	HandlerType: (*ReleaseServiceServer)(nil),
@@ -1422,6 +2122,14 @@ var _ReleaseService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetHistory",
 			Handler:    _ReleaseService_GetHistory_Handler,
 		},
+		{
+			MethodName: "UnlockRelease",
+			Handler:    _ReleaseService_UnlockRelease_Handler,
+		},
+		{
+			MethodName: "RepairRelease",
+			Handler:    _ReleaseService_RepairRelease_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -1434,6 +2142,21 @@ var _ReleaseService_serviceDesc = grpc.ServiceDesc{
 			Handler:       _ReleaseService_RunReleaseTest_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "InstallReleaseStream",
+			Handler:       _ReleaseService_InstallReleaseStream_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "UpdateReleaseStream",
+			Handler:       _ReleaseService_UpdateReleaseStream_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "RollbackReleaseStream",
+			Handler:       _ReleaseService_RollbackReleaseStream_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "hapi/services/tiller.proto",
 }
@@ -1441,87 +2164,123 @@ var _ReleaseService_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("hapi/services/tiller.proto", fileDescriptor0) }
 
 var fileDescriptor0 = []byte{
-	// 1308 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x57, 0xed, 0x6e, 0x1b, 0x45,
-	0x17, 0x8e, 0xbd, 0xfe, 0x3c, 0x4e, 0xfc, 0xba, 0xd3, 0x34, 0xd9, 0xee, 0x5b, 0x50, 0x58, 0x04,
-	0x75, 0x0b, 0x75, 0xc0, 0xf0, 0x07, 0x09, 0x21, 0xa5, 0xae, 0x95, 0x14, 0x82, 0x2b, 0xad, 0xdb,
-	0x22, 0x21, 0x21, 0x6b, 0x63, 0x8f, 0xdb, 0xa5, 0xeb, 0x5d, 0xb3, 0x33, 0x1b, 0x9a, 0x1b, 0x40,
-	0xe2, 0x27, 0x97, 0x80, 0xc4, 0x85, 0x70, 0x1f, 0xdc, 0x0c, 0x9a, 0xaf, 0xcd, 0xce, 0x7a, 0xd7,
-	0x5d, 0xf2, 0x27, 0xde, 0x99, 0x73, 0xe6, 0x7c, 0x3c, 0xcf, 0x9c, 0x33, 0x27, 0x60, 0xbd, 0x76,
-	0xd7, 0xde, 0x31, 0xc1, 0xd1, 0xa5, 0x37, 0xc7, 0xe4, 0x98, 0x7a, 0xbe, 0x8f, 0xa3, 0xc1, 0x3a,
-	0x0a, 0x69, 0x88, 0xf6, 0x99, 0x6c, 0xa0, 0x64, 0x03, 0x21, 0xb3, 0x0e, 0xf8, 0x89, 0xf9, 0x6b,
-	0x37, 0xa2, 0xe2, 0xaf, 0xd0, 0xb6, 0x0e, 0xd3, 0xfb, 0x61, 0xb0, 0xf4, 0x5e, 0x49, 0x81, 0x70,
-	0x11, 0x61, 0x1f, 0xbb, 0x04, 0xab, 0x5f, 0xed, 0x90, 0x92, 0x79, 0xc1, 0x32, 0x94, 0x82, 0xff,
-	0x6b, 0x02, 0x8a, 0x09, 0x9d, 0x45, 0x71, 0x20, 0x85, 0x77, 0x35, 0x21, 0xa1, 0x2e, 0x8d, 0x89,
-	0xe6, 0xec, 0x12, 0x47, 0xc4, 0x0b, 0x03, 0xf5, 0x2b, 0x64, 0xf6, 0xdf, 0x55, 0xb8, 0x7d, 0xee,
-	0x11, 0xea, 0x88, 0x83, 0xc4, 0xc1, 0xbf, 0xc4, 0x98, 0x50, 0xb4, 0x0f, 0x75, 0xdf, 0x5b, 0x79,
-	0xd4, 0xac, 0x1c, 0x55, 0xfa, 0x86, 0x23, 0x16, 0xe8, 0x00, 0x1a, 0xe1, 0x72, 0x49, 0x30, 0x35,
-	0xab, 0x47, 0x95, 0x7e, 0xdb, 0x91, 0x2b, 0xf4, 0x0d, 0x34, 0x49, 0x18, 0xd1, 0xd9, 0xc5, 0x95,
-	0x69, 0x1c, 0x55, 0xfa, 0xdd, 0xe1, 0x47, 0x83, 0x3c, 0x9c, 0x06, 0xcc, 0xd3, 0x34, 0x8c, 0xe8,
-	0x80, 0xfd, 0x79, 0x7c, 0xe5, 0x34, 0x08, 0xff, 0x65, 0x76, 0x97, 0x9e, 0x4f, 0x71, 0x64, 0xd6,
-	0x84, 0x5d, 0xb1, 0x42, 0xa7, 0x00, 0xdc, 0x6e, 0x18, 0x2d, 0x70, 0x64, 0xd6, 0xb9, 0xe9, 0x7e,
-	0x09, 0xd3, 0xcf, 0x98, 0xbe, 0xd3, 0x26, 0xea, 0x13, 0x7d, 0x0d, 0xbb, 0x02, 0x92, 0xd9, 0x3c,
-	0x5c, 0x60, 0x62, 0x36, 0x8e, 0x8c, 0x7e, 0x77, 0x78, 0x57, 0x98, 0x52, 0xf0, 0x4f, 0x05, 0x68,
-	0xa3, 0x70, 0x81, 0x9d, 0x8e, 0x50, 0x67, 0xdf, 0x04, 0xdd, 0x83, 0x76, 0xe0, 0xae, 0x30, 0x59,
-	0xbb, 0x73, 0x6c, 0x36, 0x79, 0x84, 0xd7, 0x1b, 0x76, 0x00, 0x2d, 0xe5, 0xdc, 0x7e, 0x0c, 0x0d,
-	0x91, 0x1a, 0xea, 0x40, 0xf3, 0xc5, 0xe4, 0xbb, 0xc9, 0xb3, 0x1f, 0x26, 0xbd, 0x1d, 0xd4, 0x82,
-	0xda, 0xe4, 0xe4, 0xfb, 0x71, 0xaf, 0x82, 0x6e, 0xc1, 0xde, 0xf9, 0xc9, 0xf4, 0xf9, 0xcc, 0x19,
-	0x9f, 0x8f, 0x4f, 0xa6, 0xe3, 0x27, 0xbd, 0x2a, 0xea, 0x02, 0x8c, 0xce, 0x4e, 0x9c, 0xe7, 0x33,
-	0xae, 0x62, 0xd8, 0xef, 0x43, 0x3b, 0xc9, 0x01, 0x35, 0xc1, 0x38, 0x99, 0x8e, 0x84, 0x89, 0x27,
-	0xe3, 0xe9, 0xa8, 0x57, 0xb1, 0x7f, 0xaf, 0xc0, 0xbe, 0x4e, 0x19, 0x59, 0x87, 0x01, 0xc1, 0x8c,
-	0xb3, 0x79, 0x18, 0x07, 0x09, 0x67, 0x7c, 0x81, 0x10, 0xd4, 0x02, 0xfc, 0x56, 0x31, 0xc6, 0xbf,
-	0x99, 0x26, 0x0d, 0xa9, 0xeb, 0x73, 0xb6, 0x0c, 0x47, 0x2c, 0xd0, 0xe7, 0xd0, 0x92, 0x50, 0x10,
-	0xb3, 0x76, 0x64, 0xf4, 0x3b, 0xc3, 0x3b, 0x3a, 0x40, 0xd2, 0xa3, 0x93, 0xa8, 0xd9, 0xa7, 0x70,
-	0x78, 0x8a, 0x55, 0x24, 0x02, 0x3f, 0x75, 0x83, 0x98, 0x5f, 0x77, 0x85, 0x79, 0x30, 0xcc, 0xaf,
-	0xbb, 0xc2, 0xc8, 0x84, 0xa6, 0xbc, 0x7e, 0x3c, 0x9c, 0xba, 0xa3, 0x96, 0x36, 0x05, 0x73, 0xd3,
-	0x90, 0xcc, 0x2b, 0xcf, 0xd2, 0xc7, 0x50, 0x63, 0x95, 0xc1, 0xcd, 0x74, 0x86, 0x48, 0x8f, 0xf3,
-	0x69, 0xb0, 0x0c, 0x1d, 0x2e, 0xd7, 0xa9, 0x33, 0xb2, 0xd4, 0x9d, 0xa5, 0xbd, 0x8e, 0xc2, 0x80,
-	0xe2, 0x80, 0xde, 0x2c, 0xfe, 0x73, 0xb8, 0x9b, 0x63, 0x49, 0x26, 0x70, 0x0c, 0x4d, 0x19, 0x1a,
-	0xb7, 0x56, 0x88, 0xab, 0xd2, 0xb2, 0xff, 0x34, 0x60, 0xff, 0xc5, 0x7a, 0xe1, 0x52, 0xac, 0x44,
-	0x5b, 0x82, 0xba, 0x0f, 0x75, 0xde, 0x61, 0x24, 0x16, 0xb7, 0x84, 0x6d, 0xd1, 0x86, 0x46, 0xec,
-	0xaf, 0x23, 0xe4, 0xe8, 0x21, 0x34, 0x2e, 0x5d, 0x3f, 0xc6, 0x84, 0x03, 0x91, 0xa0, 0x26, 0x35,
-	0x79, 0x7b, 0x72, 0xa4, 0x06, 0x3a, 0x84, 0xe6, 0x22, 0xba, 0x62, 0xfd, 0x85, 0x97, 0x64, 0xcb,
-	0x69, 0x2c, 0xa2, 0x2b, 0x27, 0x0e, 0xd0, 0x87, 0xb0, 0xb7, 0xf0, 0x88, 0x7b, 0xe1, 0xe3, 0xd9,
-	0xeb, 0x30, 0x7c, 0x43, 0x78, 0x55, 0xb6, 0x9c, 0x5d, 0xb9, 0x79, 0xc6, 0xf6, 0x90, 0xc5, 0x6e,
-	0xd2, 0x3c, 0xc2, 0x2e, 0xc5, 0x66, 0x83, 0xcb, 0x93, 0x35, 0xc3, 0x90, 0x7a, 0x2b, 0x1c, 0xc6,
-	0x94, 0x97, 0x92, 0xe1, 0xa8, 0x25, 0xfa, 0x00, 0x76, 0x23, 0x4c, 0x30, 0x9d, 0xc9, 0x28, 0x5b,
-	0xfc, 0x64, 0x87, 0xef, 0xbd, 0x14, 0x61, 0x21, 0xa8, 0xfd, 0xea, 0x7a, 0xd4, 0x6c, 0x73, 0x11,
-	0xff, 0x16, 0xc7, 0x62, 0x82, 0xd5, 0x31, 0x50, 0xc7, 0x62, 0x82, 0xe5, 0xb1, 0x7d, 0xa8, 0x2f,
-	0xc3, 0x68, 0x8e, 0xcd, 0x0e, 0x97, 0x89, 0x05, 0x3a, 0x82, 0xce, 0x02, 0x93, 0x79, 0xe4, 0xad,
-	0x29, 0x63, 0x74, 0x97, 0x63, 0x9a, 0xde, 0x62, 0x79, 0x90, 0xf8, 0x62, 0x12, 0x52, 0x4c, 0xcc,
-	0x3d, 0x91, 0x87, 0x5a, 0xdb, 0x67, 0x70, 0x27, 0x43, 0xd1, 0x4d, 0xd9, 0xfe, 0xad, 0x0a, 0x07,
-	0x4e, 0xe8, 0xfb, 0x17, 0xee, 0xfc, 0x4d, 0x09, 0xbe, 0x53, 0xd4, 0x54, 0xb7, 0x53, 0x63, 0xe4,
-	0x50, 0x93, 0xba, 0xc2, 0x35, 0xed, 0x0a, 0x6b, 0xa4, 0xd5, 0x8b, 0x49, 0x6b, 0xe8, 0xa4, 0x29,
-	0x46, 0x9a, 0x29, 0x46, 0x12, 0xb8, 0x5b, 0x5b, 0xe0, 0x6e, 0x6f, 0xc0, 0x6d, 0x7f, 0x0b, 0x87,
-	0x1b, 0x38, 0xdc, 0x14, 0xd4, 0x3f, 0x0c, 0xb8, 0xf3, 0x34, 0x20, 0xd4, 0xf5, 0xfd, 0x0c, 0xa6,
-	0x49, 0xbd, 0x54, 0x4a, 0xd7, 0x4b, 0xf5, 0xbf, 0xd4, 0x8b, 0xa1, 0x91, 0xa2, 0x18, 0xac, 0xa5,
-	0x18, 0x2c, 0x55, 0x43, 0x5a, 0xe7, 0x6a, 0x64, 0x3a, 0x17, 0x7a, 0x0f, 0x40, 0x5c, 0x7a, 0x6e,
-	0x5c, 0x80, 0xdf, 0xe6, 0x3b, 0x13, 0xd9, 0xa8, 0x14, 0x5f, 0xad, 0x7c, 0xbe, 0xd2, 0x15, 0xd4,
-	0x87, 0x9e, 0x8a, 0x67, 0x1e, 0x2d, 0x78, 0x4c, 0xb2, 0x8a, 0xba, 0x72, 0x7f, 0x14, 0x2d, 0x58,
-	0x54, 0x59, 0x0e, 0x3b, 0xdb, 0x4b, 0x66, 0x37, 0x53, 0x32, 0x4f, 0xe1, 0x20, 0x4b, 0xc9, 0x4d,
-	0xe9, 0xfd, 0xab, 0x02, 0x87, 0x2f, 0x02, 0x2f, 0x97, 0xe0, 0xbc, 0xa2, 0xd9, 0x80, 0xbc, 0x9a,
-	0x03, 0xf9, 0x3e, 0xd4, 0xd7, 0x71, 0xf4, 0x0a, 0x4b, 0x0a, 0xc5, 0x22, 0x8d, 0x65, 0x4d, 0xc7,
-	0x32, 0x83, 0x46, 0x7d, 0xf3, 0x46, 0xcf, 0xc0, 0xdc, 0x8c, 0xf2, 0x86, 0x39, 0xb3, 0xbc, 0x92,
-	0x37, 0xaf, 0x2d, 0xde, 0x37, 0xfb, 0x36, 0xdc, 0x3a, 0xc5, 0xf4, 0xa5, 0x28, 0x61, 0x09, 0x80,
-	0x3d, 0x06, 0x94, 0xde, 0xbc, 0xf6, 0x27, 0xb7, 0x74, 0x7f, 0x6a, 0x20, 0x54, 0xfa, 0x4a, 0xcb,
-	0xfe, 0x8a, 0xdb, 0x3e, 0xf3, 0x08, 0x0d, 0xa3, 0xab, 0x6d, 0xe0, 0xf6, 0xc0, 0x58, 0xb9, 0x6f,
-	0xe5, 0x93, 0xc8, 0x3e, 0xed, 0x53, 0x1e, 0x41, 0x72, 0x54, 0x46, 0x90, 0x1e, 0x30, 0x2a, 0xe5,
-	0x06, 0x8c, 0xb7, 0x80, 0x9e, 0xe3, 0x64, 0xd6, 0x79, 0xc7, 0xdb, 0xac, 0x68, 0xaa, 0xea, 0x34,
-	0x99, 0xd0, 0x9c, 0xfb, 0xd8, 0x0d, 0xe2, 0xb5, 0x24, 0x56, 0x2d, 0xd9, 0x65, 0x5d, 0xbb, 0x91,
-	0xeb, 0xfb, 0xd8, 0x97, 0xcf, 0x5c, 0xb2, 0xb6, 0x7f, 0x82, 0xdb, 0x9a, 0x67, 0x99, 0x03, 0xcb,
-	0x95, 0xbc, 0x92, 0x9e, 0xd9, 0x27, 0xfa, 0x12, 0x1a, 0x62, 0x58, 0xe4, 0x7e, 0xbb, 0xc3, 0x7b,
-	0x7a, 0x4e, 0xdc, 0x48, 0x1c, 0xc8, 0xe9, 0xd2, 0x91, 0xba, 0xc3, 0x7f, 0x5a, 0xd0, 0x55, 0xe3,
-	0x8e, 0x18, 0x65, 0x91, 0x07, 0xbb, 0xe9, 0xb9, 0x0e, 0x3d, 0x28, 0x9e, 0x74, 0x33, 0xe3, 0xba,
-	0xf5, 0xb0, 0x8c, 0xaa, 0xc8, 0xc0, 0xde, 0xf9, 0xac, 0x82, 0x08, 0xf4, 0xb2, 0xe3, 0x16, 0x7a,
-	0x94, 0x6f, 0xa3, 0x60, 0xbe, 0xb3, 0x06, 0x65, 0xd5, 0x95, 0x5b, 0x74, 0xc9, 0xef, 0x93, 0x3e,
-	0x23, 0xa1, 0x77, 0x9a, 0xd1, 0xc7, 0x32, 0xeb, 0xb8, 0xb4, 0x7e, 0xe2, 0xf7, 0x67, 0xd8, 0xd3,
-	0x5e, 0x6a, 0x54, 0x80, 0x56, 0xde, 0xc4, 0x65, 0x7d, 0x52, 0x4a, 0x37, 0xf1, 0xb5, 0x82, 0xae,
-	0xde, 0xe2, 0x50, 0x81, 0x81, 0xdc, 0xb7, 0xc9, 0xfa, 0xb4, 0x9c, 0x72, 0xe2, 0x8e, 0x40, 0x2f,
-	0xdb, 0x5f, 0x8a, 0x78, 0x2c, 0xe8, 0x96, 0x45, 0x3c, 0x16, 0xb5, 0x2d, 0x7b, 0x07, 0xb9, 0x00,
-	0xd7, 0xed, 0x05, 0xdd, 0x2f, 0x24, 0x44, 0xef, 0x4a, 0x56, 0xff, 0xdd, 0x8a, 0x89, 0x8b, 0x35,
-	0xfc, 0x2f, 0x33, 0x09, 0xa0, 0x02, 0x68, 0xf2, 0x07, 0x27, 0xeb, 0x51, 0x49, 0xed, 0x4c, 0x52,
-	0xb2, 0x63, 0x6d, 0x49, 0x4a, 0x6f, 0x87, 0x5b, 0x92, 0xca, 0x34, 0x3f, 0x7b, 0x07, 0x79, 0xd0,
-	0x75, 0xe2, 0x40, 0xba, 0x66, 0x6d, 0x01, 0x15, 0x9c, 0xde, 0xec, 0x78, 0xd6, 0x83, 0x12, 0x9a,
-	0xd7, 0xf5, 0xfd, 0x18, 0x7e, 0x6c, 0x29, 0xd5, 0x8b, 0x06, 0xff, 0x4f, 0xff, 0x8b, 0x7f, 0x03,
-	0x00, 0x00, 0xff, 0xff, 0x30, 0x18, 0x95, 0x9c, 0xd7, 0x10, 0x00, 0x00,
+	// 1888 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x38, 0xcd, 0x6e, 0x23, 0x4b,
+	0xd5, 0x63, 0xb7, 0x7f, 0x8f, 0x1d, 0x8f, 0x53, 0x71, 0x92, 0x1e, 0x7f, 0xf7, 0x7e, 0x0a, 0x8d,
+	0xb8, 0xe3, 0x99, 0xe1, 0x7a, 0x20, 0xb0, 0x00, 0x81, 0x90, 0x3c, 0x76, 0xdf, 0xc4, 0xba, 0x89,
+	0x73, 0x55, 0x76, 0x66, 0x24, 0x24, 0x64, 0x75, 0xec, 0x72, 0xd2, 0x4c, 0xbb, 0xdb, 0x74, 0x55,
+	0xe7, 0x8e, 0xb7, 0x88, 0x0d, 0x4b, 0x5e, 0x86, 0x1d, 0x12, 0xe2, 0x1d, 0x58, 0xf1, 0x00, 0xbc,
+	0x03, 0x2b, 0x54, 0x7f, 0x4e, 0xb7, 0x63, 0x27, 0x3d, 0x59, 0xb0, 0xb1, 0xeb, 0xfc, 0xd4, 0x39,
+	0x75, 0x7e, 0xea, 0x9c, 0x3a, 0x0d, 0xcd, 0x1b, 0x67, 0xe1, 0xbe, 0xa5, 0x24, 0xbc, 0x75, 0x27,
+	0x84, 0xbe, 0x65, 0xae, 0xe7, 0x91, 0xb0, 0xbd, 0x08, 0x03, 0x16, 0xa0, 0x06, 0xa7, 0xb5, 0x35,
+	0xad, 0x2d, 0x69, 0xcd, 0x03, 0xb1, 0x63, 0x72, 0xe3, 0x84, 0x4c, 0xfe, 0x4a, 0xee, 0xe6, 0x61,
+	0x1c, 0x1f, 0xf8, 0x33, 0xf7, 0x5a, 0x11, 0xa4, 0x8a, 0x90, 0x78, 0xc4, 0xa1, 0x44, 0xff, 0x27,
+	0x36, 0x69, 0x9a, 0xeb, 0xcf, 0x02, 0x45, 0xf8, 0xbf, 0x04, 0x81, 0x11, 0xca, 0xc6, 0x61, 0xe4,
+	0x2b, 0xe2, 0x8b, 0x04, 0x91, 0x32, 0x87, 0x45, 0x34, 0xa1, 0xec, 0x96, 0x84, 0xd4, 0x0d, 0x7c,
+	0xfd, 0x2f, 0x69, 0xd6, 0xdf, 0xb3, 0xb0, 0x77, 0xe6, 0x52, 0x86, 0xe5, 0x46, 0x8a, 0xc9, 0x1f,
+	0x22, 0x42, 0x19, 0x6a, 0x40, 0xde, 0x73, 0xe7, 0x2e, 0x33, 0x33, 0x47, 0x99, 0x96, 0x81, 0x25,
+	0x80, 0x0e, 0xa0, 0x10, 0xcc, 0x66, 0x94, 0x30, 0x33, 0x7b, 0x94, 0x69, 0x95, 0xb1, 0x82, 0xd0,
+	0x6f, 0xa0, 0x48, 0x83, 0x90, 0x8d, 0xaf, 0x96, 0xa6, 0x71, 0x94, 0x69, 0xd5, 0x8e, 0x7f, 0xd4,
+	0xde, 0xe4, 0xa7, 0x36, 0xd7, 0x34, 0x0c, 0x42, 0xd6, 0xe6, 0x3f, 0xef, 0x96, 0xb8, 0x40, 0xc5,
+	0x3f, 0x97, 0x3b, 0x73, 0x3d, 0x46, 0x42, 0x33, 0x27, 0xe5, 0x4a, 0x08, 0x9d, 0x00, 0x08, 0xb9,
+	0x41, 0x38, 0x25, 0xa1, 0x99, 0x17, 0xa2, 0x5b, 0x29, 0x44, 0x5f, 0x70, 0x7e, 0x5c, 0xa6, 0x7a,
+	0x89, 0x7e, 0x0d, 0x55, 0xe9, 0x92, 0xf1, 0x24, 0x98, 0x12, 0x6a, 0x16, 0x8e, 0x8c, 0x56, 0xed,
+	0xf8, 0x85, 0x14, 0xa5, 0xdd, 0x3f, 0x94, 0x4e, 0xeb, 0x06, 0x53, 0x82, 0x2b, 0x92, 0x9d, 0xaf,
+	0x29, 0xfa, 0x02, 0xca, 0xbe, 0x33, 0x27, 0x74, 0xe1, 0x4c, 0x88, 0x59, 0x14, 0x27, 0xbc, 0x43,
+	0x58, 0x3e, 0x94, 0xb4, 0x72, 0xeb, 0x1d, 0x14, 0xa4, 0x69, 0xa8, 0x02, 0xc5, 0xcb, 0xc1, 0xb7,
+	0x83, 0x8b, 0x0f, 0x83, 0xfa, 0x33, 0x54, 0x82, 0xdc, 0xa0, 0x73, 0x6e, 0xd7, 0x33, 0x68, 0x17,
+	0x76, 0xce, 0x3a, 0xc3, 0xd1, 0x18, 0xdb, 0x67, 0x76, 0x67, 0x68, 0xf7, 0xea, 0x59, 0x54, 0x03,
+	0xe8, 0x9e, 0x76, 0xf0, 0x68, 0x2c, 0x58, 0x0c, 0xeb, 0xff, 0xa1, 0xbc, 0xb2, 0x01, 0x15, 0xc1,
+	0xe8, 0x0c, 0xbb, 0x52, 0x44, 0xcf, 0x1e, 0x76, 0xeb, 0x19, 0xeb, 0xcf, 0x19, 0x68, 0x24, 0x43,
+	0x46, 0x17, 0x81, 0x4f, 0x09, 0x8f, 0xd9, 0x24, 0x88, 0xfc, 0x55, 0xcc, 0x04, 0x80, 0x10, 0xe4,
+	0x7c, 0xf2, 0x49, 0x47, 0x4c, 0xac, 0x39, 0x27, 0x0b, 0x98, 0xe3, 0x89, 0x68, 0x19, 0x58, 0x02,
+	0xe8, 0xa7, 0x50, 0x52, 0xae, 0xa0, 0x66, 0xee, 0xc8, 0x68, 0x55, 0x8e, 0xf7, 0x93, 0x0e, 0x52,
+	0x1a, 0xf1, 0x8a, 0xcd, 0x3a, 0x81, 0xc3, 0x13, 0xa2, 0x4f, 0x22, 0xfd, 0xa7, 0x33, 0x88, 0xeb,
+	0x75, 0xe6, 0xc4, 0xcc, 0x28, 0xbd, 0xce, 0x9c, 0x20, 0x13, 0x8a, 0x2a, 0xfd, 0xc4, 0x71, 0xf2,
+	0x58, 0x83, 0x16, 0x03, 0xf3, 0xbe, 0x20, 0x65, 0xd7, 0x26, 0x49, 0x5f, 0x41, 0x8e, 0xdf, 0x0c,
+	0x21, 0xa6, 0x72, 0x8c, 0x92, 0xe7, 0xec, 0xfb, 0xb3, 0x00, 0x0b, 0x7a, 0x32, 0x74, 0xc6, 0x7a,
+	0xe8, 0x4e, 0xe3, 0x5a, 0xbb, 0x81, 0xcf, 0x88, 0xcf, 0x9e, 0x76, 0xfe, 0x33, 0x78, 0xb1, 0x41,
+	0x92, 0x32, 0xe0, 0x2d, 0x14, 0xd5, 0xd1, 0x84, 0xb4, 0xad, 0x7e, 0xd5, 0x5c, 0xd6, 0xbf, 0x73,
+	0xd0, 0xb8, 0x5c, 0x4c, 0x1d, 0x46, 0x34, 0xe9, 0x81, 0x43, 0xbd, 0x84, 0xbc, 0xa8, 0x30, 0xca,
+	0x17, 0xbb, 0x52, 0xb6, 0x40, 0xb5, 0xbb, 0xfc, 0x17, 0x4b, 0x3a, 0x7a, 0x0d, 0x85, 0x5b, 0xc7,
+	0x8b, 0x08, 0x35, 0x8d, 0xb8, 0xd7, 0x14, 0xa7, 0x28, 0x4f, 0x58, 0x71, 0xa0, 0x43, 0x28, 0x4e,
+	0xc3, 0x25, 0xaf, 0x2f, 0xe2, 0x4a, 0x96, 0x70, 0x61, 0x1a, 0x2e, 0x71, 0xe4, 0xa3, 0x1f, 0xc2,
+	0xce, 0xd4, 0xa5, 0xce, 0x95, 0x47, 0xc6, 0x37, 0x41, 0xf0, 0x91, 0x8a, 0x5b, 0x59, 0xc2, 0x55,
+	0x85, 0x3c, 0xe5, 0x38, 0xd4, 0xe4, 0x99, 0x34, 0x09, 0x89, 0xc3, 0x88, 0x59, 0x10, 0xf4, 0x15,
+	0xcc, 0x7d, 0xc8, 0xdc, 0x39, 0x09, 0x22, 0x26, 0xae, 0x92, 0x81, 0x35, 0x88, 0x7e, 0x00, 0xd5,
+	0x90, 0x50, 0xc2, 0xc6, 0xea, 0x94, 0x25, 0xb1, 0xb3, 0x22, 0x70, 0xef, 0xe5, 0xb1, 0x10, 0xe4,
+	0xbe, 0x77, 0x5c, 0x66, 0x96, 0x05, 0x49, 0xac, 0xe5, 0xb6, 0x88, 0x12, 0xbd, 0x0d, 0xf4, 0xb6,
+	0x88, 0x12, 0xb5, 0xad, 0x01, 0xf9, 0x59, 0x10, 0x4e, 0x88, 0x59, 0x11, 0x34, 0x09, 0xa0, 0x23,
+	0xa8, 0x4c, 0x09, 0x9d, 0x84, 0xee, 0x82, 0xf1, 0x88, 0x56, 0x85, 0x4f, 0xe3, 0x28, 0x6e, 0x07,
+	0x8d, 0xae, 0x06, 0x01, 0x23, 0xd4, 0xdc, 0x91, 0x76, 0x68, 0x98, 0x1f, 0x65, 0xea, 0xce, 0x66,
+	0x66, 0x4d, 0x1e, 0x85, 0xaf, 0xd1, 0x57, 0xf0, 0x9c, 0xdd, 0x84, 0x84, 0x8c, 0xbf, 0x77, 0x96,
+	0xe3, 0x39, 0x09, 0xaf, 0x89, 0xf9, 0x5c, 0x90, 0x77, 0x04, 0xfa, 0x83, 0xb3, 0x3c, 0xe7, 0x48,
+	0xf4, 0x25, 0x80, 0x28, 0xdf, 0xce, 0x8c, 0xd7, 0xbc, 0xba, 0x60, 0x29, 0x73, 0x4c, 0x87, 0x23,
+	0xb8, 0x45, 0x0e, 0x0b, 0xe6, 0xee, 0x64, 0xcc, 0x71, 0xd4, 0xdc, 0x95, 0x16, 0x49, 0xdc, 0x88,
+	0xa3, 0xb8, 0x45, 0x94, 0x91, 0x05, 0x35, 0x91, 0xb4, 0x48, 0x00, 0x1c, 0xbb, 0x70, 0x22, 0x4a,
+	0xcc, 0x3d, 0x89, 0x15, 0x00, 0xb7, 0x62, 0x12, 0xf8, 0xcc, 0xf5, 0x23, 0x62, 0x36, 0xa4, 0x15,
+	0x1a, 0xb6, 0xfe, 0x98, 0x81, 0xfd, 0xb5, 0x4c, 0x7b, 0x62, 0xd2, 0xa2, 0x5f, 0x40, 0x9e, 0x3b,
+	0x81, 0x9a, 0x59, 0x51, 0x3b, 0xac, 0xcd, 0x75, 0x1a, 0x13, 0x1a, 0x44, 0xe1, 0x84, 0xf4, 0xdc,
+	0xd9, 0x0c, 0xcb, 0x0d, 0xd6, 0x3f, 0xb2, 0x70, 0x80, 0x03, 0xcf, 0xbb, 0x72, 0x26, 0x1f, 0x53,
+	0x24, 0x7c, 0x2c, 0x37, 0xb3, 0x0f, 0xe7, 0xa6, 0xb1, 0x21, 0x37, 0x63, 0x77, 0x38, 0x97, 0xb8,
+	0xc3, 0x89, 0xac, 0xcd, 0x6f, 0xcf, 0xda, 0x42, 0x32, 0x6b, 0x75, 0x4a, 0x16, 0x63, 0x29, 0xb9,
+	0xca, 0xb7, 0xd2, 0x03, 0xf9, 0x56, 0xbe, 0x9f, 0x6f, 0x3a, 0xa7, 0xe0, 0xe1, 0x9c, 0xaa, 0x6c,
+	0xc8, 0x29, 0xeb, 0x4f, 0x19, 0x38, 0xbc, 0xe7, 0xc4, 0xff, 0x7d, 0x2c, 0xff, 0x95, 0x81, 0x6a,
+	0x1c, 0xcf, 0x6d, 0xfa, 0xe8, 0xfa, 0x53, 0x1d, 0x41, 0xbe, 0x4e, 0x56, 0xe5, 0xec, 0x5a, 0x55,
+	0x5e, 0xc5, 0xdc, 0x88, 0xc5, 0xbc, 0x03, 0x85, 0xc9, 0x8d, 0xe3, 0x5f, 0x13, 0x11, 0xb4, 0xda,
+	0xf1, 0xab, 0xc7, 0x4f, 0xc4, 0x8b, 0x9f, 0x7f, 0x4d, 0xb0, 0xda, 0xb8, 0x72, 0x6e, 0x5e, 0x8a,
+	0xe5, 0x6b, 0xab, 0x0d, 0x05, 0xc9, 0x85, 0xaa, 0x50, 0x3a, 0xbf, 0xe8, 0xf5, 0xbf, 0xe9, 0xdb,
+	0xbd, 0xfa, 0x33, 0x54, 0x86, 0x7c, 0xa7, 0xd7, 0xb3, 0x7b, 0xf5, 0x0c, 0x6f, 0xe4, 0xd8, 0x3e,
+	0xbf, 0x78, 0xcf, 0x7b, 0xb5, 0xf5, 0x17, 0x03, 0xf6, 0xfb, 0x3e, 0x65, 0x8e, 0xe7, 0xad, 0x25,
+	0xea, 0xaa, 0x0a, 0x67, 0x52, 0x57, 0xe1, 0xec, 0xe7, 0x54, 0x61, 0x23, 0x91, 0xe9, 0xda, 0x45,
+	0xb9, 0x98, 0x8b, 0x52, 0x55, 0xe6, 0x84, 0xe7, 0x0b, 0xeb, 0x9e, 0xff, 0x12, 0x40, 0x96, 0x52,
+	0x21, 0x5c, 0x66, 0x74, 0x59, 0x60, 0x06, 0xaa, 0xfd, 0xe9, 0x4b, 0x50, 0xda, 0x7c, 0x09, 0xe2,
+	0x75, 0xb9, 0x05, 0x75, 0x7d, 0x9e, 0x49, 0x38, 0x15, 0x67, 0x52, 0x89, 0x5d, 0x53, 0xf8, 0x6e,
+	0x38, 0xe5, 0xa7, 0x5a, 0xbf, 0x18, 0x95, 0x87, 0x0b, 0x71, 0x35, 0x59, 0x88, 0xad, 0x3e, 0x1c,
+	0xac, 0x87, 0xe4, 0xa9, 0x7d, 0xf7, 0x6f, 0x19, 0x38, 0xbc, 0xf4, 0xdd, 0x8d, 0x01, 0xde, 0x54,
+	0x89, 0xee, 0xb9, 0x3c, 0xbb, 0xc1, 0xe5, 0xbc, 0x28, 0x47, 0xfc, 0xda, 0x1a, 0xaa, 0x28, 0x73,
+	0x20, 0xee, 0xcb, 0x5c, 0xd2, 0x97, 0x6b, 0xde, 0xc8, 0xdf, 0xf7, 0x46, 0x2c, 0x2d, 0x0a, 0xf1,
+	0xb4, 0xb0, 0xc6, 0x60, 0xde, 0x3f, 0xfe, 0x53, 0x6b, 0x00, 0x8a, 0x3d, 0xb1, 0xca, 0xf2, 0x39,
+	0x65, 0xed, 0xc1, 0xee, 0x09, 0x61, 0xef, 0x65, 0xc1, 0x54, 0x9e, 0xb1, 0x6c, 0x40, 0x71, 0xe4,
+	0x9d, 0x3e, 0x85, 0x4a, 0xea, 0xd3, 0xf3, 0x87, 0xe6, 0xd7, 0x5c, 0xd6, 0x2f, 0x85, 0xec, 0x53,
+	0x97, 0xb2, 0x20, 0x5c, 0x3e, 0xe4, 0xf5, 0x3a, 0x18, 0x73, 0xe7, 0x93, 0x7a, 0x81, 0xf1, 0xa5,
+	0x75, 0x02, 0x28, 0xbe, 0x55, 0x9d, 0x20, 0xfe, 0x9e, 0xcd, 0xa4, 0x7b, 0xcf, 0x7e, 0x02, 0xc4,
+	0xfb, 0x6b, 0x8a, 0xd0, 0xc7, 0xe2, 0x97, 0x4d, 0xc6, 0xcf, 0x84, 0xe2, 0xc4, 0x23, 0x8e, 0x1f,
+	0x2d, 0x54, 0xc4, 0x35, 0xc8, 0xb3, 0x78, 0xe1, 0x84, 0x8e, 0xe7, 0x11, 0x4f, 0xbd, 0xaa, 0x56,
+	0xb0, 0xf5, 0x3b, 0xd8, 0x4b, 0x68, 0x56, 0x36, 0x70, 0x5b, 0xe9, 0xb5, 0xd2, 0xcc, 0x97, 0xe8,
+	0xe7, 0x50, 0x90, 0xb3, 0x89, 0xd0, 0x5b, 0x3b, 0xfe, 0x22, 0x69, 0x93, 0x10, 0x12, 0xf9, 0x6a,
+	0x98, 0xc1, 0x8a, 0xd7, 0xfa, 0xa7, 0xc1, 0xcb, 0xb2, 0x60, 0xb1, 0x6f, 0x89, 0xcf, 0xd0, 0xaf,
+	0x20, 0xc7, 0x96, 0x0b, 0x69, 0x53, 0xed, 0xf8, 0xe5, 0xb6, 0x72, 0x7a, 0xb7, 0xa3, 0x3d, 0x5a,
+	0x2e, 0x08, 0x16, 0x9b, 0x56, 0x35, 0x3d, 0xbb, 0xad, 0xa6, 0x1b, 0xdb, 0x6a, 0x7a, 0xbc, 0x60,
+	0x21, 0xc8, 0x89, 0xa2, 0xa0, 0x0a, 0x32, 0x5f, 0xf3, 0xcb, 0x12, 0x12, 0x67, 0xba, 0x14, 0x89,
+	0x9d, 0xc7, 0x12, 0xb8, 0x9b, 0x57, 0x8a, 0x12, 0x2b, 0x00, 0xee, 0xe8, 0x39, 0xa1, 0xd4, 0xb9,
+	0x96, 0x7d, 0xb6, 0x8c, 0x35, 0x18, 0xcf, 0xf5, 0x72, 0xaa, 0x8b, 0xff, 0xd7, 0x0c, 0xe4, 0xb8,
+	0x7d, 0xc9, 0xb1, 0xad, 0x0e, 0xd5, 0xd3, 0x8b, 0x8b, 0x6f, 0xc7, 0xc3, 0x51, 0x07, 0x8f, 0x44,
+	0x33, 0xd8, 0x85, 0x1d, 0x81, 0xf9, 0xa6, 0x3f, 0xe8, 0x0f, 0x4f, 0xc5, 0xf8, 0xd6, 0x80, 0x3a,
+	0xb6, 0x87, 0x17, 0x97, 0xb8, 0x6b, 0x8f, 0xbb, 0xd8, 0xee, 0x70, 0x46, 0x23, 0x81, 0xbd, 0xfc,
+	0xae, 0x27, 0xb0, 0xb9, 0x04, 0xb6, 0x67, 0x9f, 0xd9, 0x1c, 0x9b, 0xe7, 0x3a, 0x3f, 0x74, 0xfa,
+	0xa3, 0xfe, 0xe0, 0xa4, 0x5e, 0xe0, 0x7d, 0xa8, 0x7b, 0x71, 0xfe, 0x1d, 0xa7, 0xd5, 0x8b, 0x92,
+	0x84, 0x07, 0x9c, 0x54, 0xe2, 0x23, 0xe0, 0xc8, 0x1e, 0x8e, 0xea, 0x65, 0xbe, 0xfa, 0xd0, 0x79,
+	0x6f, 0xd7, 0xc1, 0x7a, 0x0d, 0x8d, 0x4b, 0xdf, 0x0b, 0xd2, 0xbc, 0x9b, 0xac, 0x73, 0xd8, 0x5f,
+	0xe3, 0x55, 0x49, 0x76, 0x00, 0x85, 0x9b, 0xc0, 0xe3, 0x23, 0xb6, 0x64, 0x57, 0x10, 0x0f, 0x69,
+	0xb0, 0x20, 0xa1, 0xc3, 0xf4, 0xc0, 0x53, 0xc6, 0x77, 0x08, 0xae, 0x1a, 0x93, 0x85, 0xe3, 0x86,
+	0x29, 0x54, 0x4f, 0x61, 0x7f, 0x8d, 0xf7, 0xc9, 0x77, 0x54, 0x84, 0xdd, 0xa5, 0xd4, 0xf5, 0xaf,
+	0xc5, 0xeb, 0xa4, 0x8c, 0x35, 0x78, 0xfc, 0x9f, 0x2a, 0xd4, 0xf4, 0x08, 0x29, 0x33, 0x19, 0xb9,
+	0x50, 0x8d, 0xcf, 0xca, 0xe8, 0xd5, 0xf6, 0xaf, 0x07, 0x6b, 0x9f, 0x40, 0x9a, 0xaf, 0xd3, 0xb0,
+	0x4a, 0x33, 0xac, 0x67, 0x3f, 0xc9, 0x20, 0x0a, 0xf5, 0xf5, 0x11, 0x16, 0x7d, 0xbd, 0x59, 0xc6,
+	0x96, 0x99, 0xb9, 0xd9, 0x4e, 0xcb, 0xae, 0xd5, 0xa2, 0x5b, 0xd8, 0xbd, 0xa3, 0xaa, 0xb9, 0x13,
+	0x3d, 0x2a, 0x26, 0x39, 0xea, 0x36, 0xdf, 0xa6, 0xe6, 0x5f, 0xe9, 0xfd, 0x3d, 0xec, 0x24, 0xc6,
+	0x06, 0xb4, 0xc5, 0x5b, 0x9b, 0xa6, 0xd8, 0xe6, 0x9b, 0x54, 0xbc, 0x2b, 0x5d, 0x73, 0xa8, 0x25,
+	0x1b, 0x3c, 0xda, 0x22, 0x60, 0xe3, 0xcb, 0xac, 0xf9, 0xe3, 0x74, 0xcc, 0x2b, 0x75, 0x14, 0xea,
+	0xeb, 0x4d, 0x74, 0x5b, 0x1c, 0xb7, 0xbc, 0x15, 0x9a, 0xed, 0xb4, 0xec, 0x2b, 0xa5, 0x0e, 0xc0,
+	0x5d, 0x0f, 0x45, 0x2f, 0xb7, 0x06, 0x24, 0xd9, 0x7a, 0x9b, 0xad, 0xc7, 0x19, 0x57, 0x2a, 0x16,
+	0xf0, 0x7c, 0x6d, 0x3e, 0x40, 0x5b, 0x5c, 0xb3, 0x79, 0x16, 0x6b, 0x7e, 0x9d, 0x92, 0x7b, 0xcd,
+	0x28, 0xd5, 0x96, 0x1f, 0x30, 0x2a, 0xd9, 0xf3, 0x9b, 0xad, 0xc7, 0x19, 0x57, 0x2a, 0x5c, 0xa8,
+	0xe1, 0xc8, 0x57, 0xaa, 0x79, 0xef, 0x43, 0x5b, 0x76, 0xdf, 0x6f, 0xeb, 0xcd, 0x57, 0x29, 0x38,
+	0x63, 0xf7, 0xfb, 0x23, 0x34, 0x92, 0x39, 0x33, 0x64, 0x21, 0x71, 0xe6, 0x9f, 0x97, 0x8c, 0xd6,
+	0xe3, 0x8d, 0x56, 0x28, 0x73, 0x61, 0x2f, 0x71, 0x1d, 0x94, 0xae, 0xcf, 0xb9, 0x65, 0x69, 0x55,
+	0xcd, 0x61, 0x7f, 0x2d, 0x84, 0x4a, 0xd9, 0xe7, 0x65, 0x47, 0x5a, 0x75, 0xbc, 0x72, 0xc4, 0xbb,
+	0xd0, 0x56, 0x9b, 0x36, 0xb4, 0xb5, 0xe6, 0x9b, 0x54, 0xbc, 0xf1, 0x2a, 0x95, 0x68, 0x3b, 0xdb,
+	0x74, 0x6d, 0xea, 0x63, 0xcd, 0x37, 0xa9, 0x78, 0xb5, 0xae, 0x77, 0xf0, 0xdb, 0x92, 0x66, 0xbd,
+	0x2a, 0x88, 0x8f, 0xeb, 0x3f, 0xfb, 0xef, 0x00, 0x48, 0xcd, 0x33, 0x6e, 0x4a, 0x18, 0x00, 0x00,
 }
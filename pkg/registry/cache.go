@@ -0,0 +1,180 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registry
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"k8s.io/helm/pkg/chartutil"
+)
+
+// CacheEntry describes a chart archive stored in the cache.
+type CacheEntry struct {
+	// Ref is the reference the chart is stored under.
+	Ref string `json:"ref"`
+	// Name is the name of the chart.
+	Name string `json:"name"`
+	// Version is the version of the chart.
+	Version string `json:"version"`
+	// Digest is the digest of the chart archive.
+	Digest string `json:"digest"`
+	// Size is the size of the chart archive.
+	Size int64 `json:"size"`
+	// Created is when the chart was stored in the cache.
+	Created time.Time `json:"created"`
+}
+
+// Cache stores chart archives by reference, before they are pushed to a
+// registry or after they were pulled from one.
+//
+// Archives are stored by digest under blobs/sha256, and indexed by reference
+// in index.json.
+type Cache struct {
+	root string
+}
+
+// NewCache returns a cache rooted at the directory root.
+func NewCache(root string) *Cache {
+	return &Cache{root: root}
+}
+
+// Store stores the chart archive under ref, replacing the chart previously
+// stored under it. The tag of ref defaults to the version of the chart.
+func (c *Cache) Store(ref *Reference, archive []byte) (*CacheEntry, error) {
+	ch, err := chartutil.LoadArchive(bytes.NewReader(archive))
+	if err != nil {
+		return nil, fmt.Errorf("cannot store %s: %s", ref, err)
+	}
+	if ref.Tag == "" {
+		ref.Tag = ch.Metadata.Version
+	}
+	if err := ref.requireTag(); err != nil {
+		return nil, err
+	}
+
+	entries, err := c.List()
+	if err != nil {
+		return nil, err
+	}
+	entry := &CacheEntry{
+		Ref:     ref.String(),
+		Name:    ch.Metadata.Name,
+		Version: ch.Metadata.Version,
+		Digest:  Digest(archive),
+		Size:    int64(len(archive)),
+		Created: time.Now().UTC(),
+	}
+
+	blob := c.blobPath(entry.Digest)
+	if err := os.MkdirAll(filepath.Dir(blob), 0755); err != nil {
+		return nil, err
+	}
+	if err := ioutil.WriteFile(blob, archive, 0644); err != nil {
+		return nil, err
+	}
+
+	kept := []*CacheEntry{entry}
+	for _, e := range entries {
+		if e.Ref != entry.Ref {
+			kept = append(kept, e)
+		}
+	}
+	if err := c.writeIndex(kept); err != nil {
+		return nil, err
+	}
+	return entry, c.prune(kept)
+}
+
+// Fetch returns the chart archive stored under ref.
+func (c *Cache) Fetch(ref *Reference) (*CacheEntry, []byte, error) {
+	entries, err := c.List()
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, e := range entries {
+		if e.Ref == ref.String() {
+			archive, err := ioutil.ReadFile(c.blobPath(e.Digest))
+			return e, archive, err
+		}
+	}
+	return nil, nil, fmt.Errorf("chart %s not found in the cache, save or pull it first", ref)
+}
+
+// List lists the charts stored in the cache, sorted by reference.
+func (c *Cache) List() ([]*CacheEntry, error) {
+	b, err := ioutil.ReadFile(c.indexPath())
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	var index struct {
+		Entries []*CacheEntry `json:"entries"`
+	}
+	if err := json.Unmarshal(b, &index); err != nil {
+		return nil, fmt.Errorf("cannot parse %s: %s", c.indexPath(), err)
+	}
+	sort.Slice(index.Entries, func(i, j int) bool { return index.Entries[i].Ref < index.Entries[j].Ref })
+	return index.Entries, nil
+}
+
+func (c *Cache) writeIndex(entries []*CacheEntry) error {
+	b, err := json.MarshalIndent(map[string]interface{}{"entries": entries}, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(c.root, 0755); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(c.indexPath(), b, 0644)
+}
+
+// prune removes the archives no entry refers to anymore.
+func (c *Cache) prune(entries []*CacheEntry) error {
+	used := map[string]bool{}
+	for _, e := range entries {
+		used[c.blobPath(e.Digest)] = true
+	}
+	blobs, err := filepath.Glob(filepath.Join(c.root, "blobs", "sha256", "*"))
+	if err != nil {
+		return err
+	}
+	for _, b := range blobs {
+		if !used[b] {
+			if err := os.Remove(b); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (c *Cache) indexPath() string {
+	return filepath.Join(c.root, "index.json")
+}
+
+func (c *Cache) blobPath(digest string) string {
+	return filepath.Join(c.root, "blobs", "sha256", strings.TrimPrefix(digest, "sha256:"))
+}
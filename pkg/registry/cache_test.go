@@ -0,0 +1,87 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registry_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"k8s.io/helm/pkg/registry"
+)
+
+func TestCache(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "helm-registry-cache-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+	cache := registry.NewCache(tmp)
+
+	v1 := chartArchive(t, "mychart", "0.1.0")
+	v2 := chartArchive(t, "mychart", "0.2.0")
+
+	ref := &registry.Reference{Registry: "localhost:5000", Repository: "charts/mychart"}
+	entry, err := cache.Store(ref, v1)
+	if err != nil {
+		t.Fatalf("Failed to store: %s", err)
+	}
+	if entry.Ref != "localhost:5000/charts/mychart:0.1.0" || entry.Name != "mychart" || entry.Digest != registry.Digest(v1) {
+		t.Errorf("Expected the tag to default to the chart version, got %+v", entry)
+	}
+
+	latest := &registry.Reference{Registry: "localhost:5000", Repository: "charts/mychart", Tag: "latest"}
+	if _, err := cache.Store(latest, v1); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cache.Store(latest, v2); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := cache.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 || entries[0].Ref != ref.String() || entries[1].Ref != latest.String() {
+		t.Fatalf("Expected 2 sorted entries, got %v", entries)
+	}
+	if entries[1].Version != "0.2.0" {
+		t.Errorf("Expected latest to be replaced by 0.2.0, got %s", entries[1].Version)
+	}
+
+	e, data, err := cache.Fetch(latest)
+	if err != nil {
+		t.Fatalf("Failed to fetch: %s", err)
+	}
+	if e.Version != "0.2.0" || !bytes.Equal(data, v2) {
+		t.Errorf("Expected to fetch 0.2.0, got %s", e.Version)
+	}
+
+	blobs, _ := filepath.Glob(filepath.Join(tmp, "blobs", "sha256", "*"))
+	if len(blobs) != 2 {
+		t.Errorf("Expected 2 archives to be stored, got %d", len(blobs))
+	}
+
+	if _, _, err := cache.Fetch(&registry.Reference{Registry: "localhost:5000", Repository: "other", Tag: "1"}); err == nil {
+		t.Error("Expected fetching an unknown reference to fail")
+	}
+	if _, err := cache.Store(ref, []byte("not a chart")); err == nil {
+		t.Error("Expected storing an invalid archive to fail")
+	}
+}
@@ -0,0 +1,418 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registry
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"k8s.io/helm/pkg/chartutil"
+	"k8s.io/helm/pkg/version"
+)
+
+const (
+	// ManifestMediaType is the media type of the manifests storing charts.
+	ManifestMediaType = "application/vnd.oci.image.manifest.v1+json"
+	// ConfigMediaType is the media type of the chart metadata, stored as the manifest config.
+	ConfigMediaType = "application/vnd.cncf.helm.config.v1+json"
+	// ChartLayerMediaType is the media type of the chart archive, stored as the manifest layer.
+	ChartLayerMediaType = "application/vnd.cncf.helm.chart.content.v1.tar+gzip"
+)
+
+// Descriptor describes a blob referenced by a manifest.
+type Descriptor struct {
+	MediaType string `json:"mediaType"`
+	Digest    string `json:"digest"`
+	Size      int64  `json:"size"`
+}
+
+// Manifest is the OCI image manifest storing a chart.
+type Manifest struct {
+	SchemaVersion int          `json:"schemaVersion"`
+	MediaType     string       `json:"mediaType,omitempty"`
+	Config        Descriptor   `json:"config"`
+	Layers        []Descriptor `json:"layers"`
+}
+
+// Client pushes charts to, and pulls charts from, registries implementing the
+// OCI distribution API.
+//
+// Registries on the loopback interface are reached over plain HTTP, the
+// others over HTTPS.
+type Client struct {
+	// HTTPClient performs the requests to the registries.
+	HTTPClient *http.Client
+	// Credentials authenticate the client to the registries. It may be nil.
+	Credentials *Credentials
+
+	mu     sync.Mutex
+	tokens map[string]string
+}
+
+// NewClient returns a client authenticating with creds, which may be nil.
+func NewClient(creds *Credentials) *Client {
+	return &Client{
+		HTTPClient:  &http.Client{Transport: &http.Transport{Proxy: http.ProxyFromEnvironment}},
+		Credentials: creds,
+		tokens:      map[string]string{},
+	}
+}
+
+// Ping checks that host is a registry accepting the credentials of the client.
+func (c *Client) Ping(host string) error {
+	resp, err := c.do(host, "", func() (*http.Request, error) {
+		return http.NewRequest("GET", c.baseURL(host)+"/", nil)
+	})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return responseError(resp)
+	}
+	return nil
+}
+
+// Push stores the chart archive in the registry under ref, and returns the
+// descriptor of the chart layer.
+func (c *Client) Push(ref *Reference, archive []byte) (*Descriptor, error) {
+	if err := ref.requireTag(); err != nil {
+		return nil, err
+	}
+	ch, err := chartutil.LoadArchive(bytes.NewReader(archive))
+	if err != nil {
+		return nil, fmt.Errorf("cannot push %s: %s", ref, err)
+	}
+	config, err := json.Marshal(ch.Metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	scope := fmt.Sprintf("repository:%s:pull,push", ref.Repository)
+	configDesc, err := c.pushBlob(ref, scope, ConfigMediaType, config)
+	if err != nil {
+		return nil, err
+	}
+	layerDesc, err := c.pushBlob(ref, scope, ChartLayerMediaType, archive)
+	if err != nil {
+		return nil, err
+	}
+
+	manifest, err := json.Marshal(&Manifest{
+		SchemaVersion: 2,
+		MediaType:     ManifestMediaType,
+		Config:        *configDesc,
+		Layers:        []Descriptor{*layerDesc},
+	})
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.do(ref.Registry, scope, func() (*http.Request, error) {
+		req, err := http.NewRequest("PUT", c.repositoryURL(ref, "manifests", ref.Tag), bytes.NewReader(manifest))
+		if err == nil {
+			req.Header.Set("Content-Type", ManifestMediaType)
+		}
+		return req, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		return nil, responseError(resp)
+	}
+	return layerDesc, nil
+}
+
+// Pull retrieves the chart archive stored in the registry under ref.
+func (c *Client) Pull(ref *Reference) ([]byte, error) {
+	if err := ref.requireTag(); err != nil {
+		return nil, err
+	}
+	scope := fmt.Sprintf("repository:%s:pull", ref.Repository)
+
+	resp, err := c.do(ref.Registry, scope, func() (*http.Request, error) {
+		req, err := http.NewRequest("GET", c.repositoryURL(ref, "manifests", ref.Tag), nil)
+		if err == nil {
+			req.Header.Set("Accept", ManifestMediaType)
+		}
+		return req, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, responseError(resp)
+	}
+	var manifest Manifest
+	if err := json.NewDecoder(resp.Body).Decode(&manifest); err != nil {
+		return nil, fmt.Errorf("cannot parse the manifest of %s: %s", ref, err)
+	}
+	if manifest.Config.MediaType != ConfigMediaType {
+		return nil, fmt.Errorf("%s is not a chart: unexpected config media type %q", ref, manifest.Config.MediaType)
+	}
+
+	for _, layer := range manifest.Layers {
+		if layer.MediaType == ChartLayerMediaType {
+			return c.pullBlob(ref, scope, layer)
+		}
+	}
+	return nil, fmt.Errorf("%s is not a chart: no layer of media type %s", ref, ChartLayerMediaType)
+}
+
+// pushBlob uploads data unless the repository already has it.
+func (c *Client) pushBlob(ref *Reference, scope, mediaType string, data []byte) (*Descriptor, error) {
+	desc := &Descriptor{MediaType: mediaType, Digest: Digest(data), Size: int64(len(data))}
+
+	resp, err := c.do(ref.Registry, scope, func() (*http.Request, error) {
+		return http.NewRequest("HEAD", c.repositoryURL(ref, "blobs", desc.Digest), nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+	if resp.StatusCode == http.StatusOK {
+		return desc, nil
+	}
+
+	resp, err = c.do(ref.Registry, scope, func() (*http.Request, error) {
+		return http.NewRequest("POST", c.repositoryURL(ref, "blobs", "uploads")+"/", nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusAccepted {
+		defer resp.Body.Close()
+		return nil, responseError(resp)
+	}
+	resp.Body.Close()
+	location, err := resp.Request.URL.Parse(resp.Header.Get("Location"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid upload location: %s", err)
+	}
+	q := location.Query()
+	q.Set("digest", desc.Digest)
+	location.RawQuery = q.Encode()
+
+	resp, err = c.do(ref.Registry, scope, func() (*http.Request, error) {
+		req, err := http.NewRequest("PUT", location.String(), bytes.NewReader(data))
+		if err == nil {
+			req.Header.Set("Content-Type", "application/octet-stream")
+		}
+		return req, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		return nil, responseError(resp)
+	}
+	return desc, nil
+}
+
+// pullBlob downloads the blob described by desc, and verifies its digest.
+func (c *Client) pullBlob(ref *Reference, scope string, desc Descriptor) ([]byte, error) {
+	resp, err := c.do(ref.Registry, scope, func() (*http.Request, error) {
+		return http.NewRequest("GET", c.repositoryURL(ref, "blobs", desc.Digest), nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, responseError(resp)
+	}
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if d := Digest(data); d != desc.Digest {
+		return nil, fmt.Errorf("digest of %s does not match: expected %s, got %s", ref, desc.Digest, d)
+	}
+	return data, nil
+}
+
+// do sends the request built by newReq, authenticating to the registry host
+// for scope when it challenges the client.
+func (c *Client) do(host, scope string, newReq func() (*http.Request, error)) (*http.Response, error) {
+	send := func() (*http.Response, error) {
+		req, err := newReq()
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("User-Agent", "Helm/"+strings.TrimPrefix(version.GetVersion(), "v"))
+		c.authorize(req, host, scope)
+		return c.HTTPClient.Do(req)
+	}
+
+	resp, err := send()
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+	challenge := resp.Header.Get("WWW-Authenticate")
+	resp.Body.Close()
+	if err := c.authenticate(host, scope, challenge); err != nil {
+		return nil, err
+	}
+	return send()
+}
+
+// authorize sets the token obtained for scope on req, or else the
+// credentials of the registry host.
+func (c *Client) authorize(req *http.Request, host, scope string) {
+	c.mu.Lock()
+	token, ok := c.tokens[host+" "+scope]
+	c.mu.Unlock()
+	if ok {
+		req.Header.Set("Authorization", "Bearer "+token)
+		return
+	}
+	if username, password, ok := c.Credentials.Get(host); ok {
+		req.SetBasicAuth(username, password)
+	}
+}
+
+// authenticate answers the challenge of the registry host. Basic challenges
+// mean the credentials of the registry are missing or rejected, bearer
+// challenges are answered by obtaining a token for scope.
+func (c *Client) authenticate(host, scope, challenge string) error {
+	username, password, hasCredentials := c.Credentials.Get(host)
+	authScheme, params := parseChallenge(challenge)
+	if authScheme != "bearer" {
+		if hasCredentials {
+			return fmt.Errorf("unauthorized: the credentials for %s were rejected", host)
+		}
+		return fmt.Errorf("unauthorized: log in with 'helm registry login %s'", host)
+	}
+
+	u, err := url.Parse(params["realm"])
+	if err != nil || !u.IsAbs() {
+		return fmt.Errorf("invalid authentication realm %q from %s", params["realm"], host)
+	}
+	q := u.Query()
+	if params["service"] != "" {
+		q.Set("service", params["service"])
+	}
+	if scope != "" {
+		q.Set("scope", scope)
+	}
+	u.RawQuery = q.Encode()
+
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return err
+	}
+	if hasCredentials {
+		req.SetBasicAuth(username, password)
+	}
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		if !hasCredentials {
+			return fmt.Errorf("unauthorized: log in with 'helm registry login %s'", host)
+		}
+		return fmt.Errorf("cannot authenticate to %s: %s", host, responseError(resp))
+	}
+
+	var token struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return fmt.Errorf("cannot parse the token from %s: %s", u.Host, err)
+	}
+	if token.Token == "" {
+		token.Token = token.AccessToken
+	}
+
+	c.mu.Lock()
+	c.tokens[host+" "+scope] = token.Token
+	c.mu.Unlock()
+	return nil
+}
+
+// parseChallenge parses a WWW-Authenticate header, such as
+// 'Bearer realm="https://auth.example.com/token",service="registry"'.
+func parseChallenge(challenge string) (string, map[string]string) {
+	parts := strings.SplitN(strings.TrimSpace(challenge), " ", 2)
+	params := map[string]string{}
+	if len(parts) == 2 {
+		for _, p := range strings.Split(parts[1], ",") {
+			kv := strings.SplitN(strings.TrimSpace(p), "=", 2)
+			if len(kv) == 2 {
+				params[strings.ToLower(kv[0])] = strings.Trim(kv[1], `"`)
+			}
+		}
+	}
+	return strings.ToLower(parts[0]), params
+}
+
+func (c *Client) baseURL(host string) string {
+	scheme := "https"
+	hostname := host
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		hostname = h
+	}
+	if ip := net.ParseIP(hostname); hostname == "localhost" || (ip != nil && ip.IsLoopback()) {
+		scheme = "http"
+	}
+	return fmt.Sprintf("%s://%s/v2", scheme, host)
+}
+
+func (c *Client) repositoryURL(ref *Reference, kind, name string) string {
+	return fmt.Sprintf("%s/%s/%s/%s", c.baseURL(ref.Registry), ref.Repository, kind, name)
+}
+
+// responseError builds an error from an unexpected response, including the
+// errors reported by the registry.
+func responseError(resp *http.Response) error {
+	var body struct {
+		Errors []struct {
+			Code    string `json:"code"`
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&body)
+
+	msg := fmt.Sprintf("%s %s: %s", resp.Request.Method, resp.Request.URL, resp.Status)
+	for _, e := range body.Errors {
+		msg += fmt.Sprintf(": %s %s", e.Code, e.Message)
+	}
+	return errors.New(msg)
+}
+
+// Digest returns the OCI digest of data.
+func Digest(data []byte) string {
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
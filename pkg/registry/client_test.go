@@ -0,0 +1,136 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registry_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"k8s.io/helm/pkg/chartutil"
+	"k8s.io/helm/pkg/proto/hapi/chart"
+	"k8s.io/helm/pkg/registry"
+	"k8s.io/helm/pkg/registry/registrytest"
+)
+
+// chartArchive returns the archive of a chart named name at version.
+func chartArchive(t *testing.T, name, version string) []byte {
+	tmp, err := ioutil.TempDir("", "helm-registry-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmp)
+
+	c := &chart.Chart{
+		Metadata:  &chart.Metadata{Name: name, Version: version},
+		Templates: []*chart.Template{{Name: "templates/cm.yaml", Data: []byte("kind: ConfigMap")}},
+	}
+	where, err := chartutil.Save(c, tmp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := ioutil.ReadFile(where)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func TestPushPull(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []registrytest.Option
+	}{
+		{name: "anonymous"},
+		{name: "basic auth", opts: []registrytest.Option{registrytest.WithBasicAuth("user", "pass")}},
+		{name: "token auth", opts: []registrytest.Option{registrytest.WithTokenAuth("user", "pass")}},
+	}
+
+	archive := chartArchive(t, "mychart", "0.1.0")
+	for _, tt := range tests {
+		srv := registrytest.NewServer(tt.opts...)
+		defer srv.Stop()
+
+		creds, _ := registry.LoadCredentials(filepath.Join(os.TempDir(), "helm-registry-none", "config.json"))
+		creds.Set(srv.Host(), "user", "pass")
+		client := registry.NewClient(creds)
+		if err := client.Ping(srv.Host()); err != nil {
+			t.Errorf("%s: failed ping: %s", tt.name, err)
+		}
+
+		ref := &registry.Reference{Registry: srv.Host(), Repository: "charts/mychart", Tag: "0.1.0"}
+		desc, err := client.Push(ref, archive)
+		if err != nil {
+			t.Fatalf("%s: failed push: %s", tt.name, err)
+		}
+		if desc.Digest != registry.Digest(archive) || desc.MediaType != registry.ChartLayerMediaType {
+			t.Errorf("%s: unexpected layer %+v", tt.name, desc)
+		}
+
+		data, _ := srv.Manifest("charts/mychart", "0.1.0")
+		var manifest registry.Manifest
+		if err := json.Unmarshal(data, &manifest); err != nil {
+			t.Fatalf("%s: invalid manifest: %s", tt.name, err)
+		}
+		if manifest.Config.MediaType != registry.ConfigMediaType || len(manifest.Layers) != 1 {
+			t.Errorf("%s: unexpected manifest %s", tt.name, data)
+		}
+
+		// Pushing again reuses the uploaded blobs.
+		if _, err := client.Push(ref, archive); err != nil {
+			t.Errorf("%s: failed second push: %s", tt.name, err)
+		}
+
+		pulled, err := registry.NewClient(creds).Pull(ref)
+		if err != nil {
+			t.Fatalf("%s: failed pull: %s", tt.name, err)
+		}
+		if !bytes.Equal(pulled, archive) {
+			t.Errorf("%s: expected the pulled archive to match the pushed one", tt.name)
+		}
+	}
+}
+
+func TestPull_Errors(t *testing.T) {
+	srv := registrytest.NewServer(registrytest.WithBasicAuth("user", "pass"))
+	defer srv.Stop()
+	ref := &registry.Reference{Registry: srv.Host(), Repository: "charts/mychart", Tag: "0.1.0"}
+
+	if _, err := registry.NewClient(nil).Pull(ref); err == nil || !strings.Contains(err.Error(), "helm registry login "+srv.Host()) {
+		t.Errorf("Expected to be told to log in, got %v", err)
+	}
+
+	creds, _ := registry.LoadCredentials(filepath.Join(os.TempDir(), "helm-registry-none", "config.json"))
+	creds.Set(srv.Host(), "user", "wrong")
+	if _, err := registry.NewClient(creds).Pull(ref); err == nil || !strings.Contains(err.Error(), "rejected") {
+		t.Errorf("Expected the credentials to be rejected, got %v", err)
+	}
+
+	creds.Set(srv.Host(), "user", "pass")
+	if _, err := registry.NewClient(creds).Pull(ref); err == nil || !strings.Contains(err.Error(), "MANIFEST_UNKNOWN") {
+		t.Errorf("Expected an unknown manifest, got %v", err)
+	}
+
+	ref.Tag = ""
+	if _, err := registry.NewClient(creds).Pull(ref); err == nil {
+		t.Error("Expected a reference without tag to be rejected")
+	}
+}
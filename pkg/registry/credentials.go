@@ -0,0 +1,106 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registry
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Credentials holds the credentials of registries, in the format of the
+// Docker config.json file.
+type Credentials struct {
+	Auths map[string]AuthEntry `json:"auths"`
+
+	path string
+}
+
+// AuthEntry holds the credentials of a registry.
+type AuthEntry struct {
+	// Auth is the base64 encoding of USERNAME:PASSWORD.
+	Auth string `json:"auth"`
+}
+
+// LoadCredentials loads the credentials stored at path. A missing file holds
+// no credentials.
+func LoadCredentials(path string) (*Credentials, error) {
+	c := &Credentials{Auths: map[string]AuthEntry{}, path: path}
+	b, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return c, nil
+	} else if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(b, c); err != nil {
+		return nil, fmt.Errorf("cannot parse registry credentials %s: %s", path, err)
+	}
+	if c.Auths == nil {
+		c.Auths = map[string]AuthEntry{}
+	}
+	return c, nil
+}
+
+// Get returns the credentials of the registry host, if any.
+func (c *Credentials) Get(host string) (username, password string, ok bool) {
+	if c == nil {
+		return "", "", false
+	}
+	entry, ok := c.Auths[host]
+	if !ok {
+		return "", "", false
+	}
+	b, err := base64.StdEncoding.DecodeString(entry.Auth)
+	if err != nil {
+		return "", "", false
+	}
+	parts := strings.SplitN(string(b), ":", 2)
+	if len(parts) != 2 {
+		return "", "", false
+	}
+	return parts[0], parts[1], true
+}
+
+// Set sets the credentials of the registry host.
+func (c *Credentials) Set(host, username, password string) {
+	c.Auths[host] = AuthEntry{Auth: base64.StdEncoding.EncodeToString([]byte(username + ":" + password))}
+}
+
+// Remove removes the credentials of the registry host, reporting whether
+// there were any.
+func (c *Credentials) Remove(host string) bool {
+	_, ok := c.Auths[host]
+	delete(c.Auths, host)
+	return ok
+}
+
+// Save writes the credentials back to the file they were loaded from,
+// readable by the current user only.
+func (c *Credentials) Save() error {
+	b, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(c.path), 0755); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(c.path, b, 0600)
+}
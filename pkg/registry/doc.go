@@ -0,0 +1,28 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*Package registry stores charts in OCI registries, and retrieves them.
+
+A chart is stored as an OCI image manifest whose config holds the chart
+metadata and whose single layer is the chart archive. Charts are addressed by
+references of the form HOST[:PORT]/REPOSITORY:TAG, optionally prefixed by the
+oci:// scheme.
+
+Charts are saved to, and pulled into, a local cache before being pushed or
+exported. The credentials of the registries are stored next to that cache, in
+the format of the Docker config.json file.
+*/
+package registry // import "k8s.io/helm/pkg/registry"
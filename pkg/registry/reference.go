@@ -0,0 +1,87 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registry
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// Scheme is the URL scheme of charts stored in OCI registries.
+const Scheme = "oci"
+
+var (
+	repositoryRegexp = regexp.MustCompile(`^[a-z0-9]+(?:(?:[._]|__|-+)[a-z0-9]+)*(?:/[a-z0-9]+(?:(?:[._]|__|-+)[a-z0-9]+)*)*$`)
+	tagRegexp        = regexp.MustCompile(`^\w[\w.-]{0,127}$`)
+)
+
+// Reference identifies a chart in a registry.
+type Reference struct {
+	// Registry is the host, and optionally the port, of the registry.
+	Registry string
+	// Repository is the path of the chart in the registry.
+	Repository string
+	// Tag is the version of the chart in the repository. It may be empty.
+	Tag string
+}
+
+// ParseReference parses a reference of the form HOST[:PORT]/REPOSITORY[:TAG],
+// optionally prefixed by oci://.
+func ParseReference(s string) (*Reference, error) {
+	rest := strings.TrimPrefix(s, Scheme+"://")
+	i := strings.Index(rest, "/")
+	if i <= 0 {
+		return nil, fmt.Errorf("invalid chart reference %q: expected HOST/REPOSITORY[:TAG]", s)
+	}
+
+	ref := &Reference{Registry: rest[:i], Repository: rest[i+1:]}
+	if j := strings.LastIndex(ref.Repository, ":"); j >= 0 {
+		ref.Repository, ref.Tag = ref.Repository[:j], ref.Repository[j+1:]
+		if !tagRegexp.MatchString(ref.Tag) {
+			return nil, fmt.Errorf("invalid chart reference %q: invalid tag %q", s, ref.Tag)
+		}
+	}
+	if !repositoryRegexp.MatchString(ref.Repository) {
+		return nil, fmt.Errorf("invalid chart reference %q: invalid repository %q", s, ref.Repository)
+	}
+	return ref, nil
+}
+
+// String returns the reference, without scheme.
+func (r *Reference) String() string {
+	s := r.Registry + "/" + r.Repository
+	if r.Tag != "" {
+		s += ":" + r.Tag
+	}
+	return s
+}
+
+// ArchiveName returns the file name of the chart archive of the reference,
+// as 'helm package' would name it.
+func (r *Reference) ArchiveName() string {
+	return fmt.Sprintf("%s-%s.tgz", path.Base(r.Repository), r.Tag)
+}
+
+// requireTag returns an error if the reference has no tag.
+func (r *Reference) requireTag() error {
+	if r.Tag == "" {
+		return fmt.Errorf("chart reference %s has no tag", r)
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registry
+
+import (
+	"testing"
+)
+
+func TestParseReference(t *testing.T) {
+	tests := []struct {
+		ref     string
+		expect  Reference
+		invalid bool
+	}{
+		{
+			ref:    "localhost:5000/charts/mychart:0.1.0",
+			expect: Reference{Registry: "localhost:5000", Repository: "charts/mychart", Tag: "0.1.0"},
+		},
+		{
+			ref:    "oci://registry.example.com/mychart:1.2.3-rc.1",
+			expect: Reference{Registry: "registry.example.com", Repository: "mychart", Tag: "1.2.3-rc.1"},
+		},
+		{
+			ref:    "oci://localhost:5000/team/my-chart",
+			expect: Reference{Registry: "localhost:5000", Repository: "team/my-chart"},
+		},
+		{ref: "mychart:0.1.0", invalid: true},
+		{ref: "/mychart:0.1.0", invalid: true},
+		{ref: "localhost:5000/MyChart:0.1.0", invalid: true},
+		{ref: "localhost:5000/mychart:0.1.0+build", invalid: true},
+		{ref: "localhost:5000/mychart:", invalid: true},
+	}
+
+	for _, tt := range tests {
+		ref, err := ParseReference(tt.ref)
+		if tt.invalid {
+			if err == nil {
+				t.Errorf("%s: expected an error, got %v", tt.ref, ref)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", tt.ref, err)
+			continue
+		}
+		if *ref != tt.expect {
+			t.Errorf("%s: expected %+v, got %+v", tt.ref, tt.expect, *ref)
+		}
+	}
+}
+
+func TestReferenceArchiveName(t *testing.T) {
+	ref := &Reference{Registry: "localhost:5000", Repository: "charts/mychart", Tag: "0.1.0"}
+	if name := ref.ArchiveName(); name != "mychart-0.1.0.tgz" {
+		t.Errorf("Expected mychart-0.1.0.tgz, got %s", name)
+	}
+	if s := ref.String(); s != "localhost:5000/charts/mychart:0.1.0" {
+		t.Errorf("Unexpected reference %s", s)
+	}
+}
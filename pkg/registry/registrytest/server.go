@@ -0,0 +1,277 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package registrytest
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+)
+
+// Option configures a Server.
+type Option func(*Server)
+
+// WithBasicAuth requires the clients to authenticate with username and password.
+func WithBasicAuth(username, password string) Option {
+	return func(s *Server) {
+		s.username, s.password = username, password
+	}
+}
+
+// WithTokenAuth requires the clients to authenticate with a bearer token,
+// obtained from the token service of the server with username and password.
+func WithTokenAuth(username, password string) Option {
+	return func(s *Server) {
+		s.username, s.password = username, password
+		s.tokenAuth = true
+	}
+}
+
+// Server is an in-memory registry implementing the parts of the OCI
+// distribution API used to push and pull charts.
+type Server struct {
+	srv *httptest.Server
+
+	username  string
+	password  string
+	tokenAuth bool
+
+	mu        sync.Mutex
+	blobs     map[string][]byte
+	manifests map[string][]byte
+	tokens    map[string]bool
+	uploads   int
+}
+
+// NewServer starts a registry on the loopback interface.
+//
+// The caller is responsible for stopping the server.
+func NewServer(opts ...Option) *Server {
+	s := &Server{
+		blobs:     map[string][]byte{},
+		manifests: map[string][]byte{},
+		tokens:    map[string]bool{},
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	s.srv = httptest.NewServer(s)
+	return s
+}
+
+// Host returns the address of the registry, as used in chart references.
+func (s *Server) Host() string {
+	return strings.TrimPrefix(s.srv.URL, "http://")
+}
+
+// Stop stops the server.
+func (s *Server) Stop() {
+	s.srv.Close()
+}
+
+// Manifest returns the manifest stored in repository under tag.
+func (s *Server) Manifest(repository, tag string) ([]byte, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	m, ok := s.manifests[repository+":"+tag]
+	return m, ok
+}
+
+// ServeHTTP implements http.Handler.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == "/token" {
+		s.serveToken(w, r)
+		return
+	}
+	if !strings.HasPrefix(r.URL.Path, "/v2/") {
+		http.NotFound(w, r)
+		return
+	}
+	if !s.authorized(r) {
+		s.challenge(w, r)
+		return
+	}
+
+	p := strings.TrimPrefix(r.URL.Path, "/v2/")
+	switch {
+	case p == "":
+		w.WriteHeader(http.StatusOK)
+	case strings.Contains(p, "/blobs/uploads/"):
+		i := strings.LastIndex(p, "/blobs/uploads/")
+		s.serveUpload(w, r, p[:i], p[i+len("/blobs/uploads/"):])
+	case strings.Contains(p, "/blobs/"):
+		i := strings.LastIndex(p, "/blobs/")
+		s.serveBlob(w, r, p[i+len("/blobs/"):])
+	case strings.Contains(p, "/manifests/"):
+		i := strings.LastIndex(p, "/manifests/")
+		s.serveManifest(w, r, p[:i], p[i+len("/manifests/"):])
+	default:
+		writeError(w, http.StatusNotFound, "NAME_UNKNOWN", "unknown path")
+	}
+}
+
+func (s *Server) serveUpload(w http.ResponseWriter, r *http.Request, repository, id string) {
+	switch {
+	case r.Method == "POST" && id == "":
+		s.mu.Lock()
+		s.uploads++
+		id = fmt.Sprint(s.uploads)
+		s.mu.Unlock()
+		w.Header().Set("Location", fmt.Sprintf("/v2/%s/blobs/uploads/%s", repository, id))
+		w.WriteHeader(http.StatusAccepted)
+	case r.Method == "PUT" && id != "":
+		data, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "BLOB_UPLOAD_INVALID", err.Error())
+			return
+		}
+		digest := r.URL.Query().Get("digest")
+		if digest != digestOf(data) {
+			writeError(w, http.StatusBadRequest, "DIGEST_INVALID", "digest does not match the content")
+			return
+		}
+		s.mu.Lock()
+		s.blobs[digest] = data
+		s.mu.Unlock()
+		w.Header().Set("Docker-Content-Digest", digest)
+		w.WriteHeader(http.StatusCreated)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "UNSUPPORTED", r.Method)
+	}
+}
+
+func (s *Server) serveBlob(w http.ResponseWriter, r *http.Request, digest string) {
+	s.mu.Lock()
+	data, ok := s.blobs[digest]
+	s.mu.Unlock()
+	if !ok {
+		writeError(w, http.StatusNotFound, "BLOB_UNKNOWN", "blob unknown to registry")
+		return
+	}
+	w.Header().Set("Content-Length", fmt.Sprint(len(data)))
+	w.Header().Set("Docker-Content-Digest", digest)
+	w.WriteHeader(http.StatusOK)
+	if r.Method == "GET" {
+		w.Write(data)
+	}
+}
+
+func (s *Server) serveManifest(w http.ResponseWriter, r *http.Request, repository, tag string) {
+	key := repository + ":" + tag
+	if r.Method == "PUT" {
+		data, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "MANIFEST_INVALID", err.Error())
+			return
+		}
+		var m struct {
+			Config struct {
+				Digest string `json:"digest"`
+			} `json:"config"`
+			Layers []struct {
+				Digest string `json:"digest"`
+			} `json:"layers"`
+		}
+		if err := json.Unmarshal(data, &m); err != nil {
+			writeError(w, http.StatusBadRequest, "MANIFEST_INVALID", err.Error())
+			return
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		digests := []string{m.Config.Digest}
+		for _, l := range m.Layers {
+			digests = append(digests, l.Digest)
+		}
+		for _, d := range digests {
+			if _, ok := s.blobs[d]; !ok {
+				writeError(w, http.StatusBadRequest, "MANIFEST_BLOB_UNKNOWN", "blob unknown to registry: "+d)
+				return
+			}
+		}
+		s.manifests[key] = data
+		w.Header().Set("Docker-Content-Digest", digestOf(data))
+		w.WriteHeader(http.StatusCreated)
+		return
+	}
+
+	s.mu.Lock()
+	data, ok := s.manifests[key]
+	s.mu.Unlock()
+	if !ok {
+		writeError(w, http.StatusNotFound, "MANIFEST_UNKNOWN", "manifest unknown: "+key)
+		return
+	}
+	w.Header().Set("Content-Type", "application/vnd.oci.image.manifest.v1+json")
+	w.Header().Set("Docker-Content-Digest", digestOf(data))
+	w.WriteHeader(http.StatusOK)
+	if r.Method == "GET" {
+		w.Write(data)
+	}
+}
+
+func (s *Server) serveToken(w http.ResponseWriter, r *http.Request) {
+	if u, p, ok := r.BasicAuth(); !ok || u != s.username || p != s.password {
+		writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "invalid credentials")
+		return
+	}
+	s.mu.Lock()
+	token := fmt.Sprintf("token-%d", len(s.tokens)+1)
+	s.tokens[token] = true
+	s.mu.Unlock()
+	json.NewEncoder(w).Encode(map[string]string{"token": token})
+}
+
+func (s *Server) authorized(r *http.Request) bool {
+	if s.username == "" {
+		return true
+	}
+	if s.tokenAuth {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		return s.tokens[strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")]
+	}
+	u, p, ok := r.BasicAuth()
+	return ok && u == s.username && p == s.password
+}
+
+func (s *Server) challenge(w http.ResponseWriter, r *http.Request) {
+	if s.tokenAuth {
+		w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s/token",service="registrytest"`, s.srv.URL))
+	} else {
+		w.Header().Set("WWW-Authenticate", `Basic realm="registrytest"`)
+	}
+	writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "authentication required")
+}
+
+func writeError(w http.ResponseWriter, status int, code, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"errors": []map[string]string{{"code": code, "message": message}},
+	})
+}
+
+func digestOf(data []byte) string {
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
@@ -30,11 +30,16 @@ import (
 	"k8s.io/helm/pkg/tiller/environment"
 )
 
+// Stream receives the results of the tests as they run.
+type Stream interface {
+	Send(*services.TestReleaseResponse) error
+}
+
 // Environment encapsulates information about where test suite executes and returns results
 type Environment struct {
 	Namespace   string
 	KubeClient  environment.KubeClient
-	Stream      services.ReleaseService_RunReleaseTestServer
+	Stream      Stream
 	Timeout     int64
 	Parallel    bool
 	Parallelism uint32
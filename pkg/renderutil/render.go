@@ -83,6 +83,11 @@ func Render(c *chart.Chart, config *chart.Config, opts Options) (map[string]stri
 	if err != nil {
 		return nil, err
 	}
+	if cvals, err := vals.Table("Values"); err == nil {
+		if err := chartutil.ValidateValues(c, cvals); err != nil {
+			return nil, err
+		}
+	}
 
 	return renderer.Render(c, vals)
 }
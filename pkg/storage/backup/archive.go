@@ -0,0 +1,235 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*Package backup exports the history of releases to a portable archive, and
+restores it through any storage driver.
+
+An archive is a gzipped tarball holding an index.yaml file, which describes
+the archive, and a releases/<name>.v<version>.pb file for every revision,
+holding the revision as a serialized hapi.release.Release protobuf message.
+*/
+package backup // import "k8s.io/helm/pkg/storage/backup"
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path"
+	"sort"
+	"time"
+
+	"github.com/ghodss/yaml"
+	"github.com/golang/protobuf/proto"
+
+	rspb "k8s.io/helm/pkg/proto/hapi/release"
+)
+
+// APIVersion is the version of the archive format written by Write.
+const APIVersion = "v1"
+
+// IndexName is the name of the index of an archive.
+const IndexName = "index.yaml"
+
+// Index describes the contents of an archive.
+type Index struct {
+	APIVersion string    `json:"apiVersion"`
+	Generated  time.Time `json:"generated"`
+	Entries    []*Entry  `json:"entries"`
+}
+
+// Entry describes a release revision stored in an archive.
+type Entry struct {
+	Name      string `json:"name"`
+	Version   int32  `json:"version"`
+	Namespace string `json:"namespace"`
+	Status    string `json:"status"`
+	// Chart is the name and version of the chart of the revision.
+	Chart string `json:"chart,omitempty"`
+	// File is the path of the revision in the archive.
+	File string `json:"file"`
+	// Digest is the SHA-256 digest of the file.
+	Digest string `json:"digest"`
+	// Labels are the labels of the record of the revision in the storage
+	// driver it was exported from, if the driver stores labels.
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// Record is a release revision and the labels of its record in storage.
+type Record struct {
+	Release *rspb.Release
+	Labels  map[string]string
+}
+
+// Key returns the key of the record in storage drivers.
+func (r *Record) Key() string {
+	return key(r.Release.Name, r.Release.Version)
+}
+
+func key(name string, version int32) string {
+	return fmt.Sprintf("%s.v%d", name, version)
+}
+
+// sortRecords sorts records by release name and version.
+func sortRecords(records []*Record) {
+	sort.Slice(records, func(i, j int) bool {
+		if records[i].Release.Name != records[j].Release.Name {
+			return records[i].Release.Name < records[j].Release.Name
+		}
+		return records[i].Release.Version < records[j].Release.Version
+	})
+}
+
+// Write writes an archive of the records to w. The records are stored
+// sorted by release name and version.
+func Write(w io.Writer, records []*Record) error {
+	records = append([]*Record(nil), records...)
+	sortRecords(records)
+
+	zw := gzip.NewWriter(w)
+	tw := tar.NewWriter(zw)
+	now := time.Now()
+	index := &Index{APIVersion: APIVersion, Generated: now.UTC()}
+
+	var files [][]byte
+	for _, r := range records {
+		data, err := proto.Marshal(r.Release)
+		if err != nil {
+			return fmt.Errorf("cannot encode %s: %s", r.Key(), err)
+		}
+		sum := sha256.Sum256(data)
+		index.Entries = append(index.Entries, &Entry{
+			Name:      r.Release.Name,
+			Version:   r.Release.Version,
+			Namespace: r.Release.Namespace,
+			Status:    r.Release.GetInfo().GetStatus().GetCode().String(),
+			Chart:     chartName(r.Release),
+			File:      path.Join("releases", r.Key()+".pb"),
+			Digest:    hex.EncodeToString(sum[:]),
+			Labels:    r.Labels,
+		})
+		files = append(files, data)
+	}
+
+	// The index comes first, so that it can be read without reading the
+	// whole archive.
+	data, err := yaml.Marshal(index)
+	if err != nil {
+		return err
+	}
+	if err := writeFile(tw, IndexName, data, now); err != nil {
+		return err
+	}
+	for i, e := range index.Entries {
+		if err := writeFile(tw, e.File, files[i], now); err != nil {
+			return err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return zw.Close()
+}
+
+func writeFile(tw *tar.Writer, name string, data []byte, modTime time.Time) error {
+	err := tw.WriteHeader(&tar.Header{
+		Name:    name,
+		Mode:    0644,
+		Size:    int64(len(data)),
+		ModTime: modTime,
+	})
+	if err != nil {
+		return err
+	}
+	_, err = tw.Write(data)
+	return err
+}
+
+func chartName(rel *rspb.Release) string {
+	md := rel.GetChart().GetMetadata()
+	if md == nil {
+		return ""
+	}
+	return md.Name + "-" + md.Version
+}
+
+// Read reads an archive written by Write, and returns its index and its
+// records in the order of the index. The digest of every file is verified.
+func Read(r io.Reader) (*Index, []*Record, error) {
+	zr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid archive: %s", err)
+	}
+	defer zr.Close()
+	tr := tar.NewReader(zr)
+
+	files := map[string][]byte{}
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid archive: %s", err)
+		}
+		if hdr.Typeflag != tar.TypeReg && hdr.Typeflag != tar.TypeRegA {
+			continue
+		}
+		data, err := ioutil.ReadAll(tr)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid archive: %s", err)
+		}
+		files[path.Clean(hdr.Name)] = data
+	}
+
+	data, ok := files[IndexName]
+	if !ok {
+		return nil, nil, errors.New("invalid archive: no " + IndexName)
+	}
+	index := &Index{}
+	if err := yaml.Unmarshal(data, index); err != nil {
+		return nil, nil, fmt.Errorf("invalid %s: %s", IndexName, err)
+	}
+	if index.APIVersion != APIVersion {
+		return nil, nil, fmt.Errorf("unsupported archive version %q: only %s is supported", index.APIVersion, APIVersion)
+	}
+
+	records := make([]*Record, 0, len(index.Entries))
+	for _, e := range index.Entries {
+		data, ok := files[path.Clean(e.File)]
+		if !ok {
+			return nil, nil, fmt.Errorf("invalid archive: %s is missing", e.File)
+		}
+		sum := sha256.Sum256(data)
+		if hex.EncodeToString(sum[:]) != e.Digest {
+			return nil, nil, fmt.Errorf("invalid archive: the digest of %s does not match", e.File)
+		}
+		rel := &rspb.Release{}
+		if err := proto.Unmarshal(data, rel); err != nil {
+			return nil, nil, fmt.Errorf("invalid archive: cannot decode %s: %s", e.File, err)
+		}
+		if rel.Name != e.Name || rel.Version != e.Version {
+			return nil, nil, fmt.Errorf("invalid archive: %s holds %s", e.File, key(rel.Name, rel.Version))
+		}
+		records = append(records, &Record{Release: rel, Labels: e.Labels})
+	}
+	return index, records, nil
+}
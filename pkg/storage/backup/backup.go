@@ -0,0 +1,248 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backup // import "k8s.io/helm/pkg/storage/backup"
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/golang/protobuf/proto"
+
+	rspb "k8s.io/helm/pkg/proto/hapi/release"
+	relutil "k8s.io/helm/pkg/releaseutil"
+	"k8s.io/helm/pkg/storage"
+	"k8s.io/helm/pkg/storage/driver"
+	storageerrors "k8s.io/helm/pkg/storage/errors"
+)
+
+// Collect returns the records of every revision of the releases stored in s
+// that have at least one revision matching all the filters, sorted by release
+// name and version.
+func Collect(s *storage.Storage, filters ...relutil.FilterFunc) ([]*Record, error) {
+	matched, err := s.ListFilterAll(filters...)
+	if err != nil {
+		return nil, err
+	}
+	names := map[string]bool{}
+	for _, rel := range matched {
+		names[rel.Name] = true
+	}
+
+	var records []*Record
+	for name := range names {
+		h, err := s.History(name)
+		if err != nil {
+			return nil, err
+		}
+		for _, rel := range h {
+			r := &Record{Release: rel}
+			if l, ok := s.Driver.(driver.Labeler); ok {
+				if r.Labels, err = l.Labels(r.Key()); err != nil {
+					return nil, fmt.Errorf("cannot get the labels of %s: %s", r.Key(), err)
+				}
+			}
+			records = append(records, r)
+		}
+	}
+	sortRecords(records)
+	return records, nil
+}
+
+// Conflict is how Restore handles a revision the storage driver already
+// holds.
+type Conflict string
+
+const (
+	// ConflictSkip keeps the revision stored.
+	ConflictSkip Conflict = "skip"
+	// ConflictOverwrite replaces the revision stored with the one restored,
+	// keeping the labels of the stored record.
+	ConflictOverwrite Conflict = "overwrite"
+	// ConflictRenumber restores the history of a release after the
+	// revisions stored, renumbering the restored revisions. The revisions
+	// stored are superseded if a restored revision is deployed.
+	ConflictRenumber Conflict = "renumber"
+)
+
+// Conflicts are the ways Restore can handle conflicts.
+var Conflicts = []Conflict{ConflictSkip, ConflictOverwrite, ConflictRenumber}
+
+// RestoreOptions configures Restore.
+type RestoreOptions struct {
+	// Conflict is how revisions the driver already holds are handled. It
+	// defaults to ConflictSkip.
+	Conflict Conflict
+	// DryRun reports what would be restored without writing anything.
+	DryRun bool
+
+	Log func(string, ...interface{})
+}
+
+// RestoreResult describes the revisions handled by Restore.
+type RestoreResult struct {
+	// Restored are the revisions restored, with their version in the
+	// storage driver.
+	Restored []*rspb.Release
+	// Skipped are the keys of the revisions that were already stored.
+	Skipped []string
+}
+
+// Restore re-creates the records of an archive in the storage driver d,
+// keeping their labels if d stores labels.
+func Restore(d driver.Driver, records []*Record, opts RestoreOptions) (*RestoreResult, error) {
+	log := opts.Log
+	if log == nil {
+		log = func(_ string, _ ...interface{}) {}
+	}
+	switch opts.Conflict {
+	case "":
+		opts.Conflict = ConflictSkip
+	case ConflictSkip, ConflictOverwrite, ConflictRenumber:
+	default:
+		return nil, fmt.Errorf("unknown conflict handling %q", opts.Conflict)
+	}
+
+	byName := map[string][]*Record{}
+	var names []string
+	for _, r := range records {
+		if _, ok := byName[r.Release.Name]; !ok {
+			names = append(names, r.Release.Name)
+		}
+		byName[r.Release.Name] = append(byName[r.Release.Name], r)
+	}
+	sort.Strings(names)
+
+	result := &RestoreResult{}
+	for _, name := range names {
+		h := byName[name]
+		sort.Slice(h, func(i, j int) bool { return h[i].Release.Version < h[j].Release.Version })
+
+		stored, err := d.Query(map[string]string{"NAME": name, "OWNER": "TILLER"})
+		if err != nil && err.Error() != storageerrors.ErrReleaseNotFound(name).Error() {
+			return result, fmt.Errorf("cannot get the history of %s: %s", name, err)
+		}
+		if len(stored) > 0 && opts.Conflict == ConflictRenumber {
+			if err := renumber(d, h, stored, opts.DryRun, log); err != nil {
+				return result, err
+			}
+			for _, r := range h {
+				result.Restored = append(result.Restored, r.Release)
+			}
+			continue
+		}
+
+		isStored := map[int32]bool{}
+		for _, rel := range stored {
+			isStored[rel.Version] = true
+		}
+		for _, r := range h {
+			k := r.Key()
+			switch {
+			case !isStored[r.Release.Version]:
+				if !opts.DryRun {
+					if err := create(d, r); err != nil {
+						return result, err
+					}
+				}
+				logAction(log, opts.DryRun, "restore", "restored", "%s", k)
+			case opts.Conflict == ConflictOverwrite:
+				// The stored revision is replaced in place, so that it is
+				// kept if the restore fails.
+				if !opts.DryRun {
+					if err := d.Update(k, r.Release); err != nil {
+						return result, fmt.Errorf("cannot overwrite %s: %s", k, err)
+					}
+				}
+				logAction(log, opts.DryRun, "overwrite", "overwrote", "%s", k)
+			default:
+				log("skipping %s: already stored", k)
+				result.Skipped = append(result.Skipped, k)
+				continue
+			}
+			result.Restored = append(result.Restored, r.Release)
+		}
+	}
+	return result, nil
+}
+
+// renumber restores the history h of a release after its stored revisions.
+func renumber(d driver.Driver, h []*Record, stored []*rspb.Release, dryRun bool, log func(string, ...interface{})) error {
+	var last int32
+	for _, rel := range stored {
+		if rel.Version > last {
+			last = rel.Version
+		}
+	}
+
+	deployed := false
+	for i, r := range h {
+		rel := proto.Clone(r.Release).(*rspb.Release)
+		rel.Version = last + int32(i) + 1
+		if rel.GetInfo().GetStatus().GetCode() == rspb.Status_DEPLOYED {
+			deployed = true
+		}
+		if !dryRun {
+			if err := create(d, &Record{Release: rel, Labels: r.Labels}); err != nil {
+				return err
+			}
+		}
+		logAction(log, dryRun, "restore", "restored", "%s as %s", r.Key(), key(rel.Name, rel.Version))
+		h[i] = &Record{Release: rel, Labels: r.Labels}
+	}
+	if !deployed {
+		return nil
+	}
+
+	for _, rel := range stored {
+		if rel.GetInfo().GetStatus().GetCode() != rspb.Status_DEPLOYED {
+			continue
+		}
+		k := key(rel.Name, rel.Version)
+		if !dryRun {
+			rel = proto.Clone(rel).(*rspb.Release)
+			rel.Info.Status.Code = rspb.Status_SUPERSEDED
+			if err := d.Update(k, rel); err != nil {
+				return fmt.Errorf("cannot supersede %s: %s", k, err)
+			}
+		}
+		logAction(log, dryRun, "supersede", "superseded", "%s", k)
+	}
+	return nil
+}
+
+// logAction logs an action done, or the action a dry run would do.
+func logAction(log func(string, ...interface{}), dryRun bool, action, done, format string, v ...interface{}) {
+	if dryRun {
+		log("would "+action+" "+format, v...)
+		return
+	}
+	log(done+" "+format, v...)
+}
+
+// create stores the record in d, with its labels if d stores labels.
+func create(d driver.Driver, r *Record) error {
+	var err error
+	if l, ok := d.(driver.Labeler); ok && r.Labels != nil {
+		err = l.CreateLabeled(r.Key(), r.Release, r.Labels)
+	} else {
+		err = d.Create(r.Key(), r.Release)
+	}
+	if err != nil {
+		return fmt.Errorf("cannot restore %s: %s", r.Key(), err)
+	}
+	return nil
+}
@@ -0,0 +1,319 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backup // import "k8s.io/helm/pkg/storage/backup"
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"io/ioutil"
+	"reflect"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+
+	"k8s.io/helm/pkg/proto/hapi/chart"
+	rspb "k8s.io/helm/pkg/proto/hapi/release"
+	relutil "k8s.io/helm/pkg/releaseutil"
+	"k8s.io/helm/pkg/storage"
+	"k8s.io/helm/pkg/storage/driver"
+)
+
+func release(name string, version int32, status rspb.Status_Code) *rspb.Release {
+	return &rspb.Release{
+		Name:      name,
+		Version:   version,
+		Namespace: "default",
+		Info:      &rspb.Info{Status: &rspb.Status{Code: status}},
+		Chart:     &chart.Chart{Metadata: &chart.Metadata{Name: "mychart", Version: "0.1.0"}},
+		Manifest:  "kind: ConfigMap",
+	}
+}
+
+func newStorage(t *testing.T, rels ...*rspb.Release) *storage.Storage {
+	s := storage.Init(driver.NewMemory())
+	for _, rel := range rels {
+		if err := s.Create(rel); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return s
+}
+
+func keys(records []*Record) []string {
+	var ks []string
+	for _, r := range records {
+		ks = append(ks, r.Key())
+	}
+	return ks
+}
+
+func TestWriteRead(t *testing.T) {
+	records := []*Record{
+		{Release: release("happy-panda", 2, rspb.Status_DEPLOYED), Labels: map[string]string{"CREATED_AT": "1550000000"}},
+		{Release: release("angry-beaver", 1, rspb.Status_DELETED)},
+		{Release: release("happy-panda", 1, rspb.Status_SUPERSEDED)},
+	}
+	var buf bytes.Buffer
+	if err := Write(&buf, records); err != nil {
+		t.Fatal(err)
+	}
+	archive := buf.Bytes()
+
+	index, read, err := Read(bytes.NewReader(archive))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if index.APIVersion != APIVersion {
+		t.Errorf("Expected archive version %s, got %s", APIVersion, index.APIVersion)
+	}
+	expected := []string{"angry-beaver.v1", "happy-panda.v1", "happy-panda.v2"}
+	if got := keys(read); !reflect.DeepEqual(got, expected) {
+		t.Fatalf("Expected records %v, got %v", expected, got)
+	}
+	e := index.Entries[2]
+	if e.Status != "DEPLOYED" || e.Chart != "mychart-0.1.0" || e.File != "releases/happy-panda.v2.pb" {
+		t.Errorf("Unexpected index entry %+v", e)
+	}
+	if !proto.Equal(read[2].Release, records[0].Release) || read[2].Labels["CREATED_AT"] != "1550000000" {
+		t.Errorf("Expected %s to be read back with its labels, got %+v", read[2].Key(), read[2])
+	}
+
+	if _, _, err := Read(bytes.NewReader([]byte("not an archive"))); err == nil {
+		t.Error("Expected an error reading an invalid archive")
+	}
+}
+
+// rewrite rewrites the files of an archive with fn.
+func rewrite(t *testing.T, archive []byte, fn func(name string, data []byte) []byte) []byte {
+	zr, err := gzip.NewReader(bytes.NewReader(archive))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tr := tar.NewReader(zr)
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(zw)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, err := ioutil.ReadAll(tr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		data = fn(hdr.Name, data)
+		hdr.Size = int64(len(data))
+		if err := tw.WriteHeader(hdr); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write(data); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestReadInvalid(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(&buf, []*Record{{Release: release("happy-panda", 1, rspb.Status_DEPLOYED)}}); err != nil {
+		t.Fatal(err)
+	}
+	archive := buf.Bytes()
+
+	other, err := proto.Marshal(release("happy-panda", 1, rspb.Status_DELETED))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name string
+		fn   func(name string, data []byte) []byte
+	}{
+		{"tampered revision", func(name string, data []byte) []byte {
+			if name == IndexName {
+				return data
+			}
+			return other
+		}},
+		{"unsupported version", func(name string, data []byte) []byte {
+			if name != IndexName {
+				return data
+			}
+			return bytes.Replace(data, []byte("apiVersion: v1"), []byte("apiVersion: v2"), 1)
+		}},
+		{"missing revision", func(name string, data []byte) []byte {
+			if name != IndexName {
+				return data
+			}
+			return bytes.Replace(data, []byte("happy-panda.v1.pb"), []byte("happy-panda.v2.pb"), 1)
+		}},
+	}
+	for _, tt := range tests {
+		if _, _, err := Read(bytes.NewReader(rewrite(t, archive, tt.fn))); err == nil {
+			t.Errorf("Expected an error reading an archive with a %s", tt.name)
+		}
+	}
+	if _, _, err := Read(bytes.NewReader(rewrite(t, archive, func(_ string, data []byte) []byte { return data }))); err != nil {
+		t.Errorf("Expected an unchanged archive to be read, got %s", err)
+	}
+}
+
+func TestCollect(t *testing.T) {
+	s := newStorage(t,
+		release("happy-panda", 1, rspb.Status_SUPERSEDED),
+		release("happy-panda", 2, rspb.Status_DEPLOYED),
+		release("angry-beaver", 1, rspb.Status_DELETED),
+	)
+
+	records, err := Collect(s, relutil.StatusFilter(rspb.Status_DEPLOYED))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{"happy-panda.v1", "happy-panda.v2"}
+	if got := keys(records); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected the whole history of the deployed releases %v, got %v", expected, got)
+	}
+	for _, r := range records {
+		if r.Labels["CREATED_AT"] == "" {
+			t.Errorf("Expected the labels of %s to be collected, got %v", r.Key(), r.Labels)
+		}
+	}
+
+	records, err = Collect(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 3 {
+		t.Errorf("Expected 3 records without filters, got %v", keys(records))
+	}
+}
+
+func TestRestore(t *testing.T) {
+	archived := func() []*Record {
+		return []*Record{
+			{Release: release("happy-panda", 1, rspb.Status_SUPERSEDED), Labels: map[string]string{"CREATED_AT": "1550000000"}},
+			{Release: release("happy-panda", 2, rspb.Status_DEPLOYED), Labels: map[string]string{"CREATED_AT": "1550000000"}},
+			{Release: release("angry-beaver", 1, rspb.Status_DEPLOYED)},
+		}
+	}
+	stored := func() *driver.Memory {
+		d := driver.NewMemory()
+		rel := release("happy-panda", 1, rspb.Status_DEPLOYED)
+		rel.Manifest = "kind: Secret"
+		if err := d.Create("happy-panda.v1", rel); err != nil {
+			t.Fatal(err)
+		}
+		return d
+	}
+	restored := func(res *RestoreResult) []string {
+		var ks []string
+		for _, rel := range res.Restored {
+			ks = append(ks, key(rel.Name, rel.Version))
+		}
+		return ks
+	}
+
+	tests := []struct {
+		conflict Conflict
+		restored []string
+		skipped  []string
+		// manifest is the manifest of happy-panda.v1 after the restore.
+		manifest string
+		// status is the status of happy-panda.v1 after the restore.
+		status rspb.Status_Code
+		// deployed is the key of the deployed revision restored.
+		deployed string
+	}{
+		{ConflictSkip, []string{"angry-beaver.v1", "happy-panda.v2"}, []string{"happy-panda.v1"}, "kind: Secret", rspb.Status_DEPLOYED, "happy-panda.v2"},
+		{ConflictOverwrite, []string{"angry-beaver.v1", "happy-panda.v1", "happy-panda.v2"}, nil, "kind: ConfigMap", rspb.Status_SUPERSEDED, "happy-panda.v2"},
+		{ConflictRenumber, []string{"angry-beaver.v1", "happy-panda.v2", "happy-panda.v3"}, nil, "kind: Secret", rspb.Status_SUPERSEDED, "happy-panda.v3"},
+	}
+	for _, tt := range tests {
+		d := stored()
+		res, err := Restore(d, archived(), RestoreOptions{Conflict: tt.conflict})
+		if err != nil {
+			t.Fatalf("%s: %s", tt.conflict, err)
+		}
+		if got := restored(res); !reflect.DeepEqual(got, tt.restored) {
+			t.Errorf("%s: expected %v to be restored, got %v", tt.conflict, tt.restored, got)
+		}
+		if !reflect.DeepEqual(res.Skipped, tt.skipped) {
+			t.Errorf("%s: expected %v to be skipped, got %v", tt.conflict, tt.skipped, res.Skipped)
+		}
+		rel, err := d.Get("happy-panda.v1")
+		if err != nil {
+			t.Fatalf("%s: %s", tt.conflict, err)
+		}
+		if rel.Manifest != tt.manifest || rel.Info.Status.Code != tt.status {
+			t.Errorf("%s: expected happy-panda.v1 to be %s with %q, got %s with %q", tt.conflict, tt.status, tt.manifest, rel.Info.Status.Code, rel.Manifest)
+		}
+		lbs, err := d.Labels(tt.deployed)
+		if err != nil {
+			t.Fatalf("%s: %s", tt.conflict, err)
+		}
+		if lbs["CREATED_AT"] != "1550000000" {
+			t.Errorf("%s: expected the labels to be restored, got %v", tt.conflict, lbs)
+		}
+	}
+
+	// A failed overwrite keeps the revision stored.
+	failing := failUpdates{stored()}
+	if _, err := Restore(failing, archived(), RestoreOptions{Conflict: ConflictOverwrite}); err == nil || err.Error() != "cannot overwrite happy-panda.v1: update failed" {
+		t.Errorf("Expected the overwrite to fail, got %v", err)
+	}
+	if rel, err := failing.Get("happy-panda.v1"); err != nil || rel.Manifest != "kind: Secret" {
+		t.Errorf("Expected happy-panda.v1 to be kept, got %v (%v)", rel, err)
+	}
+
+	// A dry run restores nothing.
+	d := driver.NewMemory()
+	res, err := Restore(d, archived(), RestoreOptions{DryRun: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Restored) != 3 {
+		t.Errorf("Expected a dry run to report restoring 3 revisions, got %d", len(res.Restored))
+	}
+	if rels, _ := d.List(func(*rspb.Release) bool { return true }); len(rels) != 0 {
+		t.Errorf("Expected a dry run not to restore anything, got %d releases", len(rels))
+	}
+
+	if _, err := Restore(d, archived(), RestoreOptions{Conflict: "merge"}); err == nil {
+		t.Error("Expected an error for an unknown conflict handling")
+	}
+}
+
+// failUpdates is a driver failing to update records.
+type failUpdates struct {
+	*driver.Memory
+}
+
+func (failUpdates) Update(string, *rspb.Release) error {
+	return errors.New("update failed")
+}
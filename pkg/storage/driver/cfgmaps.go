@@ -34,6 +34,8 @@ import (
 )
 
 var _ Driver = (*ConfigMaps)(nil)
+var _ Locker = (*ConfigMaps)(nil)
+var _ Labeler = (*ConfigMaps)(nil)
 
 // ConfigMapsDriverName is the string name of the driver.
 const ConfigMapsDriverName = "ConfigMap"
@@ -43,14 +45,23 @@ const ConfigMapsDriverName = "ConfigMap"
 type ConfigMaps struct {
 	impl corev1.ConfigMapInterface
 	Log  func(string, ...interface{})
+
+	// Keys, if set, encrypts the releases stored. Encrypted releases can
+	// only be read with Keys set.
+	Keys KeyProvider
+
+	// chunkSize is the maximum length of the encoded release stored
+	// in a single ConfigMap.
+	chunkSize int
 }
 
 // NewConfigMaps initializes a new ConfigMaps wrapping an implementation of
 // the kubernetes ConfigMapsInterface.
 func NewConfigMaps(impl corev1.ConfigMapInterface) *ConfigMaps {
 	return &ConfigMaps{
-		impl: impl,
-		Log:  func(_ string, _ ...interface{}) {},
+		impl:      impl,
+		Log:       func(_ string, _ ...interface{}) {},
+		chunkSize: defaultChunkSize,
 	}
 }
 
@@ -72,8 +83,8 @@ func (cfgmaps *ConfigMaps) Get(key string) (*rspb.Release, error) {
 		cfgmaps.Log("get: failed to get %q: %s", key, err)
 		return nil, err
 	}
-	// found the configmap, decode the base64 data string
-	r, err := decodeRelease(obj.Data["release"])
+	// found the configmap, reassemble and decode the base64 data string
+	r, err := cfgmaps.decode(obj)
 	if err != nil {
 		cfgmaps.Log("get: failed to decode data %q: %s", key, err)
 		return nil, err
@@ -99,8 +110,12 @@ func (cfgmaps *ConfigMaps) List(filter func(*rspb.Release) bool) ([]*rspb.Releas
 
 	// iterate over the configmaps object list
 	// and decode each release
-	for _, item := range list.Items {
-		rls, err := decodeRelease(item.Data["release"])
+	for i := range list.Items {
+		item := &list.Items[i]
+		if isChunk(item.Labels) {
+			continue
+		}
+		rls, err := cfgmaps.decode(item)
 		if err != nil {
 			cfgmaps.Log("list: failed to decode release: %v: %s", item, err)
 			continue
@@ -136,8 +151,12 @@ func (cfgmaps *ConfigMaps) Query(labels map[string]string) ([]*rspb.Release, err
 	}
 
 	var results []*rspb.Release
-	for _, item := range list.Items {
-		rls, err := decodeRelease(item.Data["release"])
+	for i := range list.Items {
+		item := &list.Items[i]
+		if isChunk(item.Labels) {
+			continue
+		}
+		rls, err := cfgmaps.decode(item)
 		if err != nil {
 			cfgmaps.Log("query: failed to decode release: %s", err)
 			continue
@@ -150,20 +169,39 @@ func (cfgmaps *ConfigMaps) Query(labels map[string]string) ([]*rspb.Release, err
 // Create creates a new ConfigMap holding the release. If the
 // ConfigMap already exists, ErrReleaseExists is returned.
 func (cfgmaps *ConfigMaps) Create(key string, rls *rspb.Release) error {
-	// set labels for configmaps object meta data
-	var lbs labels
+	return cfgmaps.CreateLabeled(key, rls, nil)
+}
 
-	lbs.init()
-	lbs.set("CREATED_AT", strconv.Itoa(int(time.Now().Unix())))
+// Labels returns the labels of the ConfigMap holding the release named by key.
+func (cfgmaps *ConfigMaps) Labels(key string) (map[string]string, error) {
+	obj, err := cfgmaps.impl.Get(key, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, storageerrors.ErrReleaseNotFound(key)
+		}
+		return nil, err
+	}
+	return recordLabels(obj.Labels), nil
+}
 
-	// create a new configmap to hold the release
-	obj, err := newConfigMapsObject(key, rls, lbs)
+// CreateLabeled creates a new ConfigMap holding the release, labeled with lbs.
+// If the ConfigMap already exists, ErrReleaseExists is returned.
+func (cfgmaps *ConfigMaps) CreateLabeled(key string, rls *rspb.Release, lbs map[string]string) error {
+	// create the configmaps to hold the release
+	objs, err := newConfigMapsObjects(key, rls, createdLabels(lbs), cfgmaps.chunkSize, cfgmaps.Keys)
 	if err != nil {
 		cfgmaps.Log("create: failed to encode release %q: %s", rls.Name, err)
 		return err
 	}
+	// write the chunks, if any, before the configmap referencing them
+	if err := cfgmaps.writeChunks(objs[1:]); err != nil {
+		cfgmaps.Log("create: failed to create chunks of %q: %s", key, err)
+		cfgmaps.deleteChunks(key, objs[0].Labels)
+		return err
+	}
 	// push the configmap object out into the kubiverse
-	if _, err := cfgmaps.impl.Create(obj); err != nil {
+	if _, err := cfgmaps.impl.Create(objs[0]); err != nil {
+		cfgmaps.deleteChunks(key, objs[0].Labels)
 		if apierrors.IsAlreadyExists(err) {
 			return storageerrors.ErrReleaseExists(key)
 		}
@@ -183,44 +221,241 @@ func (cfgmaps *ConfigMaps) Update(key string, rls *rspb.Release) error {
 	lbs.init()
 	lbs.set("MODIFIED_AT", strconv.Itoa(int(time.Now().Unix())))
 
-	// create a new configmap object to hold the release
-	obj, err := newConfigMapsObject(key, rls, lbs)
+	// fetch the current configmap to find the chunks it refers to
+	cur, err := cfgmaps.impl.Get(key, metav1.GetOptions{})
+	if err != nil {
+		cfgmaps.Log("update: failed to get %q: %s", key, err)
+		return err
+	}
+
+	// create the configmap objects to hold the release
+	objs, err := newConfigMapsObjects(key, rls, lbs, cfgmaps.chunkSize, cfgmaps.Keys)
 	if err != nil {
 		cfgmaps.Log("update: failed to encode release %q: %s", rls.Name, err)
 		return err
 	}
+	// write the new chunks under a new generation, switch the configmap
+	// over to them and only then remove the chunks it referred to, so that
+	// the record stays readable whenever the update fails
+	if err := cfgmaps.writeChunks(objs[1:]); err != nil {
+		cfgmaps.Log("update: failed to write chunks of %q: %s", key, err)
+		cfgmaps.deleteChunks(key, objs[0].Labels)
+		return err
+	}
 	// push the configmap object out into the kubiverse
-	_, err = cfgmaps.impl.Update(obj)
+	objs[0].ResourceVersion = cur.ResourceVersion
+	_, err = cfgmaps.impl.Update(objs[0])
 	if err != nil {
 		cfgmaps.Log("update: failed to update: %s", err)
+		cfgmaps.deleteChunks(key, objs[0].Labels)
 		return err
 	}
+	// remove the chunks of the previous revision of the record
+	cfgmaps.deleteChunks(key, cur.Labels)
 	return nil
 }
 
 // Delete deletes the ConfigMap holding the release named by key.
 func (cfgmaps *ConfigMaps) Delete(key string) (rls *rspb.Release, err error) {
 	// fetch the release to check existence
-	if rls, err = cfgmaps.Get(key); err != nil {
+	obj, err := cfgmaps.impl.Get(key, metav1.GetOptions{})
+	if err != nil {
 		if apierrors.IsNotFound(err) {
-			return nil, storageerrors.ErrReleaseExists(rls.Name)
+			return nil, storageerrors.ErrReleaseNotFound(key)
 		}
 
 		cfgmaps.Log("delete: failed to get release %q: %s", key, err)
 		return nil, err
 	}
+	if rls, err = cfgmaps.decode(obj); err != nil {
+		cfgmaps.Log("delete: failed to decode data %q: %s", key, err)
+		return nil, err
+	}
 	// delete the release
 	if err = cfgmaps.impl.Delete(key, &metav1.DeleteOptions{}); err != nil {
 		return rls, err
 	}
+	// and the chunks it was split into
+	cfgmaps.deleteChunks(key, obj.Labels)
 	return rls, nil
 }
 
-// newConfigMapsObject constructs a kubernetes ConfigMap object
-// to store a release. Each configmap data entry is the base64
-// encoded string of a release's binary protobuf encoding.
+// AcquireLock locks the release name. The lock is held in the annotations of
+// a ConfigMap; an expired lock is taken over with an update conditioned on
+// the resourceVersion of the ConfigMap, so that only one operation wins.
+func (cfgmaps *ConfigMaps) AcquireLock(name string, lock *Lock) error {
+	obj := &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        lockKey(name),
+			Labels:      newLockLabels(name),
+			Annotations: lockToAnnotations(lock),
+		},
+	}
+	_, err := cfgmaps.impl.Create(obj)
+	if err == nil || !apierrors.IsAlreadyExists(err) {
+		return err
+	}
+
+	cur, err := cfgmaps.impl.Get(obj.Name, metav1.GetOptions{})
+	if err != nil {
+		cfgmaps.Log("lock: failed to get lock %q: %s", obj.Name, err)
+		return err
+	}
+	held, err := lockFromAnnotations(cur.Annotations)
+	if err != nil {
+		cfgmaps.Log("lock: taking over unreadable lock %q: %s", obj.Name, err)
+	} else if err := lockConflict(name, lock, held); err != nil {
+		return err
+	}
+	obj.ResourceVersion = cur.ResourceVersion
+	if _, err := cfgmaps.impl.Update(obj); err != nil {
+		if apierrors.IsConflict(err) || apierrors.IsNotFound(err) {
+			// the lock changed since we read it, try again against its new holder
+			return cfgmaps.AcquireLock(name, lock)
+		}
+		cfgmaps.Log("lock: failed to update lock %q: %s", obj.Name, err)
+		return err
+	}
+	return nil
+}
+
+// RenewLock stores lock for the release name if it is still held with the
+// same ID. The update is conditioned on the resourceVersion of the Z
+// read, so that a lock cleared meanwhile is not recreated.
+func (cfgmaps *ConfigMaps) RenewLock(name string, lock *Lock) error {
+	cur, err := cfgmaps.impl.Get(lockKey(name), metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return storageerrors.ErrLockNotFound(name)
+		}
+		cfgmaps.Log("lock: failed to get lock %q: %s", lockKey(name), err)
+		return err
+	}
+	held, err := lockFromAnnotations(cur.Annotations)
+	if err != nil {
+		return err
+	}
+	if held.ID != lock.ID {
+		return storageerrors.ErrReleaseLocked(name, held.String())
+	}
+	obj := cur.DeepCopy()
+	obj.Annotations = lockToAnnotations(lock)
+	if _, err := cfgmaps.impl.Update(obj); err != nil {
+		if apierrors.IsNotFound(err) {
+			return storageerrors.ErrLockNotFound(name)
+		}
+		if apierrors.IsConflict(err) {
+			// the lock changed since we read it, check it again
+			return cfgmaps.RenewLock(name, lock)
+		}
+		cfgmaps.Log("lock: failed to update lock %q: %s", obj.Name, err)
+		return err
+	}
+	return nil
+}
+
+// ReleaseLock deletes the ConfigMap holding the lock on the release name.
+func (cfgmaps *ConfigMaps) ReleaseLock(name, id string) (*Lock, error) {
+	obj, err := cfgmaps.impl.Get(lockKey(name), metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, storageerrors.ErrLockNotFound(name)
+		}
+		return nil, err
+	}
+	held, err := lockFromAnnotations(obj.Annotations)
+	if err != nil {
+		if id != "" {
+			return nil, err
+		}
+		held = &Lock{}
+	}
+	if id != "" && held.ID != id {
+		return nil, storageerrors.ErrReleaseLocked(name, held.String())
+	}
+	err = cfgmaps.impl.Delete(obj.Name, &metav1.DeleteOptions{Preconditions: &metav1.Preconditions{UID: &obj.UID}})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, storageerrors.ErrLockNotFound(name)
+		}
+		return nil, err
+	}
+	return held, nil
+}
+
+// GetLock returns the lock held on the release name.
+func (cfgmaps *ConfigMaps) GetLock(name string) (*Lock, error) {
+	obj, err := cfgmaps.impl.Get(lockKey(name), metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, storageerrors.ErrLockNotFound(name)
+		}
+		return nil, err
+	}
+	return lockFromAnnotations(obj.Annotations)
+}
+
+// decode reassembles the release stored in obj, fetching the additional
+// chunk ConfigMaps it was split into, and decodes it.
+func (cfgmaps *ConfigMaps) decode(obj *v1.ConfigMap) (*rspb.Release, error) {
+	names, err := chunkNames(obj.Name, obj.Labels)
+	if err != nil {
+		return nil, err
+	}
+	data := obj.Data["release"]
+	for i, name := range names {
+		chunk, err := cfgmaps.impl.Get(name, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get chunk %d of %q: %s", i+1, obj.Name, err)
+		}
+		data += chunk.Data["release"]
+	}
+	return decodeRelease(data, obj.Name, cfgmaps.Keys)
+}
+
+// writeChunks creates the chunk ConfigMaps.
+func (cfgmaps *ConfigMaps) writeChunks(objs []*v1.ConfigMap) error {
+	for _, obj := range objs {
+		if _, err := cfgmaps.impl.Create(obj); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteChunks deletes the chunk ConfigMaps of the release stored under key
+// in an object with the given labels.
+func (cfgmaps *ConfigMaps) deleteChunks(key string, lbs map[string]string) {
+	names, err := chunkNames(key, lbs)
+	if err != nil {
+		cfgmaps.Log("delete: failed to find chunks of %q: %s", key, err)
+		return
+	}
+	for _, name := range names {
+		if err := cfgmaps.impl.Delete(name, &metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+			cfgmaps.Log("delete: failed to delete chunk %q: %s", name, err)
+		}
+	}
+}
+
+// newConfigMapsObject constructs a single kubernetes ConfigMap
+// object to store a release, regardless of its size.
+func newConfigMapsObject(key string, rls *rspb.Release, lbs labels) (*v1.ConfigMap, error) {
+	objs, err := newConfigMapsObjects(key, rls, lbs, 0, nil)
+	if err != nil {
+		return nil, err
+	}
+	return objs[0], nil
+}
+
+// newConfigMapsObjects constructs the kubernetes ConfigMap objects
+// to store a release. The configmap data entry is the base64
+// encoded string of a release's binary protobuf encoding. When that
+// string is longer than chunkSize, it is split across several
+// configmaps: the first one is named by key and the following ones
+// are named by key with a ".<generation>.<index>" suffix.
 //
-// The following labels are used within each configmap:
+// The following labels are used within the first configmap:
 //
 //    "MODIFIED_AT"    - timestamp indicating when this configmap was last modified. (set in Update)
 //    "CREATED_AT"     - timestamp indicating when this configmap was created. (set in Create)
@@ -228,15 +463,23 @@ func (cfgmaps *ConfigMaps) Delete(key string) (rls *rspb.Release, err error) {
 //    "STATUS"         - status of the release (see proto/hapi/release.status.pb.go for variants)
 //    "OWNER"          - owner of the configmap, currently "TILLER".
 //    "NAME"           - name of the release.
+//    "CHUNKS"         - number of configmaps the release is split into, if more than one.
+//    "CHUNK_GEN"      - generation of the chunks, if more than one.
+//    "KEY_ID"         - ID of the key encrypting the release, if encrypted.
+//    "CHART_DIGEST"   - digest of the chart of the release, if stored by Dedup.
 //
-func newConfigMapsObject(key string, rls *rspb.Release, lbs labels) (*v1.ConfigMap, error) {
+// The additional configmaps only carry the "NAME", "VERSION" and
+// "CHUNK" (the index of the chunk) labels.
+//
+func newConfigMapsObjects(key string, rls *rspb.Release, lbs labels, chunkSize int, keys KeyProvider) ([]*v1.ConfigMap, error) {
 	const owner = "TILLER"
 
 	// encode the release
-	s, err := encodeRelease(rls)
+	s, err := encodeRelease(rls, key, keys)
 	if err != nil {
 		return nil, err
 	}
+	chunks := splitChunks(s, chunkSize)
 
 	if lbs == nil {
 		lbs.init()
@@ -247,13 +490,37 @@ func newConfigMapsObject(key string, rls *rspb.Release, lbs labels) (*v1.ConfigM
 	lbs.set("OWNER", owner)
 	lbs.set("STATUS", rspb.Status_Code_name[int32(rls.Info.Status.Code)])
 	lbs.set("VERSION", strconv.Itoa(int(rls.Version)))
+	var gen string
+	if len(chunks) > 1 {
+		if gen, err = newChunkGen(); err != nil {
+			return nil, err
+		}
+		lbs.set(chunksLabel, strconv.Itoa(len(chunks)))
+		lbs.set(chunkGenLabel, gen)
+	}
+	if keyID := encryptionKeyID(s); keyID != "" {
+		lbs.set(KeyIDLabel, keyID)
+	}
+	if digest := chartDigestOf(rls); digest != "" {
+		lbs.set(ChartDigestLabel, digest)
+	}
 
-	// create and return configmap object
-	return &v1.ConfigMap{
+	// create and return configmap objects
+	objs := []*v1.ConfigMap{{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:   key,
 			Labels: lbs.toMap(),
 		},
-		Data: map[string]string{"release": s},
-	}, nil
+		Data: map[string]string{"release": chunks[0]},
+	}}
+	for i := 1; i < len(chunks); i++ {
+		objs = append(objs, &v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   chunkName(key, gen, i),
+				Labels: newChunkLabels(rls.Name, rls.Version, i).toMap(),
+			},
+			Data: map[string]string{"release": chunks[i]},
+		})
+	}
+	return objs, nil
 }
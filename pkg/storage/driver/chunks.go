@@ -0,0 +1,124 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package driver // import "k8s.io/helm/pkg/storage/driver"
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+)
+
+// defaultChunkSize is the maximum length of the encoded release stored in a
+// single ConfigMap or Secret. Kubernetes rejects objects bigger than 1MiB, so
+// some room is left for the object metadata.
+const defaultChunkSize = 1000 * 1000
+
+const (
+	// chunksLabel is set on the object named by the release key when the
+	// release is split across several objects. It holds the number of chunks.
+	chunksLabel = "CHUNKS"
+	// chunkLabel is set on every additional chunk object. It holds the index
+	// of the chunk, starting at 1.
+	chunkLabel = "CHUNK"
+	// chunkGenLabel is set next to chunksLabel. It holds the generation of
+	// the chunks, which is part of their names, so that rewriting a record
+	// never touches the chunks the current head refers to.
+	chunkGenLabel = "CHUNK_GEN"
+)
+
+// splitChunks splits s into pieces of at most size bytes. A size <= 0
+// disables splitting.
+func splitChunks(s string, size int) []string {
+	if size <= 0 || len(s) <= size {
+		return []string{s}
+	}
+	chunks := make([]string, 0, (len(s)+size-1)/size)
+	for len(s) > size {
+		chunks = append(chunks, s[:size])
+		s = s[size:]
+	}
+	return append(chunks, s)
+}
+
+// chunkName returns the name of the i-th additional object holding
+// a chunk of the release stored under key. Records written before
+// chunk generations were introduced have no generation.
+func chunkName(key, gen string, i int) string {
+	if gen == "" {
+		return fmt.Sprintf("%s.%d", key, i)
+	}
+	return fmt.Sprintf("%s.%s.%d", key, gen, i)
+}
+
+// newChunkGen returns a random chunk generation.
+func newChunkGen() (string, error) {
+	b := make([]byte, 4)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// chunkNames returns the names of the additional chunk objects of the
+// record stored under key in an object with the given labels.
+func chunkNames(key string, lbs map[string]string) ([]string, error) {
+	n, err := chunkCount(lbs)
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for i := 1; i < n; i++ {
+		names = append(names, chunkName(key, lbs[chunkGenLabel], i))
+	}
+	return names, nil
+}
+
+// chunkCount returns the number of objects the release stored in an object
+// with the given labels is split into. Records written before chunking was
+// introduced do not carry the label and are made of a single object.
+func chunkCount(lbs map[string]string) (int, error) {
+	v, ok := lbs[chunksLabel]
+	if !ok {
+		return 1, nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n < 1 {
+		return 0, fmt.Errorf("invalid %s label: %q", chunksLabel, v)
+	}
+	return n, nil
+}
+
+// isChunk returns whether an object with the given labels is an additional
+// chunk of a release rather than a release record.
+func isChunk(lbs map[string]string) bool {
+	_, ok := lbs[chunkLabel]
+	return ok
+}
+
+// newChunkLabels returns the labels of the i-th additional chunk of a
+// release. The chunk carries the release name and version for operators
+// but not the OWNER label, so it is never listed as a release on its own.
+func newChunkLabels(name string, version int32, i int) labels {
+	var lbs labels
+
+	lbs.init()
+	lbs.set("NAME", name)
+	lbs.set("VERSION", strconv.Itoa(int(version)))
+	lbs.set(chunkLabel, strconv.Itoa(i))
+	return lbs
+}
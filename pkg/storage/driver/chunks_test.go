@@ -0,0 +1,54 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package driver // import "k8s.io/helm/pkg/storage/driver"
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplitChunks(t *testing.T) {
+	var tests = []struct {
+		desc   string
+		data   string
+		size   int
+		expect []string
+	}{
+		{"splitting disabled", "abcdef", 0, []string{"abcdef"}},
+		{"smaller than size", "abc", 4, []string{"abc"}},
+		{"exact multiple of size", "abcdef", 3, []string{"abc", "def"}},
+		{"remainder", "abcdefg", 3, []string{"abc", "def", "g"}},
+	}
+
+	for _, tt := range tests {
+		if got := splitChunks(tt.data, tt.size); !reflect.DeepEqual(got, tt.expect) {
+			t.Errorf("%s: expected %q, got %q", tt.desc, tt.expect, got)
+		}
+	}
+}
+
+func TestChunkCount(t *testing.T) {
+	if n, err := chunkCount(map[string]string{"NAME": "smug-pigeon"}); err != nil || n != 1 {
+		t.Errorf("Expected records without the %s label to have 1 chunk, got %d (%v)", chunksLabel, n, err)
+	}
+	if n, err := chunkCount(map[string]string{chunksLabel: "3"}); err != nil || n != 3 {
+		t.Errorf("Expected 3 chunks, got %d (%v)", n, err)
+	}
+	if _, err := chunkCount(map[string]string{chunksLabel: "zero"}); err == nil {
+		t.Errorf("Expected error for an invalid %s label", chunksLabel)
+	}
+}
@@ -0,0 +1,480 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package driver // import "k8s.io/helm/pkg/storage/driver"
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+
+	"k8s.io/helm/pkg/proto/hapi/chart"
+	rspb "k8s.io/helm/pkg/proto/hapi/release"
+	storageerrors "k8s.io/helm/pkg/storage/errors"
+)
+
+const (
+	// chartRecordPrefix prefixes the name of the records holding the charts
+	// of the revisions stored by Dedup, followed by the digest of the chart.
+	// It makes the name longer than release names can be.
+	chartRecordPrefix = "sh.helm.chart."
+
+	// chartDigestAnnotation is set on the chart metadata of the revisions
+	// stored by Dedup. It holds the digest of their chart.
+	chartDigestAnnotation = "storage.helm.sh/chart-digest"
+	// manifestBaseAnnotation is set on the chart metadata of the revisions
+	// whose manifest is stored as a delta. It holds the version of the
+	// revision the delta applies to.
+	manifestBaseAnnotation = "storage.helm.sh/manifest-base"
+	// manifestDepthAnnotation holds the number of deltas to apply to decode
+	// the manifest of a revision.
+	manifestDepthAnnotation = "storage.helm.sh/manifest-depth"
+
+	// maxDeltaDepth is the maximum number of deltas applied to decode a
+	// manifest. A revision whose manifest would need more is stored whole.
+	maxDeltaDepth = 10
+)
+
+// ChartDigestLabel is the label of the revisions stored by Dedup. It holds
+// the digest of their chart, so that the revisions using a chart are found
+// with a query. It is always set by the driver.
+const ChartDigestLabel = "CHART_DIGEST"
+
+var _ Driver = (*Dedup)(nil)
+var _ Locker = (*Dedup)(nil)
+var _ Labeler = (*Dedup)(nil)
+
+// Dedup is a storage driver storing releases through another driver,
+// deduplicating their history. The chart of a revision is stored once per
+// content digest, in a record shared by every revision using it, and the
+// manifest of a revision is stored as a delta from the manifest of the
+// previous revision. Releases are read back whole.
+//
+// The records written by Dedup cannot be read without it.
+type Dedup struct {
+	driver Driver
+	// mu serializes the creation and deletion of the chart records.
+	mu sync.Mutex
+
+	Log func(string, ...interface{})
+}
+
+// NewDedup initializes a new Dedup wrapping the driver d.
+func NewDedup(d Driver) *Dedup {
+	return &Dedup{
+		driver: d,
+		Log:    func(_ string, _ ...interface{}) {},
+	}
+}
+
+// Unwrap returns the wrapped driver, which stores the records of d.
+func (d *Dedup) Unwrap() Driver {
+	return d.driver
+}
+
+// Name returns the name of the wrapped driver.
+func (d *Dedup) Name() string {
+	return d.driver.Name()
+}
+
+// Get fetches the release named by key.
+func (d *Dedup) Get(key string) (*rspb.Release, error) {
+	rls, err := d.driver.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	return d.decode(rls, map[string]*chart.Chart{})
+}
+
+// List fetches all releases and returns the list of releases for which
+// filter(release) evaluates to true.
+func (d *Dedup) List(filter func(*rspb.Release) bool) ([]*rspb.Release, error) {
+	stored, err := d.driver.List(func(rls *rspb.Release) bool { return !isChartRecord(rls) })
+	if err != nil {
+		return nil, err
+	}
+	return d.decodeAll(stored, filter)
+}
+
+// Query fetches all releases that match the provided map of labels.
+func (d *Dedup) Query(labels map[string]string) ([]*rspb.Release, error) {
+	stored, err := d.driver.Query(labels)
+	if err != nil {
+		return nil, err
+	}
+	results, err := d.decodeAll(stored, func(rls *rspb.Release) bool { return !isChartRecord(rls) })
+	if err != nil {
+		return nil, err
+	}
+	if len(results) == 0 && len(stored) > 0 {
+		return nil, storageerrors.ErrReleaseNotFound(labels["NAME"])
+	}
+	return results, nil
+}
+
+// Create stores the release or returns ErrReleaseExists.
+func (d *Dedup) Create(key string, rls *rspb.Release) error {
+	return d.CreateLabeled(key, rls, nil)
+}
+
+// Labels returns the labels of the record of the release named by key.
+func (d *Dedup) Labels(key string) (map[string]string, error) {
+	l, ok := d.driver.(Labeler)
+	if !ok {
+		return nil, fmt.Errorf("driver %s does not store labels", d.Name())
+	}
+	return l.Labels(key)
+}
+
+// CreateLabeled stores the release in a record labeled with lbs, or returns
+// ErrReleaseExists. The labels are ignored if the wrapped driver does not
+// store labels.
+func (d *Dedup) CreateLabeled(key string, rls *rspb.Release, lbs map[string]string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	stored, err := d.encode(rls)
+	if err != nil {
+		return err
+	}
+	if l, ok := d.driver.(Labeler); ok && lbs != nil {
+		return l.CreateLabeled(key, stored, lbs)
+	}
+	return d.driver.Create(key, stored)
+}
+
+// Update updates the release named by key or returns ErrReleaseNotFound.
+func (d *Dedup) Update(key string, rls *rspb.Release) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	old, err := d.driver.Get(key)
+	if err != nil {
+		return err
+	}
+	manifest, err := d.manifest(old)
+	if err != nil {
+		return err
+	}
+	if manifest != rls.Manifest {
+		if err := d.storeDependentsWhole(old); err != nil {
+			return err
+		}
+	}
+	stored, err := d.encode(rls)
+	if err != nil {
+		return err
+	}
+	if err := d.driver.Update(key, stored); err != nil {
+		return err
+	}
+	if digest := chartDigestOf(old); digest != chartDigestOf(stored) {
+		d.collectChart(digest)
+	}
+	return nil
+}
+
+// Delete deletes the release named by key or returns ErrReleaseNotFound. The
+// revisions storing their manifest as a delta from the release are stored
+// whole beforehand.
+func (d *Dedup) Delete(key string) (*rspb.Release, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	stored, err := d.driver.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	rls, err := d.decode(stored, map[string]*chart.Chart{})
+	if err != nil {
+		return nil, err
+	}
+	if err := d.storeDependentsWhole(stored); err != nil {
+		return nil, err
+	}
+	if _, err := d.driver.Delete(key); err != nil {
+		return nil, err
+	}
+	d.collectChart(chartDigestOf(stored))
+	return rls, nil
+}
+
+// AcquireLock locks the release name through the wrapped driver.
+func (d *Dedup) AcquireLock(name string, lock *Lock) error {
+	l, ok := d.driver.(Locker)
+	if !ok {
+		return fmt.Errorf("driver %s does not support locking", d.Name())
+	}
+	return l.AcquireLock(name, lock)
+}
+
+// RenewLock renews the lock on the release name through the wrapped driver.
+func (d *Dedup) RenewLock(name string, lock *Lock) error {
+	l, ok := d.driver.(Locker)
+	if !ok {
+		return storageerrors.ErrLockNotFound(name)
+	}
+	return l.RenewLock(name, lock)
+}
+
+// ReleaseLock unlocks the release name through the wrapped driver.
+func (d *Dedup) ReleaseLock(name, id string) (*Lock, error) {
+	l, ok := d.driver.(Locker)
+	if !ok {
+		return nil, storageerrors.ErrLockNotFound(name)
+	}
+	return l.ReleaseLock(name, id)
+}
+
+// GetLock returns the lock held on the release name by the wrapped driver.
+func (d *Dedup) GetLock(name string) (*Lock, error) {
+	l, ok := d.driver.(Locker)
+	if !ok {
+		return nil, storageerrors.ErrLockNotFound(name)
+	}
+	return l.GetLock(name)
+}
+
+// encode returns the record storing rls, creating the record of its chart if
+// needed. Releases without a chart are stored whole.
+func (d *Dedup) encode(rls *rspb.Release) (*rspb.Release, error) {
+	if rls.Chart == nil {
+		return rls, nil
+	}
+	digest, err := d.storeChart(rls.Chart)
+	if err != nil {
+		return nil, err
+	}
+
+	stored := proto.Clone(rls).(*rspb.Release)
+	md := &chart.Metadata{}
+	if rls.Chart.Metadata != nil {
+		md = proto.Clone(rls.Chart.Metadata).(*chart.Metadata)
+	}
+	annotations := map[string]string{chartDigestAnnotation: digest}
+	for k, v := range md.Annotations {
+		annotations[k] = v
+	}
+	md.Annotations = annotations
+	stored.Chart = &chart.Chart{Metadata: md}
+
+	// The manifest is stored as a delta from the previous revision, unless
+	// the delta is no smaller or too many deltas would have to be applied.
+	base, err := d.driver.Get(revisionKey(rls.Name, rls.Version-1))
+	if err != nil || !isDedup(base) {
+		return stored, nil
+	}
+	depth := manifestDepthOf(base) + 1
+	if depth > maxDeltaDepth {
+		return stored, nil
+	}
+	manifest, err := d.manifest(base)
+	if err != nil {
+		return nil, err
+	}
+	if delta := makeDelta(manifest, rls.Manifest); len(delta) < len(rls.Manifest) {
+		stored.Manifest = delta
+		annotations[manifestBaseAnnotation] = strconv.Itoa(int(base.Version))
+		annotations[manifestDepthAnnotation] = strconv.Itoa(depth)
+	}
+	return stored, nil
+}
+
+// decodeAll decodes the stored records for which filter evaluates to true
+// once decoded.
+func (d *Dedup) decodeAll(stored []*rspb.Release, filter func(*rspb.Release) bool) ([]*rspb.Release, error) {
+	charts := map[string]*chart.Chart{}
+	var results []*rspb.Release
+	for _, s := range stored {
+		if isChartRecord(s) {
+			continue
+		}
+		rls, err := d.decode(s, charts)
+		if err != nil {
+			return nil, err
+		}
+		if filter(rls) {
+			results = append(results, rls)
+		}
+	}
+	return results, nil
+}
+
+// decode returns the release stored in the record stored, fetching its chart
+// from charts, a cache of the charts by digest, or from its record.
+func (d *Dedup) decode(stored *rspb.Release, charts map[string]*chart.Chart) (*rspb.Release, error) {
+	if !isDedup(stored) {
+		return stored, nil
+	}
+	rls := proto.Clone(stored).(*rspb.Release)
+
+	digest := chartDigestOf(stored)
+	c, ok := charts[digest]
+	if !ok {
+		r, err := d.driver.Get(chartKey(digest))
+		if err != nil {
+			return nil, fmt.Errorf("cannot get the chart of %s: %s", revisionKey(stored.Name, stored.Version), err)
+		}
+		c = r.Chart
+		charts[digest] = c
+	}
+	rls.Chart = c
+
+	manifest, err := d.manifest(stored)
+	if err != nil {
+		return nil, err
+	}
+	rls.Manifest = manifest
+	return rls, nil
+}
+
+// manifest returns the manifest of the release stored in the record stored,
+// applying the deltas it is stored as.
+func (d *Dedup) manifest(stored *rspb.Release) (string, error) {
+	v, ok := stored.GetChart().GetMetadata().GetAnnotations()[manifestBaseAnnotation]
+	if !ok {
+		return stored.Manifest, nil
+	}
+	key := revisionKey(stored.Name, stored.Version)
+	version, err := strconv.Atoi(v)
+	if err != nil || int32(version) >= stored.Version {
+		return "", fmt.Errorf("invalid manifest base %q of %s", v, key)
+	}
+	base, err := d.driver.Get(revisionKey(stored.Name, int32(version)))
+	if err != nil {
+		return "", fmt.Errorf("cannot get the manifest base of %s: %s", key, err)
+	}
+	manifest, err := d.manifest(base)
+	if err != nil {
+		return "", err
+	}
+	manifest, err = applyDelta(manifest, stored.Manifest)
+	if err != nil {
+		return "", fmt.Errorf("cannot decode the manifest of %s: %s", key, err)
+	}
+	return manifest, nil
+}
+
+// storeDependentsWhole stores whole the manifests of the revisions stored as
+// a delta from the record stored.
+func (d *Dedup) storeDependentsWhole(stored *rspb.Release) error {
+	revisions, err := d.driver.Query(map[string]string{"NAME": stored.Name, "OWNER": "TILLER"})
+	if err != nil {
+		if err.Error() == storageerrors.ErrReleaseNotFound(stored.Name).Error() {
+			return nil
+		}
+		return err
+	}
+	for _, r := range revisions {
+		if r.GetChart().GetMetadata().GetAnnotations()[manifestBaseAnnotation] != strconv.Itoa(int(stored.Version)) {
+			continue
+		}
+		manifest, err := d.manifest(r)
+		if err != nil {
+			return err
+		}
+		whole := proto.Clone(r).(*rspb.Release)
+		whole.Manifest = manifest
+		delete(whole.Chart.Metadata.Annotations, manifestBaseAnnotation)
+		delete(whole.Chart.Metadata.Annotations, manifestDepthAnnotation)
+		key := revisionKey(r.Name, r.Version)
+		d.Log("storing the manifest of %s whole", key)
+		if err := d.driver.Update(key, whole); err != nil {
+			return fmt.Errorf("cannot store the manifest of %s whole: %s", key, err)
+		}
+	}
+	return nil
+}
+
+// storeChart stores the chart in the record of its digest if it does not
+// exist, and returns the digest.
+func (d *Dedup) storeChart(c *chart.Chart) (string, error) {
+	var buf proto.Buffer
+	buf.SetDeterministic(true)
+	if err := buf.Marshal(c); err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(buf.Bytes())
+	digest := hex.EncodeToString(sum[:20])
+
+	key := chartKey(digest)
+	if _, err := d.driver.Get(key); err == nil {
+		return digest, nil
+	}
+	rls := &rspb.Release{
+		Name:    chartRecordPrefix + digest,
+		Version: 1,
+		Info:    &rspb.Info{Status: &rspb.Status{Code: rspb.Status_UNKNOWN}},
+		Chart:   c,
+	}
+	d.Log("storing chart %s", digest)
+	if err := d.driver.Create(key, rls); err != nil && err.Error() != storageerrors.ErrReleaseExists(key).Error() {
+		return "", fmt.Errorf("cannot store the chart %s: %s", digest, err)
+	}
+	return digest, nil
+}
+
+// collectChart deletes the record of the chart with the given digest if no
+// revision uses it anymore.
+func (d *Dedup) collectChart(digest string) {
+	if digest == "" {
+		return
+	}
+	used, err := d.driver.Query(map[string]string{ChartDigestLabel: digest, "OWNER": "TILLER"})
+	if err != nil && err.Error() != storageerrors.ErrReleaseNotFound("").Error() {
+		d.Log("cannot check if chart %s is used: %s", digest, err)
+		return
+	}
+	if len(used) > 0 {
+		return
+	}
+	d.Log("deleting unused chart %s", digest)
+	if _, err := d.driver.Delete(chartKey(digest)); err != nil {
+		d.Log("cannot delete unused chart %s: %s", digest, err)
+	}
+}
+
+// revisionKey returns the key of the revision version of the release name.
+func revisionKey(name string, version int32) string {
+	return fmt.Sprintf("%s.v%d", name, version)
+}
+
+func chartKey(digest string) string {
+	return revisionKey(chartRecordPrefix+digest, 1)
+}
+
+func isChartRecord(rls *rspb.Release) bool {
+	return strings.HasPrefix(rls.Name, chartRecordPrefix)
+}
+
+// isDedup returns whether the record was stored by Dedup.
+func isDedup(rls *rspb.Release) bool {
+	return chartDigestOf(rls) != ""
+}
+
+func chartDigestOf(rls *rspb.Release) string {
+	return rls.GetChart().GetMetadata().GetAnnotations()[chartDigestAnnotation]
+}
+
+func manifestDepthOf(rls *rspb.Release) int {
+	depth, _ := strconv.Atoi(rls.GetChart().GetMetadata().GetAnnotations()[manifestDepthAnnotation])
+	return depth
+}
@@ -0,0 +1,382 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package driver
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+
+	"k8s.io/helm/pkg/proto/hapi/chart"
+	rspb "k8s.io/helm/pkg/proto/hapi/release"
+)
+
+// dedupChart returns a chart with a few templates of a few kilobytes.
+func dedupChart(version string) *chart.Chart {
+	c := &chart.Chart{
+		Metadata: &chart.Metadata{Name: "web", Version: version, Annotations: map[string]string{"team": "web"}},
+		Values:   &chart.Config{Raw: "replicas: 1\n"},
+	}
+	for i := 0; i < 5; i++ {
+		c.Templates = append(c.Templates, &chart.Template{
+			Name: fmt.Sprintf("templates/t%d.yaml", i),
+			Data: []byte(strings.Repeat(fmt.Sprintf("# template %d\n", i), 200)),
+		})
+	}
+	return c
+}
+
+// dedupManifest returns a manifest of a few kilobytes whose image tag is
+// tag.
+func dedupManifest(tag int) string {
+	var b strings.Builder
+	for i := 0; i < 50; i++ {
+		fmt.Fprintf(&b, "---\napiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: cm-%d\ndata:\n  key: value-%d\n", i, i)
+	}
+	fmt.Fprintf(&b, "---\napiVersion: apps/v1\nkind: Deployment\nspec:\n  template:\n    spec:\n      containers:\n      - image: web:%d\n", tag)
+	return b.String()
+}
+
+func dedupRelease(name string, version int32, c *chart.Chart) *rspb.Release {
+	rls := releaseStub(name, version, "default", rspb.Status_SUPERSEDED)
+	rls.Chart = c
+	rls.Manifest = dedupManifest(int(version))
+	return rls
+}
+
+func TestDedup(t *testing.T) {
+	mem := NewMemory()
+	d := NewDedup(mem)
+	c := dedupChart("0.1.0")
+
+	var rels []*rspb.Release
+	for v := int32(1); v <= 15; v++ {
+		rls := dedupRelease("web", v, c)
+		rels = append(rels, rls)
+		if err := d.Create(testKey("web", v), rls); err != nil {
+			t.Fatal(err)
+		}
+	}
+	other := dedupRelease("api", 1, c)
+	if err := d.Create(testKey("api", 1), other); err != nil {
+		t.Fatal(err)
+	}
+	rels = append(rels, other)
+
+	// The chart is stored once, and manifests as deltas up to the maximum
+	// depth.
+	stored, err := mem.List(func(*rspb.Release) bool { return true })
+	if err != nil {
+		t.Fatal(err)
+	}
+	charts := 0
+	for _, rls := range stored {
+		if isChartRecord(rls) {
+			charts++
+			continue
+		}
+		if len(rls.Chart.Templates) != 0 {
+			t.Errorf("Expected the chart of %s not to be stored with it", testKey(rls.Name, rls.Version))
+		}
+		depth := manifestDepthOf(rls)
+		expected := int(rls.Version-1) % (maxDeltaDepth + 1)
+		if rls.Name == "api" {
+			expected = 0
+		}
+		if depth != expected {
+			t.Errorf("Expected the manifest of %s to be stored at depth %d, got %d", testKey(rls.Name, rls.Version), expected, depth)
+		}
+	}
+	if charts != 1 {
+		t.Errorf("Expected the chart to be stored once, got %d records", charts)
+	}
+
+	for _, rls := range rels {
+		got, err := d.Get(testKey(rls.Name, rls.Version))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !proto.Equal(got, rls) {
+			t.Errorf("Expected %s to be decoded whole", testKey(rls.Name, rls.Version))
+		}
+	}
+
+	history, err := d.Query(map[string]string{"NAME": "web", "OWNER": "TILLER"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(history) != 15 {
+		t.Errorf("Expected 15 revisions of web, got %d", len(history))
+	}
+	listed, err := d.List(func(rls *rspb.Release) bool { return rls.Chart.Metadata.Version == "0.1.0" })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(listed) != 16 {
+		t.Errorf("Expected the list filter to see whole releases, got %d releases", len(listed))
+	}
+
+	// Updating a revision keeps it decodable.
+	updated := proto.Clone(rels[4]).(*rspb.Release)
+	updated.Info.Status.Code = rspb.Status_DEPLOYED
+	if err := d.Update(testKey("web", 5), updated); err != nil {
+		t.Fatal(err)
+	}
+	got, err := d.Get(testKey("web", 5))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !proto.Equal(got, updated) {
+		t.Error("Expected the updated revision to be decoded whole")
+	}
+}
+
+func TestDedupDelete(t *testing.T) {
+	mem := NewMemory()
+	d := NewDedup(mem)
+
+	var rels []*rspb.Release
+	for v := int32(1); v <= 4; v++ {
+		rls := dedupRelease("web", v, dedupChart("0.1.0"))
+		if v == 4 {
+			rls.Chart = dedupChart("0.2.0")
+		}
+		rels = append(rels, rls)
+		if err := d.Create(testKey("web", v), rls); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Deleting the oldest revisions, as the history limit does, keeps the
+	// others decodable.
+	for v := int32(1); v <= 3; v++ {
+		deleted, err := d.Delete(testKey("web", v))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !proto.Equal(deleted, rels[v-1]) {
+			t.Errorf("Expected the deleted revision %d to be returned whole", v)
+		}
+		for _, rls := range rels[v:] {
+			got, err := d.Get(testKey("web", rls.Version))
+			if err != nil {
+				t.Fatalf("after deleting revision %d: %s", v, err)
+			}
+			if !proto.Equal(got, rls) {
+				t.Errorf("Expected revision %d to be decoded whole after deleting revision %d", rls.Version, v)
+			}
+		}
+	}
+
+	// The chart of the deleted revisions is deleted with the last of them.
+	stored, err := mem.List(func(*rspb.Release) bool { return true })
+	if err != nil {
+		t.Fatal(err)
+	}
+	last, err := mem.Get(testKey("web", 4))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var keys []string
+	for _, rls := range stored {
+		keys = append(keys, testKey(rls.Name, rls.Version))
+	}
+	sort.Strings(keys)
+	if expected := []string{chartKey(chartDigestOf(last)), "web.v4"}; !reflect.DeepEqual(keys, expected) {
+		t.Errorf("Expected the records %v, got %v", expected, keys)
+	}
+}
+
+func TestDedupConfigMaps(t *testing.T) {
+	cfgmaps := newTestFixtureCfgMaps(t)
+	d := NewDedup(cfgmaps)
+	c := dedupChart("0.1.0")
+	for v := int32(1); v <= 3; v++ {
+		if err := d.Create(testKey("web", v), dedupRelease("web", v, c)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	got, err := d.Get(testKey("web", 3))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !proto.Equal(got, dedupRelease("web", 3, c)) {
+		t.Error("Expected the revision to be decoded whole")
+	}
+	lbs, err := d.Labels(testKey("web", 3))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lbs["NAME"] != "web" || lbs["VERSION"] != "3" || lbs[ChartDigestLabel] == "" {
+		t.Errorf("Expected the labels of the revision, got %v", lbs)
+	}
+	if err := d.AcquireLock("web", &Lock{ID: "1", Operation: "upgrade"}); err != nil {
+		t.Fatal(err)
+	}
+	if l, err := d.GetLock("web"); err != nil || l.ID != "1" {
+		t.Errorf("Expected the lock to be held, got %v, %v", l, err)
+	}
+
+	// The chart is found in use by its label until its last revision is deleted.
+	mock := cfgmaps.impl.(*MockConfigMapsInterface)
+	for v := int32(1); v <= 3; v++ {
+		if _, err := d.Delete(testKey("web", v)); err != nil {
+			t.Fatal(err)
+		}
+		if _, ok := mock.objects[chartKey(lbs[ChartDigestLabel])]; ok != (v < 3) {
+			t.Errorf("After deleting revision %d, expected the chart record to exist: %t", v, v < 3)
+		}
+	}
+}
+
+func TestDedupSQL(t *testing.T) {
+	s, cleanup := newTestFixtureSQL(t)
+	defer cleanup()
+
+	d := NewDedup(s)
+	c := dedupChart("0.1.0")
+	for v := int32(1); v <= 2; v++ {
+		if err := d.Create(testKey("web", v), dedupRelease("web", v, c)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	lbs, err := s.Labels(testKey("web", 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	digest := lbs[ChartDigestLabel]
+	if digest == "" {
+		t.Fatalf("Expected the chart digest label, got %v", lbs)
+	}
+	for v := int32(1); v <= 2; v++ {
+		if _, err := d.Delete(testKey("web", v)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := s.Get(chartKey(digest)); err == nil {
+		t.Error("Expected the unused chart record to be deleted")
+	}
+}
+
+// encodedMemory is a Memory driver keeping the releases encoded as the
+// ConfigMaps and Secrets drivers do, to measure their encoded size and the
+// time taken to decode them.
+type encodedMemory struct {
+	*Memory
+	encoded map[string]string
+}
+
+func newEncodedMemory() *encodedMemory {
+	return &encodedMemory{Memory: NewMemory(), encoded: map[string]string{}}
+}
+
+func (mem *encodedMemory) Create(key string, rls *rspb.Release) error {
+	data, err := encodeRelease(rls, key, nil)
+	if err != nil {
+		return err
+	}
+	mem.encoded[key] = data
+	return mem.Memory.Create(key, rls)
+}
+
+func (mem *encodedMemory) Update(key string, rls *rspb.Release) error {
+	data, err := encodeRelease(rls, key, nil)
+	if err != nil {
+		return err
+	}
+	mem.encoded[key] = data
+	return mem.Memory.Update(key, rls)
+}
+
+func (mem *encodedMemory) Delete(key string) (*rspb.Release, error) {
+	delete(mem.encoded, key)
+	return mem.Memory.Delete(key)
+}
+
+func (mem *encodedMemory) Get(key string) (*rspb.Release, error) {
+	if _, err := mem.Memory.Get(key); err != nil {
+		return nil, err
+	}
+	return decodeRelease(mem.encoded[key], key, nil)
+}
+
+func (mem *encodedMemory) size() int {
+	n := 0
+	for _, data := range mem.encoded {
+		n += len(data)
+	}
+	return n
+}
+
+// storeHistory stores revisions of a release with a large chart, every
+// revision changing the image of the manifest.
+func storeHistory(t testing.TB, d Driver, revisions int32) {
+	c := dedupChart("0.1.0")
+	for i := range c.Templates {
+		c.Templates[i].Data = []byte(strings.Repeat(fmt.Sprintf("# template %d of a large chart\n", i), 5000))
+	}
+	for v := int32(1); v <= revisions; v++ {
+		if err := d.Create(testKey("web", v), dedupRelease("web", v, c)); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestDedupSize(t *testing.T) {
+	plain := newEncodedMemory()
+	storeHistory(t, plain, 50)
+	mem := newEncodedMemory()
+	storeHistory(t, NewDedup(mem), 50)
+
+	if mem.size()*5 > plain.size() {
+		t.Errorf("Expected deduplicated history to be at least 5 times smaller, got %d bytes instead of %d", mem.size(), plain.size())
+	}
+}
+
+// BenchmarkDecode compares the size of the history of a release and the
+// time taken to decode its latest revision with and without Dedup.
+func BenchmarkDecode(b *testing.B) {
+	for _, tt := range []struct {
+		name  string
+		dedup bool
+	}{
+		{"gzip", false},
+		{"dedup", true},
+	} {
+		b.Run(tt.name, func(b *testing.B) {
+			mem := newEncodedMemory()
+			var d Driver = mem
+			if tt.dedup {
+				d = NewDedup(mem)
+			}
+			storeHistory(b, d, 50)
+			b.Logf("%d revisions stored in %d bytes", 50, mem.size())
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := d.Get(testKey("web", 50)); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
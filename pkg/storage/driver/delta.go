@@ -0,0 +1,126 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package driver // import "k8s.io/helm/pkg/storage/driver"
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// maxDeltaCandidates bounds the number of lines of the base a line of the
+// target is compared with, so that repeated lines do not make makeDelta
+// quadratic.
+const maxDeltaCandidates = 8
+
+// makeDelta returns a line-based delta from base to target, which applyDelta
+// turns back into target. The delta is a sequence of operations, one per line:
+//
+//	c START COUNT   copies COUNT lines of base from line START
+//	a COUNT         adds the COUNT lines following the operation
+func makeDelta(base, target string) string {
+	baseLines := splitLines(base)
+	targetLines := splitLines(target)
+	index := make(map[string][]int, len(baseLines))
+	for i, l := range baseLines {
+		if len(index[l]) < maxDeltaCandidates {
+			index[l] = append(index[l], i)
+		}
+	}
+
+	var buf bytes.Buffer
+	var added []string
+	flush := func() {
+		if len(added) == 0 {
+			return
+		}
+		fmt.Fprintf(&buf, "a %d\n", len(added))
+		for _, l := range added {
+			buf.WriteString(l)
+		}
+		added = nil
+	}
+	for i := 0; i < len(targetLines); {
+		start, count := -1, 0
+		for _, j := range index[targetLines[i]] {
+			n := 0
+			for i+n < len(targetLines) && j+n < len(baseLines) && targetLines[i+n] == baseLines[j+n] {
+				n++
+			}
+			if n > count {
+				start, count = j, n
+			}
+		}
+		if count == 0 {
+			added = append(added, targetLines[i])
+			i++
+			continue
+		}
+		flush()
+		fmt.Fprintf(&buf, "c %d %d\n", start, count)
+		i += count
+	}
+	flush()
+	return buf.String()
+}
+
+// applyDelta applies a delta returned by makeDelta to base.
+func applyDelta(base, delta string) (string, error) {
+	baseLines := splitLines(base)
+	ops := splitLines(delta)
+	var buf bytes.Buffer
+	for i := 0; i < len(ops); i++ {
+		f := strings.Fields(ops[i])
+		switch {
+		case len(f) == 3 && f[0] == "c":
+			start, err1 := strconv.Atoi(f[1])
+			count, err2 := strconv.Atoi(f[2])
+			if err1 != nil || err2 != nil || start < 0 || count < 0 || start+count > len(baseLines) {
+				return "", fmt.Errorf("invalid delta operation %q", strings.TrimSpace(ops[i]))
+			}
+			for _, l := range baseLines[start : start+count] {
+				buf.WriteString(l)
+			}
+		case len(f) == 2 && f[0] == "a":
+			count, err := strconv.Atoi(f[1])
+			if err != nil || count < 0 || i+count >= len(ops) {
+				return "", fmt.Errorf("invalid delta operation %q", strings.TrimSpace(ops[i]))
+			}
+			for _, l := range ops[i+1 : i+1+count] {
+				buf.WriteString(l)
+			}
+			i += count
+		default:
+			return "", fmt.Errorf("invalid delta operation %q", strings.TrimSpace(ops[i]))
+		}
+	}
+	return buf.String(), nil
+}
+
+// splitLines splits s after every newline, so that joining the lines gives s
+// back. The last line lacks the newline if s does not end with one.
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	lines := strings.SplitAfter(s, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
@@ -0,0 +1,59 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package driver
+
+import (
+	"testing"
+)
+
+func TestDelta(t *testing.T) {
+	tests := []struct {
+		desc   string
+		base   string
+		target string
+	}{
+		{"identical", "a\nb\nc\n", "a\nb\nc\n"},
+		{"empty base", "", "a\nb\n"},
+		{"empty target", "a\nb\n", ""},
+		{"changed line", "kind: Deployment\nimage: nginx:1.14\nreplicas: 1\n", "kind: Deployment\nimage: nginx:1.15\nreplicas: 1\n"},
+		{"moved lines", "a\nb\nc\nd\n", "c\nd\na\nb\n"},
+		{"repeated lines", "---\nx\n---\nx\n", "---\nx\n---\ny\n---\nx\n"},
+		{"no final newline", "a\nb", "a\nb\nc"},
+		{"operation-like lines", "c 0 1\n", "a 1\nc 0 1\n"},
+	}
+	for _, tt := range tests {
+		delta := makeDelta(tt.base, tt.target)
+		got, err := applyDelta(tt.base, delta)
+		if err != nil {
+			t.Errorf("%s: %s", tt.desc, err)
+			continue
+		}
+		if got != tt.target {
+			t.Errorf("%s: expected %q, got %q from delta %q", tt.desc, tt.target, got, delta)
+		}
+	}
+
+	if delta := makeDelta("a\nb\nc\n", "a\nb\nc\n"); delta != "c 0 3\n" {
+		t.Errorf("Expected identical manifests to be copied at once, got %q", delta)
+	}
+
+	for _, delta := range []string{"c 0 4\n", "a 2\nx\n", "x 1\n", "c -1 1\n"} {
+		if _, err := applyDelta("a\nb\nc\n", delta); err == nil {
+			t.Errorf("Expected an error applying the invalid delta %q", delta)
+		}
+	}
+}
@@ -0,0 +1,195 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package driver // import "k8s.io/helm/pkg/storage/driver"
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// KeyIDLabel is the label of the records encrypted with a KeyProvider. It
+// holds the ID of the key encrypting the data key of the record.
+const KeyIDLabel = "KEY_ID"
+
+// dataKeySize is the size of the AES-256 keys encrypting the records.
+const dataKeySize = 32
+
+// magicEncrypted starts the envelope of the encrypted records, in place of
+// the gzip header of the others.
+var magicEncrypted = []byte("HELMENC1")
+
+// KeyProvider is the interface implemented by the providers of the keys
+// encrypting release records at rest. Every record is encrypted with its own
+// data key, which is in turn encrypted, or wrapped, by the provider. The
+// provider is the only one to handle the keys themselves, so that a key
+// management service can implement it.
+//
+// CurrentKeyID returns the ID of the key wrapping the data keys of new
+// records.
+//
+// WrapKey encrypts a data key with the current key, and returns the ID of
+// that key.
+//
+// UnwrapKey decrypts a data key wrapped with the key keyID.
+type KeyProvider interface {
+	CurrentKeyID() string
+	WrapKey(dataKey []byte) (keyID string, wrapped []byte, err error)
+	UnwrapKey(keyID string, wrapped []byte) ([]byte, error)
+}
+
+// encrypt encrypts data with a new data key using AES-GCM, and returns the
+// envelope holding the wrapped data key and the encrypted data. The key of
+// the record is authenticated along with the data, so that the envelope
+// cannot be read as the release of another record:
+//
+//	magicEncrypted
+//	length of the key ID, key ID
+//	length of the wrapped data key, wrapped data key
+//	nonce, encrypted data
+func encrypt(data []byte, key string, keys KeyProvider) ([]byte, error) {
+	dataKey := make([]byte, dataKeySize)
+	if _, err := io.ReadFull(rand.Reader, dataKey); err != nil {
+		return nil, err
+	}
+	keyID, wrapped, err := keys.WrapKey(dataKey)
+	if err != nil {
+		return nil, fmt.Errorf("cannot wrap data key: %s", err)
+	}
+	nonce, sealed, err := seal(dataKey, data, []byte(key))
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	buf.Write(magicEncrypted)
+	writeField(&buf, []byte(keyID))
+	writeField(&buf, wrapped)
+	buf.Write(nonce)
+	buf.Write(sealed)
+	return buf.Bytes(), nil
+}
+
+// decrypt decrypts an envelope returned by encrypt for the record key.
+func decrypt(envelope []byte, key string, keys KeyProvider) ([]byte, error) {
+	if keys == nil {
+		return nil, errors.New("the release is encrypted but no encryption keys are configured")
+	}
+	keyID, wrapped, rest, err := parseEnvelope(envelope)
+	if err != nil {
+		return nil, err
+	}
+	dataKey, err := keys.UnwrapKey(keyID, wrapped)
+	if err != nil {
+		return nil, fmt.Errorf("cannot unwrap data key: %s", err)
+	}
+	return open(dataKey, rest, []byte(key))
+}
+
+// parseEnvelope returns the key ID, the wrapped data key and the nonce
+// followed by the encrypted data of an envelope.
+func parseEnvelope(envelope []byte) (keyID string, wrapped, rest []byte, err error) {
+	r := bytes.NewReader(envelope[len(magicEncrypted):])
+	id, err := readField(r)
+	if err != nil {
+		return "", nil, nil, err
+	}
+	if wrapped, err = readField(r); err != nil {
+		return "", nil, nil, err
+	}
+	rest = envelope[len(envelope)-r.Len():]
+	return string(id), wrapped, rest, nil
+}
+
+// isEncrypted returns whether b is an envelope returned by encrypt.
+func isEncrypted(b []byte) bool {
+	return bytes.HasPrefix(b, magicEncrypted)
+}
+
+// encryptionKeyID returns the ID of the key encrypting the data key of a
+// release encoded by encodeRelease, or "" if the release is not encrypted.
+func encryptionKeyID(data string) string {
+	b, err := b64.DecodeString(data)
+	if err != nil || !isEncrypted(b) {
+		return ""
+	}
+	keyID, _, _, err := parseEnvelope(b)
+	if err != nil {
+		return ""
+	}
+	return keyID
+}
+
+// seal encrypts data with key using AES-GCM and a random nonce,
+// authenticating ad along with it.
+func seal(key, data, ad []byte) (nonce, sealed []byte, err error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	nonce = make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, nil, err
+	}
+	return nonce, gcm.Seal(nil, nonce, data, ad), nil
+}
+
+// open decrypts the nonce followed by the data encrypted by seal with the
+// same additional data ad.
+func open(key, b, ad []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(b) < gcm.NonceSize() {
+		return nil, errors.New("invalid encrypted release: too short")
+	}
+	data, err := gcm.Open(nil, b[:gcm.NonceSize()], b[gcm.NonceSize():], ad)
+	if err != nil {
+		return nil, fmt.Errorf("cannot decrypt release: %s", err)
+	}
+	return data, nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func writeField(buf *bytes.Buffer, b []byte) {
+	var n [binary.MaxVarintLen64]byte
+	buf.Write(n[:binary.PutUvarint(n[:], uint64(len(b)))])
+	buf.Write(b)
+}
+
+func readField(r *bytes.Reader) ([]byte, error) {
+	n, err := binary.ReadUvarint(r)
+	if err != nil || n > uint64(r.Len()) {
+		return nil, errors.New("invalid encrypted release: truncated envelope")
+	}
+	b := make([]byte, n)
+	r.Read(b)
+	return b, nil
+}
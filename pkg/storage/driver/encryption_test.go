@@ -0,0 +1,208 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package driver
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	rspb "k8s.io/helm/pkg/proto/hapi/release"
+)
+
+// testKeys returns the keys key-1 and key-2, the current one being current.
+func testKeys(t *testing.T, current string) *LocalKeys {
+	keys, err := LoadKeys([]byte(fmt.Sprintf(`current: %s
+keys:
+  key-1: %s
+  key-2: %s
+`, current, base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{1}, 32)), base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{2}, 32)))))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return keys
+}
+
+func TestEncryptRelease(t *testing.T) {
+	rls := releaseStub("smug-pigeon", 1, "default", rspb.Status_DEPLOYED)
+	rls.Manifest = "kind: Secret\ndata:\n  password: c2VjcmV0\n"
+	keys := testKeys(t, "key-1")
+
+	data, err := encodeRelease(rls, "smug-pigeon.v1", keys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id := encryptionKeyID(data); id != "key-1" {
+		t.Errorf("Expected the release to be encrypted with key-1, got %q", id)
+	}
+	b, _ := b64.DecodeString(data)
+	if bytes.Contains(b, []byte("smug-pigeon")) {
+		t.Error("Expected the release to be encrypted")
+	}
+
+	// Records encrypted with an older key are read after a rotation.
+	got, err := decodeRelease(data, "smug-pigeon.v1", testKeys(t, "key-2"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !proto.Equal(got, rls) {
+		t.Errorf("Expected %v, got %v", rls, got)
+	}
+
+	// Records written before encryption was enabled are still read.
+	plain, err := encodeRelease(rls, "smug-pigeon.v1", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id := encryptionKeyID(plain); id != "" {
+		t.Errorf("Expected no key ID for a release that is not encrypted, got %q", id)
+	}
+	if _, err := decodeRelease(plain, "smug-pigeon.v1", keys); err != nil {
+		t.Errorf("Expected a release that is not encrypted to be read, got %s", err)
+	}
+
+	if _, err := decodeRelease(data, "smug-pigeon.v1", nil); err == nil {
+		t.Error("Expected an error reading an encrypted release without keys")
+	}
+	if _, err := decodeRelease(data, "smug-pigeon.v2", keys); err == nil {
+		t.Error("Expected an error reading a release encrypted for another record")
+	}
+	other, err := LoadKeys([]byte("current: key-1\nkeys:\n  key-1: " + base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{3}, 32)) + "\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := decodeRelease(data, "smug-pigeon.v1", other); err == nil {
+		t.Error("Expected an error reading a release with the wrong key")
+	}
+	b[len(b)-1] ^= 1
+	if _, err := decodeRelease(b64.EncodeToString(b), "smug-pigeon.v1", keys); err == nil {
+		t.Error("Expected an error reading a tampered release")
+	}
+}
+
+func TestLoadKeys(t *testing.T) {
+	key := base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{1}, 32))
+	tests := []struct {
+		desc string
+		data string
+		err  string
+	}{
+		{"valid", "current: key-1\nkeys:\n  key-1: " + key, ""},
+		{"unknown current key", "current: key-2\nkeys:\n  key-1: " + key, `the current key "key-2" is not listed`},
+		{"short key", "current: key-1\nkeys:\n  key-1: c2hvcnQ=", "must be 32 bytes long, got 5"},
+		{"invalid key ID", "current: key/1\nkeys:\n  key/1: " + key, "must be a valid label value"},
+		{"invalid base64", "current: key-1\nkeys:\n  key-1: '!'", `invalid encryption key "key-1"`},
+	}
+	for _, tt := range tests {
+		_, err := LoadKeys([]byte(tt.data))
+		if tt.err == "" {
+			if err != nil {
+				t.Errorf("%s: %s", tt.desc, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), tt.err) {
+			t.Errorf("%s: expected error %q, got %v", tt.desc, tt.err, err)
+		}
+	}
+
+	mock := &MockSecretsInterface{objects: map[string]*v1.Secret{
+		"tiller-keys": {
+			ObjectMeta: metav1.ObjectMeta{Name: "tiller-keys"},
+			Data:       map[string][]byte{KeySecretKey: []byte("current: key-1\nkeys:\n  key-1: " + key)},
+		},
+	}}
+	keys, err := LoadKeySecret(mock, "tiller-keys")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if keys.CurrentKeyID() != "key-1" {
+		t.Errorf("Expected the current key to be key-1, got %q", keys.CurrentKeyID())
+	}
+	if _, err := LoadKeySecret(mock, "missing"); err == nil {
+		t.Error("Expected an error loading the keys of a missing Secret")
+	}
+}
+
+func TestEncryptedDrivers(t *testing.T) {
+	cfgmaps := newTestFixtureCfgMaps(t)
+	cfgmaps.Keys = testKeys(t, "key-1")
+	secrets := newTestFixtureSecrets(t)
+	secrets.Keys = testKeys(t, "key-1")
+	sqlDriver, cleanup := newTestFixtureSQL(t)
+	defer cleanup()
+	sqlDriver.Keys = testKeys(t, "key-1")
+
+	for _, d := range []interface {
+		Driver
+		Labeler
+	}{cfgmaps, secrets, sqlDriver} {
+		rls := releaseStub("smug-pigeon", 1, "default", rspb.Status_DEPLOYED)
+		key := testKey(rls.Name, rls.Version)
+		if err := d.CreateLabeled(key, rls, map[string]string{KeyIDLabel: "forged"}); err != nil {
+			t.Fatalf("%s: %s", d.Name(), err)
+		}
+		if lbs, err := d.Labels(key); err != nil || lbs[KeyIDLabel] != "key-1" {
+			t.Errorf("%s: expected the record to be labeled with key-1, got %v, %v", d.Name(), lbs, err)
+		}
+
+		// Updating a record after a rotation re-encrypts it.
+		switch d := d.(type) {
+		case *ConfigMaps:
+			d.Keys = testKeys(t, "key-2")
+		case *Secrets:
+			d.Keys = testKeys(t, "key-2")
+		case *SQL:
+			d.Keys = testKeys(t, "key-2")
+		}
+		got, err := d.Get(key)
+		if err != nil {
+			t.Fatalf("%s: %s", d.Name(), err)
+		}
+		if err := d.Update(key, got); err != nil {
+			t.Fatalf("%s: %s", d.Name(), err)
+		}
+		if lbs, err := d.Labels(key); err != nil || lbs[KeyIDLabel] != "key-2" {
+			t.Errorf("%s: expected the record to be labeled with key-2, got %v, %v", d.Name(), lbs, err)
+		}
+		if got, err = d.Get(key); err != nil || !proto.Equal(got, rls) {
+			t.Errorf("%s: expected the re-encrypted release to be read, got %v, %v", d.Name(), got, err)
+		}
+
+		// Updating a record once encryption is disabled clears its key ID.
+		switch d := d.(type) {
+		case *ConfigMaps:
+			d.Keys = nil
+		case *Secrets:
+			d.Keys = nil
+		case *SQL:
+			d.Keys = nil
+		}
+		if err := d.Update(key, got); err != nil {
+			t.Fatalf("%s: %s", d.Name(), err)
+		}
+		if lbs, err := d.Labels(key); err != nil || lbs[KeyIDLabel] != "" {
+			t.Errorf("%s: expected the record to lose its key ID, got %v, %v", d.Name(), lbs, err)
+		}
+	}
+}
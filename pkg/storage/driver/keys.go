@@ -0,0 +1,130 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package driver // import "k8s.io/helm/pkg/storage/driver"
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"regexp"
+
+	"github.com/ghodss/yaml"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+)
+
+// KeySecretKey is the key of the data of the Secret read by LoadKeySecret.
+const KeySecretKey = "keys.yaml"
+
+// keyIDPattern matches the key IDs, which must be valid label values.
+var keyIDPattern = regexp.MustCompile(`^[A-Za-z0-9]([-A-Za-z0-9_.]{0,61}[A-Za-z0-9])?$`)
+
+var _ KeyProvider = (*LocalKeys)(nil)
+
+// LocalKeys is a KeyProvider wrapping data keys with AES-256 keys it holds in
+// memory. It is loaded from a YAML document listing the keys, base64 encoded,
+// by ID:
+//
+//	current: key-2
+//	keys:
+//	  key-1: NTUtVC0yRDpYNE...
+//	  key-2: ZGI4MTJiNzA4Mz...
+//
+// The current key wraps the data keys of new records. The other keys are
+// kept to decrypt the records encrypted before a rotation.
+type LocalKeys struct {
+	current string
+	keys    map[string][]byte
+}
+
+// keyFile is the YAML document loaded by LoadKeys.
+type keyFile struct {
+	Current string            `json:"current"`
+	Keys    map[string]string `json:"keys"`
+}
+
+// LoadKeys loads the keys listed in a YAML document.
+func LoadKeys(data []byte) (*LocalKeys, error) {
+	var f keyFile
+	if err := yaml.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("invalid encryption keys: %s", err)
+	}
+	k := &LocalKeys{current: f.Current, keys: map[string][]byte{}}
+	for id, v := range f.Keys {
+		if !keyIDPattern.MatchString(id) {
+			return nil, fmt.Errorf("invalid encryption key ID %q: must be a valid label value", id)
+		}
+		key, err := base64.StdEncoding.DecodeString(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid encryption key %q: %s", id, err)
+		}
+		if len(key) != dataKeySize {
+			return nil, fmt.Errorf("invalid encryption key %q: must be %d bytes long, got %d", id, dataKeySize, len(key))
+		}
+		k.keys[id] = key
+	}
+	if _, ok := k.keys[k.current]; !ok {
+		return nil, fmt.Errorf("invalid encryption keys: the current key %q is not listed", k.current)
+	}
+	return k, nil
+}
+
+// LoadKeyFile loads the keys listed in the YAML file at path.
+func LoadKeyFile(path string) (*LocalKeys, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return LoadKeys(data)
+}
+
+// LoadKeySecret loads the keys listed in the keys.yaml entry of the Secret
+// named name.
+func LoadKeySecret(secrets corev1.SecretInterface, name string) (*LocalKeys, error) {
+	secret, err := secrets.Get(name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("cannot get the encryption keys: %s", err)
+	}
+	data, ok := secret.Data[KeySecretKey]
+	if !ok {
+		return nil, fmt.Errorf("the Secret %s has no %s entry", name, KeySecretKey)
+	}
+	return LoadKeys(data)
+}
+
+// CurrentKeyID returns the ID of the current key.
+func (k *LocalKeys) CurrentKeyID() string {
+	return k.current
+}
+
+// WrapKey encrypts a data key with the current key.
+func (k *LocalKeys) WrapKey(dataKey []byte) (string, []byte, error) {
+	nonce, sealed, err := seal(k.keys[k.current], dataKey, nil)
+	if err != nil {
+		return "", nil, err
+	}
+	return k.current, append(nonce, sealed...), nil
+}
+
+// UnwrapKey decrypts a data key wrapped with the key keyID.
+func (k *LocalKeys) UnwrapKey(keyID string, wrapped []byte) ([]byte, error) {
+	key, ok := k.keys[keyID]
+	if !ok {
+		return nil, fmt.Errorf("unknown encryption key %q", keyID)
+	}
+	return open(key, wrapped, nil)
+}
@@ -0,0 +1,63 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package driver // import "k8s.io/helm/pkg/storage/driver"
+
+import (
+	"strconv"
+	"time"
+
+	rspb "k8s.io/helm/pkg/proto/hapi/release"
+)
+
+// Labeler is the interface implemented by drivers storing labels, such as
+// CREATED_AT, with the records of releases.
+//
+// Labels returns the labels of the record of the release named by key, or
+// ErrReleaseNotFound if the release does not exist.
+//
+// CreateLabeled stores the release like Create does, in a record labeled with
+// lbs. The labels describing the release itself, such as NAME and VERSION, the
+// KEY_ID label of encrypted records and the CHART_DIGEST label of the records
+// stored by Dedup are always set by the driver.
+// CREATED_AT defaults to the current time.
+type Labeler interface {
+	Labels(key string) (map[string]string, error)
+	CreateLabeled(key string, rls *rspb.Release, lbs map[string]string) error
+}
+
+// createdLabels returns the labels of a record created with lbs.
+func createdLabels(lbs map[string]string) labels {
+	var created labels
+	created.init()
+	created.set("CREATED_AT", strconv.Itoa(int(time.Now().Unix())))
+	created.fromMap(lbs)
+	delete(created, KeyIDLabel)
+	delete(created, ChartDigestLabel)
+	return created
+}
+
+// recordLabels returns the labels of the object holding a release record,
+// without the labels describing how the record is split into objects.
+func recordLabels(lbs map[string]string) map[string]string {
+	out := make(map[string]string, len(lbs))
+	for k, v := range lbs {
+		if k != chunksLabel && k != chunkGenLabel {
+			out[k] = v
+		}
+	}
+	return out
+}
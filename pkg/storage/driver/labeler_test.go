@@ -0,0 +1,66 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package driver
+
+import (
+	"reflect"
+	"testing"
+
+	rspb "k8s.io/helm/pkg/proto/hapi/release"
+)
+
+func TestLabeler(t *testing.T) {
+	sqlDriver, cleanup := newTestFixtureSQL(t)
+	defer cleanup()
+
+	tests := []struct {
+		driver Labeler
+		// expected are the labels of the record created, other than those
+		// describing the release.
+		expected map[string]string
+	}{
+		{NewMemory(), map[string]string{"CREATED_AT": "1550000000", "MODIFIED_AT": "1550000100", "TEAM": "a"}},
+		{newTestFixtureCfgMaps(t), map[string]string{"CREATED_AT": "1550000000", "MODIFIED_AT": "1550000100", "TEAM": "a"}},
+		{newTestFixtureSecrets(t), map[string]string{"CREATED_AT": "1550000000", "MODIFIED_AT": "1550000100", "TEAM": "a"}},
+		{sqlDriver, map[string]string{"CREATED_AT": "1550000000", "MODIFIED_AT": "1550000100"}},
+	}
+	for _, tt := range tests {
+		name := tt.driver.(Driver).Name()
+		key := testKey("smug-pigeon", 2)
+		rel := largeReleaseStub("smug-pigeon", 2, "default", rspb.Status_SUPERSEDED)
+
+		lbs := map[string]string{"CREATED_AT": "1550000000", "MODIFIED_AT": "1550000100", "TEAM": "a", "NAME": "other"}
+		if err := tt.driver.CreateLabeled(key, rel, lbs); err != nil {
+			t.Fatalf("%s: failed to create release: %s", name, err)
+		}
+		got, err := tt.driver.Labels(key)
+		if err != nil {
+			t.Fatalf("%s: failed to get labels: %s", name, err)
+		}
+		expected := map[string]string{"NAME": "smug-pigeon", "OWNER": "TILLER", "STATUS": "SUPERSEDED", "VERSION": "2"}
+		for k, v := range tt.expected {
+			expected[k] = v
+		}
+		if !reflect.DeepEqual(got, expected) {
+			t.Errorf("%s: expected labels %v, got %v", name, expected, got)
+		}
+
+		if _, err := tt.driver.Labels(testKey("smug-pigeon", 3)); err == nil {
+			t.Errorf("%s: expected an error getting the labels of a missing release", name)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package driver // import "k8s.io/helm/pkg/storage/driver"
+
+import (
+	"fmt"
+	"time"
+
+	storageerrors "k8s.io/helm/pkg/storage/errors"
+)
+
+// Lock is a lease on a release, held by a single operation at a time.
+type Lock struct {
+	// ID identifies the lease. Only the holder of the lease knows it,
+	// so it is used to renew and release the lock.
+	ID string
+	// Holder identifies the client holding the lock.
+	Holder string
+	// Operation is the operation the lock was taken for, e.g. "upgrade".
+	Operation string
+	// Acquired is the time the lock was acquired.
+	Acquired time.Time
+	// Expires is the time after which the lock can be taken over by
+	// another operation, unless it is renewed.
+	Expires time.Time
+}
+
+// Expired returns whether the lease is over at time t.
+func (l *Lock) Expired(t time.Time) bool {
+	return !t.Before(l.Expires)
+}
+
+// String describes who holds the lock and since when.
+func (l *Lock) String() string {
+	return fmt.Sprintf("%s from %s since %s", l.Operation, l.Holder, l.Acquired.UTC().Format(time.RFC3339))
+}
+
+// Locker is the interface implemented by drivers able to lock releases.
+//
+// AcquireLock stores lock for the release name. It returns ErrReleaseLocked
+// if another, unexpired, lock is held on the release. Acquiring a lock with
+// the ID of the lock being held renews it.
+//
+// RenewLock stores lock for the release name if the lock held on the release
+// has the same ID. It returns ErrLockNotFound if the release is not locked
+// anymore, e.g. because the lock was cleared, and ErrReleaseLocked if another
+// lock is held on the release. Unlike AcquireLock, it never creates a lock.
+//
+// ReleaseLock deletes the lock held on the release name if its ID is id, or
+// whatever its ID if id is empty, and returns it. It returns ErrLockNotFound
+// if the release is not locked.
+//
+// GetLock returns the lock held on the release name or ErrLockNotFound.
+type Locker interface {
+	AcquireLock(name string, lock *Lock) error
+	RenewLock(name string, lock *Lock) error
+	ReleaseLock(name, id string) (*Lock, error)
+	GetLock(name string) (*Lock, error)
+}
+
+// lockConflict returns the error to report when acquiring lock on the
+// release name while held is stored, or nil if lock can replace held.
+func lockConflict(name string, lock, held *Lock) error {
+	if held.ID != lock.ID && !held.Expired(time.Now()) {
+		return storageerrors.ErrReleaseLocked(name, held.String())
+	}
+	return nil
+}
+
+// lockKey returns the name of the object holding the lock on a release.
+// Release records are named "<release>.v<version>", so they can not clash.
+func lockKey(name string) string {
+	return name + ".lock"
+}
+
+// Annotations holding a lock on the ConfigMap or Secret named by lockKey.
+const (
+	lockIDAnnotation        = "helm.sh/lock-id"
+	lockHolderAnnotation    = "helm.sh/lock-holder"
+	lockOperationAnnotation = "helm.sh/lock-operation"
+	lockAcquiredAnnotation  = "helm.sh/lock-acquired"
+	lockExpiresAnnotation   = "helm.sh/lock-expires"
+)
+
+// lockLabel marks the objects holding a lock. Like chunks, they do not carry
+// the OWNER label so they are never listed as releases.
+const lockLabel = "LOCK"
+
+func newLockLabels(name string) map[string]string {
+	return map[string]string{"NAME": name, lockLabel: "true"}
+}
+
+func lockToAnnotations(lock *Lock) map[string]string {
+	return map[string]string{
+		lockIDAnnotation:        lock.ID,
+		lockHolderAnnotation:    lock.Holder,
+		lockOperationAnnotation: lock.Operation,
+		lockAcquiredAnnotation:  lock.Acquired.UTC().Format(time.RFC3339Nano),
+		lockExpiresAnnotation:   lock.Expires.UTC().Format(time.RFC3339Nano),
+	}
+}
+
+func lockFromAnnotations(annotations map[string]string) (*Lock, error) {
+	acquired, err := time.Parse(time.RFC3339Nano, annotations[lockAcquiredAnnotation])
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s annotation: %s", lockAcquiredAnnotation, err)
+	}
+	expires, err := time.Parse(time.RFC3339Nano, annotations[lockExpiresAnnotation])
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s annotation: %s", lockExpiresAnnotation, err)
+	}
+	return &Lock{
+		ID:        annotations[lockIDAnnotation],
+		Holder:    annotations[lockHolderAnnotation],
+		Operation: annotations[lockOperationAnnotation],
+		Acquired:  acquired,
+		Expires:   expires,
+	}, nil
+}
@@ -0,0 +1,155 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package driver
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	rspb "k8s.io/helm/pkg/proto/hapi/release"
+)
+
+func newTestLock(id string, expires time.Duration) *Lock {
+	now := time.Now()
+	return &Lock{
+		ID:        id,
+		Holder:    "alice@ci (10.0.0.1:4000)",
+		Operation: "upgrade",
+		Acquired:  now,
+		Expires:   now.Add(expires),
+	}
+}
+
+// testLocker runs the same scenario against every Locker implementation.
+func testLocker(t *testing.T, l Locker) {
+	first := newTestLock("first", time.Minute)
+	if err := l.AcquireLock("smug-pigeon", first); err != nil {
+		t.Fatalf("Failed to lock: %s", err)
+	}
+	got, err := l.GetLock("smug-pigeon")
+	if err != nil {
+		t.Fatalf("Failed to get lock: %s", err)
+	}
+	if got.ID != first.ID || got.Holder != first.Holder || got.Operation != first.Operation || !got.Expires.Equal(first.Expires) {
+		t.Errorf("Expected lock %v, got %v", first, got)
+	}
+
+	second := newTestLock("second", time.Minute)
+	err = l.AcquireLock("smug-pigeon", second)
+	if err == nil || !strings.Contains(err.Error(), "is locked by upgrade from alice@ci (10.0.0.1:4000)") {
+		t.Errorf("Expected the holder to be reported, got %v", err)
+	}
+	if err := l.AcquireLock("other", second); err != nil {
+		t.Errorf("Expected other releases to be lockable: %s", err)
+	}
+
+	renewed := *first
+	renewed.Expires = renewed.Expires.Add(time.Minute)
+	if err := l.AcquireLock("smug-pigeon", &renewed); err != nil {
+		t.Errorf("Failed to renew lock: %s", err)
+	}
+	renewed.Expires = renewed.Expires.Add(time.Minute)
+	if err := l.RenewLock("smug-pigeon", &renewed); err != nil {
+		t.Errorf("Failed to renew lock: %s", err)
+	}
+	if got, err := l.GetLock("smug-pigeon"); err != nil || !got.Expires.Equal(renewed.Expires) {
+		t.Errorf("Expected the lock to expire at %s, got %v (%v)", renewed.Expires, got, err)
+	}
+	if err := l.RenewLock("smug-pigeon", second); err == nil || !strings.Contains(err.Error(), "is locked by") {
+		t.Errorf("Expected not to renew a lock held by someone else, got %v", err)
+	}
+	if _, err := l.ReleaseLock("smug-pigeon", second.ID); err == nil {
+		t.Errorf("Expected not to release a lock held by someone else")
+	}
+	released, err := l.ReleaseLock("smug-pigeon", first.ID)
+	if err != nil {
+		t.Fatalf("Failed to release lock: %s", err)
+	}
+	if released.ID != first.ID {
+		t.Errorf("Expected lock %q to be released, got %q", first.ID, released.ID)
+	}
+	if _, err := l.GetLock("smug-pigeon"); err == nil || !strings.Contains(err.Error(), "not locked") {
+		t.Errorf("Expected release to be unlocked, got %v", err)
+	}
+
+	// expired locks are taken over
+	if err := l.AcquireLock("stale", newTestLock("crashed", -time.Second)); err != nil {
+		t.Fatalf("Failed to lock: %s", err)
+	}
+	if err := l.AcquireLock("stale", second); err != nil {
+		t.Errorf("Expected expired lock to be taken over: %s", err)
+	}
+	if got, err := l.GetLock("stale"); err != nil || got.ID != second.ID {
+		t.Errorf("Expected lock %q, got %v (%v)", second.ID, got, err)
+	}
+
+	// an empty ID forcibly releases the lock
+	if _, err := l.ReleaseLock("stale", ""); err != nil {
+		t.Errorf("Failed to force release lock: %s", err)
+	}
+	if _, err := l.ReleaseLock("stale", ""); err == nil {
+		t.Errorf("Expected releasing an unlocked release to fail")
+	}
+
+	// a cleared lock is not renewed
+	if err := l.RenewLock("stale", second); err == nil || !strings.Contains(err.Error(), "not locked") {
+		t.Errorf("Expected renewing a cleared lock to fail, got %v", err)
+	}
+	if _, err := l.GetLock("stale"); err == nil {
+		t.Errorf("Expected renewing a cleared lock not to lock the release")
+	}
+}
+
+func TestMemoryLock(t *testing.T) {
+	testLocker(t, NewMemory())
+}
+
+func TestConfigMapsLock(t *testing.T) {
+	rel := releaseStub("smug-pigeon", 1, "default", rspb.Status_DEPLOYED)
+	cfgmaps := newTestFixtureCfgMaps(t, rel)
+	testLocker(t, cfgmaps)
+
+	if err := cfgmaps.AcquireLock(rel.Name, newTestLock("held", time.Minute)); err != nil {
+		t.Fatalf("Failed to lock: %s", err)
+	}
+	rls, err := cfgmaps.Query(map[string]string{"NAME": rel.Name, "OWNER": "TILLER"})
+	if err != nil || len(rls) != 1 {
+		t.Errorf("Expected the lock not to show up in the release history, got %v (%v)", rls, err)
+	}
+}
+
+func TestSecretsLock(t *testing.T) {
+	rel := releaseStub("smug-pigeon", 1, "default", rspb.Status_DEPLOYED)
+	secrets := newTestFixtureSecrets(t, rel)
+	testLocker(t, secrets)
+
+	if err := secrets.AcquireLock(rel.Name, newTestLock("held", time.Minute)); err != nil {
+		t.Fatalf("Failed to lock: %s", err)
+	}
+	rls, err := secrets.Query(map[string]string{"NAME": rel.Name, "OWNER": "TILLER"})
+	if err != nil || len(rls) != 1 {
+		t.Errorf("Expected the lock not to show up in the release history, got %v (%v)", rls, err)
+	}
+}
+
+func TestSQLLock(t *testing.T) {
+	s, cleanup := newTestFixtureSQL(t)
+	defer cleanup()
+
+	testLocker(t, s)
+}
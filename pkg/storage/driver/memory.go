@@ -26,6 +26,8 @@ import (
 )
 
 var _ Driver = (*Memory)(nil)
+var _ Locker = (*Memory)(nil)
+var _ Labeler = (*Memory)(nil)
 
 // MemoryDriverName is the string name of this driver.
 const MemoryDriverName = "Memory"
@@ -34,11 +36,12 @@ const MemoryDriverName = "Memory"
 type Memory struct {
 	sync.RWMutex
 	cache map[string]records
+	locks map[string]*Lock
 }
 
 // NewMemory initializes a new memory driver.
 func NewMemory() *Memory {
-	return &Memory{cache: map[string]records{}}
+	return &Memory{cache: map[string]records{}, locks: map[string]*Lock{}}
 }
 
 // Name returns the name of the driver.
@@ -111,16 +114,41 @@ func (mem *Memory) Query(keyvals map[string]string) ([]*rspb.Release, error) {
 
 // Create creates a new release or returns ErrReleaseExists.
 func (mem *Memory) Create(key string, rls *rspb.Release) error {
+	return mem.CreateLabeled(key, rls, nil)
+}
+
+// Labels returns the labels of the release named by key or returns
+// ErrReleaseNotFound.
+func (mem *Memory) Labels(key string) (map[string]string, error) {
+	defer unlock(mem.rlock())
+
+	name := strings.Split(key, ".v")[0]
+	if recs, ok := mem.cache[name]; ok {
+		if r := recs.Get(key); r != nil {
+			return recordLabels(r.lbs), nil
+		}
+	}
+	return nil, storageerrors.ErrReleaseNotFound(key)
+}
+
+// CreateLabeled creates a new release labeled with lbs or returns
+// ErrReleaseExists.
+func (mem *Memory) CreateLabeled(key string, rls *rspb.Release, lbs map[string]string) error {
 	defer unlock(mem.wlock())
 
+	rec := newRecord(key, rls)
+	created := createdLabels(lbs)
+	created.fromMap(rec.lbs)
+	rec.lbs = created
+
 	if recs, ok := mem.cache[rls.Name]; ok {
-		if err := recs.Add(newRecord(key, rls)); err != nil {
+		if err := recs.Add(rec); err != nil {
 			return err
 		}
 		mem.cache[rls.Name] = recs
 		return nil
 	}
-	mem.cache[rls.Name] = records{newRecord(key, rls)}
+	mem.cache[rls.Name] = records{rec}
 	return nil
 }
 
@@ -159,6 +187,67 @@ func (mem *Memory) Delete(key string) (*rspb.Release, error) {
 	return nil, storageerrors.ErrReleaseNotFound(key)
 }
 
+// AcquireLock locks the release name.
+func (mem *Memory) AcquireLock(name string, lock *Lock) error {
+	defer unlock(mem.wlock())
+
+	if held, ok := mem.locks[name]; ok {
+		if err := lockConflict(name, lock, held); err != nil {
+			return err
+		}
+	}
+	if mem.locks == nil {
+		mem.locks = map[string]*Lock{}
+	}
+	l := *lock
+	mem.locks[name] = &l
+	return nil
+}
+
+// RenewLock stores lock for the release name if it is still held with the
+// same ID.
+func (mem *Memory) RenewLock(name string, lock *Lock) error {
+	defer unlock(mem.wlock())
+
+	held, ok := mem.locks[name]
+	if !ok {
+		return storageerrors.ErrLockNotFound(name)
+	}
+	if held.ID != lock.ID {
+		return storageerrors.ErrReleaseLocked(name, held.String())
+	}
+	l := *lock
+	mem.locks[name] = &l
+	return nil
+}
+
+// ReleaseLock unlocks the release name.
+func (mem *Memory) ReleaseLock(name, id string) (*Lock, error) {
+	defer unlock(mem.wlock())
+
+	held, ok := mem.locks[name]
+	if !ok {
+		return nil, storageerrors.ErrLockNotFound(name)
+	}
+	if id != "" && held.ID != id {
+		return nil, storageerrors.ErrReleaseLocked(name, held.String())
+	}
+	delete(mem.locks, name)
+	return held, nil
+}
+
+// GetLock returns the lock held on the release name.
+func (mem *Memory) GetLock(name string) (*Lock, error) {
+	defer unlock(mem.rlock())
+
+	held, ok := mem.locks[name]
+	if !ok {
+		return nil, storageerrors.ErrLockNotFound(name)
+	}
+	l := *held
+	return &l, nil
+}
+
 // wlock locks mem for writing
 func (mem *Memory) wlock() func() {
 	mem.Lock()
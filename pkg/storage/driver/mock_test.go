@@ -17,12 +17,15 @@ limitations under the License.
 package driver // import "k8s.io/helm/pkg/storage/driver"
 
 import (
+	"encoding/hex"
 	"fmt"
+	"math/rand"
 	"testing"
 
 	"k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kblabels "k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
 
@@ -38,6 +41,19 @@ func releaseStub(name string, vers int32, namespace string, code rspb.Status_Cod
 	}
 }
 
+// largeReleaseStub returns a release whose encoding is too big
+// to fit in objects limited to a few kilobytes.
+func largeReleaseStub(name string, vers int32, namespace string, code rspb.Status_Code) *rspb.Release {
+	rls := releaseStub(name, vers, namespace, code)
+
+	// random data does not compress
+	r := rand.New(rand.NewSource(int64(vers)))
+	b := make([]byte, 8*1024)
+	r.Read(b)
+	rls.Manifest = hex.EncodeToString(b)
+	return rls
+}
+
 func testKey(name string, vers int32) string {
 	return fmt.Sprintf("%s.v%d", name, vers)
 }
@@ -106,11 +122,17 @@ func (mock *MockConfigMapsInterface) Get(name string, options metav1.GetOptions)
 	return object, nil
 }
 
-// List returns the a of ConfigMaps.
+// List returns the a of ConfigMaps matching the label selector.
 func (mock *MockConfigMapsInterface) List(opts metav1.ListOptions) (*v1.ConfigMapList, error) {
+	sel, err := kblabels.Parse(opts.LabelSelector)
+	if err != nil {
+		return nil, err
+	}
 	var list v1.ConfigMapList
 	for _, cfgmap := range mock.objects {
-		list.Items = append(list.Items, *cfgmap)
+		if sel.Matches(kblabels.Set(cfgmap.Labels)) {
+			list.Items = append(list.Items, *cfgmap)
+		}
 	}
 	return &list, nil
 }
@@ -184,11 +206,17 @@ func (mock *MockSecretsInterface) Get(name string, options metav1.GetOptions) (*
 	return object, nil
 }
 
-// List returns the a of Secret.
+// List returns the a of Secret matching the label selector.
 func (mock *MockSecretsInterface) List(opts metav1.ListOptions) (*v1.SecretList, error) {
+	sel, err := kblabels.Parse(opts.LabelSelector)
+	if err != nil {
+		return nil, err
+	}
 	var list v1.SecretList
 	for _, secret := range mock.objects {
-		list.Items = append(list.Items, *secret)
+		if sel.Matches(kblabels.Set(secret.Labels)) {
+			list.Items = append(list.Items, *secret)
+		}
 	}
 	return &list, nil
 }
@@ -34,6 +34,8 @@ import (
 )
 
 var _ Driver = (*Secrets)(nil)
+var _ Locker = (*Secrets)(nil)
+var _ Labeler = (*Secrets)(nil)
 
 // SecretsDriverName is the string name of the driver.
 const SecretsDriverName = "Secret"
@@ -43,14 +45,23 @@ const SecretsDriverName = "Secret"
 type Secrets struct {
 	impl corev1.SecretInterface
 	Log  func(string, ...interface{})
+
+	// Keys, if set, encrypts the releases stored. Encrypted releases can
+	// only be read with Keys set.
+	Keys KeyProvider
+
+	// chunkSize is the maximum length of the encoded release stored
+	// in a single Secret.
+	chunkSize int
 }
 
 // NewSecrets initializes a new Secrets wrapping an implementation of
 // the kubernetes SecretsInterface.
 func NewSecrets(impl corev1.SecretInterface) *Secrets {
 	return &Secrets{
-		impl: impl,
-		Log:  func(_ string, _ ...interface{}) {},
+		impl:      impl,
+		Log:       func(_ string, _ ...interface{}) {},
+		chunkSize: defaultChunkSize,
 	}
 }
 
@@ -72,8 +83,8 @@ func (secrets *Secrets) Get(key string) (*rspb.Release, error) {
 		secrets.Log("get: failed to get %q: %s", key, err)
 		return nil, err
 	}
-	// found the secret, decode the base64 data string
-	r, err := decodeRelease(string(obj.Data["release"]))
+	// found the secret, reassemble and decode the base64 data string
+	r, err := secrets.decode(obj)
 	if err != nil {
 		secrets.Log("get: failed to decode data %q: %s", key, err)
 		return nil, err
@@ -99,8 +110,12 @@ func (secrets *Secrets) List(filter func(*rspb.Release) bool) ([]*rspb.Release,
 
 	// iterate over the secrets object list
 	// and decode each release
-	for _, item := range list.Items {
-		rls, err := decodeRelease(string(item.Data["release"]))
+	for i := range list.Items {
+		item := &list.Items[i]
+		if isChunk(item.Labels) {
+			continue
+		}
+		rls, err := secrets.decode(item)
 		if err != nil {
 			secrets.Log("list: failed to decode release: %v: %s", item, err)
 			continue
@@ -136,8 +151,12 @@ func (secrets *Secrets) Query(labels map[string]string) ([]*rspb.Release, error)
 	}
 
 	var results []*rspb.Release
-	for _, item := range list.Items {
-		rls, err := decodeRelease(string(item.Data["release"]))
+	for i := range list.Items {
+		item := &list.Items[i]
+		if isChunk(item.Labels) {
+			continue
+		}
+		rls, err := secrets.decode(item)
 		if err != nil {
 			secrets.Log("query: failed to decode release: %s", err)
 			continue
@@ -150,20 +169,39 @@ func (secrets *Secrets) Query(labels map[string]string) ([]*rspb.Release, error)
 // Create creates a new Secret holding the release. If the
 // Secret already exists, ErrReleaseExists is returned.
 func (secrets *Secrets) Create(key string, rls *rspb.Release) error {
-	// set labels for secrets object meta data
-	var lbs labels
+	return secrets.CreateLabeled(key, rls, nil)
+}
 
-	lbs.init()
-	lbs.set("CREATED_AT", strconv.Itoa(int(time.Now().Unix())))
+// Labels returns the labels of the Secret holding the release named by key.
+func (secrets *Secrets) Labels(key string) (map[string]string, error) {
+	obj, err := secrets.impl.Get(key, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, storageerrors.ErrReleaseNotFound(key)
+		}
+		return nil, err
+	}
+	return recordLabels(obj.Labels), nil
+}
 
-	// create a new secret to hold the release
-	obj, err := newSecretsObject(key, rls, lbs)
+// CreateLabeled creates a new Secret holding the release, labeled with lbs.
+// If the Secret already exists, ErrReleaseExists is returned.
+func (secrets *Secrets) CreateLabeled(key string, rls *rspb.Release, lbs map[string]string) error {
+	// create the secrets to hold the release
+	objs, err := newSecretsObjects(key, rls, createdLabels(lbs), secrets.chunkSize, secrets.Keys)
 	if err != nil {
 		secrets.Log("create: failed to encode release %q: %s", rls.Name, err)
 		return err
 	}
+	// write the chunks, if any, before the secret referencing them
+	if err := secrets.writeChunks(objs[1:]); err != nil {
+		secrets.Log("create: failed to create chunks of %q: %s", key, err)
+		secrets.deleteChunks(key, objs[0].Labels)
+		return err
+	}
 	// push the secret object out into the kubiverse
-	if _, err := secrets.impl.Create(obj); err != nil {
+	if _, err := secrets.impl.Create(objs[0]); err != nil {
+		secrets.deleteChunks(key, objs[0].Labels)
 		if apierrors.IsAlreadyExists(err) {
 			return storageerrors.ErrReleaseExists(rls.Name)
 		}
@@ -183,44 +221,241 @@ func (secrets *Secrets) Update(key string, rls *rspb.Release) error {
 	lbs.init()
 	lbs.set("MODIFIED_AT", strconv.Itoa(int(time.Now().Unix())))
 
-	// create a new secret object to hold the release
-	obj, err := newSecretsObject(key, rls, lbs)
+	// fetch the current secret to find the chunks it refers to
+	cur, err := secrets.impl.Get(key, metav1.GetOptions{})
+	if err != nil {
+		secrets.Log("update: failed to get %q: %s", key, err)
+		return err
+	}
+
+	// create the secret objects to hold the release
+	objs, err := newSecretsObjects(key, rls, lbs, secrets.chunkSize, secrets.Keys)
 	if err != nil {
 		secrets.Log("update: failed to encode release %q: %s", rls.Name, err)
 		return err
 	}
+	// write the new chunks under a new generation, switch the secret
+	// over to them and only then remove the chunks it referred to, so that
+	// the record stays readable whenever the update fails
+	if err := secrets.writeChunks(objs[1:]); err != nil {
+		secrets.Log("update: failed to write chunks of %q: %s", key, err)
+		secrets.deleteChunks(key, objs[0].Labels)
+		return err
+	}
 	// push the secret object out into the kubiverse
-	_, err = secrets.impl.Update(obj)
+	objs[0].ResourceVersion = cur.ResourceVersion
+	_, err = secrets.impl.Update(objs[0])
 	if err != nil {
 		secrets.Log("update: failed to update: %s", err)
+		secrets.deleteChunks(key, objs[0].Labels)
 		return err
 	}
+	// remove the chunks of the previous revision of the record
+	secrets.deleteChunks(key, cur.Labels)
 	return nil
 }
 
 // Delete deletes the Secret holding the release named by key.
 func (secrets *Secrets) Delete(key string) (rls *rspb.Release, err error) {
 	// fetch the release to check existence
-	if rls, err = secrets.Get(key); err != nil {
+	obj, err := secrets.impl.Get(key, metav1.GetOptions{})
+	if err != nil {
 		if apierrors.IsNotFound(err) {
-			return nil, storageerrors.ErrReleaseExists(rls.Name)
+			return nil, storageerrors.ErrReleaseNotFound(key)
 		}
 
 		secrets.Log("delete: failed to get release %q: %s", key, err)
 		return nil, err
 	}
+	if rls, err = secrets.decode(obj); err != nil {
+		secrets.Log("delete: failed to decode data %q: %s", key, err)
+		return nil, err
+	}
 	// delete the release
 	if err = secrets.impl.Delete(key, &metav1.DeleteOptions{}); err != nil {
 		return rls, err
 	}
+	// and the chunks it was split into
+	secrets.deleteChunks(key, obj.Labels)
 	return rls, nil
 }
 
-// newSecretsObject constructs a kubernetes Secret object
-// to store a release. Each secret data entry is the base64
-// encoded string of a release's binary protobuf encoding.
+// AcquireLock locks the release name. The lock is held in the annotations of
+// a Secret; an expired lock is taken over with an update conditioned on
+// the resourceVersion of the Secret, so that only one operation wins.
+func (secrets *Secrets) AcquireLock(name string, lock *Lock) error {
+	obj := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        lockKey(name),
+			Labels:      newLockLabels(name),
+			Annotations: lockToAnnotations(lock),
+		},
+	}
+	_, err := secrets.impl.Create(obj)
+	if err == nil || !apierrors.IsAlreadyExists(err) {
+		return err
+	}
+
+	cur, err := secrets.impl.Get(obj.Name, metav1.GetOptions{})
+	if err != nil {
+		secrets.Log("lock: failed to get lock %q: %s", obj.Name, err)
+		return err
+	}
+	held, err := lockFromAnnotations(cur.Annotations)
+	if err != nil {
+		secrets.Log("lock: taking over unreadable lock %q: %s", obj.Name, err)
+	} else if err := lockConflict(name, lock, held); err != nil {
+		return err
+	}
+	obj.ResourceVersion = cur.ResourceVersion
+	if _, err := secrets.impl.Update(obj); err != nil {
+		if apierrors.IsConflict(err) || apierrors.IsNotFound(err) {
+			// the lock changed since we read it, try again against its new holder
+			return secrets.AcquireLock(name, lock)
+		}
+		secrets.Log("lock: failed to update lock %q: %s", obj.Name, err)
+		return err
+	}
+	return nil
+}
+
+// RenewLock stores lock for the release name if it is still held with the
+// same ID. The update is conditioned on the resourceVersion of the Z
+// read, so that a lock cleared meanwhile is not recreated.
+func (secrets *Secrets) RenewLock(name string, lock *Lock) error {
+	cur, err := secrets.impl.Get(lockKey(name), metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return storageerrors.ErrLockNotFound(name)
+		}
+		secrets.Log("lock: failed to get lock %q: %s", lockKey(name), err)
+		return err
+	}
+	held, err := lockFromAnnotations(cur.Annotations)
+	if err != nil {
+		return err
+	}
+	if held.ID != lock.ID {
+		return storageerrors.ErrReleaseLocked(name, held.String())
+	}
+	obj := cur.DeepCopy()
+	obj.Annotations = lockToAnnotations(lock)
+	if _, err := secrets.impl.Update(obj); err != nil {
+		if apierrors.IsNotFound(err) {
+			return storageerrors.ErrLockNotFound(name)
+		}
+		if apierrors.IsConflict(err) {
+			// the lock changed since we read it, check it again
+			return secrets.RenewLock(name, lock)
+		}
+		secrets.Log("lock: failed to update lock %q: %s", obj.Name, err)
+		return err
+	}
+	return nil
+}
+
+// ReleaseLock deletes the Secret holding the lock on the release name.
+func (secrets *Secrets) ReleaseLock(name, id string) (*Lock, error) {
+	obj, err := secrets.impl.Get(lockKey(name), metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, storageerrors.ErrLockNotFound(name)
+		}
+		return nil, err
+	}
+	held, err := lockFromAnnotations(obj.Annotations)
+	if err != nil {
+		if id != "" {
+			return nil, err
+		}
+		held = &Lock{}
+	}
+	if id != "" && held.ID != id {
+		return nil, storageerrors.ErrReleaseLocked(name, held.String())
+	}
+	err = secrets.impl.Delete(obj.Name, &metav1.DeleteOptions{Preconditions: &metav1.Preconditions{UID: &obj.UID}})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, storageerrors.ErrLockNotFound(name)
+		}
+		return nil, err
+	}
+	return held, nil
+}
+
+// GetLock returns the lock held on the release name.
+func (secrets *Secrets) GetLock(name string) (*Lock, error) {
+	obj, err := secrets.impl.Get(lockKey(name), metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, storageerrors.ErrLockNotFound(name)
+		}
+		return nil, err
+	}
+	return lockFromAnnotations(obj.Annotations)
+}
+
+// decode reassembles the release stored in obj, fetching the additional
+// chunk Secrets it was split into, and decodes it.
+func (secrets *Secrets) decode(obj *v1.Secret) (*rspb.Release, error) {
+	names, err := chunkNames(obj.Name, obj.Labels)
+	if err != nil {
+		return nil, err
+	}
+	data := string(obj.Data["release"])
+	for i, name := range names {
+		chunk, err := secrets.impl.Get(name, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get chunk %d of %q: %s", i+1, obj.Name, err)
+		}
+		data += string(chunk.Data["release"])
+	}
+	return decodeRelease(data, obj.Name, secrets.Keys)
+}
+
+// writeChunks creates the chunk Secrets.
+func (secrets *Secrets) writeChunks(objs []*v1.Secret) error {
+	for _, obj := range objs {
+		if _, err := secrets.impl.Create(obj); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteChunks deletes the chunk Secrets of the release stored under key
+// in an object with the given labels.
+func (secrets *Secrets) deleteChunks(key string, lbs map[string]string) {
+	names, err := chunkNames(key, lbs)
+	if err != nil {
+		secrets.Log("delete: failed to find chunks of %q: %s", key, err)
+		return
+	}
+	for _, name := range names {
+		if err := secrets.impl.Delete(name, &metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+			secrets.Log("delete: failed to delete chunk %q: %s", name, err)
+		}
+	}
+}
+
+// newSecretsObject constructs a single kubernetes Secret
+// object to store a release, regardless of its size.
+func newSecretsObject(key string, rls *rspb.Release, lbs labels) (*v1.Secret, error) {
+	objs, err := newSecretsObjects(key, rls, lbs, 0, nil)
+	if err != nil {
+		return nil, err
+	}
+	return objs[0], nil
+}
+
+// newSecretsObjects constructs the kubernetes Secret objects
+// to store a release. The secret data entry is the base64
+// encoded string of a release's binary protobuf encoding. When that
+// string is longer than chunkSize, it is split across several
+// secrets: the first one is named by key and the following ones
+// are named by key with a ".<generation>.<index>" suffix.
 //
-// The following labels are used within each secret:
+// The following labels are used within the first secret:
 //
 //    "MODIFIED_AT"    - timestamp indicating when this secret was last modified. (set in Update)
 //    "CREATED_AT"     - timestamp indicating when this secret was created. (set in Create)
@@ -228,15 +463,23 @@ func (secrets *Secrets) Delete(key string) (rls *rspb.Release, err error) {
 //    "STATUS"         - status of the release (see proto/hapi/release.status.pb.go for variants)
 //    "OWNER"          - owner of the secret, currently "TILLER".
 //    "NAME"           - name of the release.
+//    "CHUNKS"         - number of secrets the release is split into, if more than one.
+//    "CHUNK_GEN"      - generation of the chunks, if more than one.
+//    "KEY_ID"         - ID of the key encrypting the release, if encrypted.
+//    "CHART_DIGEST"   - digest of the chart of the release, if stored by Dedup.
 //
-func newSecretsObject(key string, rls *rspb.Release, lbs labels) (*v1.Secret, error) {
+// The additional secrets only carry the "NAME", "VERSION" and
+// "CHUNK" (the index of the chunk) labels.
+//
+func newSecretsObjects(key string, rls *rspb.Release, lbs labels, chunkSize int, keys KeyProvider) ([]*v1.Secret, error) {
 	const owner = "TILLER"
 
 	// encode the release
-	s, err := encodeRelease(rls)
+	s, err := encodeRelease(rls, key, keys)
 	if err != nil {
 		return nil, err
 	}
+	chunks := splitChunks(s, chunkSize)
 
 	if lbs == nil {
 		lbs.init()
@@ -247,13 +490,37 @@ func newSecretsObject(key string, rls *rspb.Release, lbs labels) (*v1.Secret, er
 	lbs.set("OWNER", owner)
 	lbs.set("STATUS", rspb.Status_Code_name[int32(rls.Info.Status.Code)])
 	lbs.set("VERSION", strconv.Itoa(int(rls.Version)))
+	var gen string
+	if len(chunks) > 1 {
+		if gen, err = newChunkGen(); err != nil {
+			return nil, err
+		}
+		lbs.set(chunksLabel, strconv.Itoa(len(chunks)))
+		lbs.set(chunkGenLabel, gen)
+	}
+	if keyID := encryptionKeyID(s); keyID != "" {
+		lbs.set(KeyIDLabel, keyID)
+	}
+	if digest := chartDigestOf(rls); digest != "" {
+		lbs.set(ChartDigestLabel, digest)
+	}
 
-	// create and return secret object
-	return &v1.Secret{
+	// create and return secret objects
+	objs := []*v1.Secret{{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:   key,
 			Labels: lbs.toMap(),
 		},
-		Data: map[string][]byte{"release": []byte(s)},
-	}, nil
+		Data: map[string][]byte{"release": []byte(chunks[0])},
+	}}
+	for i := 1; i < len(chunks); i++ {
+		objs = append(objs, &v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   chunkName(key, gen, i),
+				Labels: newChunkLabels(rls.Name, rls.Version, i).toMap(),
+			},
+			Data: map[string][]byte{"release": []byte(chunks[i])},
+		})
+	}
+	return objs, nil
 }
@@ -15,6 +15,7 @@ package driver
 
 import (
 	"encoding/base64"
+	"errors"
 	"reflect"
 	"testing"
 
@@ -184,3 +185,132 @@ func TestSecretUpdate(t *testing.T) {
 		t.Errorf("Expected status %s, got status %s", rel.Info.Status.Code, got.Info.Status.Code)
 	}
 }
+
+func TestSecretChunked(t *testing.T) {
+	secrets := newTestFixtureSecrets(t)
+	secrets.chunkSize = 1024
+	mock := secrets.impl.(*MockSecretsInterface)
+
+	rel := largeReleaseStub("smug-pigeon", 1, "default", rspb.Status_DEPLOYED)
+	key := testKey(rel.Name, rel.Version)
+
+	// store the release across several objects
+	if err := secrets.Create(key, rel); err != nil {
+		t.Fatalf("Failed to create release with key %q: %s", key, err)
+	}
+	if len(mock.objects) < 2 {
+		t.Fatalf("Expected release to be split, got %d objects", len(mock.objects))
+	}
+	name := chunkName(key, mock.objects[key].Labels[chunkGenLabel], 1)
+	if _, ok := mock.objects[name]; !ok {
+		t.Errorf("Expected chunk %q to exist", name)
+	}
+
+	// get the release back
+	got, err := secrets.Get(key)
+	if err != nil {
+		t.Fatalf("Failed to get release with key %q: %s", key, err)
+	}
+	if !reflect.DeepEqual(rel, got) {
+		t.Errorf("Expected reassembled release to equal the original")
+	}
+
+	// chunks are not listed as releases
+	ls, err := secrets.List(func(*rspb.Release) bool { return true })
+	if err != nil {
+		t.Fatalf("Failed to list releases: %s", err)
+	}
+	if len(ls) != 1 || !reflect.DeepEqual(rel, ls[0]) {
+		t.Errorf("Expected 1 reassembled release, got %d", len(ls))
+	}
+	ls, err = secrets.Query(map[string]string{"NAME": rel.Name, "OWNER": "TILLER"})
+	if err != nil {
+		t.Fatalf("Failed to query releases: %s", err)
+	}
+	if len(ls) != 1 || !reflect.DeepEqual(rel, ls[0]) {
+		t.Errorf("Expected 1 reassembled release, got %d", len(ls))
+	}
+
+	// shrinking the release removes the chunks it no longer needs
+	small := releaseStub(rel.Name, rel.Version, rel.Namespace, rspb.Status_SUPERSEDED)
+	if err := secrets.Update(key, small); err != nil {
+		t.Fatalf("Failed to update release: %s", err)
+	}
+	if len(mock.objects) != 1 {
+		t.Errorf("Expected stale chunks to be deleted, got %d objects", len(mock.objects))
+	}
+
+	// growing it again splits it again, and deleting it removes every chunk
+	if err := secrets.Update(key, rel); err != nil {
+		t.Fatalf("Failed to update release: %s", err)
+	}
+	if len(mock.objects) < 2 {
+		t.Errorf("Expected release to be split, got %d objects", len(mock.objects))
+	}
+	if _, err := secrets.Delete(key); err != nil {
+		t.Fatalf("Failed to delete release: %s", err)
+	}
+	if len(mock.objects) != 0 {
+		t.Errorf("Expected all chunks to be deleted, got %d objects", len(mock.objects))
+	}
+}
+
+// failSecretUpdates makes the updates of the Secret name fail.
+type failSecretUpdates struct {
+	*MockSecretsInterface
+	name string
+}
+
+func (mock *failSecretUpdates) Update(obj *v1.Secret) (*v1.Secret, error) {
+	if obj.Name == mock.name {
+		return nil, errors.New("update failed")
+	}
+	return mock.MockSecretsInterface.Update(obj)
+}
+
+func TestSecretChunkedUpdateFailure(t *testing.T) {
+	secrets := newTestFixtureSecrets(t)
+	secrets.chunkSize = 1024
+	mock := secrets.impl.(*MockSecretsInterface)
+
+	rel := largeReleaseStub("smug-pigeon", 1, "default", rspb.Status_DEPLOYED)
+	key := testKey(rel.Name, rel.Version)
+	if err := secrets.Create(key, rel); err != nil {
+		t.Fatalf("Failed to create release with key %q: %s", key, err)
+	}
+	objects := len(mock.objects)
+
+	// a failed update leaves the previous record and its chunks in place
+	secrets.impl = &failSecretUpdates{mock, key}
+	upd := largeReleaseStub(rel.Name, rel.Version, rel.Namespace, rspb.Status_SUPERSEDED)
+	upd.Manifest += "# updated"
+	if err := secrets.Update(key, upd); err == nil {
+		t.Fatalf("Expected update to fail")
+	}
+	if len(mock.objects) != objects {
+		t.Errorf("Expected the chunks of the failed update to be deleted, got %d objects", len(mock.objects))
+	}
+	got, err := secrets.Get(key)
+	if err != nil {
+		t.Fatalf("Failed to get release with key %q: %s", key, err)
+	}
+	if !reflect.DeepEqual(rel, got) {
+		t.Errorf("Expected the previous release to be preserved")
+	}
+
+	// a successful one replaces the chunks
+	secrets.impl = mock
+	if err := secrets.Update(key, upd); err != nil {
+		t.Fatalf("Failed to update release: %s", err)
+	}
+	got, err = secrets.Get(key)
+	if err != nil {
+		t.Fatalf("Failed to get release with key %q: %s", key, err)
+	}
+	if !reflect.DeepEqual(upd, got) {
+		t.Errorf("Expected the updated release")
+	}
+	if len(mock.objects) != objects {
+		t.Errorf("Expected the previous chunks to be deleted, got %d objects", len(mock.objects))
+	}
+}
@@ -0,0 +1,538 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package driver // import "k8s.io/helm/pkg/storage/driver"
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	// Register the postgres dialect with database/sql.
+	_ "github.com/lib/pq"
+
+	rspb "k8s.io/helm/pkg/proto/hapi/release"
+	storageerrors "k8s.io/helm/pkg/storage/errors"
+)
+
+var _ Driver = (*SQL)(nil)
+var _ Locker = (*SQL)(nil)
+var _ Labeler = (*SQL)(nil)
+
+// SQLDriverName is the string name of this driver.
+const SQLDriverName = "SQL"
+
+// sqlLabelColumns maps the labels used by the other drivers (see
+// newConfigMapsObject) to the indexed columns of the releases table.
+var sqlLabelColumns = map[string]string{
+	"NAME":        "name",
+	"VERSION":     "version",
+	"STATUS":      "status",
+	"OWNER":       "owner",
+	"CREATED_AT":  "created_at",
+	"MODIFIED_AT": "modified_at",
+
+	ChartDigestLabel: "chart_digest",
+}
+
+// sqlAddedColumns are the indexed columns added to the releases table since
+// its first version, with their definitions. They are added to older tables
+// when the schema is initialized.
+var sqlAddedColumns = []struct{ name, definition string }{
+	{"chart_digest", "VARCHAR(64) NOT NULL DEFAULT ''"},
+}
+
+// sqlSchema creates the releases table, its indices and the release_locks
+// table if they do not exist yet.
+// The statements are valid for both PostgreSQL and SQLite.
+var sqlSchema = []string{
+	`CREATE TABLE IF NOT EXISTS releases (
+		key VARCHAR(67) PRIMARY KEY,
+		body TEXT NOT NULL,
+		name VARCHAR(64) NOT NULL,
+		version INTEGER NOT NULL,
+		status VARCHAR(32) NOT NULL,
+		owner VARCHAR(32) NOT NULL,
+		created_at BIGINT NOT NULL,
+		modified_at BIGINT NOT NULL DEFAULT 0,
+		chart_digest VARCHAR(64) NOT NULL DEFAULT ''
+	)`,
+	`CREATE INDEX IF NOT EXISTS releases_name_idx ON releases (name)`,
+	`CREATE INDEX IF NOT EXISTS releases_version_idx ON releases (version)`,
+	`CREATE INDEX IF NOT EXISTS releases_status_idx ON releases (status)`,
+	`CREATE INDEX IF NOT EXISTS releases_owner_idx ON releases (owner)`,
+	`CREATE TABLE IF NOT EXISTS release_locks (
+		name VARCHAR(64) PRIMARY KEY,
+		id VARCHAR(64) NOT NULL,
+		holder VARCHAR(256) NOT NULL,
+		operation VARCHAR(32) NOT NULL,
+		acquired_at BIGINT NOT NULL,
+		expires_at BIGINT NOT NULL
+	)`,
+}
+
+// SQL is the sql storage driver implementation. Releases are stored in
+// a single "releases" table, with the labels used by the ConfigMaps and
+// Secrets drivers stored as indexed columns next to the encoded release.
+type SQL struct {
+	db  *sql.DB
+	Log func(string, ...interface{})
+
+	// Keys, if set, encrypts the releases stored. Encrypted releases can
+	// only be read with Keys set.
+	Keys KeyProvider
+}
+
+// NewSQL opens a connection to the database identified by dialect and
+// connectionString and creates the releases table if needed. The dialect
+// must name a database/sql driver registered in the binary, e.g. "postgres".
+func NewSQL(dialect, connectionString string) (*SQL, error) {
+	db, err := sql.Open(dialect, connectionString)
+	if err != nil {
+		return nil, err
+	}
+	if err := db.Ping(); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	s := &SQL{
+		db:  db,
+		Log: func(_ string, _ ...interface{}) {},
+	}
+	if err := s.ensureSchema(); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+// Name returns the name of the driver.
+func (s *SQL) Name() string {
+	return SQLDriverName
+}
+
+func (s *SQL) ensureSchema() error {
+	for _, stmt := range sqlSchema {
+		if _, err := s.db.Exec(stmt); err != nil {
+			return fmt.Errorf("sql: failed to initialize schema: %s", err)
+		}
+	}
+	for _, c := range sqlAddedColumns {
+		// selecting a missing column fails in every dialect
+		if _, err := s.db.Exec(fmt.Sprintf("SELECT %s FROM releases LIMIT 1", c.name)); err != nil {
+			if _, err := s.db.Exec(fmt.Sprintf("ALTER TABLE releases ADD COLUMN %s %s", c.name, c.definition)); err != nil {
+				return fmt.Errorf("sql: failed to add column %s: %s", c.name, err)
+			}
+		}
+		if _, err := s.db.Exec(fmt.Sprintf("CREATE INDEX IF NOT EXISTS releases_%s_idx ON releases (%s)", c.name, c.name)); err != nil {
+			return fmt.Errorf("sql: failed to initialize schema: %s", err)
+		}
+	}
+	return nil
+}
+
+// Get returns the release named by key or returns ErrReleaseNotFound.
+func (s *SQL) Get(key string) (*rspb.Release, error) {
+	var body string
+	err := s.db.QueryRow("SELECT body FROM releases WHERE key = $1", key).Scan(&body)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, storageerrors.ErrReleaseNotFound(key)
+		}
+
+		s.Log("get: failed to get %q: %s", key, err)
+		return nil, err
+	}
+
+	rls, err := decodeRelease(body, key, s.Keys)
+	if err != nil {
+		s.Log("get: failed to decode data %q: %s", key, err)
+		return nil, err
+	}
+	return rls, nil
+}
+
+// List returns the list of all releases owned by Tiller such that
+// filter(release) == true.
+func (s *SQL) List(filter func(*rspb.Release) bool) ([]*rspb.Release, error) {
+	rows, err := s.db.Query("SELECT key, body FROM releases WHERE owner = $1", "TILLER")
+	if err != nil {
+		s.Log("list: failed to list: %s", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []*rspb.Release
+	for rows.Next() {
+		var key, body string
+		if err := rows.Scan(&key, &body); err != nil {
+			s.Log("list: failed to scan row: %s", err)
+			return nil, err
+		}
+		rls, err := decodeRelease(body, key, s.Keys)
+		if err != nil {
+			s.Log("list: failed to decode release %q: %s", key, err)
+			continue
+		}
+		if filter(rls) {
+			results = append(results, rls)
+		}
+	}
+	return results, rows.Err()
+}
+
+// Query returns the set of releases that match the provided set of labels.
+// Only the labels stored as columns are supported; matching is done by the
+// database so only matching releases are decoded.
+func (s *SQL) Query(labels map[string]string) ([]*rspb.Release, error) {
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		if _, ok := sqlLabelColumns[k]; !ok {
+			return nil, fmt.Errorf("sql: unsupported label %q", k)
+		}
+		keys = append(keys, k)
+	}
+	// sort the keys so the generated query is deterministic
+	sort.Strings(keys)
+
+	var (
+		where []string
+		args  []interface{}
+	)
+	for i, k := range keys {
+		column := sqlLabelColumns[k]
+		where = append(where, fmt.Sprintf("%s = $%d", column, i+1))
+
+		switch column {
+		case "name", "status", "owner", "chart_digest":
+			args = append(args, labels[k])
+		default:
+			v, err := strconv.ParseInt(labels[k], 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid label value: %q: %s", labels[k], err)
+			}
+			args = append(args, v)
+		}
+	}
+
+	query := "SELECT key, body FROM releases"
+	if len(where) > 0 {
+		query += " WHERE " + strings.Join(where, " AND ")
+	}
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		s.Log("query: failed to query with labels: %s", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []*rspb.Release
+	for rows.Next() {
+		var key, body string
+		if err := rows.Scan(&key, &body); err != nil {
+			s.Log("query: failed to scan row: %s", err)
+			return nil, err
+		}
+		rls, err := decodeRelease(body, key, s.Keys)
+		if err != nil {
+			s.Log("query: failed to decode release %q: %s", key, err)
+			continue
+		}
+		results = append(results, rls)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(results) == 0 {
+		return nil, storageerrors.ErrReleaseNotFound(labels["NAME"])
+	}
+	return results, nil
+}
+
+// Create stores a new release or returns ErrReleaseExists.
+func (s *SQL) Create(key string, rls *rspb.Release) error {
+	return s.CreateLabeled(key, rls, nil)
+}
+
+// Labels returns the labels stored in the columns of the release named by
+// key or returns ErrReleaseNotFound. The KEY_ID label of encrypted releases
+// is read from the encoded release.
+func (s *SQL) Labels(key string) (map[string]string, error) {
+	var (
+		name, status, owner, body string
+		chartDigest               string
+		version                   int
+		createdAt, modifiedAt     int64
+	)
+	err := s.db.QueryRow(
+		"SELECT name, version, status, owner, created_at, modified_at, chart_digest, body FROM releases WHERE key = $1", key,
+	).Scan(&name, &version, &status, &owner, &createdAt, &modifiedAt, &chartDigest, &body)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, storageerrors.ErrReleaseNotFound(key)
+		}
+		return nil, err
+	}
+
+	lbs := map[string]string{
+		"NAME":       name,
+		"VERSION":    strconv.Itoa(version),
+		"STATUS":     status,
+		"OWNER":      owner,
+		"CREATED_AT": strconv.FormatInt(createdAt, 10),
+	}
+	if modifiedAt != 0 {
+		lbs["MODIFIED_AT"] = strconv.FormatInt(modifiedAt, 10)
+	}
+	if keyID := encryptionKeyID(body); keyID != "" {
+		lbs[KeyIDLabel] = keyID
+	}
+	if chartDigest != "" {
+		lbs[ChartDigestLabel] = chartDigest
+	}
+	return lbs, nil
+}
+
+// CreateLabeled stores a new release with the CREATED_AT and MODIFIED_AT
+// labels of lbs, or returns ErrReleaseExists. The releases table has no
+// column for other labels.
+func (s *SQL) CreateLabeled(key string, rls *rspb.Release, lbs map[string]string) error {
+	created := createdLabels(lbs)
+	createdAt, err := strconv.ParseInt(created.get("CREATED_AT"), 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid label value: %q: %s", created.get("CREATED_AT"), err)
+	}
+	var modifiedAt int64
+	if v := created.get("MODIFIED_AT"); v != "" {
+		if modifiedAt, err = strconv.ParseInt(v, 10, 64); err != nil {
+			return fmt.Errorf("invalid label value: %q: %s", v, err)
+		}
+	}
+
+	body, err := encodeRelease(rls, key, s.Keys)
+	if err != nil {
+		s.Log("create: failed to encode release %q: %s", rls.Name, err)
+		return err
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		s.Log("create: failed to begin transaction: %s", err)
+		return err
+	}
+	defer tx.Rollback()
+
+	var existing string
+	err = tx.QueryRow("SELECT key FROM releases WHERE key = $1", key).Scan(&existing)
+	switch {
+	case err == nil:
+		return storageerrors.ErrReleaseExists(key)
+	case err != sql.ErrNoRows:
+		s.Log("create: failed to check existence of %q: %s", key, err)
+		return err
+	}
+
+	_, err = tx.Exec(
+		"INSERT INTO releases (key, body, name, version, status, owner, created_at, modified_at, chart_digest) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)",
+		key,
+		body,
+		rls.Name,
+		int(rls.Version),
+		rspb.Status_Code_name[int32(rls.Info.Status.Code)],
+		"TILLER",
+		createdAt,
+		modifiedAt,
+		chartDigestOf(rls),
+	)
+	if err != nil {
+		s.Log("create: failed to insert %q: %s", key, err)
+		return err
+	}
+	return tx.Commit()
+}
+
+// Update updates a release or returns ErrReleaseNotFound.
+func (s *SQL) Update(key string, rls *rspb.Release) error {
+	body, err := encodeRelease(rls, key, s.Keys)
+	if err != nil {
+		s.Log("update: failed to encode release %q: %s", rls.Name, err)
+		return err
+	}
+
+	res, err := s.db.Exec(
+		"UPDATE releases SET body = $1, name = $2, version = $3, status = $4, modified_at = $5, chart_digest = $6 WHERE key = $7",
+		body,
+		rls.Name,
+		int(rls.Version),
+		rspb.Status_Code_name[int32(rls.Info.Status.Code)],
+		time.Now().Unix(),
+		chartDigestOf(rls),
+		key,
+	)
+	if err != nil {
+		s.Log("update: failed to update %q: %s", key, err)
+		return err
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return storageerrors.ErrReleaseNotFound(key)
+	}
+	return nil
+}
+
+// Delete deletes a release or returns ErrReleaseNotFound.
+func (s *SQL) Delete(key string) (*rspb.Release, error) {
+	rls, err := s.Get(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.db.Exec("DELETE FROM releases WHERE key = $1", key); err != nil {
+		s.Log("delete: failed to delete %q: %s", key, err)
+		return rls, err
+	}
+	return rls, nil
+}
+
+// AcquireLock locks the release name. An expired lock is taken over with an
+// update conditioned on the ID of the lock read, so that only one operation wins.
+func (s *SQL) AcquireLock(name string, lock *Lock) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		s.Log("lock: failed to begin transaction: %s", err)
+		return err
+	}
+	defer tx.Rollback()
+
+	held, err := scanLock(tx.QueryRow("SELECT id, holder, operation, acquired_at, expires_at FROM release_locks WHERE name = $1", name))
+	switch {
+	case err == sql.ErrNoRows:
+		_, err = tx.Exec(
+			"INSERT INTO release_locks (name, id, holder, operation, acquired_at, expires_at) VALUES ($1, $2, $3, $4, $5, $6)",
+			name,
+			lock.ID,
+			lock.Holder,
+			lock.Operation,
+			lock.Acquired.UnixNano(),
+			lock.Expires.UnixNano(),
+		)
+		if err != nil {
+			tx.Rollback()
+			if _, gerr := s.GetLock(name); gerr == nil {
+				// another operation locked the release meanwhile
+				return s.AcquireLock(name, lock)
+			}
+			s.Log("lock: failed to insert lock %q: %s", name, err)
+			return err
+		}
+	case err != nil:
+		s.Log("lock: failed to get lock %q: %s", name, err)
+		return err
+	default:
+		if err := lockConflict(name, lock, held); err != nil {
+			return err
+		}
+		res, err := tx.Exec(
+			"UPDATE release_locks SET id = $1, holder = $2, operation = $3, acquired_at = $4, expires_at = $5 WHERE name = $6 AND id = $7",
+			lock.ID,
+			lock.Holder,
+			lock.Operation,
+			lock.Acquired.UnixNano(),
+			lock.Expires.UnixNano(),
+			name,
+			held.ID,
+		)
+		if err != nil {
+			s.Log("lock: failed to update lock %q: %s", name, err)
+			return err
+		}
+		if n, err := res.RowsAffected(); err == nil && n == 0 {
+			// the lock changed since we read it, try again against its new holder
+			tx.Rollback()
+			return s.AcquireLock(name, lock)
+		}
+	}
+	return tx.Commit()
+}
+
+// RenewLock extends the expiry of the lock on the release name if it is still
+// held with the ID of lock, so that a lock cleared meanwhile is not recreated.
+func (s *SQL) RenewLock(name string, lock *Lock) error {
+	res, err := s.db.Exec("UPDATE release_locks SET expires_at = $1 WHERE name = $2 AND id = $3", lock.Expires.UnixNano(), name, lock.ID)
+	if err != nil {
+		s.Log("lock: failed to renew lock %q: %s", name, err)
+		return err
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		held, err := s.GetLock(name)
+		if err != nil {
+			return err
+		}
+		return storageerrors.ErrReleaseLocked(name, held.String())
+	}
+	return nil
+}
+
+// ReleaseLock deletes the lock held on the release name.
+func (s *SQL) ReleaseLock(name, id string) (*Lock, error) {
+	held, err := s.GetLock(name)
+	if err != nil {
+		return nil, err
+	}
+	if id != "" && held.ID != id {
+		return nil, storageerrors.ErrReleaseLocked(name, held.String())
+	}
+	res, err := s.db.Exec("DELETE FROM release_locks WHERE name = $1 AND id = $2", name, held.ID)
+	if err != nil {
+		s.Log("unlock: failed to delete lock %q: %s", name, err)
+		return nil, err
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return nil, storageerrors.ErrLockNotFound(name)
+	}
+	return held, nil
+}
+
+// GetLock returns the lock held on the release name or ErrLockNotFound.
+func (s *SQL) GetLock(name string) (*Lock, error) {
+	held, err := scanLock(s.db.QueryRow("SELECT id, holder, operation, acquired_at, expires_at FROM release_locks WHERE name = $1", name))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, storageerrors.ErrLockNotFound(name)
+		}
+		s.Log("lock: failed to get lock %q: %s", name, err)
+		return nil, err
+	}
+	return held, nil
+}
+
+func scanLock(row *sql.Row) (*Lock, error) {
+	var (
+		lock              Lock
+		acquired, expires int64
+	)
+	if err := row.Scan(&lock.ID, &lock.Holder, &lock.Operation, &acquired, &expires); err != nil {
+		return nil, err
+	}
+	lock.Acquired = time.Unix(0, acquired)
+	lock.Expires = time.Unix(0, expires)
+	return &lock, nil
+}
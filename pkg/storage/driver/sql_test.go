@@ -0,0 +1,257 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package driver
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	// Register the sqlite3 dialect with database/sql.
+	_ "github.com/mattn/go-sqlite3"
+
+	rspb "k8s.io/helm/pkg/proto/hapi/release"
+)
+
+// newTestFixtureSQL initializes a SQL driver backed by a temporary SQLite
+// database holding the provided releases.
+func newTestFixtureSQL(t *testing.T, releases ...*rspb.Release) (*SQL, func()) {
+	dir, err := ioutil.TempDir("", "helm-sql-driver")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := NewSQL("sqlite3", filepath.Join(dir, "releases.db"))
+	if err != nil {
+		os.RemoveAll(dir)
+		t.Fatalf("Failed to initialize sql driver: %s", err)
+	}
+	for _, rls := range releases {
+		if err := s.Create(testKey(rls.Name, rls.Version), rls); err != nil {
+			t.Fatalf("Test setup failed to create: %s", err)
+		}
+	}
+	return s, func() {
+		s.db.Close()
+		os.RemoveAll(dir)
+	}
+}
+
+func TestSQLAddedColumns(t *testing.T) {
+	dir, err := ioutil.TempDir("", "helm-sql-driver")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "releases.db")
+
+	// a table created before the chart_digest column
+	s, err := NewSQL("sqlite3", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, stmt := range []string{"DROP TABLE releases", strings.Replace(sqlSchema[0], ",\n\t\tchart_digest VARCHAR(64) NOT NULL DEFAULT ''", "", 1)} {
+		if _, err := s.db.Exec(stmt); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := s.db.Exec("SELECT chart_digest FROM releases"); err == nil {
+		t.Fatal("Expected the old table to lack the chart_digest column")
+	}
+	s.db.Close()
+
+	s, err = NewSQL("sqlite3", path)
+	if err != nil {
+		t.Fatalf("Failed to initialize sql driver: %s", err)
+	}
+	defer s.db.Close()
+	rls := releaseStub("smug-pigeon", 1, "default", rspb.Status_DEPLOYED)
+	if err := s.Create(testKey(rls.Name, rls.Version), rls); err != nil {
+		t.Fatalf("Failed to create release: %s", err)
+	}
+	if _, err := s.Query(map[string]string{ChartDigestLabel: "", "NAME": rls.Name}); err != nil {
+		t.Errorf("Failed to query the added column: %s", err)
+	}
+}
+
+func TestSQLName(t *testing.T) {
+	s, cleanup := newTestFixtureSQL(t)
+	defer cleanup()
+
+	if s.Name() != SQLDriverName {
+		t.Errorf("Expected name to be %q, got %q", SQLDriverName, s.Name())
+	}
+}
+
+func TestSQLGet(t *testing.T) {
+	rel := releaseStub("smug-pigeon", 1, "default", rspb.Status_DEPLOYED)
+	key := testKey(rel.Name, rel.Version)
+
+	s, cleanup := newTestFixtureSQL(t, rel)
+	defer cleanup()
+
+	got, err := s.Get(key)
+	if err != nil {
+		t.Fatalf("Failed to get release: %s", err)
+	}
+	if !reflect.DeepEqual(rel, got) {
+		t.Errorf("Expected {%q}, got {%q}", rel, got)
+	}
+
+	if _, err := s.Get(testKey(rel.Name, 2)); err == nil {
+		t.Errorf("Expected error getting a missing release")
+	}
+}
+
+func TestSQLList(t *testing.T) {
+	s, cleanup := newTestFixtureSQL(t, []*rspb.Release{
+		releaseStub("key-1", 1, "default", rspb.Status_DELETED),
+		releaseStub("key-2", 1, "default", rspb.Status_DELETED),
+		releaseStub("key-3", 1, "default", rspb.Status_DEPLOYED),
+		releaseStub("key-4", 1, "default", rspb.Status_DEPLOYED),
+		releaseStub("key-5", 1, "default", rspb.Status_SUPERSEDED),
+		releaseStub("key-6", 1, "default", rspb.Status_SUPERSEDED),
+	}...)
+	defer cleanup()
+
+	for _, code := range []rspb.Status_Code{rspb.Status_DELETED, rspb.Status_DEPLOYED, rspb.Status_SUPERSEDED} {
+		ls, err := s.List(func(rel *rspb.Release) bool {
+			return rel.Info.Status.Code == code
+		})
+		if err != nil {
+			t.Errorf("Failed to list %s: %s", code, err)
+		}
+		if len(ls) != 2 {
+			t.Errorf("Expected 2 %s, got %d", code, len(ls))
+		}
+	}
+}
+
+func TestSQLQuery(t *testing.T) {
+	s, cleanup := newTestFixtureSQL(t, []*rspb.Release{
+		releaseStub("rls-a", 1, "default", rspb.Status_SUPERSEDED),
+		releaseStub("rls-a", 2, "default", rspb.Status_DEPLOYED),
+		releaseStub("rls-b", 1, "default", rspb.Status_DEPLOYED),
+	}...)
+	defer cleanup()
+
+	var tests = []struct {
+		desc   string
+		labels map[string]string
+		count  int
+	}{
+		{"by name", map[string]string{"NAME": "rls-a", "OWNER": "TILLER"}, 2},
+		{"by status", map[string]string{"STATUS": "DEPLOYED", "OWNER": "TILLER"}, 2},
+		{"by name and version", map[string]string{"NAME": "rls-a", "VERSION": "1"}, 1},
+	}
+	for _, tt := range tests {
+		ls, err := s.Query(tt.labels)
+		if err != nil {
+			t.Errorf("%s: failed to query: %s", tt.desc, err)
+			continue
+		}
+		if len(ls) != tt.count {
+			t.Errorf("%s: expected %d releases, got %d", tt.desc, tt.count, len(ls))
+		}
+	}
+
+	if _, err := s.Query(map[string]string{"NAME": "rls-c"}); err == nil {
+		t.Errorf("Expected error querying a missing release")
+	}
+	if _, err := s.Query(map[string]string{"UNKNOWN": "foo"}); err == nil {
+		t.Errorf("Expected error querying an unknown label")
+	}
+}
+
+func TestSQLCreate(t *testing.T) {
+	s, cleanup := newTestFixtureSQL(t)
+	defer cleanup()
+
+	rel := releaseStub("smug-pigeon", 1, "default", rspb.Status_DEPLOYED)
+	key := testKey(rel.Name, rel.Version)
+
+	if err := s.Create(key, rel); err != nil {
+		t.Fatalf("Failed to create release with key %q: %s", key, err)
+	}
+	if err := s.Create(key, rel); err == nil {
+		t.Errorf("Expected error creating an existing release")
+	}
+
+	got, err := s.Get(key)
+	if err != nil {
+		t.Fatalf("Failed to get release with key %q: %s", key, err)
+	}
+	if !reflect.DeepEqual(rel, got) {
+		t.Errorf("Expected {%q}, got {%q}", rel, got)
+	}
+}
+
+func TestSQLUpdate(t *testing.T) {
+	rel := releaseStub("smug-pigeon", 1, "default", rspb.Status_DEPLOYED)
+	key := testKey(rel.Name, rel.Version)
+
+	s, cleanup := newTestFixtureSQL(t, rel)
+	defer cleanup()
+
+	rel.Info.Status.Code = rspb.Status_SUPERSEDED
+	if err := s.Update(key, rel); err != nil {
+		t.Fatalf("Failed to update release: %s", err)
+	}
+
+	got, err := s.Get(key)
+	if err != nil {
+		t.Fatalf("Failed to get release with key %q: %s", key, err)
+	}
+	if rel.Info.Status.Code != got.Info.Status.Code {
+		t.Errorf("Expected status %s, got status %s", rel.Info.Status.Code, got.Info.Status.Code)
+	}
+
+	// the status column must follow the release
+	ls, err := s.Query(map[string]string{"NAME": rel.Name, "STATUS": "SUPERSEDED"})
+	if err != nil || len(ls) != 1 {
+		t.Errorf("Expected 1 superseded release, got %d (%v)", len(ls), err)
+	}
+
+	if err := s.Update(testKey(rel.Name, 2), rel); err == nil {
+		t.Errorf("Expected error updating a missing release")
+	}
+}
+
+func TestSQLDelete(t *testing.T) {
+	rel := releaseStub("smug-pigeon", 1, "default", rspb.Status_DEPLOYED)
+	key := testKey(rel.Name, rel.Version)
+
+	s, cleanup := newTestFixtureSQL(t, rel)
+	defer cleanup()
+
+	got, err := s.Delete(key)
+	if err != nil {
+		t.Fatalf("Failed to delete release with key %q: %s", key, err)
+	}
+	if !reflect.DeepEqual(rel, got) {
+		t.Errorf("Expected {%q}, got {%q}", rel, got)
+	}
+	if _, err := s.Get(key); err == nil {
+		t.Errorf("Expected error getting a deleted release")
+	}
+	if _, err := s.Delete(key); err == nil {
+		t.Errorf("Expected error deleting a missing release")
+	}
+}
@@ -32,7 +32,9 @@ var magicGzip = []byte{0x1f, 0x8b, 0x08}
 
 // encodeRelease encodes a release returning a base64 encoded
 // gzipped binary protobuf encoding representation, or error.
-func encodeRelease(rls *rspb.Release) (string, error) {
+// The gzipped encoding is encrypted if keys is not nil, for the
+// record stored under key only.
+func encodeRelease(rls *rspb.Release, key string, keys KeyProvider) (string, error) {
 	b, err := proto.Marshal(rls)
 	if err != nil {
 		return "", err
@@ -47,20 +49,34 @@ func encodeRelease(rls *rspb.Release) (string, error) {
 	}
 	w.Close()
 
-	return b64.EncodeToString(buf.Bytes()), nil
+	b = buf.Bytes()
+	if keys != nil {
+		if b, err = encrypt(b, key, keys); err != nil {
+			return "", err
+		}
+	}
+	return b64.EncodeToString(b), nil
 }
 
 // decodeRelease decodes the bytes in data into a release
 // type. Data must contain a base64 encoded string of a
 // valid protobuf encoding of a release, otherwise
-// an error is returned.
-func decodeRelease(data string) (*rspb.Release, error) {
+// an error is returned. Encrypted releases are decrypted
+// with keys, and must have been encrypted for the record
+// stored under key.
+func decodeRelease(data string, key string, keys KeyProvider) (*rspb.Release, error) {
 	// base64 decode string
 	b, err := b64.DecodeString(data)
 	if err != nil {
 		return nil, err
 	}
 
+	if isEncrypted(b) {
+		if b, err = decrypt(b, key, keys); err != nil {
+			return nil, err
+		}
+	}
+
 	// For backwards compatibility with releases that were stored before
 	// compression was introduced we skip decompression if the
 	// gzip magic header is not found
@@ -24,4 +24,8 @@ var (
 	ErrReleaseExists = func(release string) error { return fmt.Errorf("release: %q already exists", release) }
 	// ErrInvalidKey indicates that a release key could not be parsed.
 	ErrInvalidKey = func(release string) error { return fmt.Errorf("release: %q invalid key", release) }
+	// ErrReleaseLocked indicates that a release is locked by another operation.
+	ErrReleaseLocked = func(release, holder string) error { return fmt.Errorf("release: %q is locked by %s", release, holder) }
+	// ErrLockNotFound indicates that a release is not locked.
+	ErrLockNotFound = func(release string) error { return fmt.Errorf("release: %q is not locked", release) }
 )
@@ -0,0 +1,178 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage // import "k8s.io/helm/pkg/storage"
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/golang/protobuf/proto"
+
+	rspb "k8s.io/helm/pkg/proto/hapi/release"
+	"k8s.io/helm/pkg/storage/driver"
+	storageerrors "k8s.io/helm/pkg/storage/errors"
+)
+
+// MigrateOptions configures a migration between storage drivers.
+type MigrateOptions struct {
+	// DryRun reports the records that would be copied and deleted without
+	// writing anything.
+	DryRun bool
+	// DeleteSource deletes the records from the source driver once every
+	// record has been copied and verified.
+	DeleteSource bool
+
+	Log func(string, ...interface{})
+}
+
+// MigrateResult lists the keys of the records handled by a migration.
+type MigrateResult struct {
+	// Copied are the records copied to the destination driver.
+	Copied []string
+	// Skipped are the records the destination driver already held, copied
+	// by an earlier migration that was interrupted.
+	Skipped []string
+	// Deleted are the records deleted from the source driver.
+	Deleted []string
+}
+
+// Migrate copies every revision of every release stored by the driver from
+// to the driver to, and verifies that the copies read back equal to the
+// originals. Records are copied with their labels when both drivers store
+// labels, see driver.Labeler.
+//
+// Records the destination already holds unchanged are skipped, so that a
+// migration interrupted midway can be run again to resume it. A record that
+// differs in the destination fails the migration before anything is deleted.
+func Migrate(from, to driver.Driver, opts MigrateOptions) (*MigrateResult, error) {
+	log := opts.Log
+	if log == nil {
+		log = func(_ string, _ ...interface{}) {}
+	}
+
+	rels, err := from.List(func(*rspb.Release) bool { return true })
+	if err != nil {
+		return nil, fmt.Errorf("cannot list the releases of the %s driver: %s", from.Name(), err)
+	}
+	sort.Slice(rels, func(i, j int) bool {
+		if rels[i].Name != rels[j].Name {
+			return rels[i].Name < rels[j].Name
+		}
+		return rels[i].Version < rels[j].Version
+	})
+
+	result := &MigrateResult{}
+	for _, rel := range rels {
+		key := makeKey(rel.Name, rel.Version)
+		lbs, err := recordLabels(from, key)
+		if err != nil {
+			return result, fmt.Errorf("cannot get the labels of %s in the %s driver: %s", key, from.Name(), err)
+		}
+
+		_, err = to.Get(key)
+		switch {
+		case err == nil:
+			if err := verifyRecord(to, key, rel, lbs); err != nil {
+				return result, fmt.Errorf("%s already exists in the %s driver and differs: %s", key, to.Name(), err)
+			}
+			log("skipping %s: already migrated", key)
+			result.Skipped = append(result.Skipped, key)
+			continue
+		case err.Error() != storageerrors.ErrReleaseNotFound(key).Error():
+			return result, fmt.Errorf("cannot get %s from the %s driver: %s", key, to.Name(), err)
+		}
+
+		if opts.DryRun {
+			log("would copy %s", key)
+			result.Copied = append(result.Copied, key)
+			continue
+		}
+		if err := createRecord(to, key, rel, lbs); err != nil {
+			return result, fmt.Errorf("cannot copy %s to the %s driver: %s", key, to.Name(), err)
+		}
+		if err := verifyRecord(to, key, rel, lbs); err != nil {
+			return result, fmt.Errorf("%s was not copied to the %s driver correctly: %s", key, to.Name(), err)
+		}
+		log("copied %s", key)
+		result.Copied = append(result.Copied, key)
+	}
+
+	if !opts.DeleteSource {
+		return result, nil
+	}
+	for _, rel := range rels {
+		key := makeKey(rel.Name, rel.Version)
+		if opts.DryRun {
+			log("would delete %s", key)
+		} else {
+			if _, err := from.Delete(key); err != nil {
+				return result, fmt.Errorf("cannot delete %s from the %s driver: %s", key, from.Name(), err)
+			}
+			log("deleted %s", key)
+		}
+		result.Deleted = append(result.Deleted, key)
+	}
+	return result, nil
+}
+
+// recordLabels returns the labels of the record key if d stores labels.
+func recordLabels(d driver.Driver, key string) (map[string]string, error) {
+	l, ok := d.(driver.Labeler)
+	if !ok {
+		return nil, nil
+	}
+	return l.Labels(key)
+}
+
+// createRecord stores rel under key in d, labeled with lbs if d stores labels.
+func createRecord(d driver.Driver, key string, rel *rspb.Release, lbs map[string]string) error {
+	if l, ok := d.(driver.Labeler); ok && lbs != nil {
+		return l.CreateLabeled(key, rel, lbs)
+	}
+	return d.Create(key, rel)
+}
+
+// verifyRecord checks that the record key of d holds rel and, if d stores
+// labels, every label of lbs but the ID of the encryption key, which depends
+// on d.
+func verifyRecord(d driver.Driver, key string, rel *rspb.Release, lbs map[string]string) error {
+	got, err := d.Get(key)
+	if err != nil {
+		return err
+	}
+	if !proto.Equal(got, rel) {
+		return errors.New("the release read back differs")
+	}
+	gotLabels, err := recordLabels(d, key)
+	if err != nil || gotLabels == nil {
+		return err
+	}
+	var keys []string
+	for k := range lbs {
+		if k != driver.KeyIDLabel && k != driver.ChartDigestLabel {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if gotLabels[k] != lbs[k] {
+			return fmt.Errorf("label %s is %q instead of %q", k, gotLabels[k], lbs[k])
+		}
+	}
+	return nil
+}
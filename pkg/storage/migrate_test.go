@@ -0,0 +1,87 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage // import "k8s.io/helm/pkg/storage"
+
+import (
+	"reflect"
+	"testing"
+
+	rspb "k8s.io/helm/pkg/proto/hapi/release"
+	"k8s.io/helm/pkg/storage/driver"
+)
+
+func TestMigrate(t *testing.T) {
+	from := driver.NewMemory()
+	for _, rls := range []*rspb.Release{
+		ReleaseTestData{Name: "happy-panda", Version: 2, Status: rspb.Status_DEPLOYED}.ToRelease(),
+		ReleaseTestData{Name: "angry-beaver", Version: 1, Status: rspb.Status_DEPLOYED}.ToRelease(),
+		ReleaseTestData{Name: "happy-panda", Version: 1, Status: rspb.Status_SUPERSEDED}.ToRelease(),
+	} {
+		key := makeKey(rls.Name, rls.Version)
+		assertErrNil(t.Fatal, from.CreateLabeled(key, rls, map[string]string{"CREATED_AT": "1550000000"}), "CreateLabeled")
+	}
+	all := []string{"angry-beaver.v1", "happy-panda.v1", "happy-panda.v2"}
+
+	// A dry run copies nothing.
+	to := driver.NewMemory()
+	res, err := Migrate(from, to, MigrateOptions{DryRun: true, DeleteSource: true})
+	assertErrNil(t.Fatal, err, "Migrate")
+	if !reflect.DeepEqual(res.Copied, all) || !reflect.DeepEqual(res.Deleted, all) {
+		t.Errorf("Expected a dry run to report copying and deleting %v, got %+v", all, res)
+	}
+	if rels, _ := to.List(func(*rspb.Release) bool { return true }); len(rels) != 0 {
+		t.Errorf("Expected a dry run not to copy anything, got %d releases", len(rels))
+	}
+
+	// An interrupted migration is resumed.
+	rls, err := from.Get("angry-beaver.v1")
+	assertErrNil(t.Fatal, err, "Get")
+	assertErrNil(t.Fatal, to.CreateLabeled("angry-beaver.v1", rls, map[string]string{"CREATED_AT": "1550000000"}), "CreateLabeled")
+
+	res, err = Migrate(from, to, MigrateOptions{})
+	assertErrNil(t.Fatal, err, "Migrate")
+	if !reflect.DeepEqual(res.Copied, all[1:]) || !reflect.DeepEqual(res.Skipped, all[:1]) || len(res.Deleted) != 0 {
+		t.Errorf("Expected %v to be copied and %v skipped, got %+v", all[1:], all[:1], res)
+	}
+	for _, key := range all {
+		lbs, err := to.Labels(key)
+		assertErrNil(t.Fatal, err, "Labels")
+		if lbs["CREATED_AT"] != "1550000000" {
+			t.Errorf("Expected the labels of %s to be kept, got %v", key, lbs)
+		}
+	}
+
+	// Migrating again deletes the source records once they are all verified.
+	res, err = Migrate(from, to, MigrateOptions{DeleteSource: true})
+	assertErrNil(t.Fatal, err, "Migrate")
+	if !reflect.DeepEqual(res.Skipped, all) || !reflect.DeepEqual(res.Deleted, all) {
+		t.Errorf("Expected %v to be skipped and deleted, got %+v", all, res)
+	}
+	if rels, _ := from.List(func(*rspb.Release) bool { return true }); len(rels) != 0 {
+		t.Errorf("Expected the source records to be deleted, got %d releases", len(rels))
+	}
+
+	// Migrating back fails on records that differ.
+	changed := ReleaseTestData{Name: "happy-panda", Version: 1, Status: rspb.Status_DELETED}.ToRelease()
+	assertErrNil(t.Fatal, from.Create("happy-panda.v1", changed), "Create")
+	if _, err := Migrate(to, from, MigrateOptions{DeleteSource: true}); err == nil {
+		t.Error("Expected an error migrating to a record that differs")
+	}
+	if _, err := to.Get("happy-panda.v1"); err != nil {
+		t.Errorf("Expected no record to be deleted after a failed migration, got %s", err)
+	}
+}
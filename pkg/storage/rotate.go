@@ -0,0 +1,76 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage // import "k8s.io/helm/pkg/storage"
+
+import (
+	"fmt"
+	"sort"
+
+	rspb "k8s.io/helm/pkg/proto/hapi/release"
+	"k8s.io/helm/pkg/storage/driver"
+)
+
+// RotateKeys re-encrypts with the key keyID the records of d encrypted with
+// another key, or not encrypted, and returns their keys. The driver must
+// store labels and encrypt the records it stores with the key keyID.
+func RotateKeys(d driver.Driver, keyID string, log func(string, ...interface{})) ([]string, error) {
+	if log == nil {
+		log = func(_ string, _ ...interface{}) {}
+	}
+	// The records of a deduplicating driver, charts included, are re-encrypted
+	// as stored, through the driver it wraps.
+	if dedup, ok := d.(*driver.Dedup); ok {
+		d = dedup.Unwrap()
+	}
+	l, ok := d.(driver.Labeler)
+	if !ok {
+		return nil, fmt.Errorf("the %s driver does not store the keys of its records", d.Name())
+	}
+
+	rels, err := d.List(func(*rspb.Release) bool { return true })
+	if err != nil {
+		return nil, fmt.Errorf("cannot list the releases of the %s driver: %s", d.Name(), err)
+	}
+	sort.Slice(rels, func(i, j int) bool {
+		if rels[i].Name != rels[j].Name {
+			return rels[i].Name < rels[j].Name
+		}
+		return rels[i].Version < rels[j].Version
+	})
+
+	var rotated []string
+	for _, rel := range rels {
+		key := makeKey(rel.Name, rel.Version)
+		lbs, err := l.Labels(key)
+		if err != nil {
+			return rotated, fmt.Errorf("cannot get the labels of %s: %s", key, err)
+		}
+		if lbs[driver.KeyIDLabel] == keyID {
+			continue
+		}
+		if err := d.Update(key, rel); err != nil {
+			return rotated, fmt.Errorf("cannot re-encrypt %s: %s", key, err)
+		}
+		if lbs[driver.KeyIDLabel] == "" {
+			log("encrypted %s with key %s", key, keyID)
+		} else {
+			log("re-encrypted %s from key %s to key %s", key, lbs[driver.KeyIDLabel], keyID)
+		}
+		rotated = append(rotated, key)
+	}
+	return rotated, nil
+}
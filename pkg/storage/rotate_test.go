@@ -0,0 +1,104 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage // import "k8s.io/helm/pkg/storage"
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/helm/pkg/proto/hapi/chart"
+	rspb "k8s.io/helm/pkg/proto/hapi/release"
+	"k8s.io/helm/pkg/storage/driver"
+)
+
+// keyLabeler is a Memory driver recording the key IDs of its records, as the
+// drivers encrypting them do.
+type keyLabeler struct {
+	*driver.Memory
+	keyID   string
+	keyIDs  map[string]string
+	updated []string
+}
+
+func (d *keyLabeler) Labels(key string) (map[string]string, error) {
+	lbs, err := d.Memory.Labels(key)
+	if err != nil {
+		return nil, err
+	}
+	if id := d.keyIDs[key]; id != "" {
+		lbs[driver.KeyIDLabel] = id
+	}
+	return lbs, nil
+}
+
+func (d *keyLabeler) Update(key string, rls *rspb.Release) error {
+	d.keyIDs[key] = d.keyID
+	d.updated = append(d.updated, key)
+	return d.Memory.Update(key, rls)
+}
+
+func TestRotateKeys(t *testing.T) {
+	d := &keyLabeler{Memory: driver.NewMemory(), keyID: "key-2", keyIDs: map[string]string{
+		"angry-beaver.v1": "key-1",
+		"happy-panda.v2":  "key-2",
+	}}
+	for _, rls := range []*rspb.Release{
+		ReleaseTestData{Name: "happy-panda", Version: 2, Status: rspb.Status_DEPLOYED}.ToRelease(),
+		ReleaseTestData{Name: "angry-beaver", Version: 1, Status: rspb.Status_DEPLOYED}.ToRelease(),
+		ReleaseTestData{Name: "happy-panda", Version: 1, Status: rspb.Status_SUPERSEDED}.ToRelease(),
+	} {
+		assertErrNil(t.Fatal, d.Create(makeKey(rls.Name, rls.Version), rls), "Create")
+	}
+
+	rotated, err := RotateKeys(d, "key-2", nil)
+	assertErrNil(t.Fatal, err, "RotateKeys")
+	expected := []string{"angry-beaver.v1", "happy-panda.v1"}
+	if !reflect.DeepEqual(rotated, expected) || !reflect.DeepEqual(d.updated, expected) {
+		t.Errorf("Expected %v to be re-encrypted, got %v", expected, rotated)
+	}
+
+	// Rotating again has nothing to do.
+	rotated, err = RotateKeys(d, "key-2", nil)
+	assertErrNil(t.Fatal, err, "RotateKeys")
+	if len(rotated) != 0 {
+		t.Errorf("Expected nothing to be re-encrypted, got %v", rotated)
+	}
+}
+
+func TestRotateKeys_Dedup(t *testing.T) {
+	d := &keyLabeler{Memory: driver.NewMemory(), keyID: "key-2", keyIDs: map[string]string{}}
+	dedup := driver.NewDedup(d)
+	rls := ReleaseTestData{Name: "happy-panda", Version: 1, Status: rspb.Status_DEPLOYED}.ToRelease()
+	rls.Chart = &chart.Chart{Metadata: &chart.Metadata{Name: "hello", Version: "0.1.0"}}
+	assertErrNil(t.Fatal, dedup.Create(makeKey(rls.Name, rls.Version), rls), "Create")
+
+	rotated, err := RotateKeys(dedup, "key-2", nil)
+	assertErrNil(t.Fatal, err, "RotateKeys")
+	if len(rotated) != 2 {
+		t.Fatalf("Expected the revision and its chart record to be re-encrypted, got %v", rotated)
+	}
+	for _, key := range rotated {
+		lbs, err := d.Labels(key)
+		assertErrNil(t.Fatal, err, "Labels")
+		if lbs[driver.KeyIDLabel] != "key-2" {
+			t.Errorf("Expected %s to be encrypted with key-2, got %q", key, lbs[driver.KeyIDLabel])
+		}
+	}
+	if _, err := dedup.Get(makeKey(rls.Name, rls.Version)); err != nil {
+		t.Errorf("Expected the rotated release to be read back: %s", err)
+	}
+}
@@ -23,6 +23,7 @@ import (
 	rspb "k8s.io/helm/pkg/proto/hapi/release"
 	relutil "k8s.io/helm/pkg/releaseutil"
 	"k8s.io/helm/pkg/storage/driver"
+	storageerrors "k8s.io/helm/pkg/storage/errors"
 )
 
 // NoReleasesErr indicates that a given release cannot be found
@@ -252,6 +253,50 @@ func (s *Storage) Last(name string) (*rspb.Release, error) {
 	return h[0], nil
 }
 
+// AcquireLock locks the release name for the operation described by lock.
+// An error naming the holder is returned if the release is already locked.
+// Releases are not locked if the storage driver does not support it.
+func (s *Storage) AcquireLock(name string, lock *driver.Lock) error {
+	l, ok := s.Driver.(driver.Locker)
+	if !ok {
+		s.Log("driver %s does not support locking, not locking %q", s.Name(), name)
+		return nil
+	}
+	s.Log("locking release %q for %s", name, lock.Operation)
+	return l.AcquireLock(name, lock)
+}
+
+// RenewLock renews the lock held on the release name with the ID of lock. It
+// fails if the lock was cleared or taken over, rather than locking the release
+// again.
+func (s *Storage) RenewLock(name string, lock *driver.Lock) error {
+	l, ok := s.Driver.(driver.Locker)
+	if !ok {
+		return nil
+	}
+	return l.RenewLock(name, lock)
+}
+
+// ReleaseLock unlocks the release name if the lock ID is id, or whatever the
+// lock if id is empty, and returns the lock that was released.
+func (s *Storage) ReleaseLock(name, id string) (*driver.Lock, error) {
+	l, ok := s.Driver.(driver.Locker)
+	if !ok {
+		return nil, storageerrors.ErrLockNotFound(name)
+	}
+	s.Log("unlocking release %q", name)
+	return l.ReleaseLock(name, id)
+}
+
+// GetLock returns the lock held on the release name.
+func (s *Storage) GetLock(name string) (*driver.Lock, error) {
+	l, ok := s.Driver.(driver.Locker)
+	if !ok {
+		return nil, storageerrors.ErrLockNotFound(name)
+	}
+	return l.GetLock(name)
+}
+
 // makeKey concatenates a release name and version into
 // a string with format ```<release_name>#v<version>```.
 // This key is used to uniquely identify storage objects.
@@ -0,0 +1,314 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tiller
+
+import (
+	"fmt"
+	"strings"
+
+	ctx "golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/client-go/kubernetes"
+
+	"k8s.io/helm/pkg/proto/hapi/services"
+	"k8s.io/helm/pkg/storage"
+	storageerrors "k8s.io/helm/pkg/storage/errors"
+)
+
+// Verbs callers may be authorized to perform on releases.
+const (
+	VerbGet    = "get"
+	VerbList   = "list"
+	VerbCreate = "create"
+	VerbUpdate = "update"
+	VerbDelete = "delete"
+)
+
+const (
+	// releasesGroup and releasesResource name the resource RBAC rules grant
+	// verbs on to authorize callers for releases.
+	releasesGroup    = "helm.sh"
+	releasesResource = "releases"
+
+	releaseService = "hapi.services.tiller.ReleaseService"
+)
+
+// UserInfo identifies an authenticated caller.
+type UserInfo struct {
+	Username string
+	Groups   []string
+}
+
+// Authenticator identifies callers by the bearer token they send.
+type Authenticator interface {
+	// Authenticate returns the user token belongs to, or an error if the
+	// token is not valid.
+	Authenticate(token string) (*UserInfo, error)
+}
+
+// Policy decides whether users may perform verbs on the releases of a
+// namespace.
+type Policy interface {
+	// Allowed reports whether user may perform verb on the releases in
+	// namespace. An empty namespace stands for all namespaces.
+	Allowed(user *UserInfo, verb, namespace string) (bool, error)
+}
+
+// Authorizer checks that callers of the release service may perform the
+// methods they call on the releases of the target namespace.
+type Authorizer struct {
+	Authenticator Authenticator
+	Policy        Policy
+	// Releases are looked up to find the namespace of existing releases.
+	Releases *storage.Storage
+	// Log logs denied calls.
+	Log func(string, ...interface{})
+}
+
+// NewAuthorizer creates an Authorizer authenticating callers with authn and
+// checking them against policy.
+func NewAuthorizer(authn Authenticator, policy Policy, releases *storage.Storage) *Authorizer {
+	return &Authorizer{
+		Authenticator: authn,
+		Policy:        policy,
+		Releases:      releases,
+		Log:           func(_ string, _ ...interface{}) {},
+	}
+}
+
+// authorize authenticates the caller of method and checks that they may
+// perform req, returning the authenticated user.
+func (a *Authorizer) authorize(c ctx.Context, method string, req interface{}) (*UserInfo, error) {
+	verb, namespace, ok, err := a.attributes(req)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, nil
+	}
+
+	token := tokenFromContext(c)
+	if token == "" {
+		a.Log("denied %s: no credentials", method)
+		return nil, status.Error(codes.Unauthenticated, "Tiller requires a Kubernetes bearer token to authorize calls, check your kubeconfig")
+	}
+	user, err := a.Authenticator.Authenticate(token)
+	if err != nil {
+		a.Log("denied %s: %s", method, err)
+		return nil, status.Errorf(codes.Unauthenticated, "cannot authenticate: %s", err)
+	}
+	if verb == "" {
+		// Authorized by the release server once the release is locked.
+		return user, nil
+	}
+	if err := a.allow(method, user, verb, namespace); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// allow checks that user may perform verb on the releases in namespace.
+func (a *Authorizer) allow(method string, user *UserInfo, verb, namespace string) error {
+	allowed, err := a.Policy.Allowed(user, verb, namespace)
+	if err != nil {
+		return fmt.Errorf("cannot authorize %s: %s", user.Username, err)
+	}
+	if !allowed {
+		a.Log("denied %s: user %q (groups %v) may not %s releases in %s", method, user.Username, user.Groups, verb, describeNamespace(namespace))
+		return status.Errorf(codes.PermissionDenied, "user %q may not %s releases in %s", user.Username, verb, describeNamespace(namespace))
+	}
+	return nil
+}
+
+// attributes returns the verb req performs and the namespace it performs it
+// in, an empty namespace standing for all namespaces. ok is false for
+// requests which need no authorization, and the verb is empty for requests
+// whose caller is authenticated but authorized by the release server.
+func (a *Authorizer) attributes(req interface{}) (verb, namespace string, ok bool, err error) {
+	var name string
+	switch r := req.(type) {
+	case *services.GetVersionRequest:
+		return "", "", false, nil
+	case *services.ListReleasesRequest:
+		return VerbList, r.Namespace, true, nil
+	case *services.InstallReleaseRequest:
+		return VerbCreate, r.Namespace, true, nil
+	case *services.GetReleaseStatusRequest:
+		verb, name = VerbGet, r.Name
+	case *services.GetReleaseContentRequest:
+		verb, name = VerbGet, r.Name
+	case *services.GetHistoryRequest:
+		verb, name = VerbGet, r.Name
+	case *services.UpdateReleaseRequest:
+		verb, name = VerbUpdate, r.Name
+	case *services.RollbackReleaseRequest:
+		verb, name = VerbUpdate, r.Name
+	case *services.TestReleaseRequest:
+		verb, name = VerbUpdate, r.Name
+	case *services.UnlockReleaseRequest:
+		verb, name = VerbUpdate, r.Name
+	case *services.RepairReleaseRequest:
+		verb, name = VerbUpdate, r.Name
+	case *services.UninstallReleaseRequest:
+		verb, name = VerbDelete, r.Name
+	default:
+		return "", "", false, status.Errorf(codes.PermissionDenied, "cannot authorize request %T", req)
+	}
+	if name == "" {
+		return verb, "", true, nil
+	}
+
+	h, err := a.Releases.History(name)
+	if err != nil && err.Error() != storageerrors.ErrReleaseNotFound(name).Error() {
+		return "", "", false, err
+	}
+	if len(h) == 0 {
+		// The release may be installed before an upgrade locks it, so
+		// upgrades of unknown releases are authorized by the release server
+		// once the release is locked. Other methods called for unknown
+		// releases need access to all namespaces.
+		if _, ok := req.(*services.UpdateReleaseRequest); ok {
+			return "", "", true, nil
+		}
+		return verb, "", true, nil
+	}
+	return verb, h[0].Namespace, true, nil
+}
+
+func describeNamespace(namespace string) string {
+	if namespace == "" {
+		return "all namespaces"
+	}
+	return fmt.Sprintf("namespace %q", namespace)
+}
+
+// tokenFromContext returns the bearer token the client sent, if any.
+func tokenFromContext(c ctx.Context) string {
+	if md, ok := metadata.FromIncomingContext(c); ok {
+		if v, ok := md["authorization"]; ok && len(v) > 0 && strings.HasPrefix(v[0], "Bearer ") {
+			return strings.TrimPrefix(v[0], "Bearer ")
+		}
+	}
+	return ""
+}
+
+type authenticatedUserKey struct{}
+
+// withAuthenticatedUser returns a copy of c carrying user.
+func withAuthenticatedUser(c ctx.Context, user *UserInfo) ctx.Context {
+	if user == nil {
+		return c
+	}
+	return ctx.WithValue(c, authenticatedUserKey{}, user)
+}
+
+// authenticatedUser returns the user the caller authenticated as, if any.
+func authenticatedUser(c ctx.Context) *UserInfo {
+	user, _ := c.Value(authenticatedUserKey{}).(*UserInfo)
+	return user
+}
+
+// authorizedStream authorizes the request received on a stream before it
+// is handled.
+type authorizedStream struct {
+	grpc.ServerStream
+	c          ctx.Context
+	method     string
+	authorizer *Authorizer
+	authorized bool
+}
+
+func (s *authorizedStream) Context() ctx.Context {
+	return s.c
+}
+
+func (s *authorizedStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	if s.authorized {
+		return nil
+	}
+	user, err := s.authorizer.authorize(s.c, s.method, m)
+	if err != nil {
+		return err
+	}
+	s.c = withAuthenticatedUser(s.c, user)
+	s.authorized = true
+	return nil
+}
+
+type kubeAuthenticator struct {
+	client kubernetes.Interface
+}
+
+// NewKubeAuthenticator returns an Authenticator validating tokens with the
+// TokenReview API of Kubernetes.
+func NewKubeAuthenticator(client kubernetes.Interface) Authenticator {
+	return &kubeAuthenticator{client: client}
+}
+
+func (k *kubeAuthenticator) Authenticate(token string) (*UserInfo, error) {
+	review, err := k.client.AuthenticationV1().TokenReviews().Create(&authenticationv1.TokenReview{
+		Spec: authenticationv1.TokenReviewSpec{Token: token},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("token review failed: %s", err)
+	}
+	if !review.Status.Authenticated {
+		if review.Status.Error != "" {
+			return nil, fmt.Errorf("invalid token: %s", review.Status.Error)
+		}
+		return nil, fmt.Errorf("invalid token")
+	}
+	return &UserInfo{Username: review.Status.User.Username, Groups: review.Status.User.Groups}, nil
+}
+
+type kubePolicy struct {
+	client kubernetes.Interface
+}
+
+// NewKubePolicy returns a Policy asking the SubjectAccessReview API of
+// Kubernetes whether users may perform verbs on the virtual resource
+// releases.helm.sh, so that releases are authorized with RBAC rules.
+func NewKubePolicy(client kubernetes.Interface) Policy {
+	return &kubePolicy{client: client}
+}
+
+func (k *kubePolicy) Allowed(user *UserInfo, verb, namespace string) (bool, error) {
+	review, err := k.client.AuthorizationV1().SubjectAccessReviews().Create(&authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			User:   user.Username,
+			Groups: user.Groups,
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Namespace: namespace,
+				Verb:      verb,
+				Group:     releasesGroup,
+				Resource:  releasesResource,
+			},
+		},
+	})
+	if err != nil {
+		return false, fmt.Errorf("subject access review failed: %s", err)
+	}
+	return review.Status.Allowed, nil
+}
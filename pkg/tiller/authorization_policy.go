@@ -0,0 +1,103 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tiller
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"github.com/ghodss/yaml"
+)
+
+// PolicyFile is a Policy granting users and groups verbs on the releases
+// of namespaces, as an alternative to RBAC rules.
+type PolicyFile struct {
+	Rules []PolicyRule `json:"rules"`
+}
+
+// PolicyRule grants verbs on the releases of namespaces to users and groups.
+//
+// "*" matches any user, group, namespace or verb. Only rules matching every
+// namespace grant verbs on all namespaces at once, e.g. to list releases
+// without a namespace.
+type PolicyRule struct {
+	Users      []string `json:"users,omitempty"`
+	Groups     []string `json:"groups,omitempty"`
+	Namespaces []string `json:"namespaces"`
+	Verbs      []string `json:"verbs"`
+}
+
+// LoadPolicyFile loads a PolicyFile from the YAML file at path.
+func LoadPolicyFile(path string) (*PolicyFile, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	p := &PolicyFile{}
+	if err := yaml.Unmarshal(b, p); err != nil {
+		return nil, fmt.Errorf("cannot parse policy file %s: %s", path, err)
+	}
+	for i, r := range p.Rules {
+		if len(r.Users) == 0 && len(r.Groups) == 0 {
+			return nil, fmt.Errorf("rule %d of policy file %s has neither users nor groups", i+1, path)
+		}
+	}
+	return p, nil
+}
+
+// Allowed implements Policy.
+func (p *PolicyFile) Allowed(user *UserInfo, verb, namespace string) (bool, error) {
+	for _, r := range p.Rules {
+		if r.matchesUser(user) && matches(r.Verbs, verb) && r.matchesNamespace(namespace) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r PolicyRule) matchesUser(user *UserInfo) bool {
+	if matches(r.Users, user.Username) {
+		return true
+	}
+	for _, g := range user.Groups {
+		if matches(r.Groups, g) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r PolicyRule) matchesNamespace(namespace string) bool {
+	if namespace == "" {
+		return contains(r.Namespaces, "*")
+	}
+	return matches(r.Namespaces, namespace)
+}
+
+// matches reports whether s is in patterns, or patterns contain "*".
+func matches(patterns []string, s string) bool {
+	return contains(patterns, "*") || contains(patterns, s)
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
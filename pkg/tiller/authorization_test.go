@@ -0,0 +1,389 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tiller
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	testcore "k8s.io/client-go/testing"
+
+	"k8s.io/helm/pkg/proto/hapi/release"
+	"k8s.io/helm/pkg/proto/hapi/services"
+	"k8s.io/helm/pkg/storage"
+	"k8s.io/helm/pkg/storage/driver"
+	"k8s.io/helm/pkg/version"
+)
+
+// tokenAuthenticator authenticates tokens of the form USER:GROUP.
+type tokenAuthenticator struct{}
+
+func (tokenAuthenticator) Authenticate(token string) (*UserInfo, error) {
+	parts := strings.SplitN(token, ":", 2)
+	if len(parts) != 2 {
+		return nil, errors.New("invalid token")
+	}
+	return &UserInfo{Username: parts[0], Groups: []string{parts[1]}}, nil
+}
+
+var testPolicy = &PolicyFile{
+	Rules: []PolicyRule{
+		{Groups: []string{"team-a"}, Namespaces: []string{"team-a"}, Verbs: []string{"*"}},
+		{Users: []string{"viewer"}, Namespaces: []string{"team-a", "team-b"}, Verbs: []string{VerbGet, VerbList}},
+		{Users: []string{"admin"}, Namespaces: []string{"*"}, Verbs: []string{"*"}},
+	},
+}
+
+func authorizerFixture() *Authorizer {
+	releases := storage.Init(driver.NewMemory())
+	rel := releaseStub()
+	rel.Namespace = "team-b"
+	releases.Create(rel)
+	return NewAuthorizer(tokenAuthenticator{}, testPolicy, releases)
+}
+
+func tokenContext(token string) context.Context {
+	md := metadata.Pairs("x-helm-api-client", version.GetVersion())
+	if token != "" {
+		md.Set("authorization", "Bearer "+token)
+	}
+	return metadata.NewIncomingContext(context.TODO(), md)
+}
+
+func TestAuthorizer(t *testing.T) {
+	tests := []struct {
+		name  string
+		token string
+		req   interface{}
+		user  string
+		code  codes.Code
+	}{
+		{
+			name: "version without credentials",
+			req:  &services.GetVersionRequest{},
+		},
+		{
+			name: "install without credentials",
+			req:  &services.InstallReleaseRequest{Namespace: "team-a"},
+			code: codes.Unauthenticated,
+		},
+		{
+			name:  "install with invalid token",
+			token: "garbage",
+			req:   &services.InstallReleaseRequest{Namespace: "team-a"},
+			code:  codes.Unauthenticated,
+		},
+		{
+			name:  "install in own namespace",
+			token: "alice:team-a",
+			req:   &services.InstallReleaseRequest{Namespace: "team-a"},
+			user:  "alice",
+		},
+		{
+			name:  "install in other namespace",
+			token: "alice:team-a",
+			req:   &services.InstallReleaseRequest{Namespace: "team-b"},
+			code:  codes.PermissionDenied,
+		},
+		{
+			name:  "upgrade release of other namespace",
+			token: "alice:team-a",
+			req:   &services.UpdateReleaseRequest{Name: "angry-panda"},
+			code:  codes.PermissionDenied,
+		},
+		{
+			name:  "status of release of other namespace",
+			token: "viewer:none",
+			req:   &services.GetReleaseStatusRequest{Name: "angry-panda"},
+			user:  "viewer",
+		},
+		{
+			name:  "delete release of other namespace",
+			token: "viewer:none",
+			req:   &services.UninstallReleaseRequest{Name: "angry-panda"},
+			code:  codes.PermissionDenied,
+		},
+		{
+			name:  "history of unknown release",
+			token: "alice:team-a",
+			req:   &services.GetHistoryRequest{Name: "unknown"},
+			code:  codes.PermissionDenied,
+		},
+		{
+			name:  "upgrade of unknown release",
+			token: "alice:team-a",
+			req:   &services.UpdateReleaseRequest{Name: "unknown"},
+			user:  "alice",
+		},
+		{
+			name: "upgrade of unknown release without credentials",
+			req:  &services.UpdateReleaseRequest{Name: "unknown"},
+			code: codes.Unauthenticated,
+		},
+		{
+			name:  "unlock unknown release",
+			token: "alice:team-a",
+			req:   &services.UnlockReleaseRequest{Name: "unknown"},
+			code:  codes.PermissionDenied,
+		},
+		{
+			name:  "unlock unknown release as admin",
+			token: "admin:none",
+			req:   &services.UnlockReleaseRequest{Name: "unknown"},
+			user:  "admin",
+		},
+		{
+			name:  "list all namespaces",
+			token: "viewer:none",
+			req:   &services.ListReleasesRequest{},
+			code:  codes.PermissionDenied,
+		},
+		{
+			name:  "list all namespaces as admin",
+			token: "admin:none",
+			req:   &services.ListReleasesRequest{},
+			user:  "admin",
+		},
+		{
+			name:  "repair all releases",
+			token: "alice:team-a",
+			req:   &services.RepairReleaseRequest{},
+			code:  codes.PermissionDenied,
+		},
+	}
+
+	a := authorizerFixture()
+	for _, tt := range tests {
+		user, err := a.authorize(tokenContext(tt.token), "test", tt.req)
+		if code := status.Code(err); code != tt.code {
+			t.Errorf("%s: expected code %s, got %s (%v)", tt.name, tt.code, code, err)
+			continue
+		}
+		var username string
+		if user != nil {
+			username = user.Username
+		}
+		if username != tt.user {
+			t.Errorf("%s: expected user %q, got %q", tt.name, tt.user, username)
+		}
+	}
+}
+
+func TestAuthorizer_LogsDenials(t *testing.T) {
+	var logged []string
+	a := authorizerFixture()
+	a.Log = func(format string, args ...interface{}) {
+		logged = append(logged, fmt.Sprintf(format, args...))
+	}
+
+	a.authorize(tokenContext("alice:team-a"), "/hapi.services.tiller.ReleaseService/UninstallRelease", &services.UninstallReleaseRequest{Name: "angry-panda"})
+	expected := `denied /hapi.services.tiller.ReleaseService/UninstallRelease: user "alice" (groups [team-a]) may not delete releases in namespace "team-b"`
+	if len(logged) != 1 || logged[0] != expected {
+		t.Errorf("Expected %q to be logged, got %q", expected, logged)
+	}
+}
+
+func TestUnaryInterceptor_Authorization(t *testing.T) {
+	interceptor := newUnaryInterceptor(authorizerFixture())
+	info := &grpc.UnaryServerInfo{FullMethod: "/hapi.services.tiller.ReleaseService/GetReleaseStatus"}
+	req := &services.GetReleaseStatusRequest{Name: "angry-panda"}
+
+	var holder string
+	handler := func(c context.Context, req interface{}) (interface{}, error) {
+		holder = userFromContext(c)
+		return &services.GetReleaseStatusResponse{}, nil
+	}
+
+	if _, err := interceptor(tokenContext("alice:team-a"), req, info, handler); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("Expected permission denied, got %v", err)
+	}
+	if _, err := interceptor(tokenContext("viewer:none"), req, info, handler); err != nil {
+		t.Fatalf("Expected call to be authorized, got %s", err)
+	}
+	if holder != "viewer" {
+		t.Errorf("Expected handler to see user viewer, got %q", holder)
+	}
+
+	// other services are not authorized
+	info.FullMethod = "/grpc.health.v1.Health/Check"
+	if _, err := interceptor(tokenContext(""), struct{}{}, info, handler); err != nil {
+		t.Errorf("Expected health check to be allowed, got %s", err)
+	}
+}
+
+type recvStream struct {
+	grpc.ServerStream
+	c   context.Context
+	req *services.ListReleasesRequest
+}
+
+func (s *recvStream) Context() context.Context { return s.c }
+
+func (s *recvStream) RecvMsg(m interface{}) error {
+	*m.(*services.ListReleasesRequest) = *s.req
+	return nil
+}
+
+func TestStreamInterceptor_Authorization(t *testing.T) {
+	interceptor := newStreamInterceptor(authorizerFixture())
+	info := &grpc.StreamServerInfo{FullMethod: "/hapi.services.tiller.ReleaseService/ListReleases", IsServerStream: true}
+	handler := func(srv interface{}, ss grpc.ServerStream) error {
+		req := &services.ListReleasesRequest{}
+		if err := ss.RecvMsg(req); err != nil {
+			return err
+		}
+		if u := userFromContext(ss.Context()); u != "viewer" {
+			return fmt.Errorf("expected user viewer, got %q", u)
+		}
+		return nil
+	}
+
+	tests := []struct {
+		namespace string
+		code      codes.Code
+	}{
+		{namespace: "team-b"},
+		{namespace: "", code: codes.PermissionDenied},
+	}
+	for _, tt := range tests {
+		ss := &recvStream{c: tokenContext("viewer:none"), req: &services.ListReleasesRequest{Namespace: tt.namespace}}
+		if err := interceptor(nil, ss, info, handler); status.Code(err) != tt.code {
+			t.Errorf("namespace %q: expected code %s, got %v", tt.namespace, tt.code, err)
+		}
+	}
+}
+
+func TestLoadPolicyFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "helm-policy-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	tests := []struct {
+		name    string
+		content string
+		err     string
+	}{
+		{
+			name:    "valid",
+			content: "rules:\n- groups: [team-a]\n  namespaces: [team-a]\n  verbs: ['*']\n",
+		},
+		{
+			name:    "rule without subjects",
+			content: "rules:\n- namespaces: [team-a]\n  verbs: ['*']\n",
+			err:     "rule 1 of policy file",
+		},
+		{
+			name:    "invalid yaml",
+			content: "rules: {",
+			err:     "cannot parse policy file",
+		},
+	}
+	for _, tt := range tests {
+		path := filepath.Join(dir, tt.name)
+		if err := ioutil.WriteFile(path, []byte(tt.content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		p, err := LoadPolicyFile(path)
+		if tt.err != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("%s: expected error containing %q, got %v", tt.name, tt.err, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %s", tt.name, err)
+			continue
+		}
+		if ok, _ := p.Allowed(&UserInfo{Username: "alice", Groups: []string{"team-a"}}, VerbDelete, "team-a"); !ok {
+			t.Errorf("%s: expected group team-a to be allowed in its namespace", tt.name)
+		}
+	}
+}
+
+func TestKubeAuthorization(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	client.PrependReactor("create", "tokenreviews", func(action testcore.Action) (bool, runtime.Object, error) {
+		review := action.(testcore.CreateAction).GetObject().(*authenticationv1.TokenReview)
+		if review.Spec.Token == "valid" {
+			review.Status.Authenticated = true
+			review.Status.User = authenticationv1.UserInfo{Username: "alice", Groups: []string{"team-a"}}
+		}
+		return true, review, nil
+	})
+	client.PrependReactor("create", "subjectaccessreviews", func(action testcore.Action) (bool, runtime.Object, error) {
+		review := action.(testcore.CreateAction).GetObject().(*authorizationv1.SubjectAccessReview)
+		attrs := review.Spec.ResourceAttributes
+		review.Status.Allowed = review.Spec.User == "alice" && attrs.Namespace == "team-a" &&
+			attrs.Group == "helm.sh" && attrs.Resource == "releases" && attrs.Verb == VerbCreate
+		return true, review, nil
+	})
+
+	authn := NewKubeAuthenticator(client)
+	if _, err := authn.Authenticate("invalid"); err == nil {
+		t.Error("Expected invalid token to be rejected")
+	}
+	user, err := authn.Authenticate("valid")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if user.Username != "alice" {
+		t.Errorf("Expected user alice, got %q", user.Username)
+	}
+
+	policy := NewKubePolicy(client)
+	for _, ns := range []string{"team-a", "team-b"} {
+		allowed, err := policy.Allowed(user, VerbCreate, ns)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if allowed != (ns == "team-a") {
+			t.Errorf("namespace %s: expected allowed %t, got %t", ns, ns == "team-a", allowed)
+		}
+	}
+}
+
+func TestAuthorizer_StorageErrors(t *testing.T) {
+	a := authorizerFixture()
+	a.Releases = storage.Init(&failingDriver{Driver: driver.NewMemory()})
+	if _, err := a.authorize(tokenContext("admin:none"), "test", &services.GetHistoryRequest{Name: "angry-panda"}); err == nil {
+		t.Error("Expected storage errors to deny the call")
+	}
+}
+
+type failingDriver struct {
+	driver.Driver
+}
+
+func (failingDriver) Query(map[string]string) ([]*release.Release, error) {
+	return nil, errors.New("storage unavailable")
+}
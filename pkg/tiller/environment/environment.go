@@ -102,6 +102,10 @@ type KubeClient interface {
 	// by "\n---\n").
 	Create(namespace string, reader io.Reader, timeout int64, shouldWait bool) error
 
+	// CreateWithOptions is like Create, with the create behavior controlled
+	// by options.
+	CreateWithOptions(namespace string, reader io.Reader, opts kube.CreateOptions) error
+
 	// Get gets one or more resources. Returned string hsa the format like kubectl
 	// provides with the column headers separating the resource types.
 	//
@@ -135,6 +139,10 @@ type KubeClient interface {
 	// by "\n---\n").
 	Update(namespace string, originalReader, modifiedReader io.Reader, force bool, recreate bool, timeout int64, shouldWait bool) error
 
+	// UpdateWithOptions is like Update, with the update behavior controlled
+	// by options.
+	UpdateWithOptions(namespace string, originalReader, modifiedReader io.Reader, opts kube.UpdateOptions) error
+
 	Build(namespace string, reader io.Reader) (kube.Result, error)
 	BuildUnstructured(namespace string, reader io.Reader) (kube.Result, error)
 
@@ -155,6 +163,12 @@ func (p *PrintingKubeClient) Create(ns string, r io.Reader, timeout int64, shoul
 	return err
 }
 
+// CreateWithOptions prints the values of what would be created with a real KubeClient.
+func (p *PrintingKubeClient) CreateWithOptions(ns string, r io.Reader, opts kube.CreateOptions) error {
+	_, err := io.Copy(p.Out, r)
+	return err
+}
+
 // Get prints the values of what would be created with a real KubeClient.
 func (p *PrintingKubeClient) Get(ns string, r io.Reader) (string, error) {
 	_, err := io.Copy(p.Out, r)
@@ -181,6 +195,12 @@ func (p *PrintingKubeClient) Update(ns string, currentReader, modifiedReader io.
 	return err
 }
 
+// UpdateWithOptions implements KubeClient UpdateWithOptions.
+func (p *PrintingKubeClient) UpdateWithOptions(ns string, currentReader, modifiedReader io.Reader, opts kube.UpdateOptions) error {
+	_, err := io.Copy(p.Out, modifiedReader)
+	return err
+}
+
 // Build implements KubeClient Build.
 func (p *PrintingKubeClient) Build(ns string, reader io.Reader) (kube.Result, error) {
 	return []*resource.Info{}, nil
@@ -0,0 +1,70 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tiller
+
+import (
+	"time"
+
+	ctx "golang.org/x/net/context"
+
+	"k8s.io/helm/pkg/audit"
+	"k8s.io/helm/pkg/proto/hapi/release"
+)
+
+// audit records an operation on the release name, started at start, in the
+// audit sink of the server. rel is the release resulting from the operation,
+// if any, and namespace the namespace of the release when rel is unknown.
+func (s *ReleaseServer) audit(c ctx.Context, operation string, start time.Time, name, namespace string, rel *release.Release, err error) {
+	if s.Audit == nil {
+		return
+	}
+
+	e := &audit.Event{
+		Time:            start.UTC(),
+		Operation:       operation,
+		Release:         name,
+		Namespace:       namespace,
+		DurationSeconds: time.Since(start).Seconds(),
+		Outcome:         audit.OutcomeSuccess,
+	}
+	if user := callerIdentity(c); user != nil {
+		e.User, e.Groups = user.Username, user.Groups
+	}
+	if cert := verifiedClientCert(c); cert != nil {
+		e.Subject = cert.Subject.String()
+	}
+	if rel != nil {
+		e.Release, e.Namespace, e.Revision = rel.Name, rel.Namespace, rel.Version
+		if md := rel.GetChart().GetMetadata(); md != nil {
+			e.Chart, e.ChartVersion = md.Name, md.Version
+		}
+		e.ValuesDigest = audit.Digest(rel.GetConfig().GetRaw())
+	} else if e.Namespace == "" && name != "" {
+		// The operation failed without a resulting release, but the release
+		// may exist.
+		if last, err := s.env.Releases.Last(name); err == nil {
+			e.Namespace = last.Namespace
+		}
+	}
+	if err != nil {
+		e.Outcome, e.Error = audit.OutcomeFailure, err.Error()
+	}
+
+	if err := s.Audit.Write(e); err != nil {
+		s.Log("warning: %s", err)
+	}
+}
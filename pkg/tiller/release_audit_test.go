@@ -0,0 +1,99 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tiller
+
+import (
+	"testing"
+
+	"k8s.io/helm/pkg/audit"
+	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/proto/hapi/release"
+	"k8s.io/helm/pkg/proto/hapi/services"
+)
+
+type recordingAuditSink struct {
+	events []*audit.Event
+}
+
+func (s *recordingAuditSink) Write(e *audit.Event) error {
+	s.events = append(s.events, e)
+	return nil
+}
+
+func TestReleaseOperationsAudit(t *testing.T) {
+	rs := rsFixture()
+	sink := &recordingAuditSink{}
+	rs.Audit = sink
+	c := tlsPeerContext(helm.NewContext(), "alice", "dev")
+
+	if _, err := rs.InstallRelease(c, installRequest(withName("audited"), withDryRun())); err != nil {
+		t.Fatalf("Failed dry-run install: %s", err)
+	}
+	if len(sink.events) != 0 {
+		t.Fatalf("Expected dry runs not to be audited, got %v", sink.events)
+	}
+
+	installed, err := rs.InstallRelease(c, installRequest(withName("audited")))
+	if err != nil {
+		t.Fatalf("Failed install: %s", err)
+	}
+	if _, err := rs.UpdateRelease(c, &services.UpdateReleaseRequest{Name: "audited", Chart: buildChart(withKube(">=99.0.0"))}); err == nil {
+		t.Fatal("Expected the upgrade to fail")
+	}
+	if _, err := rs.UninstallRelease(c, &services.UninstallReleaseRequest{Name: "audited", DryRun: true}); err != nil {
+		t.Fatalf("Failed dry-run uninstall: %s", err)
+	}
+	if rel, err := rs.env.Releases.Last("audited"); err != nil || rel.Info.Status.Code != release.Status_DEPLOYED {
+		t.Fatalf("Expected a dry-run uninstall to leave the release deployed, got %v (%v)", rel.GetInfo().GetStatus().GetCode(), err)
+	}
+	if _, err := rs.UninstallRelease(c, &services.UninstallReleaseRequest{Name: "audited"}); err != nil {
+		t.Fatalf("Failed uninstall: %s", err)
+	}
+
+	expected := []struct {
+		operation string
+		revision  int32
+		outcome   string
+	}{
+		{audit.OperationInstall, 1, audit.OutcomeSuccess},
+		{audit.OperationUpgrade, 0, audit.OutcomeFailure},
+		{audit.OperationUninstall, 1, audit.OutcomeSuccess},
+	}
+	if len(sink.events) != len(expected) {
+		t.Fatalf("Expected %d events, got %d: %v", len(expected), len(sink.events), sink.events)
+	}
+	for i, ex := range expected {
+		e := sink.events[i]
+		if e.Operation != ex.operation || e.Revision != ex.revision || e.Outcome != ex.outcome {
+			t.Errorf("Expected event %d to be %v, got %s", i, ex, e)
+		}
+		if e.Release != "audited" || e.Namespace != "spaced" {
+			t.Errorf("Expected event %d to be about spaced/audited, got %s/%s", i, e.Namespace, e.Release)
+		}
+		if e.User != "alice" || len(e.Groups) != 1 || e.Groups[0] != "dev" || e.Subject != "CN=alice,O=dev" {
+			t.Errorf("Expected event %d to identify alice, got %q %v %q", i, e.User, e.Groups, e.Subject)
+		}
+	}
+
+	install := sink.events[0]
+	if install.Chart != "hello" || install.ValuesDigest != audit.Digest(installed.Release.GetConfig().GetRaw()) {
+		t.Errorf("Expected the install event to carry the chart and values digest, got %q %q", install.Chart, install.ValuesDigest)
+	}
+	if upgrade := sink.events[1]; upgrade.Error == "" {
+		t.Error("Expected the failed upgrade event to carry the error")
+	}
+}
@@ -0,0 +1,111 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tiller
+
+import (
+	"sort"
+
+	"github.com/ghodss/yaml"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"k8s.io/helm/pkg/proto/hapi/release"
+	relutil "k8s.io/helm/pkg/releaseutil"
+	storageerrors "k8s.io/helm/pkg/storage/errors"
+)
+
+// errUnidentifiedCaller is returned when the caller of an authorized call
+// was not authenticated.
+var errUnidentifiedCaller = status.Error(codes.Unauthenticated, "Tiller authorizes calls, but cannot identify the caller")
+
+// authorizeStored checks that the caller may perform verb on the release
+// name in the namespace it is stored in. It is called once the release is
+// locked, so that the release cannot be installed after the check. Calls on
+// a release that is not stored would create it, in a namespace the request
+// does not name.
+func (s *ReleaseServer) authorizeStored(verb, name string) error {
+	if s.Authorizer == nil {
+		return nil
+	}
+	if s.caller == nil {
+		return errUnidentifiedCaller
+	}
+	h, err := s.env.Releases.History(name)
+	if err != nil && err.Error() != storageerrors.ErrReleaseNotFound(name).Error() {
+		return err
+	}
+	if len(h) == 0 {
+		return s.Authorizer.allow(verb+" of "+name, s.caller, VerbCreate, "")
+	}
+	return s.Authorizer.allow(verb+" of "+name, s.caller, verb, h[0].Namespace)
+}
+
+// authorizeNamespaces checks that the caller may perform verb on releases in
+// every namespace the objects of rel are explicitly rendered into, in
+// addition to the namespace of the release the call was authorized in.
+func (s *ReleaseServer) authorizeNamespaces(verb string, rel *release.Release) error {
+	if s.Authorizer == nil {
+		return nil
+	}
+	if s.caller == nil {
+		return errUnidentifiedCaller
+	}
+	namespaces, err := objectNamespaces(rel)
+	if err != nil {
+		return err
+	}
+	for _, ns := range namespaces {
+		if ns == rel.Namespace {
+			continue
+		}
+		if err := s.Authorizer.allow("objects of "+rel.Name, s.caller, verb, ns); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// objectNamespaces returns the namespaces set in the metadata of the objects
+// of the manifest and the hooks of rel, sorted.
+func objectNamespaces(rel *release.Release) ([]string, error) {
+	var manifests []string
+	for _, m := range relutil.SplitManifests(rel.Manifest) {
+		manifests = append(manifests, m)
+	}
+	for _, h := range rel.Hooks {
+		manifests = append(manifests, h.Manifest)
+	}
+
+	seen := map[string]bool{}
+	var namespaces []string
+	for _, m := range manifests {
+		var obj struct {
+			Metadata struct {
+				Namespace string `json:"namespace"`
+			} `json:"metadata"`
+		}
+		if err := yaml.Unmarshal([]byte(m), &obj); err != nil {
+			return nil, err
+		}
+		if ns := obj.Metadata.Namespace; ns != "" && !seen[ns] {
+			seen[ns] = true
+			namespaces = append(namespaces, ns)
+		}
+	}
+	sort.Strings(namespaces)
+	return namespaces, nil
+}
@@ -0,0 +1,103 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tiller
+
+import (
+	"reflect"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/proto/hapi/chart"
+	"k8s.io/helm/pkg/proto/hapi/release"
+	"k8s.io/helm/pkg/proto/hapi/services"
+)
+
+func withObjectIn(namespace string) chartOption {
+	return func(opts *chartOptions) {
+		opts.Templates = append(opts.Templates, &chart.Template{
+			Name: "templates/elsewhere.yaml",
+			Data: []byte("kind: ConfigMap\nmetadata:\n  name: elsewhere\n  namespace: " + namespace + "\n"),
+		})
+	}
+}
+
+func TestInstallRelease_AuthorizesObjectNamespaces(t *testing.T) {
+	rs := rsFixture()
+	rs.Authorizer = NewAuthorizer(tokenAuthenticator{}, testPolicy, rs.env.Releases)
+
+	req := installRequest(withName("elsewhere"), withChart(withObjectIn("team-b")))
+	req.Namespace = "team-a"
+
+	c := withAuthenticatedUser(helm.NewContext(), &UserInfo{Username: "alice", Groups: []string{"team-a"}})
+	_, err := rs.InstallRelease(c, req)
+	if status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("Expected objects in team-b to be denied, got %v", err)
+	}
+	if rels, _ := rs.env.Releases.ListReleases(); len(rels) != 0 {
+		t.Errorf("Expected no release to be recorded, got %d", len(rels))
+	}
+
+	c = withAuthenticatedUser(helm.NewContext(), &UserInfo{Username: "admin"})
+	if _, err := rs.InstallRelease(c, req); err != nil {
+		t.Errorf("Failed install: %s", err)
+	}
+}
+
+func TestUpdateRelease_AuthorizesStoredRelease(t *testing.T) {
+	rs := rsFixture()
+	rs.Authorizer = NewAuthorizer(tokenAuthenticator{}, testPolicy, rs.env.Releases)
+	req := &services.UpdateReleaseRequest{Name: "angry-panda", Chart: chartStub()}
+	alice := withAuthenticatedUser(helm.NewContext(), &UserInfo{Username: "alice", Groups: []string{"team-a"}})
+
+	// The interceptor lets the upgrade of an unknown release through, the
+	// release being installed before the upgrade locks it.
+	rel := releaseStub()
+	rel.Namespace = "team-b"
+	rs.env.Releases.Create(rel)
+	if _, err := rs.UpdateRelease(alice, req); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("Expected the upgrade of a release of team-b to be denied, got %v", err)
+	}
+	if _, err := rs.UpdateRelease(helm.NewContext(), req); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("Expected the upgrade of an unidentified caller to be denied, got %v", err)
+	}
+
+	req.Name = "unknown"
+	if _, err := rs.UpdateRelease(alice, req); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("Expected the upgrade of an unknown release to be denied, got %v", err)
+	}
+}
+
+func TestObjectNamespaces(t *testing.T) {
+	rel := &release.Release{
+		Namespace: "team-a",
+		Manifest:  "---\nkind: ConfigMap\nmetadata:\n  name: a\n  namespace: team-c\n---\nkind: ConfigMap\nmetadata:\n  name: b\n",
+		Hooks: []*release.Hook{
+			{Manifest: "kind: Job\nmetadata:\n  name: c\n  namespace: team-b\n"},
+			{Manifest: "kind: Job\nmetadata:\n  name: d\n  namespace: team-c\n"},
+		},
+	}
+	namespaces, err := objectNamespaces(rel)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := []string{"team-b", "team-c"}; !reflect.DeepEqual(namespaces, expected) {
+		t.Errorf("Expected namespaces %v, got %v", expected, namespaces)
+	}
+}
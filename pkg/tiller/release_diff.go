@@ -0,0 +1,227 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tiller
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/pmezard/go-difflib/difflib"
+
+	"k8s.io/helm/pkg/proto/hapi/release"
+	"k8s.io/helm/pkg/proto/hapi/services"
+	"k8s.io/helm/pkg/releaseutil"
+)
+
+// diffContextLines is the number of unchanged lines shown around each change.
+const diffContextLines = 3
+
+// resourceHead identifies the resource described by a manifest.
+type resourceHead struct {
+	Kind     string `json:"kind"`
+	Metadata struct {
+		Name        string            `json:"name"`
+		Namespace   string            `json:"namespace"`
+		Annotations map[string]string `json:"annotations"`
+	} `json:"metadata"`
+}
+
+// resourceManifest is the manifest of a single resource of a release.
+type resourceManifest struct {
+	resourceHead
+	content string
+}
+
+func (r *resourceManifest) key() string {
+	return fmt.Sprintf("%s/%s/%s", r.Kind, r.Metadata.Namespace, r.Metadata.Name)
+}
+
+// diffReleases returns the difference between the manifests of the current and
+// target releases, for each resource that is added, removed or modified. The
+// values of Secrets are masked.
+func diffReleases(current, target *release.Release) ([]*services.ResourceDiff, error) {
+	from, err := splitResources(current.Manifest, current.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	to, err := splitResources(target.Manifest, target.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, 0, len(from)+len(to))
+	for k := range from {
+		keys = append(keys, k)
+	}
+	for k := range to {
+		if _, ok := from[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	fromName := fmt.Sprintf("%s.v%d", current.Name, current.Version)
+	toName := fmt.Sprintf("%s.v%d", target.Name, target.Version)
+
+	var diffs []*services.ResourceDiff
+	for _, k := range keys {
+		a, b := from[k], to[k]
+
+		var aContent, bContent string
+		if a != nil {
+			aContent = a.content
+		}
+		if b != nil {
+			bContent = b.content
+		}
+		if aContent == bContent {
+			continue
+		}
+
+		head := a
+		change := services.ResourceDiff_MODIFIED
+		switch {
+		case a == nil:
+			head, change = b, services.ResourceDiff_ADDED
+		case b == nil:
+			change = services.ResourceDiff_REMOVED
+		}
+		if head.Kind == "Secret" {
+			aContent, bContent = maskSecrets(aContent, bContent)
+		}
+
+		text, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+			A:        splitLines(aContent),
+			B:        splitLines(bContent),
+			FromFile: fromName,
+			ToFile:   toName,
+			Context:  diffContextLines,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		diffs = append(diffs, &services.ResourceDiff{
+			Kind:      head.Kind,
+			Namespace: head.Metadata.Namespace,
+			Name:      head.Metadata.Name,
+			Change:    change,
+			Diff:      text,
+		})
+	}
+	return diffs, nil
+}
+
+// splitResources splits a release manifest into the manifests of its resources,
+// indexed by kind, namespace and name. Resources without a namespace are
+// assigned the release namespace.
+func splitResources(manifest, namespace string) (map[string]*resourceManifest, error) {
+	list, err := listResources(manifest, namespace)
+	if err != nil {
+		return nil, err
+	}
+	resources := make(map[string]*resourceManifest, len(list))
+	for _, r := range list {
+		resources[r.key()] = r
+	}
+	return resources, nil
+}
+
+// listResources splits a release manifest into the manifests of its
+// resources, in the order of the manifest.
+func listResources(manifest, namespace string) ([]*resourceManifest, error) {
+	var resources []*resourceManifest
+	docs := releaseutil.SplitManifests(manifest)
+	for i := 0; i < len(docs); i++ {
+		content := docs[fmt.Sprintf("manifest-%d", i)]
+		var r resourceManifest
+		if err := yaml.Unmarshal([]byte(content), &r.resourceHead); err != nil {
+			return nil, fmt.Errorf("YAML parse error: %s", err)
+		}
+		// skip documents that only contain comments
+		if r.Kind == "" {
+			continue
+		}
+		if r.Metadata.Namespace == "" {
+			r.Metadata.Namespace = namespace
+		}
+		r.content = content
+		resources = append(resources, &r)
+	}
+	return resources, nil
+}
+
+// maskSecrets replaces the values of the data and stringData fields of
+// the two revisions of a Secret, so they do not leak in the diff. Changed
+// values are replaced with distinct placeholders so the changed keys still
+// show up in the diff.
+func maskSecrets(a, b string) (string, string) {
+	var objA, objB map[string]interface{}
+	if a != "" {
+		if err := yaml.Unmarshal([]byte(a), &objA); err != nil {
+			return "", ""
+		}
+	}
+	if b != "" {
+		if err := yaml.Unmarshal([]byte(b), &objB); err != nil {
+			return "", ""
+		}
+	}
+
+	for _, field := range []string{"data", "stringData"} {
+		dataA, _ := objA[field].(map[string]interface{})
+		dataB, _ := objB[field].(map[string]interface{})
+		for k, v := range dataA {
+			va := fmt.Sprint(v)
+			if vb, ok := dataB[k]; ok && fmt.Sprint(vb) == va {
+				dataA[k] = fmt.Sprintf("REDACTED # (%d bytes)", len(va))
+				dataB[k] = dataA[k]
+				continue
+			}
+			dataA[k] = fmt.Sprintf("-------- # (%d bytes)", len(va))
+		}
+		for k, v := range dataB {
+			if _, ok := dataA[k]; ok && dataA[k] == dataB[k] {
+				continue
+			}
+			dataB[k] = fmt.Sprintf("++++++++ # (%d bytes)", len(fmt.Sprint(v)))
+		}
+	}
+
+	return marshalMasked(objA), marshalMasked(objB)
+}
+
+func marshalMasked(obj map[string]interface{}) string {
+	if obj == nil {
+		return ""
+	}
+	b, err := yaml.Marshal(obj)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(b))
+}
+
+// splitLines splits s into lines for difflib, an empty string having no lines.
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return difflib.SplitLines(s)
+}
@@ -0,0 +1,167 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tiller
+
+import (
+	"strings"
+	"testing"
+
+	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/proto/hapi/chart"
+	"k8s.io/helm/pkg/proto/hapi/release"
+	"k8s.io/helm/pkg/proto/hapi/services"
+)
+
+var diffCurrentManifest = `---
+# Source: hello/templates/cm.yaml
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: kept
+data:
+  key: one
+---
+# Source: hello/templates/removed.yaml
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: removed
+---
+# Source: hello/templates/secret.yaml
+apiVersion: v1
+kind: Secret
+metadata:
+  name: creds
+data:
+  password: c2VjcmV0
+  user: YWRtaW4=
+`
+
+var diffTargetManifest = `---
+# Source: hello/templates/cm.yaml
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: kept
+data:
+  key: two
+---
+# Source: hello/templates/added.yaml
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: added
+  namespace: other
+---
+# Source: hello/templates/secret.yaml
+apiVersion: v1
+kind: Secret
+metadata:
+  name: creds
+data:
+  password: bmV3c2VjcmV0
+  user: YWRtaW4=
+`
+
+func TestDiffReleases(t *testing.T) {
+	current := &release.Release{Name: "hello", Namespace: "spaced", Version: 1, Manifest: diffCurrentManifest}
+	target := &release.Release{Name: "hello", Namespace: "spaced", Version: 2, Manifest: diffTargetManifest}
+
+	diffs, err := diffReleases(current, target)
+	if err != nil {
+		t.Fatalf("Failed to diff releases: %s", err)
+	}
+
+	expected := []struct {
+		kind, namespace, name string
+		change                services.ResourceDiff_Change
+	}{
+		{"ConfigMap", "other", "added", services.ResourceDiff_ADDED},
+		{"ConfigMap", "spaced", "kept", services.ResourceDiff_MODIFIED},
+		{"ConfigMap", "spaced", "removed", services.ResourceDiff_REMOVED},
+		{"Secret", "spaced", "creds", services.ResourceDiff_MODIFIED},
+	}
+	if len(diffs) != len(expected) {
+		t.Fatalf("Expected %d diffs, got %d: %v", len(expected), len(diffs), diffs)
+	}
+	for i, e := range expected {
+		d := diffs[i]
+		if d.Kind != e.kind || d.Namespace != e.namespace || d.Name != e.name || d.Change != e.change {
+			t.Errorf("Expected diff %d to be %v, got %s/%s/%s %s", i, e, d.Kind, d.Namespace, d.Name, d.Change)
+		}
+		if !strings.HasPrefix(d.Diff, "--- hello.v1\n+++ hello.v2\n") {
+			t.Errorf("Unexpected diff header for %s: %q", d.Name, d.Diff)
+		}
+	}
+
+	if !strings.Contains(diffs[1].Diff, "-  key: one\n+  key: two\n") {
+		t.Errorf("Unexpected diff for modified ConfigMap: %q", diffs[1].Diff)
+	}
+
+	secret := diffs[3].Diff
+	for _, value := range []string{"c2VjcmV0", "bmV3c2VjcmV0", "YWRtaW4="} {
+		if strings.Contains(secret, value) {
+			t.Errorf("Expected Secret value %q to be masked: %q", value, secret)
+		}
+	}
+	if !strings.Contains(secret, "-  password: '-------- # (8 bytes)'") || !strings.Contains(secret, "+  password: '++++++++ # (12 bytes)'") {
+		t.Errorf("Expected changed Secret value to show up in the diff: %q", secret)
+	}
+	if !strings.Contains(secret, "   user: 'REDACTED # (8 bytes)'") {
+		t.Errorf("Expected unchanged Secret value to be redacted: %q", secret)
+	}
+}
+
+func TestUpdateRelease_Diff(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+	rel := releaseStub()
+	rs.env.Releases.Create(rel)
+
+	req := &services.UpdateReleaseRequest{
+		Name: rel.Name,
+		Chart: &chart.Chart{
+			Metadata: &chart.Metadata{Name: "hello"},
+			Templates: []*chart.Template{
+				{Name: "templates/hello", Data: []byte("kind: ConfigMap\nmetadata:\n  name: hello\ndata:\n  hello: world\n")},
+			},
+		},
+		Diff: true,
+	}
+	res, err := rs.UpdateRelease(c, req)
+	if err != nil {
+		t.Fatalf("Failed diff: %s", err)
+	}
+
+	if len(res.Diffs) != 1 {
+		t.Fatalf("Expected 1 diff, got %d", len(res.Diffs))
+	}
+	if res.Diffs[0].Change != services.ResourceDiff_ADDED {
+		t.Errorf("Expected an added resource, got %s", res.Diffs[0].Change)
+	}
+
+	if _, err := rs.env.Releases.Get(rel.Name, 2); err == nil {
+		t.Errorf("Expected diff not to store a new release")
+	}
+	current, err := rs.env.Releases.Get(rel.Name, rel.Version)
+	if err != nil {
+		t.Fatalf("Failed to get current release: %s", err)
+	}
+	if current.Info.Status.Code != release.Status_DEPLOYED {
+		t.Errorf("Expected current release to stay deployed, got %s", current.Info.Status.Code)
+	}
+}
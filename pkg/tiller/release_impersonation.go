@@ -0,0 +1,84 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tiller
+
+import (
+	"crypto/x509"
+
+	ctx "golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"k8s.io/helm/pkg/kube"
+)
+
+// asCaller returns a copy of the server for the operation of the caller,
+// which is performed on the cluster as the caller if the server impersonates
+// its callers.
+func (s *ReleaseServer) asCaller(c ctx.Context) (*ReleaseServer, error) {
+	rs := *s
+	rs.caller = authenticatedUser(c)
+	if s.KubeClientForUser == nil {
+		return &rs, nil
+	}
+	user := callerIdentity(c)
+	if user == nil {
+		return nil, status.Error(codes.Unauthenticated, "Tiller performs operations as its callers, but cannot identify the caller: send a Kubernetes bearer token or a client certificate")
+	}
+	s.Log("performing operation as %q (groups %v)", user.Username, user.Groups)
+
+	env := *s.env
+	env.KubeClient = s.KubeClientForUser(user)
+	if s.progress != nil {
+		env.KubeClient = &progressKubeClient{
+			KubeClient: env.KubeClient,
+			progress:   func(e kube.Event) { s.progress(resourceEvent(e)) },
+		}
+	}
+	rs.env = &env
+	return &rs, nil
+}
+
+// callerIdentity returns the user the caller authenticated as with a bearer
+// token or, failing that, with a verified TLS client certificate, whose common
+// name is the user and whose organizations are the groups of the user.
+func callerIdentity(c ctx.Context) *UserInfo {
+	if user := authenticatedUser(c); user != nil {
+		return user
+	}
+	cert := verifiedClientCert(c)
+	if cert == nil || cert.Subject.CommonName == "" {
+		return nil
+	}
+	return &UserInfo{Username: cert.Subject.CommonName, Groups: cert.Subject.Organization}
+}
+
+// verifiedClientCert returns the verified TLS client certificate of the
+// caller, if any.
+func verifiedClientCert(c ctx.Context) *x509.Certificate {
+	p, ok := peer.FromContext(c)
+	if !ok {
+		return nil
+	}
+	info, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(info.State.VerifiedChains) == 0 || len(info.State.VerifiedChains[0]) == 0 {
+		return nil
+	}
+	return info.State.VerifiedChains[0][0]
+}
@@ -0,0 +1,154 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tiller
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"io"
+	"io/ioutil"
+	"net"
+	"testing"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/kube"
+	"k8s.io/helm/pkg/proto/hapi/services"
+	"k8s.io/helm/pkg/tiller/environment"
+)
+
+// userKubeClient records the resources created as a user.
+type userKubeClient struct {
+	environment.PrintingKubeClient
+	user    *UserInfo
+	created *[]string
+}
+
+func (u *userKubeClient) Create(namespace string, reader io.Reader, timeout int64, shouldWait bool) error {
+	return u.CreateWithOptions(namespace, reader, kube.CreateOptions{Timeout: timeout, ShouldWait: shouldWait})
+}
+
+func (u *userKubeClient) CreateWithOptions(namespace string, reader io.Reader, opts kube.CreateOptions) error {
+	*u.created = append(*u.created, u.user.Username)
+	if opts.Progress != nil {
+		opts.Progress(kube.Event{Type: kube.EventResourceCreated, Kind: "ConfigMap", Name: "test"})
+	}
+	return nil
+}
+
+func impersonatingFixture(created *[]string) *ReleaseServer {
+	rs := rsFixture()
+	rs.KubeClientForUser = func(user *UserInfo) environment.KubeClient {
+		return &userKubeClient{PrintingKubeClient: environment.PrintingKubeClient{Out: ioutil.Discard}, user: user, created: created}
+	}
+	return rs
+}
+
+func tlsPeerContext(c context.Context, commonName string, organizations ...string) context.Context {
+	cert := &x509.Certificate{Subject: pkix.Name{CommonName: commonName, Organization: organizations}}
+	return peer.NewContext(c, &peer.Peer{
+		Addr:     &net.TCPAddr{IP: net.IPv4(10, 0, 0, 1), Port: 4000},
+		AuthInfo: credentials.TLSInfo{State: tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{cert}}}},
+	})
+}
+
+func TestInstallRelease_Impersonation(t *testing.T) {
+	tests := []struct {
+		name string
+		c    context.Context
+		user string
+		code codes.Code
+	}{
+		{
+			name: "authenticated user",
+			c:    withAuthenticatedUser(helm.NewContext(), &UserInfo{Username: "alice", Groups: []string{"team-a"}}),
+			user: "alice",
+		},
+		{
+			name: "client certificate",
+			c:    tlsPeerContext(helm.NewContext(), "bob", "team-b"),
+			user: "bob",
+		},
+		{
+			name: "authenticated user over client certificate",
+			c:    withAuthenticatedUser(tlsPeerContext(helm.NewContext(), "bob"), &UserInfo{Username: "alice"}),
+			user: "alice",
+		},
+		{
+			name: "client certificate without common name",
+			c:    tlsPeerContext(helm.NewContext(), ""),
+			code: codes.Unauthenticated,
+		},
+		{
+			name: "anonymous caller",
+			c:    helm.NewContext(),
+			code: codes.Unauthenticated,
+		},
+	}
+
+	for _, tt := range tests {
+		var created []string
+		rs := impersonatingFixture(&created)
+		_, err := rs.InstallRelease(tt.c, installRequest())
+		if code := status.Code(err); code != tt.code {
+			t.Errorf("%s: expected code %s, got %s (%v)", tt.name, tt.code, code, err)
+			continue
+		}
+		if tt.code != codes.OK {
+			if len(created) != 0 {
+				t.Errorf("%s: expected no resources to be created, got %v", tt.name, created)
+			}
+			continue
+		}
+		if len(created) == 0 {
+			t.Errorf("%s: expected resources to be created as %s", tt.name, tt.user)
+		}
+		for _, u := range created {
+			if u != tt.user {
+				t.Errorf("%s: expected resources to be created as %s, got %s", tt.name, tt.user, u)
+			}
+		}
+	}
+}
+
+func TestInstallRelease_ImpersonationKeepsProgress(t *testing.T) {
+	var created []string
+	var events []*services.ReleaseEvent
+	rs := impersonatingFixture(&created).withProgress(func(ev *services.ReleaseEvent) {
+		events = append(events, ev)
+	})
+
+	c := withAuthenticatedUser(helm.NewContext(), &UserInfo{Username: "alice"})
+	if _, err := rs.InstallRelease(c, installRequest()); err != nil {
+		t.Fatalf("Failed install: %s", err)
+	}
+	if len(created) == 0 {
+		t.Fatal("Expected resources to be created as alice")
+	}
+	for _, ev := range events {
+		if ev.Type == services.ReleaseEvent_RESOURCE_CREATED {
+			return
+		}
+	}
+	t.Errorf("Expected a resource created event, got %v", events)
+}
@@ -19,9 +19,11 @@ package tiller
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	ctx "golang.org/x/net/context"
 
+	"k8s.io/helm/pkg/audit"
 	"k8s.io/helm/pkg/chartutil"
 	"k8s.io/helm/pkg/hooks"
 	"k8s.io/helm/pkg/proto/hapi/release"
@@ -32,6 +34,27 @@ import (
 
 // InstallRelease installs a release and stores the release record.
 func (s *ReleaseServer) InstallRelease(c ctx.Context, req *services.InstallReleaseRequest) (*services.InstallReleaseResponse, error) {
+	start := time.Now()
+	res, err := s.installRelease(c, req)
+	if !req.DryRun {
+		s.audit(c, audit.OperationInstall, start, req.Name, req.Namespace, res.GetRelease(), err)
+	}
+	return res, err
+}
+
+func (s *ReleaseServer) installRelease(c ctx.Context, req *services.InstallReleaseRequest) (*services.InstallReleaseResponse, error) {
+	s, err := s.asCaller(c)
+	if err != nil {
+		return nil, err
+	}
+	// generated names are unique, only the releases named by the client need a lock
+	if req.Name != "" && !req.DryRun {
+		unlock, err := s.lockRelease(c, req.Name, "install")
+		if err != nil {
+			return nil, err
+		}
+		defer unlock()
+	}
 	s.Log("preparing install for %s", req.Name)
 	rel, err := s.prepareRelease(req)
 	if err != nil {
@@ -45,6 +68,9 @@ func (s *ReleaseServer) InstallRelease(c ctx.Context, req *services.InstallRelea
 		}
 		return res, err
 	}
+	if err := s.authorizeNamespaces(VerbCreate, rel); err != nil {
+		return nil, err
+	}
 
 	s.Log("performing install for %s", req.Name)
 	res, err := s.performRelease(rel, req)
@@ -164,6 +190,9 @@ func (s *ReleaseServer) performRelease(r *release.Release, req *services.Install
 		res.Release.Info.Description = "Dry run complete"
 		return res, nil
 	}
+	if err := s.checkLock(r.Name); err != nil {
+		return res, err
+	}
 
 	// crd-install hooks
 	if !req.DisableHooks && !req.DisableCrdHook {
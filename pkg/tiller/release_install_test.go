@@ -529,6 +529,27 @@ func TestInstallRelease_WrongKubeVersion(t *testing.T) {
 	}
 }
 
+func TestInstallRelease_ValuesSchema(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+
+	schema := `{"properties": {"replicas": {"type": "integer"}}}`
+	req := installRequest(
+		withChart(withSchema(schema), withDependency(withSchema(schema))),
+	)
+	req.Values = &chart.Config{Raw: "replicas: 1\nhello:\n  replicas: many\n"}
+
+	_, err := rs.InstallRelease(c, req)
+	if err == nil {
+		t.Fatalf("Expected values violating the schema to be rejected")
+	}
+
+	expect := "hello.replicas: Invalid type. Expected: integer, given: string"
+	if !strings.Contains(err.Error(), expect) {
+		t.Errorf("Expected %q to contain %q", err.Error(), expect)
+	}
+}
+
 func TestInstallRelease_Description(t *testing.T) {
 	c := helm.NewContext()
 	rs := rsFixture()
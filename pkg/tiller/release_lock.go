@@ -0,0 +1,188 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tiller
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	ctx "golang.org/x/net/context"
+	"google.golang.org/grpc/peer"
+
+	"k8s.io/helm/pkg/proto/hapi/services"
+	"k8s.io/helm/pkg/storage/driver"
+	storageerrors "k8s.io/helm/pkg/storage/errors"
+)
+
+// lockTTL is how long a release lock is held unless renewed. Locks are
+// renewed while the operation runs, so this only bounds how long a release
+// stays locked after Tiller died in the middle of an operation.
+var lockTTL = time.Minute
+
+// lockRelease locks the release name for the operation, and keeps renewing
+// the lock until the returned function is called to unlock the release. If
+// the lock is cleared meanwhile, it is not renewed anymore and checkLock
+// fails, to abort the operation.
+func (s *ReleaseServer) lockRelease(c ctx.Context, name, operation string) (func(), error) {
+	if err := validateReleaseName(name); err != nil {
+		return nil, err
+	}
+	id, err := newLockID()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	lock := &driver.Lock{
+		ID:        id,
+		Holder:    lockHolder(c),
+		Operation: operation,
+		Acquired:  now,
+		Expires:   now.Add(lockTTL),
+	}
+	if err := s.env.Releases.AcquireLock(name, lock); err != nil {
+		return nil, fmt.Errorf("%s. If the lock is stale, clear it with 'helm unlock %s'", err, name)
+	}
+
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		t := time.NewTicker(lockTTL / 3)
+		defer t.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-t.C:
+				renewed := *lock
+				renewed.Expires = time.Now().Add(lockTTL)
+				err := s.env.Releases.RenewLock(name, &renewed)
+				switch {
+				case err == nil:
+				case lockLost(name, err):
+					// The lock was cleared, e.g. with 'helm unlock': stop
+					// renewing it rather than locking the release again.
+					s.Log("warning: the lock on %s was lost, aborting the %s: %s", name, operation, err)
+					s.lostLocks.add(name)
+					return
+				default:
+					s.Log("warning: failed to renew the lock on %s: %s", name, err)
+				}
+			}
+		}
+	}()
+
+	return func() {
+		close(done)
+		<-stopped
+		if s.lostLocks.remove(name) {
+			return
+		}
+		if _, err := s.env.Releases.ReleaseLock(name, id); err != nil {
+			s.Log("warning: failed to unlock %s: %s", name, err)
+		}
+	}, nil
+}
+
+// checkLock returns an error if the lock taken on the release name by the
+// operation was lost while it ran, so that the operation is aborted.
+func (s *ReleaseServer) checkLock(name string) error {
+	if s.lostLocks.has(name) {
+		return fmt.Errorf("the lock on %s was cleared while the operation ran, aborting it", name)
+	}
+	return nil
+}
+
+// lockLost returns whether err, returned when renewing the lock on the
+// release name, means that the lock was cleared or taken over.
+func lockLost(name string, err error) bool {
+	return err.Error() == storageerrors.ErrLockNotFound(name).Error() ||
+		strings.HasPrefix(err.Error(), storageerrors.ErrReleaseLocked(name, "").Error())
+}
+
+// lostLockSet remembers the releases whose lock was lost by the operation
+// holding it, until the operation unlocks the release.
+type lostLockSet struct {
+	mu    sync.Mutex
+	names map[string]bool
+}
+
+func newLostLockSet() *lostLockSet {
+	return &lostLockSet{names: map[string]bool{}}
+}
+
+func (l *lostLockSet) add(name string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.names[name] = true
+}
+
+func (l *lostLockSet) has(name string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.names[name]
+}
+
+// remove forgets the release name, returning whether its lock was lost.
+func (l *lostLockSet) remove(name string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	lost := l.names[name]
+	delete(l.names, name)
+	return lost
+}
+
+// UnlockRelease clears the lock held on a release, whoever holds it.
+func (s *ReleaseServer) UnlockRelease(c ctx.Context, req *services.UnlockReleaseRequest) (*services.UnlockReleaseResponse, error) {
+	if err := validateReleaseName(req.Name); err != nil {
+		s.Log("unlockRelease: Release name is invalid: %s", req.Name)
+		return nil, err
+	}
+
+	lock, err := s.env.Releases.ReleaseLock(req.Name, "")
+	if err != nil {
+		return nil, err
+	}
+	s.Log("cleared lock on %s held by %s", req.Name, lock)
+	return &services.UnlockReleaseResponse{Holder: lock.Holder, Operation: lock.Operation}, nil
+}
+
+// lockHolder describes the client calling Tiller: the user it identified
+// itself as and its address. Locks taken outside of a call are held by Tiller.
+func lockHolder(c ctx.Context) string {
+	p, ok := peer.FromContext(c)
+	if !ok {
+		return "tiller"
+	}
+	addr := p.Addr.String()
+	if u := userFromContext(c); u != "" {
+		return fmt.Sprintf("%s (%s)", u, addr)
+	}
+	return addr
+}
+
+func newLockID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
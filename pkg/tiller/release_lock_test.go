@@ -0,0 +1,149 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tiller
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/proto/hapi/services"
+	"k8s.io/helm/pkg/storage/driver"
+)
+
+func heldLock() *driver.Lock {
+	now := time.Now()
+	return &driver.Lock{
+		ID:        "other",
+		Holder:    "bob@ci (10.0.0.2:4000)",
+		Operation: "rollback",
+		Acquired:  now,
+		Expires:   now.Add(time.Minute),
+	}
+}
+
+func TestUpdateRelease_Locked(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+	rel := releaseStub()
+	rs.env.Releases.Create(rel)
+	if err := rs.env.Releases.AcquireLock(rel.Name, heldLock()); err != nil {
+		t.Fatalf("Failed to lock release: %s", err)
+	}
+
+	req := &services.UpdateReleaseRequest{Name: rel.Name, Chart: rel.Chart}
+	_, err := rs.UpdateRelease(c, req)
+	if err == nil {
+		t.Fatal("Expected upgrade of a locked release to fail")
+	}
+	for _, expected := range []string{"is locked by rollback from bob@ci (10.0.0.2:4000)", "helm unlock angry-panda"} {
+		if !strings.Contains(err.Error(), expected) {
+			t.Errorf("Expected error to contain %q, got %q", expected, err)
+		}
+	}
+	if _, err := rs.env.Releases.Get(rel.Name, 2); err == nil {
+		t.Error("Expected no new revision to be stored")
+	}
+
+	// previews do not need the lock
+	req.DryRun = true
+	if _, err := rs.UpdateRelease(c, req); err != nil {
+		t.Errorf("Failed dry-run upgrade of a locked release: %s", err)
+	}
+}
+
+func TestUpdateRelease_Unlocks(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+	rel := releaseStub()
+	rs.env.Releases.Create(rel)
+
+	req := &services.UpdateReleaseRequest{Name: rel.Name, Chart: rel.Chart}
+	if _, err := rs.UpdateRelease(c, req); err != nil {
+		t.Fatalf("Failed upgrade: %s", err)
+	}
+	if l, err := rs.env.Releases.GetLock(rel.Name); err == nil {
+		t.Errorf("Expected the release to be unlocked after the upgrade, got %v", l)
+	}
+}
+
+func TestLockRelease_Renews(t *testing.T) {
+	defer func(ttl time.Duration) { lockTTL = ttl }(lockTTL)
+	lockTTL = 30 * time.Millisecond
+
+	rs := rsFixture()
+	unlock, err := rs.lockRelease(helm.NewContext(), "angry-panda", "upgrade")
+	if err != nil {
+		t.Fatalf("Failed to lock release: %s", err)
+	}
+	time.Sleep(4 * lockTTL)
+	if _, err := rs.lockRelease(helm.NewContext(), "angry-panda", "upgrade"); err == nil {
+		t.Error("Expected the lock to be renewed while held")
+	}
+
+	unlock()
+	if _, err := rs.env.Releases.GetLock("angry-panda"); err == nil {
+		t.Error("Expected the release to be unlocked")
+	}
+}
+
+func TestLockRelease_Cleared(t *testing.T) {
+	defer func(ttl time.Duration) { lockTTL = ttl }(lockTTL)
+	lockTTL = 30 * time.Millisecond
+
+	rs := rsFixture()
+	unlock, err := rs.lockRelease(helm.NewContext(), "angry-panda", "upgrade")
+	if err != nil {
+		t.Fatalf("Failed to lock release: %s", err)
+	}
+	if _, err := rs.UnlockRelease(helm.NewContext(), &services.UnlockReleaseRequest{Name: "angry-panda"}); err != nil {
+		t.Fatalf("Failed unlock: %s", err)
+	}
+	time.Sleep(4 * lockTTL)
+	if l, err := rs.env.Releases.GetLock("angry-panda"); err == nil {
+		t.Errorf("Expected a cleared lock not to be renewed, got %v", l)
+	}
+	if err := rs.checkLock("angry-panda"); err == nil {
+		t.Error("Expected the operation to be aborted once its lock is cleared")
+	}
+
+	unlock()
+	if err := rs.checkLock("angry-panda"); err != nil {
+		t.Errorf("Expected the lost lock to be forgotten once unlocked: %s", err)
+	}
+}
+
+func TestUnlockRelease(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+	if err := rs.env.Releases.AcquireLock("angry-panda", heldLock()); err != nil {
+		t.Fatalf("Failed to lock release: %s", err)
+	}
+
+	res, err := rs.UnlockRelease(c, &services.UnlockReleaseRequest{Name: "angry-panda"})
+	if err != nil {
+		t.Fatalf("Failed unlock: %s", err)
+	}
+	if res.Holder != "bob@ci (10.0.0.2:4000)" || res.Operation != "rollback" {
+		t.Errorf("Unexpected cleared lock: %v", res)
+	}
+
+	if _, err := rs.UnlockRelease(c, &services.UnlockReleaseRequest{Name: "angry-panda"}); err == nil {
+		t.Error("Expected unlocking an unlocked release to fail")
+	}
+}
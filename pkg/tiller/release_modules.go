@@ -58,14 +58,26 @@ func (m *LocalReleaseModule) Create(r *release.Release, req *services.InstallRel
 func (m *LocalReleaseModule) Update(current, target *release.Release, req *services.UpdateReleaseRequest, env *environment.Environment) error {
 	c := bytes.NewBufferString(current.Manifest)
 	t := bytes.NewBufferString(target.Manifest)
-	return env.KubeClient.Update(target.Namespace, c, t, req.Force, req.Recreate, req.Timeout, req.Wait)
+	return env.KubeClient.UpdateWithOptions(target.Namespace, c, t, kube.UpdateOptions{
+		Force:         req.Force,
+		Recreate:      req.Recreate,
+		Timeout:       req.Timeout,
+		ShouldWait:    req.Wait,
+		ThreeWayMerge: req.ThreeWayMerge,
+	})
 }
 
 // Rollback performs a rollback from current to target release
 func (m *LocalReleaseModule) Rollback(current, target *release.Release, req *services.RollbackReleaseRequest, env *environment.Environment) error {
 	c := bytes.NewBufferString(current.Manifest)
 	t := bytes.NewBufferString(target.Manifest)
-	return env.KubeClient.Update(target.Namespace, c, t, req.Force, req.Recreate, req.Timeout, req.Wait)
+	return env.KubeClient.UpdateWithOptions(target.Namespace, c, t, kube.UpdateOptions{
+		Force:         req.Force,
+		Recreate:      req.Recreate,
+		Timeout:       req.Timeout,
+		ShouldWait:    req.Wait,
+		ThreeWayMerge: req.ThreeWayMerge,
+	})
 }
 
 // Status returns kubectl-like formatted status of release objects
@@ -95,12 +107,13 @@ func (m *RemoteReleaseModule) Create(r *release.Release, req *services.InstallRe
 // Update calls rudder.UpgradeRelease
 func (m *RemoteReleaseModule) Update(current, target *release.Release, req *services.UpdateReleaseRequest, env *environment.Environment) error {
 	upgrade := &rudderAPI.UpgradeReleaseRequest{
-		Current:  current,
-		Target:   target,
-		Recreate: req.Recreate,
-		Timeout:  req.Timeout,
-		Wait:     req.Wait,
-		Force:    req.Force,
+		Current:       current,
+		Target:        target,
+		Recreate:      req.Recreate,
+		Timeout:       req.Timeout,
+		Wait:          req.Wait,
+		Force:         req.Force,
+		ThreeWayMerge: req.ThreeWayMerge,
 	}
 	_, err := rudder.UpgradeRelease(upgrade)
 	return err
@@ -109,11 +122,12 @@ func (m *RemoteReleaseModule) Update(current, target *release.Release, req *serv
 // Rollback calls rudder.Rollback
 func (m *RemoteReleaseModule) Rollback(current, target *release.Release, req *services.RollbackReleaseRequest, env *environment.Environment) error {
 	rollback := &rudderAPI.RollbackReleaseRequest{
-		Current:  current,
-		Target:   target,
-		Recreate: req.Recreate,
-		Timeout:  req.Timeout,
-		Wait:     req.Wait,
+		Current:       current,
+		Target:        target,
+		Recreate:      req.Recreate,
+		Timeout:       req.Timeout,
+		Wait:          req.Wait,
+		ThreeWayMerge: req.ThreeWayMerge,
 	}
 	_, err := rudder.RollbackRelease(rollback)
 	return err
@@ -0,0 +1,64 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tiller
+
+import (
+	"k8s.io/helm/pkg/policy"
+	"k8s.io/helm/pkg/proto/hapi/release"
+	"k8s.io/helm/pkg/proto/hapi/services"
+)
+
+// checkPolicies checks the rendered hooks and manifests of a release against
+// the policy rules of the server.
+//
+// Violations fail the operation, unless the rules only warn about them; the
+// warnings are then logged and reported as progress events.
+func (s *ReleaseServer) checkPolicies(hooks []*release.Hook, manifests []Manifest) error {
+	if s.PolicyChecker == nil {
+		return nil
+	}
+
+	var objs []*policy.Object
+	for _, m := range manifests {
+		obj, err := policy.ParseObject(m.Name, m.Content)
+		if err != nil {
+			return err
+		}
+		if obj != nil {
+			objs = append(objs, obj)
+		}
+	}
+	for _, h := range hooks {
+		obj, err := policy.ParseObject(h.Path, h.Manifest)
+		if err != nil {
+			return err
+		}
+		if obj != nil {
+			objs = append(objs, obj)
+		}
+	}
+
+	report, err := s.PolicyChecker.Check(objs)
+	if err != nil {
+		return err
+	}
+	for _, v := range report.Violations {
+		s.Log("warning: policy violation: %s", v)
+		s.sendProgress(&services.ReleaseEvent{Type: services.ReleaseEvent_WARNING, Kind: v.Kind, Name: v.Name, Message: v.String()})
+	}
+	return nil
+}
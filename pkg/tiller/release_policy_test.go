@@ -0,0 +1,88 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tiller
+
+import (
+	"strings"
+	"testing"
+
+	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/policy"
+	"k8s.io/helm/pkg/proto/hapi/chart"
+	"k8s.io/helm/pkg/proto/hapi/services"
+)
+
+var privilegedPod = `apiVersion: v1
+kind: Pod
+metadata:
+  name: debug
+spec:
+  containers:
+  - name: debug
+    image: busybox
+    securityContext:
+      privileged: true
+`
+
+func withPrivilegedPod() chartOption {
+	return func(opts *chartOptions) {
+		opts.Templates = append(opts.Templates, &chart.Template{Name: "templates/pod.yaml", Data: []byte(privilegedPod)})
+	}
+}
+
+func TestInstallRelease_PolicyViolation(t *testing.T) {
+	rs := rsFixture()
+	rs.PolicyChecker = &policy.Checker{Validators: []policy.Validator{policy.NoPrivileged{}}}
+
+	_, err := rs.InstallRelease(helm.NewContext(), installRequest(withChart(withPrivilegedPod())))
+	if err == nil {
+		t.Fatal("Expected the policy violation to fail the install")
+	}
+	expected := `[no-privileged] Pod/debug in hello/templates/pod.yaml: container "debug" is privileged`
+	if !strings.Contains(err.Error(), expected) {
+		t.Errorf("Expected error to contain %q, got %q", expected, err)
+	}
+	if rels, _ := rs.env.Releases.ListReleases(); len(rels) != 0 {
+		t.Errorf("Expected no release to be recorded, got %d", len(rels))
+	}
+
+	// Compliant charts are installed as usual.
+	if _, err := rs.InstallRelease(helm.NewContext(), installRequest()); err != nil {
+		t.Errorf("Failed install: %s", err)
+	}
+}
+
+func TestInstallReleaseStream_PolicyWarning(t *testing.T) {
+	rs := rsFixture()
+	rs.PolicyChecker = &policy.Checker{Validators: []policy.Validator{policy.NoPrivileged{}}, WarnOnly: true}
+
+	stream := &mockReleaseEventServer{}
+	if err := rs.InstallReleaseStream(installRequest(withChart(withPrivilegedPod())), stream); err != nil {
+		t.Fatalf("Failed install: %s", err)
+	}
+
+	var warnings []string
+	for _, ev := range stream.events {
+		if ev.Type == services.ReleaseEvent_WARNING {
+			warnings = append(warnings, ev.Message)
+		}
+	}
+	expected := `[no-privileged] Pod/debug in hello/templates/pod.yaml: container "debug" is privileged`
+	if len(warnings) != 1 || warnings[0] != expected {
+		t.Errorf("Expected warning %q, got %v", expected, warnings)
+	}
+}
@@ -0,0 +1,147 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tiller
+
+import (
+	"io"
+	"sync"
+
+	"k8s.io/helm/pkg/kube"
+	"k8s.io/helm/pkg/proto/hapi/release"
+	"k8s.io/helm/pkg/proto/hapi/services"
+	"k8s.io/helm/pkg/tiller/environment"
+)
+
+// InstallReleaseStream installs a release, streaming its progress while it is deployed.
+func (s *ReleaseServer) InstallReleaseStream(req *services.InstallReleaseRequest, stream services.ReleaseService_InstallReleaseStreamServer) error {
+	send := s.progressSender(stream.Send)
+	res, err := s.withProgress(send).InstallRelease(stream.Context(), req)
+	if err != nil {
+		return err
+	}
+	return stream.Send(completeEvent(res.Release))
+}
+
+// UpdateReleaseStream upgrades a release, streaming its progress while it is deployed.
+func (s *ReleaseServer) UpdateReleaseStream(req *services.UpdateReleaseRequest, stream services.ReleaseService_UpdateReleaseStreamServer) error {
+	send := s.progressSender(stream.Send)
+	res, err := s.withProgress(send).UpdateRelease(stream.Context(), req)
+	if err != nil {
+		return err
+	}
+	return stream.Send(completeEvent(res.Release))
+}
+
+// RollbackReleaseStream rolls back a release, streaming its progress while it is deployed.
+func (s *ReleaseServer) RollbackReleaseStream(req *services.RollbackReleaseRequest, stream services.ReleaseService_RollbackReleaseStreamServer) error {
+	send := s.progressSender(stream.Send)
+	res, err := s.withProgress(send).RollbackRelease(stream.Context(), req)
+	if err != nil {
+		return err
+	}
+	return stream.Send(completeEvent(res.Release))
+}
+
+// progressSender serializes the events sent on a stream. A failure to send an
+// event, typically because the client went away, does not abort the operation.
+func (s *ReleaseServer) progressSender(send func(*services.ReleaseEvent) error) func(*services.ReleaseEvent) {
+	var mu sync.Mutex
+	return func(ev *services.ReleaseEvent) {
+		mu.Lock()
+		defer mu.Unlock()
+		if err := send(ev); err != nil {
+			s.Log("warning: failed to send progress event: %s", err)
+		}
+	}
+}
+
+// withProgress returns a copy of the server reporting the progress of its
+// operations to send.
+func (s *ReleaseServer) withProgress(send func(*services.ReleaseEvent)) *ReleaseServer {
+	env := *s.env
+	env.KubeClient = &progressKubeClient{
+		KubeClient: s.env.KubeClient,
+		progress:   func(e kube.Event) { send(resourceEvent(e)) },
+	}
+	rs := *s
+	rs.env = &env
+	rs.progress = send
+	return &rs
+}
+
+// sendProgress reports ev if the server streams its progress.
+func (s *ReleaseServer) sendProgress(ev *services.ReleaseEvent) {
+	if s.progress != nil {
+		s.progress(ev)
+	}
+}
+
+func completeEvent(r *release.Release) *services.ReleaseEvent {
+	return &services.ReleaseEvent{Type: services.ReleaseEvent_COMPLETE, Release: r}
+}
+
+var resourceEventTypes = map[kube.EventType]services.ReleaseEvent_Type{
+	kube.EventResourceCreated: services.ReleaseEvent_RESOURCE_CREATED,
+	kube.EventResourceUpdated: services.ReleaseEvent_RESOURCE_UPDATED,
+	kube.EventResourceDeleted: services.ReleaseEvent_RESOURCE_DELETED,
+	kube.EventWaiting:         services.ReleaseEvent_WAITING,
+}
+
+func resourceEvent(e kube.Event) *services.ReleaseEvent {
+	return &services.ReleaseEvent{
+		Type:      resourceEventTypes[e.Type],
+		Kind:      e.Kind,
+		Namespace: e.Namespace,
+		Name:      e.Name,
+		Ready:     e.Ready,
+		Total:     e.Total,
+		Message:   e.Message,
+	}
+}
+
+// progressKubeClient reports the progress of the resources created and
+// updated through the wrapped KubeClient.
+type progressKubeClient struct {
+	environment.KubeClient
+	progress kube.ProgressFunc
+}
+
+func (c *progressKubeClient) Create(namespace string, reader io.Reader, timeout int64, shouldWait bool) error {
+	return c.CreateWithOptions(namespace, reader, kube.CreateOptions{
+		Timeout:    timeout,
+		ShouldWait: shouldWait,
+	})
+}
+
+func (c *progressKubeClient) CreateWithOptions(namespace string, reader io.Reader, opts kube.CreateOptions) error {
+	opts.Progress = c.progress
+	return c.KubeClient.CreateWithOptions(namespace, reader, opts)
+}
+
+func (c *progressKubeClient) Update(namespace string, originalReader, targetReader io.Reader, force bool, recreate bool, timeout int64, shouldWait bool) error {
+	return c.UpdateWithOptions(namespace, originalReader, targetReader, kube.UpdateOptions{
+		Force:      force,
+		Recreate:   recreate,
+		Timeout:    timeout,
+		ShouldWait: shouldWait,
+	})
+}
+
+func (c *progressKubeClient) UpdateWithOptions(namespace string, originalReader, targetReader io.Reader, opts kube.UpdateOptions) error {
+	opts.Progress = c.progress
+	return c.KubeClient.UpdateWithOptions(namespace, originalReader, targetReader, opts)
+}
@@ -0,0 +1,115 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tiller
+
+import (
+	"io"
+	"os"
+	"testing"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/metadata"
+
+	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/kube"
+	"k8s.io/helm/pkg/proto/hapi/release"
+	"k8s.io/helm/pkg/proto/hapi/services"
+	"k8s.io/helm/pkg/tiller/environment"
+)
+
+type mockReleaseEventServer struct {
+	events []*services.ReleaseEvent
+}
+
+func (rs *mockReleaseEventServer) Send(ev *services.ReleaseEvent) error {
+	rs.events = append(rs.events, ev)
+	return nil
+}
+func (rs *mockReleaseEventServer) SetHeader(m metadata.MD) error  { return nil }
+func (rs *mockReleaseEventServer) SendHeader(m metadata.MD) error { return nil }
+func (rs *mockReleaseEventServer) SetTrailer(m metadata.MD)       {}
+func (rs *mockReleaseEventServer) SendMsg(v interface{}) error    { return nil }
+func (rs *mockReleaseEventServer) RecvMsg(v interface{}) error    { return nil }
+func (rs *mockReleaseEventServer) Context() context.Context       { return helm.NewContext() }
+
+// progressingKubeClient reports a single created resource per call.
+type progressingKubeClient struct {
+	environment.PrintingKubeClient
+}
+
+func (c *progressingKubeClient) CreateWithOptions(ns string, r io.Reader, opts kube.CreateOptions) error {
+	if opts.Progress != nil {
+		opts.Progress(kube.Event{Type: kube.EventResourceCreated, Kind: "ConfigMap", Namespace: ns, Name: "created"})
+	}
+	return nil
+}
+
+func TestInstallReleaseStream(t *testing.T) {
+	rs := rsFixture()
+	rs.env.KubeClient = &progressingKubeClient{environment.PrintingKubeClient{Out: os.Stdout}}
+
+	stream := &mockReleaseEventServer{}
+	if err := rs.InstallReleaseStream(installRequest(), stream); err != nil {
+		t.Fatalf("Failed install: %s", err)
+	}
+
+	expected := []struct {
+		typ  services.ReleaseEvent_Type
+		name string
+		hook string
+	}{
+		{services.ReleaseEvent_RESOURCE_CREATED, "created", ""},
+		{services.ReleaseEvent_HOOK_STARTED, "test-cm", "post-install"},
+		{services.ReleaseEvent_RESOURCE_CREATED, "created", ""},
+		{services.ReleaseEvent_HOOK_FINISHED, "test-cm", "post-install"},
+		{services.ReleaseEvent_COMPLETE, "", ""},
+	}
+	if len(stream.events) != len(expected) {
+		t.Fatalf("Expected %d events, got %d: %v", len(expected), len(stream.events), stream.events)
+	}
+	for i, e := range expected {
+		ev := stream.events[i]
+		if ev.Type != e.typ || ev.Name != e.name || ev.Hook != e.hook {
+			t.Errorf("Expected event %d to be %v, got %s %q %q", i, e, ev.Type, ev.Name, ev.Hook)
+		}
+	}
+
+	rel := stream.events[len(stream.events)-1].Release
+	if rel == nil || rel.Info.Status.Code != release.Status_DEPLOYED {
+		t.Errorf("Expected the final event to carry the deployed release, got %v", rel)
+	}
+	if _, ok := rs.env.KubeClient.(*progressingKubeClient); !ok {
+		t.Errorf("Expected streaming not to alter the server environment")
+	}
+}
+
+func TestUpdateReleaseStream(t *testing.T) {
+	rs := rsFixture()
+	rel := releaseStub()
+	rs.env.Releases.Create(rel)
+
+	stream := &mockReleaseEventServer{}
+	req := &services.UpdateReleaseRequest{Name: rel.Name, Chart: rel.Chart}
+	if err := rs.UpdateReleaseStream(req, stream); err != nil {
+		t.Fatalf("Failed upgrade: %s", err)
+	}
+
+	last := stream.events[len(stream.events)-1]
+	if last.Type != services.ReleaseEvent_COMPLETE || last.Release.Version != 2 {
+		t.Errorf("Expected the final event to carry revision 2, got %v", last)
+	}
+}
@@ -0,0 +1,201 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tiller
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	ctx "golang.org/x/net/context"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/cli-runtime/pkg/genericclioptions/resource"
+
+	"k8s.io/helm/pkg/proto/hapi/release"
+	"k8s.io/helm/pkg/proto/hapi/services"
+	relutil "k8s.io/helm/pkg/releaseutil"
+	"k8s.io/helm/pkg/storage"
+	"k8s.io/helm/pkg/timeconv"
+)
+
+// pendingOperations maps the statuses of revisions being deployed to the
+// operation deploying them.
+var pendingOperations = map[release.Status_Code]string{
+	release.Status_PENDING_INSTALL:  "install",
+	release.Status_PENDING_UPGRADE:  "upgrade",
+	release.Status_PENDING_ROLLBACK: "rollback",
+}
+
+// resourceExists returns an error if the resource described by info can not
+// be fetched from the cluster.
+var resourceExists = func(info *resource.Info) error {
+	return info.Get()
+}
+
+// RepairRelease reconciles the last revision of a release left pending by an
+// interrupted operation with the cluster: it is marked deployed if all its
+// resources exist, failed otherwise. An upgrade paused between waves is left
+// as is, one interrupted before applying all its waves is marked failed.
+//
+// Without a release name, every revision pending for longer than
+// PendingTimeout is marked failed.
+func (s *ReleaseServer) RepairRelease(c ctx.Context, req *services.RepairReleaseRequest) (*services.RepairReleaseResponse, error) {
+	s, err := s.asCaller(c)
+	if err != nil {
+		return nil, err
+	}
+	if req.Name == "" {
+		if s.PendingTimeout <= 0 {
+			return nil, errors.New("no pending release timeout is configured in Tiller, name the release to repair")
+		}
+		failed, err := s.FailStalePendingReleases(s.PendingTimeout)
+		return &services.RepairReleaseResponse{Releases: failed}, err
+	}
+
+	unlock, err := s.lockRelease(c, req.Name, "repair")
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	rel, err := s.env.Releases.Last(req.Name)
+	if err != nil {
+		return nil, err
+	}
+	missing, err := s.missingResources(rel)
+	if err != nil {
+		return nil, err
+	}
+	res := &services.RepairReleaseResponse{Missing: missing}
+
+	op, ok := pendingOperations[rel.Info.Status.Code]
+	if !ok {
+		s.Log("repair: %s revision %d is %s, leaving it as is", rel.Name, rel.Version, rel.Info.Status.Code)
+		return res, nil
+	}
+
+	waves := rel.Info.GetWaves()
+	switch {
+	case waves.GetPaused():
+		s.Log("repair: %s revision %d is paused after wave %d of %d, leaving it as is", rel.Name, rel.Version, waves.Applied, waves.Total)
+		return res, nil
+	case waves != nil && waves.Applied < waves.Total:
+		// The waves not applied yet can not be deployed by a repair.
+		s.Log("repair: marking %s revision %d failed, only %d of %d waves were applied", rel.Name, rel.Version, waves.Applied, waves.Total)
+		rel.Info.Status.Code = release.Status_FAILED
+		rel.Info.Description = fmt.Sprintf("Repaired: %s was interrupted after wave %d of %d", op, waves.Applied, waves.Total)
+	case len(missing) > 0:
+		s.Log("repair: marking %s revision %d failed, %d resources are missing", rel.Name, rel.Version, len(missing))
+		rel.Info.Status.Code = release.Status_FAILED
+		rel.Info.Description = fmt.Sprintf("Repaired: %s was interrupted, %d resources are missing from the cluster", op, len(missing))
+	default:
+		// Supersede the deployed revisions, as the interrupted operation would have done.
+		deployed, err := s.env.Releases.DeployedAll(rel.Name)
+		if err != nil && !strings.Contains(err.Error(), storage.NoReleasesErr) {
+			return nil, err
+		}
+		for _, r := range deployed {
+			s.Log("repair: superseding previous deployment %d", r.Version)
+			r.Info.Status.Code = release.Status_SUPERSEDED
+			s.recordRelease(r, true)
+		}
+
+		s.Log("repair: marking %s revision %d deployed", rel.Name, rel.Version)
+		rel.Info.Status.Code = release.Status_DEPLOYED
+		rel.Info.Description = fmt.Sprintf("Repaired: %s was interrupted, all resources exist in the cluster", op)
+	}
+	s.recordRelease(rel, true)
+
+	res.Releases = []*release.Release{rel}
+	return res, nil
+}
+
+// FailStalePendingReleases marks failed the revisions left pending for longer
+// than timeout, skipping the releases an operation still holds the lock of
+// and the upgrades paused between waves.
+func (s *ReleaseServer) FailStalePendingReleases(timeout time.Duration) ([]*release.Release, error) {
+	var filters []relutil.FilterFunc
+	for code := range pendingOperations {
+		filters = append(filters, relutil.StatusFilter(code))
+	}
+	pending, err := s.env.Releases.ListFilterAny(filters...)
+	if err != nil {
+		return nil, err
+	}
+
+	var failed []*release.Release
+	for _, r := range pending {
+		since := timeconv.Time(r.Info.LastDeployed)
+		if time.Since(since) < timeout || r.Info.GetWaves().GetPaused() {
+			continue
+		}
+		if rel, ok := s.failStalePendingRelease(r.Name, r.Version, since); ok {
+			failed = append(failed, rel)
+		}
+	}
+	return failed, nil
+}
+
+// failStalePendingRelease marks failed the given revision of a release,
+// provided no operation holds the release lock and it is still pending.
+func (s *ReleaseServer) failStalePendingRelease(name string, version int32, since time.Time) (*release.Release, bool) {
+	unlock, err := s.lockRelease(ctx.Background(), name, "repair")
+	if err != nil {
+		s.Log("warning: not failing pending release %s: %s", name, err)
+		return nil, false
+	}
+	defer unlock()
+
+	// the operation may have completed before we got the lock
+	rel, err := s.env.Releases.Get(name, version)
+	if err != nil {
+		s.Log("warning: not failing pending release %s: %s", name, err)
+		return nil, false
+	}
+	op, ok := pendingOperations[rel.Info.Status.Code]
+	if !ok || rel.Info.GetWaves().GetPaused() {
+		return nil, false
+	}
+
+	s.Log("marking %s revision %d failed, it has been %s since %s", name, version, rel.Info.Status.Code, since)
+	rel.Info.Status.Code = release.Status_FAILED
+	rel.Info.Description = fmt.Sprintf("Marked failed by Tiller: %s was interrupted, pending since %s", op, since.UTC().Format(time.RFC3339))
+	s.recordRelease(rel, true)
+	return rel, true
+}
+
+// missingResources lists the resources of the release which do not exist
+// in the cluster.
+func (s *ReleaseServer) missingResources(rel *release.Release) ([]string, error) {
+	infos, err := s.env.KubeClient.BuildUnstructured(rel.Namespace, bytes.NewBufferString(rel.Manifest))
+	if err != nil {
+		return nil, err
+	}
+
+	var missing []string
+	for _, info := range infos {
+		if err := resourceExists(info); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return nil, err
+			}
+			missing = append(missing, fmt.Sprintf("%s %s/%s", info.Mapping.GroupVersionKind.Kind, info.Namespace, info.Name))
+		}
+	}
+	return missing, nil
+}
@@ -0,0 +1,246 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tiller
+
+import (
+	"io"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/cli-runtime/pkg/genericclioptions/resource"
+
+	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/kube"
+	"k8s.io/helm/pkg/proto/hapi/release"
+	"k8s.io/helm/pkg/proto/hapi/services"
+	"k8s.io/helm/pkg/tiller/environment"
+	"k8s.io/helm/pkg/timeconv"
+)
+
+// repairKubeClient builds a Deployment and a Service out of any manifest.
+type repairKubeClient struct {
+	environment.PrintingKubeClient
+}
+
+func (c *repairKubeClient) BuildUnstructured(ns string, r io.Reader) (kube.Result, error) {
+	newInfo := func(kind, name string) *resource.Info {
+		return &resource.Info{
+			Name:      name,
+			Namespace: ns,
+			Mapping:   &meta.RESTMapping{GroupVersionKind: schema.GroupVersionKind{Kind: kind}},
+		}
+	}
+	return kube.Result{newInfo("Deployment", "web"), newInfo("Service", "web")}, nil
+}
+
+func pendingReleaseStub(name string, version int32, age time.Duration) *release.Release {
+	rel := namedReleaseStub(name, release.Status_PENDING_UPGRADE)
+	rel.Version = version
+	rel.Info.LastDeployed = timeconv.Timestamp(time.Now().Add(-age))
+	return rel
+}
+
+func TestFailStalePendingReleases(t *testing.T) {
+	rs := rsFixture()
+	stale := pendingReleaseStub("stale", 1, time.Hour)
+	fresh := pendingReleaseStub("fresh", 1, time.Minute)
+	locked := pendingReleaseStub("locked", 1, time.Hour)
+	paused := pendingReleaseStub("paused", 1, time.Hour)
+	paused.Info.Waves = &release.Waves{Total: 3, Applied: 1, Paused: true}
+	deployed := namedReleaseStub("deployed", release.Status_DEPLOYED)
+	for _, rel := range []*release.Release{stale, fresh, locked, paused, deployed} {
+		rs.env.Releases.Create(rel)
+	}
+	if err := rs.env.Releases.AcquireLock(locked.Name, heldLock()); err != nil {
+		t.Fatalf("Failed to lock release: %s", err)
+	}
+
+	failed, err := rs.FailStalePendingReleases(10 * time.Minute)
+	if err != nil {
+		t.Fatalf("Failed to check stale releases: %s", err)
+	}
+	if len(failed) != 1 || failed[0].Name != stale.Name {
+		t.Fatalf("Expected only %q to be marked failed, got %v", stale.Name, failed)
+	}
+
+	expected := map[string]release.Status_Code{
+		"stale":    release.Status_FAILED,
+		"fresh":    release.Status_PENDING_UPGRADE,
+		"locked":   release.Status_PENDING_UPGRADE,
+		"paused":   release.Status_PENDING_UPGRADE,
+		"deployed": release.Status_DEPLOYED,
+	}
+	for name, code := range expected {
+		rel, err := rs.env.Releases.Get(name, 1)
+		if err != nil {
+			t.Fatalf("Failed to get %s: %s", name, err)
+		}
+		if rel.Info.Status.Code != code {
+			t.Errorf("Expected %s to be %s, got %s", name, code, rel.Info.Status.Code)
+		}
+	}
+	rel, _ := rs.env.Releases.Get("stale", 1)
+	if !strings.Contains(rel.Info.Description, "upgrade was interrupted") {
+		t.Errorf("Unexpected description: %q", rel.Info.Description)
+	}
+}
+
+func TestRepairRelease(t *testing.T) {
+	defer func(f func(*resource.Info) error) { resourceExists = f }(resourceExists)
+
+	tests := []struct {
+		name       string
+		missing    []string
+		waves      *release.Waves
+		rev2       release.Status_Code
+		rev1       release.Status_Code
+		descPrefix string
+	}{
+		{
+			name:       "all resources exist",
+			rev2:       release.Status_DEPLOYED,
+			rev1:       release.Status_SUPERSEDED,
+			descPrefix: "Repaired: upgrade was interrupted, all resources exist",
+		},
+		{
+			name:       "resources are missing",
+			missing:    []string{"Service spaced/web"},
+			rev2:       release.Status_FAILED,
+			rev1:       release.Status_DEPLOYED,
+			descPrefix: "Repaired: upgrade was interrupted, 1 resources are missing",
+		},
+		{
+			name:       "waves were not all applied",
+			waves:      &release.Waves{Total: 3, Applied: 1},
+			rev2:       release.Status_FAILED,
+			rev1:       release.Status_DEPLOYED,
+			descPrefix: "Repaired: upgrade was interrupted after wave 1 of 3",
+		},
+	}
+
+	for _, tt := range tests {
+		resourceExists = func(info *resource.Info) error {
+			for _, m := range tt.missing {
+				if m == info.Mapping.GroupVersionKind.Kind+" "+info.Namespace+"/"+info.Name {
+					return apierrors.NewNotFound(schema.GroupResource{Resource: "tests"}, info.Name)
+				}
+			}
+			return nil
+		}
+
+		rs := rsFixture()
+		rs.env.KubeClient = &repairKubeClient{environment.PrintingKubeClient{Out: os.Stdout}}
+		rel := releaseStub()
+		rel.Namespace = "spaced"
+		rs.env.Releases.Create(rel)
+		pending := pendingReleaseStub(rel.Name, 2, time.Second)
+		pending.Namespace = "spaced"
+		pending.Info.Waves = tt.waves
+		rs.env.Releases.Create(pending)
+
+		res, err := rs.RepairRelease(helm.NewContext(), &services.RepairReleaseRequest{Name: rel.Name})
+		if err != nil {
+			t.Fatalf("%s: failed repair: %s", tt.name, err)
+		}
+		if !reflect.DeepEqual(res.Missing, tt.missing) {
+			t.Errorf("%s: expected missing resources %v, got %v", tt.name, tt.missing, res.Missing)
+		}
+		if len(res.Releases) != 1 || res.Releases[0].Version != 2 {
+			t.Fatalf("%s: expected revision 2 to be repaired, got %v", tt.name, res.Releases)
+		}
+
+		for version, code := range map[int32]release.Status_Code{1: tt.rev1, 2: tt.rev2} {
+			r, err := rs.env.Releases.Get(rel.Name, version)
+			if err != nil {
+				t.Fatalf("%s: failed to get revision %d: %s", tt.name, version, err)
+			}
+			if r.Info.Status.Code != code {
+				t.Errorf("%s: expected revision %d to be %s, got %s", tt.name, version, code, r.Info.Status.Code)
+			}
+		}
+		if d := res.Releases[0].Info.Description; !strings.HasPrefix(d, tt.descPrefix) {
+			t.Errorf("%s: unexpected description %q", tt.name, d)
+		}
+	}
+}
+
+func TestRepairRelease_NotPending(t *testing.T) {
+	rs := rsFixture()
+	rel := releaseStub()
+	rs.env.Releases.Create(rel)
+
+	res, err := rs.RepairRelease(helm.NewContext(), &services.RepairReleaseRequest{Name: rel.Name})
+	if err != nil {
+		t.Fatalf("Failed repair: %s", err)
+	}
+	if len(res.Releases) != 0 {
+		t.Errorf("Expected a deployed release not to be repaired, got %v", res.Releases)
+	}
+}
+
+func TestRepairRelease_PausedWaves(t *testing.T) {
+	defer func(f func(*resource.Info) error) { resourceExists = f }(resourceExists)
+	resourceExists = func(*resource.Info) error { return nil }
+
+	rs := rsFixture()
+	rs.env.KubeClient = &repairKubeClient{environment.PrintingKubeClient{Out: os.Stdout}}
+	rel := releaseStub()
+	rs.env.Releases.Create(rel)
+	paused := pendingReleaseStub(rel.Name, 2, time.Second)
+	paused.Info.Waves = &release.Waves{Total: 3, Applied: 1, Paused: true}
+	rs.env.Releases.Create(paused)
+
+	res, err := rs.RepairRelease(helm.NewContext(), &services.RepairReleaseRequest{Name: rel.Name})
+	if err != nil {
+		t.Fatalf("Failed repair: %s", err)
+	}
+	if len(res.Releases) != 0 {
+		t.Errorf("Expected a paused upgrade not to be repaired, got %v", res.Releases)
+	}
+	for version, code := range map[int32]release.Status_Code{1: release.Status_DEPLOYED, 2: release.Status_PENDING_UPGRADE} {
+		r, err := rs.env.Releases.Get(rel.Name, version)
+		if err != nil {
+			t.Fatalf("Failed to get revision %d: %s", version, err)
+		}
+		if r.Info.Status.Code != code {
+			t.Errorf("Expected revision %d to be %s, got %s", version, code, r.Info.Status.Code)
+		}
+	}
+}
+
+func TestRepairRelease_AllWithoutTimeout(t *testing.T) {
+	rs := rsFixture()
+	if _, err := rs.RepairRelease(helm.NewContext(), &services.RepairReleaseRequest{}); err == nil {
+		t.Error("Expected repairing all releases to fail without a pending timeout")
+	}
+
+	rs.PendingTimeout = time.Minute
+	rs.env.Releases.Create(pendingReleaseStub("stale", 1, time.Hour))
+	res, err := rs.RepairRelease(helm.NewContext(), &services.RepairReleaseRequest{})
+	if err != nil {
+		t.Fatalf("Failed repair: %s", err)
+	}
+	if len(res.Releases) != 1 || res.Releases[0].Info.Status.Code != release.Status_FAILED {
+		t.Errorf("Expected the stale release to be marked failed, got %v", res.Releases)
+	}
+}
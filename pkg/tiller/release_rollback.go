@@ -20,9 +20,11 @@ import (
 	"fmt"
 	"k8s.io/helm/pkg/storage"
 	"strings"
+	"time"
 
 	ctx "golang.org/x/net/context"
 
+	"k8s.io/helm/pkg/audit"
 	"k8s.io/helm/pkg/hooks"
 	"k8s.io/helm/pkg/proto/hapi/release"
 	"k8s.io/helm/pkg/proto/hapi/services"
@@ -31,17 +33,56 @@ import (
 
 // RollbackRelease rolls back to a previous version of the given release.
 func (s *ReleaseServer) RollbackRelease(c ctx.Context, req *services.RollbackReleaseRequest) (*services.RollbackReleaseResponse, error) {
+	start := time.Now()
+	res, err := s.rollbackRelease(c, req)
+	if !req.DryRun && !req.Diff {
+		s.audit(c, audit.OperationRollback, start, req.Name, "", res.GetRelease(), err)
+	}
+	return res, err
+}
+
+func (s *ReleaseServer) rollbackRelease(c ctx.Context, req *services.RollbackReleaseRequest) (*services.RollbackReleaseResponse, error) {
+	s, err := s.asCaller(c)
+	if err != nil {
+		return nil, err
+	}
+	if !req.DryRun && !req.Diff {
+		unlock, err := s.lockRelease(c, req.Name, "rollback")
+		if err != nil {
+			return nil, err
+		}
+		defer unlock()
+	}
+	return s.rollback(req)
+}
+
+// rollback rolls back a release whose lock is held by the caller.
+func (s *ReleaseServer) rollback(req *services.RollbackReleaseRequest) (*services.RollbackReleaseResponse, error) {
 	s.Log("preparing rollback of %s", req.Name)
 	currentRelease, targetRelease, err := s.prepareRollback(req)
 	if err != nil {
 		return nil, err
 	}
+	if err := s.authorizeNamespaces(VerbUpdate, targetRelease); err != nil {
+		return nil, err
+	}
+
+	if req.Diff {
+		s.Log("computing diff for rollback of %s", req.Name)
+		diffs, err := diffReleases(currentRelease, targetRelease)
+		if err != nil {
+			return nil, err
+		}
+		return &services.RollbackReleaseResponse{Release: targetRelease, Diffs: diffs}, nil
+	}
 
 	if !req.DryRun {
 		s.Log("creating rolled back release for %s", req.Name)
+		notify := s.notifyStatus(targetRelease)
 		if err := s.env.Releases.Create(targetRelease); err != nil {
 			return nil, err
 		}
+		notify()
 	}
 	s.Log("performing rollback of %s", req.Name)
 	res, err := s.performRollback(currentRelease, targetRelease, req)
@@ -51,9 +92,11 @@ func (s *ReleaseServer) RollbackRelease(c ctx.Context, req *services.RollbackRel
 
 	if !req.DryRun {
 		s.Log("updating status for rolled back release for %s", req.Name)
+		notify := s.notifyStatus(targetRelease)
 		if err := s.env.Releases.Update(targetRelease); err != nil {
 			return res, err
 		}
+		notify()
 	}
 
 	return res, nil
@@ -125,6 +168,9 @@ func (s *ReleaseServer) performRollback(currentRelease, targetRelease *release.R
 		s.Log("dry run for %s", targetRelease.Name)
 		return res, nil
 	}
+	if err := s.checkLock(targetRelease.Name); err != nil {
+		return res, err
+	}
 
 	// pre-rollback hooks
 	if !req.DisableHooks {
@@ -135,7 +181,12 @@ func (s *ReleaseServer) performRollback(currentRelease, targetRelease *release.R
 		s.Log("rollback hooks disabled for %s", req.Name)
 	}
 
-	if err := s.ReleaseModule.Rollback(currentRelease, targetRelease, req, s.env); err != nil {
+	// an upgrade with steps may not have applied all of its resources
+	appliedRelease, err := s.appliedRelease(currentRelease)
+	if err != nil {
+		return res, err
+	}
+	if err := s.ReleaseModule.Rollback(appliedRelease, targetRelease, req, s.env); err != nil {
 		msg := fmt.Sprintf("Rollback %q failed: %s", targetRelease.Name, err)
 		s.Log("warning: %s", msg)
 		currentRelease.Info.Status.Code = release.Status_SUPERSEDED
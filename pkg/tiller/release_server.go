@@ -23,14 +23,17 @@ import (
 	"path"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/technosophos/moniker"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/kubernetes"
 
+	"k8s.io/helm/pkg/audit"
 	"k8s.io/helm/pkg/chartutil"
 	"k8s.io/helm/pkg/hooks"
+	"k8s.io/helm/pkg/policy"
 	"k8s.io/helm/pkg/proto/hapi/chart"
 	"k8s.io/helm/pkg/proto/hapi/release"
 	"k8s.io/helm/pkg/proto/hapi/services"
@@ -38,6 +41,7 @@ import (
 	"k8s.io/helm/pkg/tiller/environment"
 	"k8s.io/helm/pkg/timeconv"
 	"k8s.io/helm/pkg/version"
+	"k8s.io/helm/pkg/webhook"
 )
 
 // releaseNameMaxLen is the maximum length of a release name.
@@ -84,6 +88,31 @@ type ReleaseServer struct {
 	env       *environment.Environment
 	clientset kubernetes.Interface
 	Log       func(string, ...interface{})
+	// PendingTimeout is how long a revision can stay pending before it is
+	// considered interrupted and marked failed. Zero disables the check.
+	PendingTimeout time.Duration
+	// KubeClientForUser, if set, returns a KubeClient impersonating user, so
+	// that operations are performed on the cluster as their callers.
+	KubeClientForUser func(user *UserInfo) environment.KubeClient
+	// Authorizer, if set, also authorizes callers in the namespaces of the
+	// objects rendered for their releases.
+	Authorizer *Authorizer
+	// PolicyChecker, if set, checks rendered manifests against policy rules
+	// before they are applied.
+	PolicyChecker *policy.Checker
+	// Audit, if set, records the operations performed on releases.
+	Audit audit.Sink
+	// Webhooks, if set, are notified of the status transitions of releases.
+	Webhooks *webhook.Notifier
+	// caller is the user the caller of the operation authenticated as.
+	caller *UserInfo
+	// progress receives the progress events of the operation, if streamed.
+	progress func(*services.ReleaseEvent)
+	// statuses remembers the statuses recorded for the webhooks.
+	statuses *statusCache
+	// lostLocks remembers the releases whose lock was lost by the operation
+	// holding it.
+	lostLocks *lostLockSet
 }
 
 // NewReleaseServer creates a new release server.
@@ -102,6 +131,8 @@ func NewReleaseServer(env *environment.Environment, clientset kubernetes.Interfa
 		clientset:     clientset,
 		ReleaseModule: releaseModule,
 		Log:           func(_ string, _ ...interface{}) {},
+		statuses:      newStatusCache(),
+		lostLocks:     newLostLockSet(),
 	}
 }
 
@@ -295,6 +326,12 @@ func (s *ReleaseServer) renderResources(ch *chart.Chart, values chartutil.Values
 		}
 	}
 
+	if vals, err := values.Table("Values"); err == nil {
+		if err := chartutil.ValidateValues(ch, vals); err != nil {
+			return nil, nil, "", err
+		}
+	}
+
 	s.Log("rendering %s chart using values", ch.GetMetadata().Name)
 	renderer := s.engine(ch)
 	files, err := renderer.Render(ch, values)
@@ -345,6 +382,10 @@ func (s *ReleaseServer) renderResources(ch *chart.Chart, values chartutil.Values
 		return nil, b, "", err
 	}
 
+	if err := s.checkPolicies(hooks, manifests); err != nil {
+		return nil, nil, "", err
+	}
+
 	// Aggregate all valid manifests into one big doc.
 	b := bytes.NewBuffer(nil)
 	for _, m := range manifests {
@@ -357,13 +398,17 @@ func (s *ReleaseServer) renderResources(ch *chart.Chart, values chartutil.Values
 
 // recordRelease with an update operation in case reuse has been set.
 func (s *ReleaseServer) recordRelease(r *release.Release, reuse bool) {
+	notify := s.notifyStatus(r)
 	if reuse {
 		if err := s.env.Releases.Update(r); err != nil {
 			s.Log("warning: Failed to update release %s: %s", r.Name, err)
+			return
 		}
 	} else if err := s.env.Releases.Create(r); err != nil {
 		s.Log("warning: Failed to record release %s: %s", r.Name, err)
+		return
 	}
+	notify()
 }
 
 func (s *ReleaseServer) execHook(hs []*release.Hook, name, namespace, hook string, timeout int64) error {
@@ -390,6 +435,8 @@ func (s *ReleaseServer) execHook(hs []*release.Hook, name, namespace, hook strin
 			return err
 		}
 
+		s.sendProgress(&services.ReleaseEvent{Type: services.ReleaseEvent_HOOK_STARTED, Kind: h.Kind, Namespace: namespace, Name: h.Name, Hook: hook})
+
 		b := bytes.NewBufferString(h.Manifest)
 		if err := kubeCli.Create(namespace, b, timeout, false); err != nil {
 			s.Log("warning: Release %s %s %s failed: %s", name, hook, h.Path, err)
@@ -411,6 +458,8 @@ func (s *ReleaseServer) execHook(hs []*release.Hook, name, namespace, hook strin
 				return err
 			}
 		}
+
+		s.sendProgress(&services.ReleaseEvent{Type: services.ReleaseEvent_HOOK_FINISHED, Kind: h.Kind, Namespace: namespace, Name: h.Name, Hook: hook})
 	}
 
 	s.Log("hooks complete for %s %s", hook, name)
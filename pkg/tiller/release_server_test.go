@@ -163,6 +163,12 @@ func withDependency(dependencyOpts ...chartOption) chartOption {
 	}
 }
 
+func withSchema(schema string) chartOption {
+	return func(opts *chartOptions) {
+		opts.Schema = []byte(schema)
+	}
+}
+
 func withNotes(notes string) chartOption {
 	return func(opts *chartOptions) {
 		opts.Templates = append(opts.Templates, &chart.Template{
@@ -490,6 +496,10 @@ func (u *updateFailingKubeClient) Update(namespace string, originalReader, modif
 	return errors.New("Failed update in kube client")
 }
 
+func (u *updateFailingKubeClient) UpdateWithOptions(namespace string, originalReader, modifiedReader io.Reader, opts kube.UpdateOptions) error {
+	return errors.New("Failed update in kube client")
+}
+
 func newHookFailingKubeClient() *hookFailingKubeClient {
 	return &hookFailingKubeClient{
 		PrintingKubeClient: environment.PrintingKubeClient{Out: ioutil.Discard},
@@ -586,6 +596,9 @@ func (kc *mockHooksKubeClient) Create(ns string, r io.Reader, timeout int64, sho
 
 	return nil
 }
+func (kc *mockHooksKubeClient) CreateWithOptions(ns string, r io.Reader, opts kube.CreateOptions) error {
+	return kc.Create(ns, r, opts.Timeout, opts.ShouldWait)
+}
 func (kc *mockHooksKubeClient) Get(ns string, r io.Reader) (string, error) {
 	return "", nil
 }
@@ -619,6 +632,9 @@ func (kc *mockHooksKubeClient) WatchUntilReady(ns string, r io.Reader, timeout i
 func (kc *mockHooksKubeClient) Update(ns string, currentReader, modifiedReader io.Reader, force bool, recreate bool, timeout int64, shouldWait bool) error {
 	return nil
 }
+func (kc *mockHooksKubeClient) UpdateWithOptions(ns string, currentReader, modifiedReader io.Reader, opts kube.UpdateOptions) error {
+	return nil
+}
 func (kc *mockHooksKubeClient) Build(ns string, reader io.Reader) (kube.Result, error) {
 	return []*resource.Info{}, nil
 }
@@ -28,6 +28,10 @@ import (
 
 // GetReleaseStatus gets the status information for a named release.
 func (s *ReleaseServer) GetReleaseStatus(c ctx.Context, req *services.GetReleaseStatusRequest) (*services.GetReleaseStatusResponse, error) {
+	s, err := s.asCaller(c)
+	if err != nil {
+		return nil, err
+	}
 	if err := validateReleaseName(req.Name); err != nil {
 		s.Log("getStatus: Release name is invalid: %s", req.Name)
 		return nil, err
@@ -64,7 +68,12 @@ func (s *ReleaseServer) GetReleaseStatus(c ctx.Context, req *services.GetRelease
 
 	// Ok, we got the status of the release as we had jotted down, now we need to match the
 	// manifest we stashed away with reality from the cluster.
-	resp, err := s.ReleaseModule.Status(rel, req, s.env)
+	// An upgrade with steps may not have applied all of its resources yet.
+	applied, err := s.appliedRelease(rel)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.ReleaseModule.Status(applied, req, s.env)
 	if sc == release.Status_DELETED || sc == release.Status_FAILED {
 		// Skip errors if this is already deleted or failed.
 		return statusResp, nil
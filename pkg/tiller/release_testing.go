@@ -17,6 +17,10 @@ limitations under the License.
 package tiller
 
 import (
+	"fmt"
+	"time"
+
+	"k8s.io/helm/pkg/audit"
 	"k8s.io/helm/pkg/proto/hapi/release"
 	"k8s.io/helm/pkg/proto/hapi/services"
 	reltesting "k8s.io/helm/pkg/releasetesting"
@@ -26,16 +30,31 @@ const maxParallelism = 20
 
 // RunReleaseTest runs pre-defined tests stored as hooks on a given release
 func (s *ReleaseServer) RunReleaseTest(req *services.TestReleaseRequest, stream services.ReleaseService_RunReleaseTestServer) error {
+	start := time.Now()
+	rel, err := s.runReleaseTest(req, stream)
+	auditErr := err
+	if err == nil {
+		auditErr = failedTests(rel)
+	}
+	s.audit(stream.Context(), audit.OperationTest, start, req.Name, "", rel, auditErr)
+	return err
+}
+
+func (s *ReleaseServer) runReleaseTest(req *services.TestReleaseRequest, stream services.ReleaseService_RunReleaseTestServer) (*release.Release, error) {
+	s, err := s.asCaller(stream.Context())
+	if err != nil {
+		return nil, err
+	}
 
 	if err := validateReleaseName(req.Name); err != nil {
 		s.Log("releaseTest: Release name is invalid: %s", req.Name)
-		return err
+		return nil, err
 	}
 
 	// finds the non-deleted release with the given name
 	rel, err := s.env.Releases.Last(req.Name)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	testEnv := &reltesting.Environment{
@@ -46,6 +65,20 @@ func (s *ReleaseServer) RunReleaseTest(req *services.TestReleaseRequest, stream
 		Parallel:    req.Parallel,
 		Parallelism: maxParallelism,
 	}
+	if err := s.runTestSuite(rel, testEnv, req.Cleanup); err != nil {
+		return rel, err
+	}
+
+	if err := s.env.Releases.Update(rel); err != nil {
+		s.Log("test: Failed to store updated release: %s", err)
+	}
+
+	return rel, nil
+}
+
+// runTestSuite runs the tests of rel in testEnv and records their results in
+// the status of rel. The caller is responsible for storing rel.
+func (s *ReleaseServer) runTestSuite(rel *release.Release, testEnv *reltesting.Environment, cleanup bool) error {
 	s.Log("running tests for release %s", rel.Name)
 	tSuite, err := reltesting.NewTestSuite(rel)
 	if err != nil {
@@ -64,13 +97,76 @@ func (s *ReleaseServer) RunReleaseTest(req *services.TestReleaseRequest, stream
 		Results:     tSuite.Results,
 	}
 
-	if req.Cleanup {
+	if cleanup {
 		testEnv.DeleteTestPods(tSuite.TestManifests)
 	}
+	return nil
+}
 
-	if err := s.env.Releases.Update(rel); err != nil {
-		s.Log("test: Failed to store updated release: %s", err)
+// testUpdate runs the tests of an upgraded release, streaming their results as
+// progress events. If any test fails and the request is atomic, the release is
+// rolled back to the revision deployed before the upgrade.
+func (s *ReleaseServer) testUpdate(previous, updated *release.Release, req *services.UpdateReleaseRequest) error {
+	testEnv := &reltesting.Environment{
+		Namespace:   updated.Namespace,
+		KubeClient:  s.env.KubeClient,
+		Timeout:     req.Timeout,
+		Stream:      progressTestStream{s},
+		Parallelism: maxParallelism,
+	}
+	err := s.runTestSuite(updated, testEnv, false)
+	if err == nil {
+		if err := s.env.Releases.Update(updated); err != nil {
+			s.Log("test: Failed to store updated release: %s", err)
+		}
+		err = failedTests(updated)
+	}
+	if err == nil {
+		return nil
+	}
+	if !req.AtomicTests {
+		return fmt.Errorf("release %q was upgraded, but its tests failed: %s", updated.Name, err)
+	}
+
+	s.Log("tests of %s failed, rolling back to revision %d: %s", updated.Name, previous.Version, err)
+	_, rbErr := s.rollback(&services.RollbackReleaseRequest{
+		Name:         updated.Name,
+		Version:      previous.Version,
+		DisableHooks: req.DisableHooks,
+		Recreate:     req.Recreate,
+		Timeout:      req.Timeout,
+		Wait:         req.Wait,
+		Force:        req.Force,
+		Description:  fmt.Sprintf("Rollback to %d: tests of revision %d failed", previous.Version, updated.Version),
+	})
+	if rbErr != nil {
+		return fmt.Errorf("tests of release %q failed: %s; rollback to revision %d failed: %s", updated.Name, err, previous.Version, rbErr)
 	}
+	return fmt.Errorf("tests of release %q failed, rolled back to revision %d: %s", updated.Name, previous.Version, err)
+}
 
+// progressTestStream reports the results of tests as progress events.
+type progressTestStream struct {
+	s *ReleaseServer
+}
+
+func (p progressTestStream) Send(res *services.TestReleaseResponse) error {
+	p.s.sendProgress(&services.ReleaseEvent{Type: services.ReleaseEvent_TEST, Message: res.Msg})
+	return nil
+}
+
+// failedTests returns an error counting the failed tests of the last test
+// suite run of rel, if any failed.
+func failedTests(rel *release.Release) error {
+	run := rel.GetInfo().GetStatus().GetLastTestSuiteRun()
+	failed := 0
+	for _, r := range run.GetResults() {
+		if r.Status == release.TestRun_FAILURE {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d tests failed", failed, len(run.GetResults()))
+	}
 	return nil
 }
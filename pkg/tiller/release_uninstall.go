@@ -19,9 +19,11 @@ package tiller
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	ctx "golang.org/x/net/context"
 
+	"k8s.io/helm/pkg/audit"
 	"k8s.io/helm/pkg/hooks"
 	"k8s.io/helm/pkg/proto/hapi/release"
 	"k8s.io/helm/pkg/proto/hapi/services"
@@ -31,10 +33,28 @@ import (
 
 // UninstallRelease deletes all of the resources associated with this release, and marks the release DELETED.
 func (s *ReleaseServer) UninstallRelease(c ctx.Context, req *services.UninstallReleaseRequest) (*services.UninstallReleaseResponse, error) {
+	start := time.Now()
+	res, err := s.uninstallRelease(c, req)
+	if !req.DryRun {
+		s.audit(c, audit.OperationUninstall, start, req.Name, "", res.GetRelease(), err)
+	}
+	return res, err
+}
+
+func (s *ReleaseServer) uninstallRelease(c ctx.Context, req *services.UninstallReleaseRequest) (*services.UninstallReleaseResponse, error) {
+	s, err := s.asCaller(c)
+	if err != nil {
+		return nil, err
+	}
 	if err := validateReleaseName(req.Name); err != nil {
 		s.Log("uninstallRelease: Release name is invalid: %s", req.Name)
 		return nil, err
 	}
+	unlock, err := s.lockRelease(c, req.Name, "delete")
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
 
 	rels, err := s.env.Releases.History(req.Name)
 	if err != nil {
@@ -52,6 +72,9 @@ func (s *ReleaseServer) UninstallRelease(c ctx.Context, req *services.UninstallR
 	// already marked deleted?
 	if rel.Info.Status.Code == release.Status_DELETED {
 		if req.Purge {
+			if req.DryRun {
+				return &services.UninstallReleaseResponse{Release: rel}, nil
+			}
 			if err := s.purgeReleases(rels...); err != nil {
 				s.Log("uninstall: Failed to purge the release: %s", err)
 				return nil, err
@@ -61,6 +84,11 @@ func (s *ReleaseServer) UninstallRelease(c ctx.Context, req *services.UninstallR
 		return nil, fmt.Errorf("the release named %q is already deleted", req.Name)
 	}
 
+	if req.DryRun {
+		s.Log("uninstall: dry run for %s", req.Name)
+		return &services.UninstallReleaseResponse{Release: rel}, nil
+	}
+
 	s.Log("uninstall: Deleting %s", req.Name)
 	rel.Info.Status.Code = release.Status_DELETING
 	rel.Info.Deleted = timeconv.Now()
@@ -75,10 +103,17 @@ func (s *ReleaseServer) UninstallRelease(c ctx.Context, req *services.UninstallR
 		s.Log("delete hooks disabled for %s", req.Name)
 	}
 
+	if err := s.checkLock(rel.Name); err != nil {
+		return res, err
+	}
+
 	// From here on out, the release is currently considered to be in Status_DELETING
 	// state.
+	notify := s.notifyStatus(rel)
 	if err := s.env.Releases.Update(rel); err != nil {
 		s.Log("uninstall: Failed to store updated release: %s", err)
+	} else {
+		notify()
 	}
 
 	kept, errs := s.ReleaseModule.Delete(rel, req, s.env)
@@ -102,18 +137,23 @@ func (s *ReleaseServer) UninstallRelease(c ctx.Context, req *services.UninstallR
 	} else {
 		rel.Info.Description = req.Description
 	}
+	notify = s.notifyStatus(rel)
 
 	if req.Purge {
 		s.Log("purge requested for %s", req.Name)
 		err := s.purgeReleases(rels...)
 		if err != nil {
 			s.Log("uninstall: Failed to purge the release: %s", err)
+			return res, err
 		}
-		return res, err
+		notify()
+		return res, nil
 	}
 
 	if err := s.env.Releases.Update(rel); err != nil {
 		s.Log("uninstall: Failed to store updated release: %s", err)
+	} else {
+		notify()
 	}
 
 	if len(es) > 0 {
@@ -19,9 +19,11 @@ package tiller
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	ctx "golang.org/x/net/context"
 
+	"k8s.io/helm/pkg/audit"
 	"k8s.io/helm/pkg/chartutil"
 	"k8s.io/helm/pkg/hooks"
 	"k8s.io/helm/pkg/proto/hapi/release"
@@ -31,25 +33,66 @@ import (
 
 // UpdateRelease takes an existing release and new information, and upgrades the release.
 func (s *ReleaseServer) UpdateRelease(c ctx.Context, req *services.UpdateReleaseRequest) (*services.UpdateReleaseResponse, error) {
+	start := time.Now()
+	res, err := s.updateRelease(c, req)
+	if !req.DryRun && !req.Diff {
+		s.audit(c, audit.OperationUpgrade, start, req.Name, "", res.GetRelease(), err)
+	}
+	return res, err
+}
+
+func (s *ReleaseServer) updateRelease(c ctx.Context, req *services.UpdateReleaseRequest) (*services.UpdateReleaseResponse, error) {
+	s, err := s.asCaller(c)
+	if err != nil {
+		return nil, err
+	}
 	if err := validateReleaseName(req.Name); err != nil {
 		s.Log("updateRelease: Release name is invalid: %s", req.Name)
 		return nil, err
 	}
+	if !req.DryRun && !req.Diff {
+		unlock, err := s.lockRelease(c, req.Name, "upgrade")
+		if err != nil {
+			return nil, err
+		}
+		defer unlock()
+	}
+	if err := s.authorizeStored(VerbUpdate, req.Name); err != nil {
+		return nil, err
+	}
+	if req.Continue {
+		return s.continueUpdate(req)
+	}
 	s.Log("preparing update for %s", req.Name)
 	currentRelease, updatedRelease, err := s.prepareUpdate(req)
 	if err != nil {
-		if req.Force {
+		if req.Force && !req.Diff {
 			// Use the --force, Luke.
 			return s.performUpdateForce(req)
 		}
 		return nil, err
 	}
+	if err := s.authorizeNamespaces(VerbUpdate, updatedRelease); err != nil {
+		return nil, err
+	}
+
+	if req.Diff {
+		s.Log("computing diff for %s", req.Name)
+		diffs, err := diffReleases(currentRelease, updatedRelease)
+		if err != nil {
+			return nil, err
+		}
+		updatedRelease.Info.Description = "Diff complete"
+		return &services.UpdateReleaseResponse{Release: updatedRelease, Diffs: diffs}, nil
+	}
 
 	if !req.DryRun {
 		s.Log("creating updated release for %s", req.Name)
+		notify := s.notifyStatus(updatedRelease)
 		if err := s.env.Releases.Create(updatedRelease); err != nil {
 			return nil, err
 		}
+		notify()
 	}
 
 	s.Log("performing update for %s", req.Name)
@@ -60,9 +103,14 @@ func (s *ReleaseServer) UpdateRelease(c ctx.Context, req *services.UpdateRelease
 
 	if !req.DryRun {
 		s.Log("updating status for updated release for %s", req.Name)
+		notify := s.notifyStatus(updatedRelease)
 		if err := s.env.Releases.Update(updatedRelease); err != nil {
 			return res, err
 		}
+		notify()
+		if req.TestAfter && !updatedRelease.Info.Waves.GetPaused() {
+			return res, s.testUpdate(currentRelease, updatedRelease, req)
+		}
 	}
 
 	return res, nil
@@ -90,6 +138,9 @@ func (s *ReleaseServer) prepareUpdate(req *services.UpdateReleaseRequest) (*rele
 	if err != nil {
 		return nil, nil, err
 	}
+	if lastRelease.Info.Status.Code == release.Status_PENDING_UPGRADE && lastRelease.Info.Waves != nil {
+		return nil, nil, fmt.Errorf("release %q has an upgrade in progress, continue it with --continue or roll it back", req.Name)
+	}
 
 	// Increment revision count. This is passed to templates, and also stored on
 	// the release object.
@@ -138,6 +189,13 @@ func (s *ReleaseServer) prepareUpdate(req *services.UpdateReleaseRequest) (*rele
 	if len(notesTxt) > 0 {
 		updatedRelease.Info.Status.Notes = notesTxt
 	}
+	if req.Steps {
+		waves, err := manifestWaves(updatedRelease.Manifest, updatedRelease.Namespace)
+		if err != nil {
+			return nil, nil, err
+		}
+		updatedRelease.Info.Waves = &release.Waves{Total: int32(len(waves))}
+	}
 	err = validateManifest(s.env.KubeClient, currentRelease.Namespace, manifestDoc.Bytes())
 	return currentRelease, updatedRelease, err
 }
@@ -173,6 +231,10 @@ func (s *ReleaseServer) performUpdateForce(req *services.UpdateReleaseRequest) (
 		return res, err
 	}
 
+	if err := s.authorizeNamespaces(VerbUpdate, newRelease); err != nil {
+		return res, err
+	}
+
 	// update new release with next revision number so as to append to the old release's history
 	newRelease.Version = oldRelease.Version + 1
 	res.Release = newRelease
@@ -279,20 +341,41 @@ func (s *ReleaseServer) performUpdate(originalRelease, updatedRelease *release.R
 	} else {
 		s.Log("update hooks disabled for %s", req.Name)
 	}
-	if err := s.ReleaseModule.Update(originalRelease, updatedRelease, req, s.env); err != nil {
+	return res, s.deployUpdate(originalRelease, updatedRelease, req)
+}
+
+// deployUpdate applies the resources of an updated release and runs its
+// post-upgrade hooks, unless the upgrade pauses between waves.
+func (s *ReleaseServer) deployUpdate(originalRelease, updatedRelease *release.Release, req *services.UpdateReleaseRequest) error {
+	if err := s.checkLock(updatedRelease.Name); err != nil {
+		return err
+	}
+	var err error
+	if updatedRelease.Info.Waves != nil {
+		err = s.applyWaves(originalRelease, updatedRelease, req)
+	} else {
+		err = s.ReleaseModule.Update(originalRelease, updatedRelease, req, s.env)
+	}
+	if err != nil {
+		if lerr := s.checkLock(updatedRelease.Name); lerr != nil {
+			return lerr
+		}
 		msg := fmt.Sprintf("Upgrade %q failed: %s", updatedRelease.Name, err)
 		s.Log("warning: %s", msg)
 		updatedRelease.Info.Status.Code = release.Status_FAILED
 		updatedRelease.Info.Description = msg
 		s.recordRelease(originalRelease, true)
 		s.recordRelease(updatedRelease, true)
-		return res, err
+		return err
+	}
+	if updatedRelease.Info.Waves.GetPaused() {
+		return nil
 	}
 
 	// post-upgrade hooks
 	if !req.DisableHooks {
 		if err := s.execHook(updatedRelease.Hooks, updatedRelease.Name, updatedRelease.Namespace, hooks.PostUpgrade, req.Timeout); err != nil {
-			return res, err
+			return err
 		}
 	}
 
@@ -306,5 +389,5 @@ func (s *ReleaseServer) performUpdate(originalRelease, updatedRelease *release.R
 		updatedRelease.Info.Description = req.Description
 	}
 
-	return res, nil
+	return nil
 }
@@ -18,16 +18,21 @@ package tiller
 
 import (
 	"fmt"
+	"io"
+	"io/ioutil"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/golang/protobuf/proto"
+	"k8s.io/api/core/v1"
 
 	"k8s.io/helm/pkg/chartutil"
 	"k8s.io/helm/pkg/helm"
 	"k8s.io/helm/pkg/proto/hapi/chart"
 	"k8s.io/helm/pkg/proto/hapi/release"
 	"k8s.io/helm/pkg/proto/hapi/services"
+	"k8s.io/helm/pkg/tiller/environment"
 	"reflect"
 )
 
@@ -623,3 +628,100 @@ func compareStoredAndReturnedRelease(t *testing.T, rs ReleaseServer, res service
 
 	return storedRelease
 }
+
+type testPhaseKubeClient struct {
+	environment.PrintingKubeClient
+	phase v1.PodPhase
+}
+
+func (k *testPhaseKubeClient) WaitAndGetCompletedPodPhase(namespace string, reader io.Reader, timeout time.Duration) (v1.PodPhase, error) {
+	return k.phase, nil
+}
+
+func TestUpdateReleaseTestAfter(t *testing.T) {
+	tests := []struct {
+		name        string
+		phase       v1.PodPhase
+		atomic      bool
+		wantErr     string
+		wantVersion int32
+		wantMessage string
+	}{
+		{
+			name:        "passing tests",
+			phase:       v1.PodSucceeded,
+			wantVersion: 2,
+			wantMessage: "PASSED: finding-nemo",
+		},
+		{
+			name:        "failing tests",
+			phase:       v1.PodFailed,
+			wantErr:     `release "angry-panda" was upgraded, but its tests failed: 1 of 1 tests failed`,
+			wantVersion: 2,
+			wantMessage: "FAILED: finding-nemo, run `kubectl logs finding-nemo --namespace ` for more info",
+		},
+		{
+			name:        "failing atomic tests",
+			phase:       v1.PodFailed,
+			atomic:      true,
+			wantErr:     `tests of release "angry-panda" failed, rolled back to revision 1: 1 of 1 tests failed`,
+			wantVersion: 3,
+			wantMessage: "FAILED: finding-nemo, run `kubectl logs finding-nemo --namespace ` for more info",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rs := rsFixture()
+			rel := releaseStub()
+			rs.env.Releases.Create(rel)
+			rs.env.KubeClient = &testPhaseKubeClient{
+				PrintingKubeClient: environment.PrintingKubeClient{Out: ioutil.Discard},
+				phase:              tt.phase,
+			}
+
+			var messages []string
+			rs = rs.withProgress(func(ev *services.ReleaseEvent) {
+				if ev.Type == services.ReleaseEvent_TEST {
+					messages = append(messages, ev.Message)
+				}
+			})
+
+			ch := buildChart()
+			ch.Templates = append(ch.Templates, &chart.Template{Name: "templates/test", Data: []byte(manifestWithTestHook)})
+			req := &services.UpdateReleaseRequest{
+				Name:        rel.Name,
+				Chart:       ch,
+				TestAfter:   true,
+				AtomicTests: tt.atomic,
+			}
+			_, err := rs.UpdateRelease(helm.NewContext(), req)
+			if tt.wantErr == "" && err != nil {
+				t.Fatalf("Failed upgrade: %s", err)
+			}
+			if tt.wantErr != "" && (err == nil || err.Error() != tt.wantErr) {
+				t.Fatalf("Expected error %q, got %v", tt.wantErr, err)
+			}
+
+			if len(messages) == 0 || messages[len(messages)-1] != tt.wantMessage {
+				t.Errorf("Expected test message %q, got %v", tt.wantMessage, messages)
+			}
+
+			tested, err := rs.env.Releases.Get(rel.Name, 2)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if n := len(tested.Info.Status.GetLastTestSuiteRun().GetResults()); n != 1 {
+				t.Errorf("Expected 1 test result recorded on revision 2, got %d", n)
+			}
+
+			deployed, err := rs.env.Releases.Deployed(rel.Name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if deployed.Version != tt.wantVersion {
+				t.Errorf("Expected revision %d to be deployed, got %d", tt.wantVersion, deployed.Version)
+			}
+		})
+	}
+}
@@ -0,0 +1,230 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tiller
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"k8s.io/helm/pkg/proto/hapi/release"
+	"k8s.io/helm/pkg/proto/hapi/services"
+)
+
+// waveAnnotation assigns a resource to a wave of the upgrades with steps.
+// Waves are applied in increasing order; resources without the annotation
+// belong to wave 0.
+const waveAnnotation = "helm.sh/wave"
+
+// resourceWave returns the wave of a resource.
+func resourceWave(r *resourceManifest) (int, error) {
+	v, ok := r.Metadata.Annotations[waveAnnotation]
+	if !ok {
+		return 0, nil
+	}
+	wave, err := strconv.Atoi(strings.TrimSpace(v))
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s annotation %q on %s %q", waveAnnotation, v, r.Kind, r.Metadata.Name)
+	}
+	return wave, nil
+}
+
+// manifestWaves returns the waves of the resources of a release manifest, in
+// the order they are applied.
+func manifestWaves(manifest, namespace string) ([]int, error) {
+	resources, err := listResources(manifest, namespace)
+	if err != nil {
+		return nil, err
+	}
+	seen := map[int]bool{}
+	var waves []int
+	for _, r := range resources {
+		wave, err := resourceWave(r)
+		if err != nil {
+			return nil, err
+		}
+		if !seen[wave] {
+			seen[wave] = true
+			waves = append(waves, wave)
+		}
+	}
+	if len(waves) == 0 {
+		// an upgrade removing all resources still needs a wave to do so
+		waves = []int{0}
+	}
+	sort.Ints(waves)
+	return waves, nil
+}
+
+// waveManifest returns the manifest of a release upgraded from the original
+// to the updated manifest up to the given number of waves: the resources of
+// the applied waves are updated, the others are left as in the original
+// manifest. Resources removed by the upgrade are only removed once all waves
+// are applied.
+func waveManifest(original, updated, namespace string, applied int) (string, error) {
+	waves, err := manifestWaves(updated, namespace)
+	if err != nil {
+		return "", err
+	}
+	if applied >= len(waves) {
+		return updated, nil
+	}
+	if applied == 0 {
+		return original, nil
+	}
+	last := waves[applied-1]
+
+	from, err := listResources(original, namespace)
+	if err != nil {
+		return "", err
+	}
+	to, err := listResources(updated, namespace)
+	if err != nil {
+		return "", err
+	}
+	done := map[string]*resourceManifest{}
+	for _, r := range to {
+		if wave, _ := resourceWave(r); wave <= last {
+			done[r.key()] = r
+		}
+	}
+
+	var docs []string
+	for _, r := range from {
+		if u, ok := done[r.key()]; ok {
+			docs = append(docs, u.content)
+			delete(done, r.key())
+		} else {
+			docs = append(docs, r.content)
+		}
+	}
+	for _, r := range to {
+		if _, ok := done[r.key()]; ok {
+			docs = append(docs, r.content)
+		}
+	}
+	return strings.Join(docs, "\n---\n"), nil
+}
+
+// waveRelease returns a copy of the updated release, with the manifest
+// deployed once the given number of waves of its upgrade are applied.
+func waveRelease(original, updated *release.Release, applied int) (*release.Release, error) {
+	manifest, err := waveManifest(original.Manifest, updated.Manifest, updated.Namespace, applied)
+	if err != nil {
+		return nil, err
+	}
+	r := *updated
+	r.Manifest = manifest
+	return &r, nil
+}
+
+// applyWaves applies the waves of an upgrade not applied yet, waiting for the
+// resources of each wave to be ready and storing the progress of the upgrade
+// after it. If the request pauses, it stops after the next wave but the last.
+func (s *ReleaseServer) applyWaves(original, updated *release.Release, req *services.UpdateReleaseRequest) error {
+	waves := updated.Info.Waves
+	waves.Paused = false
+	wreq := *req
+	wreq.Wait = true
+	for waves.Applied < waves.Total {
+		if err := s.checkLock(updated.Name); err != nil {
+			return err
+		}
+		from, err := waveRelease(original, updated, int(waves.Applied))
+		if err != nil {
+			return err
+		}
+		to, err := waveRelease(original, updated, int(waves.Applied)+1)
+		if err != nil {
+			return err
+		}
+
+		msg := fmt.Sprintf("Applying wave %d of %d", waves.Applied+1, waves.Total)
+		s.Log("%s for %s", msg, updated.Name)
+		s.sendProgress(&services.ReleaseEvent{Type: services.ReleaseEvent_WAVE, Message: msg})
+		if err := s.ReleaseModule.Update(from, to, &wreq, s.env); err != nil {
+			return err
+		}
+
+		waves.Applied++
+		waves.Paused = req.Pause && waves.Applied < waves.Total
+		updated.Info.Description = fmt.Sprintf("Applied wave %d of %d", waves.Applied, waves.Total)
+		if waves.Paused {
+			updated.Info.Description = fmt.Sprintf("Upgrade paused after wave %d of %d", waves.Applied, waves.Total)
+		}
+		s.recordRelease(updated, true)
+		if waves.Paused {
+			return nil
+		}
+	}
+	return nil
+}
+
+// continueUpdate continues an upgrade with steps that was paused, or
+// interrupted by a restart of Tiller.
+func (s *ReleaseServer) continueUpdate(req *services.UpdateReleaseRequest) (*services.UpdateReleaseResponse, error) {
+	updatedRelease, err := s.env.Releases.Last(req.Name)
+	if err != nil {
+		return nil, err
+	}
+	if updatedRelease.Info.Status.Code != release.Status_PENDING_UPGRADE || updatedRelease.Info.Waves == nil {
+		return nil, fmt.Errorf("release %q has no upgrade to continue", req.Name)
+	}
+	currentRelease, err := s.env.Releases.Deployed(req.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &services.UpdateReleaseResponse{Release: updatedRelease}
+	if req.DryRun {
+		s.Log("dry run for %s", updatedRelease.Name)
+		return res, nil
+	}
+
+	s.Log("continuing upgrade of %s after wave %d of %d", req.Name, updatedRelease.Info.Waves.Applied, updatedRelease.Info.Waves.Total)
+	if err := s.deployUpdate(currentRelease, updatedRelease, req); err != nil {
+		return res, err
+	}
+
+	s.Log("updating status for updated release for %s", req.Name)
+	notify := s.notifyStatus(updatedRelease)
+	if err := s.env.Releases.Update(updatedRelease); err != nil {
+		return res, err
+	}
+	notify()
+	if req.TestAfter && !updatedRelease.Info.Waves.Paused {
+		return res, s.testUpdate(currentRelease, updatedRelease, req)
+	}
+	return res, nil
+}
+
+// appliedRelease returns the release as deployed in the cluster: for an
+// upgrade with steps that was paused, interrupted or failed before applying
+// all of its waves, a copy of the release with the manifest of the waves
+// applied so far.
+func (s *ReleaseServer) appliedRelease(r *release.Release) (*release.Release, error) {
+	waves := r.Info.Waves
+	if waves == nil || waves.Applied >= waves.Total {
+		return r, nil
+	}
+	original, err := s.env.Releases.Deployed(r.Name)
+	if err != nil {
+		return nil, err
+	}
+	return waveRelease(original, r, int(waves.Applied))
+}
@@ -0,0 +1,208 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tiller
+
+import (
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/kube"
+	"k8s.io/helm/pkg/proto/hapi/chart"
+	"k8s.io/helm/pkg/proto/hapi/release"
+	"k8s.io/helm/pkg/proto/hapi/services"
+	"k8s.io/helm/pkg/tiller/environment"
+)
+
+func waveConfigMap(name, wave, value string) string {
+	m := "kind: ConfigMap\nmetadata:\n  name: " + name + "\n"
+	if wave != "" {
+		m += "  annotations:\n    helm.sh/wave: \"" + wave + "\"\n"
+	}
+	return m + "data:\n  value: " + value
+}
+
+// configMapValues returns the values of the config maps of a manifest, as
+// name=value pairs.
+func configMapValues(t *testing.T, manifest string) string {
+	resources, err := listResources(manifest, "default")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var values []string
+	for _, r := range resources {
+		values = append(values, r.Metadata.Name+"="+r.content[strings.LastIndex(r.content, " ")+1:])
+	}
+	return strings.Join(values, ",")
+}
+
+func TestWaveManifest(t *testing.T) {
+	original := strings.Join([]string{
+		waveConfigMap("web", "", "v1"),
+		waveConfigMap("db", "1", "v1"),
+		waveConfigMap("old", "", "v1"),
+	}, "\n---\n")
+	updated := strings.Join([]string{
+		waveConfigMap("web", "", "v2"),
+		waveConfigMap("db", "1", "v2"),
+		waveConfigMap("cache", "2", "v2"),
+	}, "\n---\n")
+
+	tests := []struct {
+		applied  int
+		expected string
+	}{
+		{0, "web=v1,db=v1,old=v1"},
+		{1, "web=v2,db=v1,old=v1"},
+		{2, "web=v2,db=v2,old=v1"},
+		{3, "web=v2,db=v2,cache=v2"},
+	}
+	for _, tt := range tests {
+		manifest, err := waveManifest(original, updated, "default", tt.applied)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := configMapValues(t, manifest); got != tt.expected {
+			t.Errorf("After %d waves, expected %s, got %s", tt.applied, tt.expected, got)
+		}
+	}
+
+	if _, err := manifestWaves(waveConfigMap("web", "first", "v1"), "default"); err == nil {
+		t.Error("Expected an error for an invalid wave")
+	}
+}
+
+// waveKubeClient records the manifests deployed by updates.
+type waveKubeClient struct {
+	environment.PrintingKubeClient
+	updates [][2]string
+}
+
+func (k *waveKubeClient) UpdateWithOptions(namespace string, originalReader, targetReader io.Reader, opts kube.UpdateOptions) error {
+	original, _ := ioutil.ReadAll(originalReader)
+	target, _ := ioutil.ReadAll(targetReader)
+	k.updates = append(k.updates, [2]string{string(original), string(target)})
+	return nil
+}
+
+func waveChart(value string) *chart.Chart {
+	return &chart.Chart{
+		Metadata: &chart.Metadata{Name: "hello"},
+		Templates: []*chart.Template{
+			{Name: "templates/web", Data: []byte(waveConfigMap("web", "", value))},
+			{Name: "templates/db", Data: []byte(waveConfigMap("db", "1", value))},
+		},
+	}
+}
+
+func TestUpdateReleaseSteps(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+	kc := &waveKubeClient{PrintingKubeClient: environment.PrintingKubeClient{Out: ioutil.Discard}}
+	rs.env.KubeClient = kc
+
+	if _, err := rs.InstallRelease(c, &services.InstallReleaseRequest{Name: "waves", Namespace: "default", Chart: waveChart("v1")}); err != nil {
+		t.Fatalf("Failed install: %s", err)
+	}
+
+	res, err := rs.UpdateRelease(c, &services.UpdateReleaseRequest{Name: "waves", Chart: waveChart("v2"), Steps: true, Pause: true})
+	if err != nil {
+		t.Fatalf("Failed upgrade: %s", err)
+	}
+	if code := res.Release.Info.Status.Code; code != release.Status_PENDING_UPGRADE {
+		t.Errorf("Expected a pending upgrade, got %s", code)
+	}
+	if w := res.Release.Info.Waves; w.Applied != 1 || w.Total != 2 || !w.Paused {
+		t.Errorf("Expected the upgrade to pause after wave 1 of 2, got %v", w)
+	}
+	if len(kc.updates) != 1 {
+		t.Fatalf("Expected 1 update, got %d", len(kc.updates))
+	}
+	if got := configMapValues(t, kc.updates[0][1]); got != "db=v1,web=v2" {
+		t.Errorf("Expected only wave 0 to be updated, got %s", got)
+	}
+	stored, err := rs.env.Releases.Get("waves", 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !stored.Info.Waves.Paused {
+		t.Error("Expected the pause to be stored")
+	}
+
+	if _, err := rs.UpdateRelease(c, &services.UpdateReleaseRequest{Name: "waves", Chart: waveChart("v3")}); err == nil || !strings.Contains(err.Error(), "upgrade in progress") {
+		t.Errorf("Expected an upgrade in progress to block upgrades, got %v", err)
+	}
+
+	res, err = rs.UpdateRelease(c, &services.UpdateReleaseRequest{Name: "waves", Continue: true})
+	if err != nil {
+		t.Fatalf("Failed to continue the upgrade: %s", err)
+	}
+	if code := res.Release.Info.Status.Code; code != release.Status_DEPLOYED {
+		t.Errorf("Expected the upgrade to be deployed, got %s", code)
+	}
+	if w := res.Release.Info.Waves; w.Applied != 2 || w.Paused {
+		t.Errorf("Expected all waves to be applied, got %v", w)
+	}
+	if len(kc.updates) != 2 {
+		t.Fatalf("Expected 2 updates, got %d", len(kc.updates))
+	}
+	if got := configMapValues(t, kc.updates[1][1]); got != "db=v2,web=v2" {
+		t.Errorf("Expected the last wave to deploy the upgraded manifest, got %s", got)
+	}
+	previous, err := rs.env.Releases.Get("waves", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if code := previous.Info.Status.Code; code != release.Status_SUPERSEDED {
+		t.Errorf("Expected the previous revision to be superseded, got %s", code)
+	}
+
+	if _, err := rs.UpdateRelease(c, &services.UpdateReleaseRequest{Name: "waves", Continue: true}); err == nil {
+		t.Error("Expected an error continuing a deployed release")
+	}
+}
+
+func TestRollbackPausedUpgrade(t *testing.T) {
+	c := helm.NewContext()
+	rs := rsFixture()
+	kc := &waveKubeClient{PrintingKubeClient: environment.PrintingKubeClient{Out: ioutil.Discard}}
+	rs.env.KubeClient = kc
+
+	if _, err := rs.InstallRelease(c, &services.InstallReleaseRequest{Name: "waves", Namespace: "default", Chart: waveChart("v1")}); err != nil {
+		t.Fatalf("Failed install: %s", err)
+	}
+	if _, err := rs.UpdateRelease(c, &services.UpdateReleaseRequest{Name: "waves", Chart: waveChart("v2"), Steps: true, Pause: true}); err != nil {
+		t.Fatalf("Failed upgrade: %s", err)
+	}
+
+	res, err := rs.RollbackRelease(c, &services.RollbackReleaseRequest{Name: "waves", Version: 1})
+	if err != nil {
+		t.Fatalf("Failed rollback: %s", err)
+	}
+	if code := res.Release.Info.Status.Code; code != release.Status_DEPLOYED {
+		t.Errorf("Expected the rollback to be deployed, got %s", code)
+	}
+	if len(kc.updates) != 2 {
+		t.Fatalf("Expected 2 updates, got %d", len(kc.updates))
+	}
+	// the rollback starts from the resources deployed by the first wave
+	if got := configMapValues(t, kc.updates[1][0]); got != "db=v1,web=v2" {
+		t.Errorf("Expected the rollback to start from the first wave, got %s", got)
+	}
+}
@@ -0,0 +1,105 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tiller
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"k8s.io/helm/pkg/proto/hapi/release"
+	"k8s.io/helm/pkg/webhook"
+)
+
+// statusCache remembers the last status recorded for the revisions of
+// releases, to tell the transitions of their statuses.
+type statusCache struct {
+	mu    sync.Mutex
+	codes map[string]release.Status_Code
+}
+
+func newStatusCache() *statusCache {
+	return &statusCache{codes: map[string]release.Status_Code{}}
+}
+
+// get returns the status recorded for the revision key, if any.
+func (c *statusCache) get(key string) (release.Status_Code, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	code, ok := c.codes[key]
+	return code, ok
+}
+
+// swap records code as the status of the revision key, returning the status
+// recorded before, if any.
+func (c *statusCache) swap(key string, code release.Status_Code) (release.Status_Code, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	old, ok := c.codes[key]
+	switch code {
+	case release.Status_SUPERSEDED, release.Status_DELETED:
+		// Revisions rarely change status again once superseded or deleted;
+		// if they do, the status stored before is looked up.
+		delete(c.codes, key)
+	default:
+		c.codes[key] = code
+	}
+	return old, ok
+}
+
+// notifyStatus returns a function notifying the webhooks of the server if the
+// status of r differs from the status last recorded for its revision. It is
+// called before r is stored, so that the stored status can be looked up when
+// unknown, and the function it returns once r has been stored.
+func (s *ReleaseServer) notifyStatus(r *release.Release) func() {
+	if s.Webhooks == nil {
+		return func() {}
+	}
+	key := fmt.Sprintf("%s.v%d", r.Name, r.Version)
+	var stored *release.Release
+	if _, ok := s.statuses.get(key); !ok {
+		// Revisions created before Tiller started.
+		stored, _ = s.env.Releases.Get(r.Name, r.Version)
+	}
+
+	return func() {
+		code := r.GetInfo().GetStatus().GetCode()
+		old, ok := s.statuses.swap(key, code)
+		if !ok && stored != nil {
+			old, ok = stored.GetInfo().GetStatus().GetCode(), true
+		}
+		if ok && old == code {
+			return
+		}
+
+		p := &webhook.Payload{
+			Release:     r.Name,
+			Namespace:   r.Namespace,
+			Revision:    r.Version,
+			NewStatus:   code.String(),
+			Description: r.GetInfo().GetDescription(),
+			Time:        time.Now().UTC(),
+		}
+		if ok {
+			p.OldStatus = old.String()
+		}
+		if md := r.GetChart().GetMetadata(); md != nil {
+			p.Chart, p.ChartVersion = md.Name, md.Version
+		}
+		s.Webhooks.Notify(p)
+	}
+}
@@ -0,0 +1,109 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tiller
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/proto/hapi/services"
+	"k8s.io/helm/pkg/tiller/environment"
+	"k8s.io/helm/pkg/webhook"
+)
+
+func TestReleaseStatusWebhooks(t *testing.T) {
+	var (
+		mu          sync.Mutex
+		transitions []string
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		if !webhook.Verify("s3cr3t", body, r.Header.Get(webhook.SignatureHeader)) {
+			t.Errorf("Invalid signature of %s", body)
+		}
+		var p webhook.Payload
+		if err := json.Unmarshal(body, &p); err != nil {
+			t.Error(err)
+		}
+		mu.Lock()
+		transitions = append(transitions, fmt.Sprintf("v%d %s->%s", p.Revision, p.OldStatus, p.NewStatus))
+		mu.Unlock()
+	}))
+	defer srv.Close()
+
+	rs := rsFixture()
+	rs.Webhooks = webhook.NewNotifier(&webhook.Webhook{URL: srv.URL, Secret: "s3cr3t", Backoff: time.Millisecond})
+	c := helm.NewContext()
+
+	if _, err := rs.InstallRelease(c, installRequest(withName("hooked"))); err != nil {
+		t.Fatalf("Failed install: %s", err)
+	}
+	rs.Webhooks.Wait()
+	expected := []string{"v1 ->PENDING_INSTALL", "v1 PENDING_INSTALL->DEPLOYED"}
+	if strings.Join(transitions, ", ") != strings.Join(expected, ", ") {
+		t.Errorf("Expected transitions %v, got %v", expected, transitions)
+	}
+
+	transitions = nil
+	rs.env.KubeClient = newUpdateFailingKubeClient()
+	if _, err := rs.UpdateRelease(c, &services.UpdateReleaseRequest{Name: "hooked", Chart: buildChart()}); err == nil {
+		t.Fatal("Expected the upgrade to fail")
+	}
+	rs.Webhooks.Wait()
+	expected = []string{"v2 ->PENDING_UPGRADE", "v2 PENDING_UPGRADE->FAILED"}
+	if strings.Join(transitions, ", ") != strings.Join(expected, ", ") {
+		t.Errorf("Expected transitions %v, got %v", expected, transitions)
+	}
+
+	transitions = nil
+	rs.env.KubeClient = &environment.PrintingKubeClient{Out: ioutil.Discard}
+	if _, err := rs.RollbackRelease(c, &services.RollbackReleaseRequest{Name: "hooked", Version: 1}); err != nil {
+		t.Fatalf("Failed rollback: %s", err)
+	}
+	rs.Webhooks.Wait()
+	expected = []string{"v3 ->PENDING_ROLLBACK", "v2 FAILED->SUPERSEDED", "v1 DEPLOYED->SUPERSEDED", "v3 PENDING_ROLLBACK->DEPLOYED"}
+	if strings.Join(transitions, ", ") != strings.Join(expected, ", ") {
+		t.Errorf("Expected transitions %v, got %v", expected, transitions)
+	}
+}
+
+func TestReleaseStatusWebhooks_NotStored(t *testing.T) {
+	var notified int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&notified, 1)
+	}))
+	defer srv.Close()
+
+	rs := rsFixture()
+	rs.Webhooks = webhook.NewNotifier(&webhook.Webhook{URL: srv.URL, Backoff: time.Millisecond})
+
+	// Updating a release which was never stored fails.
+	rs.recordRelease(releaseStub(), true)
+	rs.Webhooks.Wait()
+	if n := atomic.LoadInt32(&notified); n != 0 {
+		t.Errorf("Expected no notification when the release is not stored, got %d", n)
+	}
+}
@@ -35,12 +35,7 @@ const maxMsgSize = 1024 * 1024 * 20
 
 // DefaultServerOpts returns the set of default grpc ServerOption's that Tiller requires.
 func DefaultServerOpts() []grpc.ServerOption {
-	return []grpc.ServerOption{
-		grpc.MaxRecvMsgSize(maxMsgSize),
-		grpc.MaxSendMsgSize(maxMsgSize),
-		grpc.UnaryInterceptor(newUnaryInterceptor()),
-		grpc.StreamInterceptor(newStreamInterceptor()),
-	}
+	return serverOpts(nil)
 }
 
 // NewServer creates a new grpc server.
@@ -48,7 +43,22 @@ func NewServer(opts ...grpc.ServerOption) *grpc.Server {
 	return grpc.NewServer(append(DefaultServerOpts(), opts...)...)
 }
 
-func newUnaryInterceptor() grpc.UnaryServerInterceptor {
+// NewAuthorizingServer creates a new grpc server on which callers may only
+// call the methods of the release service authorizer allows them to.
+func NewAuthorizingServer(authorizer *Authorizer, opts ...grpc.ServerOption) *grpc.Server {
+	return grpc.NewServer(append(serverOpts(authorizer), opts...)...)
+}
+
+func serverOpts(authorizer *Authorizer) []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.MaxRecvMsgSize(maxMsgSize),
+		grpc.MaxSendMsgSize(maxMsgSize),
+		grpc.UnaryInterceptor(newUnaryInterceptor(authorizer)),
+		grpc.StreamInterceptor(newStreamInterceptor(authorizer)),
+	}
+}
+
+func newUnaryInterceptor(authorizer *Authorizer) grpc.UnaryServerInterceptor {
 	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
 		if err := checkClientVersion(ctx); err != nil {
 			// whitelist GetVersion() from the version check
@@ -57,16 +67,26 @@ func newUnaryInterceptor() grpc.UnaryServerInterceptor {
 				return nil, err
 			}
 		}
+		if s, _ := splitMethod(info.FullMethod); authorizer != nil && s == releaseService {
+			user, err := authorizer.authorize(ctx, info.FullMethod, req)
+			if err != nil {
+				return nil, err
+			}
+			ctx = withAuthenticatedUser(ctx, user)
+		}
 		return goprom.UnaryServerInterceptor(ctx, req, info, handler)
 	}
 }
 
-func newStreamInterceptor() grpc.StreamServerInterceptor {
+func newStreamInterceptor(authorizer *Authorizer) grpc.StreamServerInterceptor {
 	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
 		if err := checkClientVersion(ss.Context()); err != nil {
 			log.Println(err)
 			return err
 		}
+		if s, _ := splitMethod(info.FullMethod); authorizer != nil && s == releaseService {
+			ss = &authorizedStream{ServerStream: ss, c: ss.Context(), method: info.FullMethod, authorizer: authorizer}
+		}
 		return goprom.StreamServerInterceptor(srv, ss, info, handler)
 	}
 }
@@ -87,6 +107,20 @@ func versionFromContext(ctx context.Context) string {
 	return ""
 }
 
+// userFromContext returns the user the client authenticated as, or else
+// the user it identified itself as, if any.
+func userFromContext(ctx context.Context) string {
+	if u := authenticatedUser(ctx); u != nil {
+		return u.Username
+	}
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if v, ok := md["x-helm-client-user"]; ok && len(v) > 0 {
+			return v[0]
+		}
+	}
+	return ""
+}
+
 func checkClientVersion(ctx context.Context) error {
 	clientVersion := versionFromContext(ctx)
 	if !version.IsCompatible(clientVersion, version.GetVersion()) {
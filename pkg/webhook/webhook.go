@@ -0,0 +1,244 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+/*Package webhook notifies HTTP endpoints of the status transitions of releases.
+
+Payloads are posted as JSON, signed with an HMAC-SHA256 of the body under a
+secret shared with the receiver, and retried with exponential backoff when the
+receiver is unavailable.
+*/
+package webhook // import "k8s.io/helm/pkg/webhook"
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ghodss/yaml"
+)
+
+// SignatureHeader is the header carrying the signature of a payload.
+const SignatureHeader = "X-Helm-Signature"
+
+const (
+	defaultMaxAttempts = 5
+	defaultBackoff     = time.Second
+)
+
+// Payload describes the transition of a release revision between two statuses.
+type Payload struct {
+	Release      string `json:"release"`
+	Namespace    string `json:"namespace"`
+	Revision     int32  `json:"revision"`
+	Chart        string `json:"chart,omitempty"`
+	ChartVersion string `json:"chartVersion,omitempty"`
+	// OldStatus is empty for new revisions.
+	OldStatus   string    `json:"oldStatus,omitempty"`
+	NewStatus   string    `json:"newStatus"`
+	Description string    `json:"description,omitempty"`
+	Time        time.Time `json:"time"`
+}
+
+// Webhook is an endpoint notified of status transitions.
+type Webhook struct {
+	URL string `json:"url"`
+	// Secret signs the payloads, if set.
+	Secret string `json:"secret,omitempty"`
+	// Statuses limits the notifications to the transitions to these
+	// statuses, e.g. FAILED. All transitions are notified if empty.
+	Statuses []string `json:"statuses,omitempty"`
+	// MaxAttempts is how many times a payload is sent before giving up,
+	// 5 by default.
+	MaxAttempts int `json:"maxAttempts,omitempty"`
+	// Backoff is the delay before the first retry, doubled on each retry,
+	// one second by default.
+	Backoff time.Duration `json:"-"`
+}
+
+// Sign returns the signature of body under secret, as "sha256=<hex>".
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify reports whether signature is the signature of body under secret.
+func Verify(secret string, body []byte, signature string) bool {
+	return hmac.Equal([]byte(Sign(secret, body)), []byte(signature))
+}
+
+// wants reports whether the webhook is notified of transitions to status.
+func (w *Webhook) wants(status string) bool {
+	if len(w.Statuses) == 0 {
+		return true
+	}
+	for _, s := range w.Statuses {
+		if strings.EqualFold(s, status) {
+			return true
+		}
+	}
+	return false
+}
+
+// Send posts the payload to the webhook with client, retrying while the
+// request fails or the receiver responds with a server error or 429.
+func (w *Webhook) Send(client *http.Client, p *Payload) error {
+	body, err := json.Marshal(p)
+	if err != nil {
+		return err
+	}
+	attempts, backoff := w.MaxAttempts, w.Backoff
+	if attempts <= 0 {
+		attempts = defaultMaxAttempts
+	}
+	if backoff <= 0 {
+		backoff = defaultBackoff
+	}
+
+	for i := 1; ; i++ {
+		retry, err := w.post(client, body)
+		if err == nil {
+			return nil
+		}
+		if !retry || i == attempts {
+			return fmt.Errorf("cannot notify %s after %d attempt(s): %s", w.URL, i, err)
+		}
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+}
+
+// post posts body once, reporting whether a failure is worth retrying.
+func (w *Webhook) post(client *http.Client, body []byte) (bool, error) {
+	req, err := http.NewRequest("POST", w.URL, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if w.Secret != "" {
+		req.Header.Set(SignatureHeader, Sign(w.Secret, body))
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	resp.Body.Close()
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return false, nil
+	case resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests:
+		return true, fmt.Errorf("responded %s", resp.Status)
+	default:
+		return false, fmt.Errorf("responded %s", resp.Status)
+	}
+}
+
+// queueSize is how many notifications can wait for a webhook before new ones
+// are dropped.
+const queueSize = 100
+
+// Notifier notifies webhooks of status transitions in the background. The
+// transitions are sent to each webhook in the order they were notified.
+type Notifier struct {
+	Webhooks []*Webhook
+	Client   *http.Client
+	Log      func(string, ...interface{})
+
+	once   sync.Once
+	queues []chan *Payload
+	wg     sync.WaitGroup
+}
+
+// NewNotifier returns a notifier of webhooks.
+func NewNotifier(webhooks ...*Webhook) *Notifier {
+	return &Notifier{
+		Webhooks: webhooks,
+		Client:   &http.Client{Timeout: 10 * time.Second},
+		Log:      func(_ string, _ ...interface{}) {},
+	}
+}
+
+// start starts a worker sending the notifications of each webhook.
+func (n *Notifier) start() {
+	for _, w := range n.Webhooks {
+		q := make(chan *Payload, queueSize)
+		n.queues = append(n.queues, q)
+		go func(w *Webhook) {
+			for p := range q {
+				if err := w.Send(n.Client, p); err != nil {
+					n.Log("warning: %s", err)
+				}
+				n.wg.Done()
+			}
+		}(w)
+	}
+}
+
+// Notify queues the payload for the webhooks interested in its new status,
+// without waiting for them.
+func (n *Notifier) Notify(p *Payload) {
+	n.once.Do(n.start)
+	for i, w := range n.Webhooks {
+		if !w.wants(p.NewStatus) {
+			continue
+		}
+		n.wg.Add(1)
+		select {
+		case n.queues[i] <- p:
+		default:
+			n.wg.Done()
+			n.Log("warning: dropped the notification of %s revision %d to %s: too many pending notifications", p.Release, p.Revision, w.URL)
+		}
+	}
+}
+
+// Wait waits for the queued notifications to be sent or given up.
+func (n *Notifier) Wait() {
+	n.wg.Wait()
+}
+
+// LoadConfig loads the webhooks configured in the YAML file at path:
+//
+//	webhooks:
+//	- url: https://hooks.example.com/helm
+//	  secret: s3cr3t
+//	  statuses: [FAILED]
+func LoadConfig(path string) (*Notifier, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var config struct {
+		Webhooks []*Webhook `json:"webhooks"`
+	}
+	if err := yaml.Unmarshal(b, &config); err != nil {
+		return nil, fmt.Errorf("cannot parse webhook configuration %s: %s", path, err)
+	}
+	for i, w := range config.Webhooks {
+		if w.URL == "" {
+			return nil, fmt.Errorf("webhook %d in %s has no url", i+1, path)
+		}
+	}
+	return NewNotifier(config.Webhooks...), nil
+}
@@ -0,0 +1,147 @@
+/*
+Copyright The Helm Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhook
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// receiver records the payloads posted to it, responding with the given
+// statuses in turn and 200 once they are used up.
+type receiver struct {
+	mu        sync.Mutex
+	secret    string
+	responses []int
+	payloads  []*Payload
+	attempts  int
+	badSigs   int
+}
+
+func (r *receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, _ := ioutil.ReadAll(req.Body)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.attempts++
+	if !Verify(r.secret, body, req.Header.Get(SignatureHeader)) {
+		r.badSigs++
+	}
+	if len(r.responses) > 0 {
+		status := r.responses[0]
+		r.responses = r.responses[1:]
+		w.WriteHeader(status)
+		return
+	}
+	var p Payload
+	json.Unmarshal(body, &p)
+	r.payloads = append(r.payloads, &p)
+}
+
+func TestSign(t *testing.T) {
+	body := []byte(`{"release":"web"}`)
+	sig := Sign("s3cr3t", body)
+	if !strings.HasPrefix(sig, "sha256=") || len(sig) != len("sha256=")+64 {
+		t.Errorf("Unexpected signature %s", sig)
+	}
+	if !Verify("s3cr3t", body, sig) {
+		t.Error("Expected the signature to verify")
+	}
+	if Verify("other", body, sig) || Verify("s3cr3t", []byte(`{"release":"db"}`), sig) {
+		t.Error("Expected the signature not to verify with another secret or body")
+	}
+}
+
+func TestWebhookSend(t *testing.T) {
+	tests := []struct {
+		name      string
+		responses []int
+		attempts  int
+		err       string
+	}{
+		{"delivered", nil, 1, ""},
+		{"retried", []int{http.StatusServiceUnavailable, http.StatusTooManyRequests}, 3, ""},
+		{"given up", []int{500, 500, 500, 500}, 3, "after 3 attempt(s): responded 500 Internal Server Error"},
+		{"rejected", []int{http.StatusBadRequest}, 1, "after 1 attempt(s): responded 400 Bad Request"},
+	}
+	for _, tt := range tests {
+		rcv := &receiver{secret: "s3cr3t", responses: tt.responses}
+		srv := httptest.NewServer(rcv)
+		w := &Webhook{URL: srv.URL, Secret: "s3cr3t", MaxAttempts: 3, Backoff: time.Millisecond}
+
+		err := w.Send(http.DefaultClient, &Payload{Release: "web", Revision: 2, OldStatus: "PENDING_UPGRADE", NewStatus: "FAILED"})
+		srv.Close()
+
+		if tt.err == "" && err != nil {
+			t.Errorf("%s: unexpected error %s", tt.name, err)
+		}
+		if tt.err != "" && (err == nil || !strings.HasSuffix(err.Error(), tt.err)) {
+			t.Errorf("%s: expected error ending in %q, got %v", tt.name, tt.err, err)
+		}
+		if rcv.attempts != tt.attempts {
+			t.Errorf("%s: expected %d attempts, got %d", tt.name, tt.attempts, rcv.attempts)
+		}
+		if rcv.badSigs != 0 {
+			t.Errorf("%s: %d payloads were not signed correctly", tt.name, rcv.badSigs)
+		}
+	}
+}
+
+func TestNotifier(t *testing.T) {
+	failures := &receiver{secret: "a"}
+	all := &receiver{secret: "b"}
+	srvFailures, srvAll := httptest.NewServer(failures), httptest.NewServer(all)
+	defer srvFailures.Close()
+	defer srvAll.Close()
+
+	n := NewNotifier(
+		&Webhook{URL: srvFailures.URL, Secret: "a", Statuses: []string{"FAILED"}},
+		&Webhook{URL: srvAll.URL, Secret: "b"},
+	)
+	n.Notify(&Payload{Release: "web", Revision: 1, NewStatus: "DEPLOYED"})
+	n.Notify(&Payload{Release: "web", Revision: 2, OldStatus: "DEPLOYED", NewStatus: "FAILED"})
+	n.Wait()
+
+	if len(failures.payloads) != 1 || failures.payloads[0].Revision != 2 {
+		t.Errorf("Expected the failure to be notified only, got %v", failures.payloads)
+	}
+	if len(all.payloads) != 2 {
+		t.Errorf("Expected both transitions to be notified, got %v", all.payloads)
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	n, err := LoadConfig("testdata/webhooks.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(n.Webhooks) != 2 {
+		t.Fatalf("Expected 2 webhooks, got %d", len(n.Webhooks))
+	}
+	w := n.Webhooks[0]
+	if w.URL != "https://hooks.example.com/helm" || w.Secret != "s3cr3t" || w.MaxAttempts != 3 {
+		t.Errorf("Unexpected webhook %+v", w)
+	}
+	if !w.wants("FAILED") || w.wants("SUPERSEDED") || !n.Webhooks[1].wants("SUPERSEDED") {
+		t.Error("Expected the statuses to filter the transitions")
+	}
+}